@@ -24,8 +24,10 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/tektoncd/results/pkg/api/server/features"
@@ -33,7 +35,6 @@ import (
 	"github.com/tektoncd/results/internal/fieldmask"
 
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth/impersonation"
-	"github.com/tektoncd/results/pkg/converter"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc/codes"
@@ -60,14 +61,26 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	promclient "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tektoncd/results/pkg/api/server/aggregates"
+	"github.com/tektoncd/results/pkg/api/server/audit"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/notify"
+	"github.com/tektoncd/results/pkg/api/server/ratelimit"
+	"github.com/tektoncd/results/pkg/api/server/redact"
 	"github.com/tektoncd/results/pkg/api/server/tlsconfig"
 	v1alpha2 "github.com/tektoncd/results/pkg/api/server/v1alpha2"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/migration"
 	v1alpha2pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	v1alpha3pb "github.com/tektoncd/results/proto/v1alpha3/results_go_proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	_ "go.uber.org/automaxprocs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -77,8 +90,13 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"k8s.io/apimachinery/pkg/util/wait"
+	knativetracing "knative.dev/pkg/observability/tracing"
 )
 
+// shutdownTimeout is how long requests in flight are given to complete on
+// shutdown, within the default termination grace period of Pods.
+const shutdownTimeout = 20 * time.Second
+
 func main() {
 	serverConfig := config.Get()
 
@@ -93,6 +111,11 @@ func main() {
 		log.Errorf("Failed to load feature gates: %v", err)
 	}
 
+	tracerProvider, err := setupTracing(serverConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	// Load server TLS configuration
 	certFile := path.Join(serverConfig.TLS_PATH, "tls.crt")
 	keyFile := path.Join(serverConfig.TLS_PATH, "tls.key")
@@ -154,7 +177,6 @@ func main() {
 	// DSN derived from https://pkg.go.dev/gorm.io/driver/postgres
 
 	var db *gorm.DB
-	var err error
 
 	dbURI := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s sslrootcert=%s", serverConfig.DB_HOST, serverConfig.DB_USER, serverConfig.DB_PASSWORD, serverConfig.DB_NAME, serverConfig.DB_PORT, serverConfig.DB_SSLMODE, serverConfig.DB_SSLROOTCERT)
 
@@ -194,6 +216,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
+	if err := db.Use(serverdb.TracingPlugin{}); err != nil {
+		log.Fatalf("Failed to set up database tracing: %v", err)
+	}
 
 	var sqlDB *sql.DB
 
@@ -215,14 +240,6 @@ func main() {
 		sqlDB.SetMaxIdleConns(maxIdle)
 	}
 
-	if serverConfig.CONVERTER_ENABLE {
-		log.Info("Starting api converter")
-		go func() {
-			conv := converter.New(log, db, serverConfig.CONVERTER_DB_LIMIT)
-			conv.Start(context.Background())
-		}()
-	}
-
 	// Set grpc worker pool
 	grpcWorkers := serverConfig.GRPC_WORKER_POOL
 	var streamWorkers grpc.ServerOption
@@ -232,6 +249,7 @@ func main() {
 
 	// Create the authorization authCheck
 	var authCheck auth.Checker
+	var authn auth.Authenticator
 	serverMuxOptions := []runtime.ServeMuxOption{runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
 			UseProtoNames: true,
@@ -243,6 +261,21 @@ func main() {
 	if serverConfig.AUTH_DISABLE {
 		log.Warn("Kubernetes RBAC authorization check disabled - all requests will be allowed by the API server")
 		authCheck = &auth.AllowAll{}
+	} else if serverConfig.AUTH_OIDC_ISSUER_URL != "" {
+		log.Infof("OIDC authorization check enabled with issuer %s", serverConfig.AUTH_OIDC_ISSUER_URL)
+		rules, err := auth.LoadRules(serverConfig.AUTH_OIDC_RULES_PATH)
+		if err != nil {
+			log.Fatalf("Error loading OIDC authorization rules: %v", err)
+		}
+		oidc, err := auth.NewOIDC(ctx, serverConfig.AUTH_OIDC_ISSUER_URL, serverConfig.AUTH_OIDC_AUDIENCE, rules,
+			auth.WithJWKSURL(serverConfig.AUTH_OIDC_JWKS_URL),
+			auth.WithClaims(serverConfig.AUTH_OIDC_USERNAME_CLAIM, serverConfig.AUTH_OIDC_GROUPS_CLAIM),
+		)
+		if err != nil {
+			log.Fatalf("Error creating OIDC authorization check: %v", err)
+		}
+		authCheck = oidc
+		authn = oidc
 	} else {
 		log.Info("Kubernetes RBAC authorization check enabled")
 		// Create k8s client
@@ -268,15 +301,157 @@ func main() {
 			log.Info("Kubernetes RBAC impersonation enabled")
 			serverMuxOptions = append(serverMuxOptions, runtime.WithIncomingHeaderMatcher(impersonation.HeaderMatcher))
 		}
-		authCheck = auth.NewRBAC(k8s, auth.WithImpersonation(serverConfig.AUTH_IMPERSONATE))
+		if serverConfig.AUTH_CACHE_SIZE > 0 {
+			log.Infof("Kubernetes RBAC decision cache enabled with %d entries", serverConfig.AUTH_CACHE_SIZE)
+		}
+		rbac := auth.NewRBAC(k8s,
+			auth.WithImpersonation(serverConfig.AUTH_IMPERSONATE),
+			auth.WithCache(serverConfig.AUTH_CACHE_SIZE, serverConfig.AUTH_CACHE_ALLOW_TTL, serverConfig.AUTH_CACHE_DENY_TTL),
+		)
+		authCheck = rbac
+		authn = rbac
+
+		if dir := serverConfig.AUTH_CLUSTERS_KUBECONFIG_DIR; dir != "" {
+			configs, err := auth.LoadKubeconfigs(dir)
+			if err != nil {
+				log.Fatalf("Error loading the kubeconfigs of clusters: %v", err)
+			}
+			remotes := make(map[string]auth.Checker, len(configs))
+			for name, config := range configs {
+				if qps > 0 {
+					config.QPS = (float32)(qps)
+				}
+				if burst > 0 {
+					config.Burst = burst
+				}
+				client, err := kubernetes.NewForConfig(config)
+				if err != nil {
+					log.Fatalf("Error creating kubernetes clientset of cluster %s: %v", name, err)
+				}
+				remotes[name] = auth.NewRBAC(client,
+					auth.WithImpersonation(serverConfig.AUTH_IMPERSONATE),
+					auth.WithCache(serverConfig.AUTH_CACHE_SIZE, serverConfig.AUTH_CACHE_ALLOW_TTL, serverConfig.AUTH_CACHE_DENY_TTL),
+				)
+			}
+			clusters, err := auth.NewClusters(serverConfig.CLUSTER_NAME, rbac, remotes)
+			if err != nil {
+				log.Fatalf("Error creating cluster authorization check: %v", err)
+			}
+			log.Infof("Kubernetes RBAC authorization delegated to cluster %s and %d other clusters", serverConfig.CLUSTER_NAME, len(remotes))
+			authCheck = clusters
+			authn = clusters
+		}
+	}
+
+	if authn != nil && serverConfig.AUTH_POLICY_PATH != "" {
+		policies, err := auth.LoadPolicies(serverConfig.AUTH_POLICY_PATH)
+		if err != nil {
+			log.Fatalf("Error loading authorization policies: %v", err)
+		}
+		authCheck, err = auth.NewPolicyChecker(authCheck, authn, policies)
+		if err != nil {
+			log.Fatalf("Error creating policy authorization check: %v", err)
+		}
+		log.Infof("Fine-grained authorization enabled with %d policies", len(policies))
+	}
+
+	serverOpts := []v1alpha2.Option{v1alpha2.WithAuth(authCheck)}
+	if serverConfig.LOGS_REDACTION_RULES_PATH != "" {
+		rules, err := redact.LoadRules(serverConfig.LOGS_REDACTION_RULES_PATH)
+		if err != nil {
+			log.Fatalf("Error loading log redaction rules: %v", err)
+		}
+		redactor, err := redact.New(rules)
+		if err != nil {
+			log.Fatalf("Error creating log redactor: %v", err)
+		}
+		serverOpts = append(serverOpts, v1alpha2.WithRedactor(redactor))
+		log.Infof("Log redaction enabled with %d rules", len(rules))
+	}
+
+	var notifier *notify.Notifier
+	if serverConfig.NOTIFY_SUBSCRIPTIONS_PATH != "" {
+		subs, err := notify.LoadSubscriptions(serverConfig.NOTIFY_SUBSCRIPTIONS_PATH)
+		if err != nil {
+			log.Fatalf("Error loading notification subscriptions: %v", err)
+		}
+		notifyOpts := []notify.Option{notify.WithRetries(serverConfig.NOTIFY_RETRIES)}
+		if serverConfig.NOTIFY_DEAD_LETTER_PATH != "" {
+			f, err := os.OpenFile(serverConfig.NOTIFY_DEAD_LETTER_PATH, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+			if err != nil {
+				log.Fatalf("Error opening notification dead-letter log: %v", err)
+			}
+			notifyOpts = append(notifyOpts, notify.WithDeadLetter(f))
+		}
+		notifier, err = notify.New(subs, log, notifyOpts...)
+		if err != nil {
+			log.Fatalf("Error creating notifier: %v", err)
+		}
+		serverOpts = append(serverOpts, v1alpha2.WithNotifier(notifier))
+		log.Infof("Notifications enabled with %d subscriptions", len(subs))
 	}
 
 	// Register API server(s)
-	v1a2, err := v1alpha2.New(serverConfig, log, db, v1alpha2.WithAuth(authCheck))
+	v1a2, err := v1alpha2.New(serverConfig, log, db, serverOpts...)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
+	// Migrations are started once the server has created the table of
+	// their checkpoints.
+	if serverConfig.CONVERTER_ENABLE {
+		migrations, err := migration.Select(strings.Split(serverConfig.CONVERTER_MIGRATIONS, ","))
+		if err != nil {
+			log.Fatalf("Error selecting record data migrations: %v", err)
+		}
+		runner := migration.NewRunner(log, db, migrations,
+			migration.WithBatchSize(serverConfig.CONVERTER_DB_LIMIT),
+			migration.WithRateLimit(serverConfig.CONVERTER_RATE_LIMIT),
+			migration.WithDryRun(serverConfig.CONVERTER_DRY_RUN),
+		)
+		log.Infof("Starting %d record data migrations", len(migrations))
+		go func() {
+			if err := runner.Run(context.Background()); err != nil {
+				log.Errorf("Record data migrations failed: %v", err)
+			}
+		}()
+	}
+
+	var auditor *audit.Auditor
+	if serverConfig.AUDIT_SINK != "" {
+		var sink audit.Sink
+		switch serverConfig.AUDIT_SINK {
+		case audit.SinkStdout:
+			sink = audit.NewStdoutSink()
+		case audit.SinkFile:
+			sink, err = audit.NewFileSink(serverConfig.AUDIT_FILE_PATH)
+			if err != nil {
+				log.Fatalf("Error creating audit sink: %v", err)
+			}
+		case audit.SinkWebhook:
+			sink = audit.NewWebhookSink(serverConfig.AUDIT_WEBHOOK_URL, log)
+		default:
+			log.Fatalf("Unknown audit sink %q", serverConfig.AUDIT_SINK)
+		}
+		auditor = audit.New(sink, log,
+			audit.WithAuthenticator(authn),
+			audit.WithEtagLookup(v1a2.Etag),
+			audit.WithMutationsOnly(serverConfig.AUDIT_MUTATIONS_ONLY),
+		)
+		log.Infof("Audit events enabled with %s sink", serverConfig.AUDIT_SINK)
+	}
+
+	var limiter *ratelimit.Limiter
+	if serverConfig.RATE_LIMIT_QPS > 0 || serverConfig.RATE_LIMIT_SUMMARY_QPS > 0 || serverConfig.RATE_LIMIT_LARGE_PAGE_QPS > 0 {
+		limiter = ratelimit.New(ratelimit.Budget{QPS: serverConfig.RATE_LIMIT_QPS, Burst: serverConfig.RATE_LIMIT_BURST},
+			ratelimit.WithAuthenticator(authn),
+			ratelimit.WithSummaryBudget(ratelimit.Budget{QPS: serverConfig.RATE_LIMIT_SUMMARY_QPS, Burst: serverConfig.RATE_LIMIT_SUMMARY_BURST}),
+			ratelimit.WithLargePageBudget(serverConfig.RATE_LIMIT_LARGE_PAGE_SIZE,
+				ratelimit.Budget{QPS: serverConfig.RATE_LIMIT_LARGE_PAGE_QPS, Burst: serverConfig.RATE_LIMIT_LARGE_PAGE_BURST}),
+		)
+		log.Infof("Rate limiting enabled with %v calls per second per client and method", serverConfig.RATE_LIMIT_QPS)
+	}
+
 	// Shared options for the logger, with a custom gRPC code to log level function.
 	zapOpts := []grpc_zap.Option{
 		grpc_zap.WithDecider(func(fullMethodName string, _ error) bool {
@@ -292,11 +467,14 @@ func main() {
 
 	svrOpts := []grpc.ServerOption{
 		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
 			// The grpc_ctxtags context updater should be before everything else
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 			grpc_zap.UnaryServerInterceptor(grpcLogger, zapOpts...),
 			grpc_auth.UnaryServerInterceptor(determineAuth),
+			limiter.UnaryServerInterceptor(),
+			auditor.UnaryServerInterceptor(),
 			prometheus.UnaryServerInterceptor,
 			fieldmask.UnaryServerInterceptor(f.Get(features.PartialResponse)),
 			recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(recoveryHandler)),
@@ -306,6 +484,8 @@ func main() {
 			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 			grpc_zap.StreamServerInterceptor(grpcLogger, zapOpts...),
 			grpc_auth.StreamServerInterceptor(determineAuth),
+			limiter.StreamServerInterceptor(),
+			auditor.StreamServerInterceptor(),
 			prometheus.StreamServerInterceptor,
 			recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(recoveryHandler)),
 		),
@@ -360,6 +540,27 @@ func main() {
 		}
 	}()
 
+	// Export aggregates of the stored Runs along with the server metrics
+	if serverConfig.METRICS_AGGREGATES_INTERVAL > 0 {
+		var groupBy []string
+		for _, group := range strings.Split(serverConfig.METRICS_AGGREGATES_GROUP_BY, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groupBy = append(groupBy, group)
+			}
+		}
+		exporter, err := aggregates.New(db, log,
+			aggregates.WithInterval(serverConfig.METRICS_AGGREGATES_INTERVAL),
+			aggregates.WithWindow(serverConfig.METRICS_AGGREGATES_WINDOW),
+			aggregates.WithGroupBy(groupBy...),
+			aggregates.WithFilter(serverConfig.METRICS_AGGREGATES_FILTER),
+		)
+		if err != nil {
+			log.Fatalf("Failed to set up aggregate metrics: %v", err)
+		}
+		promclient.MustRegister(exporter)
+		go exporter.Run(ctx)
+	}
+
 	// Load client TLS to dial gRPC
 	if tlsError == nil {
 		// This is an internal client to proxy request from the REST listener to gRPC listener.
@@ -388,6 +589,7 @@ func main() {
 		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(100 * 1024 * 1024)),
 		grpc.WithNoProxy(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 
 	// Register gRPC server endpoint to gRPC gateway
@@ -408,17 +610,70 @@ func main() {
 
 	// Start server with gRPC and REST handler
 	log.Infof("gRPC and REST server listening on: %s", serverConfig.SERVER_PORT)
-	if tlsError != nil {
-		log.Fatal(http.ListenAndServe(":"+serverConfig.SERVER_PORT, grpcHandler(gs, httpMux)))
+	httpServer := &http.Server{
+		Addr:    ":" + serverConfig.SERVER_PORT,
+		Handler: grpcHandler(gs, httpMux),
 	}
+	served := make(chan error, 1)
+	go func() {
+		if tlsError != nil {
+			served <- httpServer.ListenAndServe()
+			return
+		}
+		// Serve with the TLS config
+		httpServer.TLSConfig = serverTLSConfig
+		served <- httpServer.ListenAndServeTLS(certFile, keyFile)
+	}()
 
-	// Create HTTP server with TLS config
-	httpServer := &http.Server{
-		Addr:      ":" + serverConfig.SERVER_PORT,
-		Handler:   grpcHandler(gs, httpMux),
-		TLSConfig: serverTLSConfig,
+	// Stop serving on SIGTERM and flush what the server buffered before
+	// exiting, since deferred calls don't run when exiting with log.Fatal.
+	stopCtx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	var serveErr error
+	select {
+	case serveErr = <-served:
+	case <-stopCtx.Done():
+		log.Info("Shutting down")
+	}
+	shutdown(httpServer, gs, log)
+	// Send the events queued by the requests served before exiting.
+	notifier.Close()
+	// Flush the audit events of the requests served before exiting.
+	if err := auditor.Close(); err != nil {
+		log.Errorf("Error closing the audit sink: %v", err)
+	}
+	// Export the spans batched so far.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelFlush()
+	if err := tracerProvider.Shutdown(flushCtx); err != nil {
+		log.Errorf("Error shutting down tracing: %v", err)
+	}
+	if serveErr != nil {
+		log.Fatalf("Error serving: %v", serveErr)
+	}
+}
+
+// shutdown stops the HTTP and gRPC servers, letting the requests in flight
+// complete for up to shutdownTimeout.
+func shutdown(httpServer *http.Server, gs *grpc.Server, log *zap.SugaredLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Errorf("Error shutting down the HTTP server: %v", err)
+	}
+	// gRPC requests may be served over h2c connections hijacked from the HTTP
+	// server, which its Shutdown doesn't wait for.
+	stopped := make(chan struct{})
+	go func() {
+		gs.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Warn("Timed out waiting for gRPC requests to complete")
+		gs.Stop()
 	}
-	log.Fatal(httpServer.ListenAndServeTLS(certFile, keyFile))
 }
 
 // grpcHandler forwards the request to gRPC server based on the Content-Type header.
@@ -432,12 +687,42 @@ func grpcHandler(grpcServer *grpc.Server, httpHandler http.Handler) http.Handler
 	}), &http2.Server{})
 }
 
+// setupTracing installs the global TracerProvider exporting the spans of the
+// server as configured, and the propagator reading trace context from gRPC
+// metadata.
+func setupTracing(serverConfig *config.Config) (*knativetracing.TracerProvider, error) {
+	cfg := knativetracing.Config{
+		Protocol:     serverConfig.TRACING_PROTOCOL,
+		Endpoint:     serverConfig.TRACING_ENDPOINT,
+		SamplingRate: serverConfig.TRACING_SAMPLING_RATE,
+	}
+	if cfg.Protocol == "" {
+		cfg.Protocol = knativetracing.ProtocolNone
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	tracerProvider, err := knativetracing.NewTracerProvider(context.Background(), cfg,
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("tekton-results-api"))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTextMapPropagator(knativetracing.DefaultTextMapPropagator())
+	otel.SetTracerProvider(tracerProvider)
+	return tracerProvider, nil
+}
+
 // recoveryHandler returns custom messages when server panics
 func recoveryHandler(p any) error {
 	return status.Errorf(codes.Unknown, "Error: %v", p)
 }
 
 func determineAuth(ctx context.Context) (context.Context, error) {
+	// Tokens reviewed to identify the caller, e.g. by the rate limiter, are
+	// remembered for the auth checker of the request.
+	ctx = auth.NewRequestContext(ctx)
+
 	// This code is used to extract values
 	// it is not doing any form of verification.
 
@@ -25,6 +25,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/results/pkg/watcher/logs"
@@ -32,9 +33,11 @@ import (
 	creds "github.com/tektoncd/results/pkg/watcher/grpc"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/customrun"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/generic"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/pipelinerun"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/taskrun"
 	v1alpha2pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -42,6 +45,7 @@ import (
 	"google.golang.org/grpc/credentials/oauth"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/transport"
@@ -72,6 +76,10 @@ var (
 	burst                        = flag.Int("burst", rest.DefaultBurst, "Kubernetes client Burst setting")
 	logsAPI                      = flag.Bool("logs_api", false, "Disable sending logs. If not set, the logs will be sent only if server support API for it")
 	logsTimestamps               = flag.Bool("logs_timestamps", false, "Collect logs with timestamps")
+	logsFollow                   = flag.Bool("logs_follow", false, "If enabled, logs of Runs are streamed while they are executing instead of once they complete")
+	logsFlushInterval            = flag.Duration("logs_flush_interval", 10*time.Second, "How often the logs of executing Runs are made readable in the API server when logs_follow is enabled")
+	logsPerStep                  = flag.Bool("logs_per_step", false, "If enabled, the log of each step of TaskRuns is also stored separately once the step terminates")
+	logsRedactSecrets            = flag.Bool("logs_redact_secrets", false, "If enabled, values of the Secrets used by the Pods of Runs are masked in their logs. Requires permission to get Secrets")
 	labelSelector                = flag.String("label_selector", "", "Selector (label query) to filter objects to be deleted. Matching objects must satisfy all labels requirements to be eligible for deletion")
 	requeueInterval              = flag.Duration("requeue_interval", 10*time.Minute, "How long the Watcher waits to reprocess keys on certain events (e.g. an object doesn't match the provided selectors)")
 	namespace                    = flag.String("namespace", corev1.NamespaceAll, "Should the Watcher only watch a single namespace, then this value needs to be set to the namespace name otherwise leave it empty.")
@@ -85,6 +93,9 @@ var (
 	storeDeadline                = flag.Duration("store_deadline", 10*time.Minute, "How long to wait for storing the PipelineRun and TaskRun resources before aborting and clearing the finalizer in case of delete event")
 	forwardBuffer                = flag.Duration("forward_buffer", 150*time.Second, "This determines duration since completion time of TaskRun to wait for forwarder to finish")
 	managedByValues              = flag.String("managed_by_values", "", "Comma-separated list of additional spec.managedBy values the watcher will process. Runs with unset, empty, whitespace-only or \"tekton.dev/pipeline\" managedBy values are always accepted.")
+	watchedResources             = flag.String("watched_resources", "", "Path to a YAML file listing additional kinds of objects to store, such as Shipwright BuildRuns, and how to derive their status. Typically mounted from a ConfigMap")
+	childObjectKinds             = flag.String("child_object_kinds", "", "Comma-separated list of the kinds, as Kind.group, of the objects CustomRuns can declare as child objects to store, e.g. \"Approval.example.dev,ConfigMap\". Other kinds are skipped")
+	clusterName                  = flag.String("cluster_name", "", "Name of the cluster the watcher runs in, recorded in the Results it stores. Set it when several clusters store their Results in the same API server")
 )
 
 func main() {
@@ -135,14 +146,26 @@ func main() {
 		FinalizerRequeueInterval:     finalizerRequeueInterval,
 		ForwardBuffer:                forwardBuffer,
 		LogsTimestamps:               *logsTimestamps,
+		LogsFollow:                   *logsFollow,
+		LogsFlushInterval:            *logsFlushInterval,
+		LogsPerStep:                  *logsPerStep,
+		LogsRedactSecrets:            *logsRedactSecrets,
 		SummaryLabels:                *summaryLabels,
 		SummaryAnnotations:           *summaryAnnotations,
 		DisableStoringIncompleteRuns: *disableStoringIncompleteRuns,
 		AllowedManagedByValues:       reconciler.ParseManagedByValues(*managedByValues),
+		ChildObjectKinds:             reconciler.ParseKinds(*childObjectKinds),
+		ClusterName:                  *clusterName,
 	}
 
 	log.Printf("dynamic reconcile timeout %s and update log timeout is %s", cfg.DynamicReconcileTimeout.String(), cfg.UpdateLogTimeout.String())
 	log.Printf("managed_by_values: %v", sets.List(cfg.AllowedManagedByValues))
+	if cfg.ClusterName != "" {
+		if errs := validation.IsDNS1123Label(cfg.ClusterName); len(errs) > 0 {
+			log.Fatalf("Malformed -cluster_name value: %s", strings.Join(errs, ", "))
+		}
+		log.Printf("cluster_name: %s", cfg.ClusterName)
+	}
 
 	if selector := *labelSelector; selector != "" {
 		if err := cfg.SetLabelSelector(selector); err != nil {
@@ -163,6 +186,19 @@ func main() {
 		},
 	}
 
+	if *watchedResources != "" {
+		resources, err := generic.LoadResources(*watchedResources)
+		if err != nil {
+			log.Fatalf("Error loading watched resources: %v", err)
+		}
+		for _, resource := range resources {
+			log.Printf("watching %s", resource.GroupVersionKind())
+			ctors = append(ctors, func(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+				return generic.NewControllerWithConfig(ctx, results, cfg, resource)
+			})
+		}
+	}
+
 	// This parses flags.
 	k8scfg := injection.ParseAndGetRESTConfigOrDie()
 
@@ -188,6 +224,8 @@ func connectToAPIServer(ctx context.Context, apiAddr string, authMode string) (*
 
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
+		// Trace context is propagated to the API server in gRPC metadata.
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 	// Add in additional credentials to requests if desired.
 	switch authMode {
@@ -213,6 +251,9 @@ func connectToAPIServer(ctx context.Context, apiAddr string, authMode string) (*
 	case "insecure":
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	if *clusterName != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds.Cluster(*clusterName)))
+	}
 
 	log.Printf("dialing %s...\n", apiAddr)
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
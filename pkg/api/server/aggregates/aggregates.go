@@ -0,0 +1,397 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aggregates exports Prometheus metrics aggregating the Runs stored in
+// the database, such as success ratios and durations by pipeline. Unlike the
+// metrics of the watcher, they survive restarts and cover all the clusters
+// reporting to the API server.
+package aggregates
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/prometheus/client_golang/prometheus"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+)
+
+// summary lists the aggregations computed by the lister for each group.
+const summary = "total,succeeded,failed,cancelled,avg_duration,p95_duration,max_duration"
+
+// kinds lists the kind label of the metrics with the CEL constant of the type
+// of their Records.
+var kinds = []struct {
+	label    string
+	dataType string
+}{
+	{"pipelinerun", "PIPELINE_RUN"},
+	{"taskrun", "TASK_RUN"},
+}
+
+var (
+	labels = []string{"kind", "group_by", "group"}
+
+	runsDesc = prometheus.NewDesc("results_api_aggregate_runs",
+		"Number of Runs completed during the aggregation window, by status.",
+		append(labels, "status"), nil)
+	successRatioDesc = prometheus.NewDesc("results_api_aggregate_success_ratio",
+		"Ratio of the Runs completed during the aggregation window which succeeded.",
+		labels, nil)
+	runsPerHourDesc = prometheus.NewDesc("results_api_aggregate_runs_per_hour",
+		"Average number of Runs completed per hour during the aggregation window.",
+		labels, nil)
+	durationDesc = prometheus.NewDesc("results_api_aggregate_duration_seconds",
+		"Duration of the Runs completed during the aggregation window, by statistic (avg, p95 or max).",
+		append(labels, "stat"), nil)
+	refreshDesc = prometheus.NewDesc("results_api_aggregate_last_refresh_timestamp_seconds",
+		"Time at which the aggregates were last computed successfully.",
+		nil, nil)
+)
+
+// Exporter periodically computes aggregates of the Records in the database,
+// and exposes them as a prometheus.Collector. Values are those of the last
+// refresh, so that scrapes never query the database.
+type Exporter struct {
+	db       *gorm.DB
+	env      *cel.Env
+	logger   *zap.SugaredLogger
+	interval time.Duration
+	window   time.Duration
+	groupBy  []string
+	filter   string
+	now      func() time.Time
+
+	mu          sync.RWMutex
+	metrics     []prometheus.Metric
+	lastRefresh time.Time
+}
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithInterval sets how often aggregates are computed. Defaults to 5 minutes.
+func WithInterval(interval time.Duration) Option {
+	return func(e *Exporter) {
+		if interval > 0 {
+			e.interval = interval
+		}
+	}
+}
+
+// WithWindow sets the period before each refresh in which Runs must have
+// completed to be aggregated. Defaults to 24 hours.
+func WithWindow(window time.Duration) Option {
+	return func(e *Exporter) {
+		if window > 0 {
+			e.window = window
+		}
+	}
+}
+
+// WithGroupBy sets the groupings of the aggregates, in the group_by syntax of
+// GetRecordListSummary: namespace, pipeline, repository or "label <key>".
+// Each grouping produces a separate set of series. Defaults to namespace.
+func WithGroupBy(groupBy ...string) Option {
+	return func(e *Exporter) {
+		if len(groupBy) > 0 {
+			e.groupBy = groupBy
+		}
+	}
+}
+
+// WithFilter restricts the aggregated Records to those matching a CEL
+// expression.
+func WithFilter(filter string) Option {
+	return func(e *Exporter) {
+		e.filter = strings.TrimSpace(filter)
+	}
+}
+
+// New returns an Exporter aggregating the Records of db.
+func New(db *gorm.DB, logger *zap.SugaredLogger, opts ...Option) (*Exporter, error) {
+	env, err := resultscel.NewRecordsEnv()
+	if err != nil {
+		return nil, err
+	}
+	e := &Exporter{
+		db:       db,
+		env:      env,
+		logger:   logger,
+		interval: 5 * time.Minute,
+		window:   24 * time.Hour,
+		groupBy:  []string{"namespace"},
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.filter != "" {
+		if _, err := cel2sql.Convert(env, e.filter); err != nil {
+			return nil, fmt.Errorf("invalid aggregates filter: %w", err)
+		}
+	}
+	for _, group := range e.groupBy {
+		// Groups by time would produce a new series for each period.
+		switch strings.SplitN(group, " ", 2)[0] {
+		case "namespace", "pipeline", "repository", "cluster", "label":
+		default:
+			return nil, fmt.Errorf("invalid aggregates group %q: must be namespace, pipeline, repository, cluster or label <key>", group)
+		}
+		if _, err := e.aggregator(kinds[0].dataType, group, e.now()); err != nil {
+			return nil, fmt.Errorf("invalid aggregates group %q: %w", group, err)
+		}
+	}
+	return e, nil
+}
+
+// Run refreshes the aggregates every interval until ctx is done.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if err := e.refresh(ctx); err != nil {
+			e.logger.Errorf("Failed to compute aggregate metrics: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{runsDesc, successRatioDesc, runsPerHourDesc, durationDesc, refreshDesc} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, m := range e.metrics {
+		ch <- m
+	}
+	if !e.lastRefresh.IsZero() {
+		ch <- prometheus.MustNewConstMetric(refreshDesc, prometheus.GaugeValue, float64(e.lastRefresh.Unix()))
+	}
+}
+
+// refresh computes all the aggregates, and replaces the exported metrics if
+// they all could be computed.
+func (e *Exporter) refresh(ctx context.Context) error {
+	now := e.now()
+	var metrics []prometheus.Metric
+	for _, kind := range kinds {
+		for _, group := range e.groupBy {
+			a, err := e.aggregator(kind.dataType, group, now)
+			if err != nil {
+				return err
+			}
+			sm, err := a.Aggregate(ctx, e.db)
+			if err != nil {
+				return fmt.Errorf("error aggregating %s by %s: %w", kind.label, group, err)
+			}
+			// Runs without a value for the group and those with an empty
+			// value share the same series, so their aggregates are merged.
+			var groups []*aggregate
+			byValue := map[string]*aggregate{}
+			for _, s := range sm.GetSummary() {
+				agg, err := parseAggregate(s.GetFields())
+				if err != nil {
+					return fmt.Errorf("error aggregating %s by %s: %w", kind.label, group, err)
+				}
+				if prev, ok := byValue[agg.group]; ok {
+					prev.merge(agg)
+					continue
+				}
+				byValue[agg.group] = agg
+				groups = append(groups, agg)
+			}
+			for _, agg := range groups {
+				metrics = append(metrics, e.toMetrics(agg, kind.label, group)...)
+			}
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metrics = metrics
+	e.lastRefresh = now
+	return nil
+}
+
+// aggregator returns the Aggregator of the Runs of dataType completed during
+// the window ending at now, grouped by group.
+func (e *Exporter) aggregator(dataType, group string, now time.Time) (*lister.Aggregator, error) {
+	filter := fmt.Sprintf(`data_type == %s && data.status.completionTime > timestamp(%q)`,
+		dataType, now.Add(-e.window).UTC().Format(time.RFC3339))
+	if e.filter != "" {
+		filter = fmt.Sprintf("%s && (%s)", filter, e.filter)
+	}
+	return lister.OfRecordList(e.env, "-", "-", &pb.RecordListSummaryRequest{
+		Parent:  "-/results/-",
+		Filter:  filter,
+		GroupBy: group,
+		Summary: summary,
+	})
+}
+
+// durationStats lists the statistics of the durations of Runs in summaries.
+var durationStats = []string{"avg", "p95", "max"}
+
+// aggregate holds the aggregates of a group of the summary.
+type aggregate struct {
+	group                               string
+	total, succeeded, failed, cancelled float64
+	// durations maps the statistics of durations to their value, for the
+	// groups of Runs with a duration.
+	durations map[string]time.Duration
+}
+
+// parseAggregate returns the aggregates of a group of the summary.
+func parseAggregate(fields map[string]*structpb.Value) (*aggregate, error) {
+	a := &aggregate{
+		group:     groupValue(fields["group_value"]),
+		total:     fields["total"].GetNumberValue(),
+		succeeded: fields["succeeded"].GetNumberValue(),
+		failed:    fields["failed"].GetNumberValue(),
+		cancelled: fields["cancelled"].GetNumberValue(),
+		durations: map[string]time.Duration{},
+	}
+	for _, stat := range durationStats {
+		v, ok := fields[stat+"_duration"]
+		if !ok || v.GetStringValue() == "" {
+			continue
+		}
+		d, err := parseInterval(v.GetStringValue())
+		if err != nil {
+			return nil, err
+		}
+		a.durations[stat] = d
+	}
+	return a, nil
+}
+
+// merge adds the Runs of b to the group of a. Averages are weighted by the
+// number of Runs, and the p95 of the merged groups is the highest of theirs,
+// which is an upper bound of the actual one.
+func (a *aggregate) merge(b *aggregate) {
+	if avgA, ok := a.durations["avg"]; ok {
+		if avgB, ok := b.durations["avg"]; ok && a.total+b.total > 0 {
+			a.durations["avg"] = time.Duration((float64(avgA)*a.total + float64(avgB)*b.total) / (a.total + b.total))
+		}
+	} else if avgB, ok := b.durations["avg"]; ok {
+		a.durations["avg"] = avgB
+	}
+	for _, stat := range []string{"p95", "max"} {
+		if d, ok := b.durations[stat]; ok && d > a.durations[stat] {
+			a.durations[stat] = d
+		}
+	}
+	a.total += b.total
+	a.succeeded += b.succeeded
+	a.failed += b.failed
+	a.cancelled += b.cancelled
+}
+
+// toMetrics returns the metrics of a group of the summary.
+func (e *Exporter) toMetrics(a *aggregate, kind, group string) []prometheus.Metric {
+	values := []string{kind, group, a.group}
+	gauge := func(desc *prometheus.Desc, value float64, extra ...string) prometheus.Metric {
+		return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, append(values, extra...)...)
+	}
+
+	metrics := []prometheus.Metric{
+		gauge(runsDesc, a.succeeded, "succeeded"),
+		gauge(runsDesc, a.failed, "failed"),
+		gauge(runsDesc, a.cancelled, "cancelled"),
+		gauge(runsDesc, a.total-a.succeeded-a.failed-a.cancelled, "others"),
+		gauge(runsPerHourDesc, a.total/e.window.Hours()),
+	}
+	if a.total > 0 {
+		metrics = append(metrics, gauge(successRatioDesc, a.succeeded/a.total))
+	}
+	for _, stat := range durationStats {
+		if d, ok := a.durations[stat]; ok {
+			metrics = append(metrics, gauge(durationDesc, d.Seconds(), stat))
+		}
+	}
+	return metrics
+}
+
+// groupValue returns the value of the group of a summary. Runs without a
+// value for the group, e.g. TaskRuns outside of pipelines grouped by
+// pipeline, are grouped under the empty value.
+func groupValue(v *structpb.Value) string {
+	if s, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+		return s.StringValue
+	}
+	return ""
+}
+
+// parseInterval parses the text representation of a Postgres interval, as
+// returned for the duration aggregates, e.g. "1 day 02:03:04.5".
+func parseInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	fields := strings.Fields(s)
+	for len(fields) > 1 {
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		switch strings.TrimSuffix(fields[1], "s") {
+		case "day":
+			d += time.Duration(n) * 24 * time.Hour
+		case "mon":
+			d += time.Duration(n) * 30 * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		fields = fields[2:]
+	}
+	if len(fields) == 0 {
+		return d, nil
+	}
+	clock := fields[0]
+	sign := time.Duration(1)
+	if strings.HasPrefix(clock, "-") {
+		sign, clock = -1, clock[1:]
+	}
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid interval %q", s)
+	}
+	hours, err1 := strconv.Atoi(parts[0])
+	minutes, err2 := strconv.Atoi(parts[1])
+	seconds, err3 := strconv.ParseFloat(parts[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, fmt.Errorf("invalid interval %q", s)
+	}
+	clockDuration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+	return d + sign*clockDuration, nil
+}
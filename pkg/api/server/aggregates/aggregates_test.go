@@ -0,0 +1,217 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregates
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var columns = []string{"total", "succeeded", "failed", "cancelled", "avg_duration", "p95_duration", "max_duration", "group_value"}
+
+func newExporter(t *testing.T, opts ...Option) (*Exporter, sqlmock.Sqlmock) {
+	t.Helper()
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := New(db, zap.NewNop().Sugar(), opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	e.now = func() time.Time { return time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC) }
+	return e, mock
+}
+
+// gather returns the samples of the exporter as "name{labels} value".
+func gather(t *testing.T, e *Exporter) []string {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(e)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	var got []string
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			var labels []string
+			for _, l := range m.GetLabel() {
+				labels = append(labels, l.GetName()+"="+l.GetValue())
+			}
+			got = append(got, f.GetName()+"{"+strings.Join(labels, ",")+"} "+
+				strconv.FormatFloat(m.GetGauge().GetValue(), 'f', -1, 64))
+		}
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestRefresh(t *testing.T) {
+	e, mock := newExporter(t, WithWindow(10*time.Hour), WithGroupBy("pipeline"), WithFilter(`data.metadata.namespace == "ns"`))
+	mock.ExpectQuery(`PERCENTILE_CONT\(0.95\).* FROM "records" WHERE .*type = 'tekton.dev/v1.PipelineRun'.*'2026-01-02T02:00:00Z'.*= 'ns'.* GROUP BY "group_value"`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(10, 8, 1, 0, "00:01:00", "00:02:30.5", "1 day 00:00:01", "ns/build"))
+	mock.ExpectQuery(`type = 'tekton.dev/v1.TaskRun'`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(2, 0, 0, 0, nil, nil, nil, nil))
+
+	if err := e.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	want := []string{
+		"results_api_aggregate_duration_seconds{group=ns/build,group_by=pipeline,kind=pipelinerun,stat=avg} 60",
+		"results_api_aggregate_duration_seconds{group=ns/build,group_by=pipeline,kind=pipelinerun,stat=max} 86401",
+		"results_api_aggregate_duration_seconds{group=ns/build,group_by=pipeline,kind=pipelinerun,stat=p95} 150.5",
+		"results_api_aggregate_last_refresh_timestamp_seconds{} 1767355200",
+		"results_api_aggregate_runs_per_hour{group=,group_by=pipeline,kind=taskrun} 0.2",
+		"results_api_aggregate_runs_per_hour{group=ns/build,group_by=pipeline,kind=pipelinerun} 1",
+		"results_api_aggregate_runs{group=,group_by=pipeline,kind=taskrun,status=cancelled} 0",
+		"results_api_aggregate_runs{group=,group_by=pipeline,kind=taskrun,status=failed} 0",
+		"results_api_aggregate_runs{group=,group_by=pipeline,kind=taskrun,status=others} 2",
+		"results_api_aggregate_runs{group=,group_by=pipeline,kind=taskrun,status=succeeded} 0",
+		"results_api_aggregate_runs{group=ns/build,group_by=pipeline,kind=pipelinerun,status=cancelled} 0",
+		"results_api_aggregate_runs{group=ns/build,group_by=pipeline,kind=pipelinerun,status=failed} 1",
+		"results_api_aggregate_runs{group=ns/build,group_by=pipeline,kind=pipelinerun,status=others} 1",
+		"results_api_aggregate_runs{group=ns/build,group_by=pipeline,kind=pipelinerun,status=succeeded} 8",
+		"results_api_aggregate_success_ratio{group=ns/build,group_by=pipeline,kind=pipelinerun} 0.8",
+		"results_api_aggregate_success_ratio{group=,group_by=pipeline,kind=taskrun} 0",
+	}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, gather(t, e)); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestRefresh_unsetGroup(t *testing.T) {
+	e, mock := newExporter(t, WithGroupBy("cluster"))
+	// Runs without a cluster and those of the empty cluster share a series.
+	mock.ExpectQuery(`type = 'tekton.dev/v1.PipelineRun'`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, 3, 0, 0, "00:01:00", "00:02:00", "00:03:00", nil).
+			AddRow(1, 0, 1, 0, "00:05:00", "00:05:00", "00:05:00", "").
+			AddRow(2, 2, 0, 0, nil, nil, nil, "build-eu-1"))
+	mock.ExpectQuery(`type = 'tekton.dev/v1.TaskRun'`).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	if err := e.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	want := []string{
+		"results_api_aggregate_duration_seconds{group=,group_by=cluster,kind=pipelinerun,stat=avg} 120",
+		"results_api_aggregate_duration_seconds{group=,group_by=cluster,kind=pipelinerun,stat=max} 300",
+		"results_api_aggregate_duration_seconds{group=,group_by=cluster,kind=pipelinerun,stat=p95} 300",
+		"results_api_aggregate_last_refresh_timestamp_seconds{} 1767355200",
+		"results_api_aggregate_runs_per_hour{group=,group_by=cluster,kind=pipelinerun} 0.16666666666666666",
+		"results_api_aggregate_runs_per_hour{group=build-eu-1,group_by=cluster,kind=pipelinerun} 0.08333333333333333",
+		"results_api_aggregate_runs{group=,group_by=cluster,kind=pipelinerun,status=cancelled} 0",
+		"results_api_aggregate_runs{group=,group_by=cluster,kind=pipelinerun,status=failed} 1",
+		"results_api_aggregate_runs{group=,group_by=cluster,kind=pipelinerun,status=others} 0",
+		"results_api_aggregate_runs{group=,group_by=cluster,kind=pipelinerun,status=succeeded} 3",
+		"results_api_aggregate_runs{group=build-eu-1,group_by=cluster,kind=pipelinerun,status=cancelled} 0",
+		"results_api_aggregate_runs{group=build-eu-1,group_by=cluster,kind=pipelinerun,status=failed} 0",
+		"results_api_aggregate_runs{group=build-eu-1,group_by=cluster,kind=pipelinerun,status=others} 0",
+		"results_api_aggregate_runs{group=build-eu-1,group_by=cluster,kind=pipelinerun,status=succeeded} 2",
+		"results_api_aggregate_success_ratio{group=,group_by=cluster,kind=pipelinerun} 0.75",
+		"results_api_aggregate_success_ratio{group=build-eu-1,group_by=cluster,kind=pipelinerun} 1",
+	}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, gather(t, e)); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	e, mock := newExporter(t)
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(1, 1, 0, 0, "00:00:01", "00:00:01", "00:00:01", "ns"))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(columns))
+	if err := e.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	before := gather(t, e)
+
+	mock.ExpectQuery("SELECT").WillReturnError(errors.New("connection refused"))
+	if err := e.refresh(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	// Metrics of the last successful refresh are kept.
+	if diff := cmp.Diff(before, gather(t, e)); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	for _, opt := range []Option{
+		WithGroupBy("hour"),
+		WithGroupBy("label"),
+		WithGroupBy("foo"),
+		WithFilter("data_type =="),
+	} {
+		if _, err := New(nil, zap.NewNop().Sugar(), opt); err == nil {
+			t.Error("expected error")
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "00:00:00", want: 0},
+		{in: "01:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "00:00:01.25", want: 1250 * time.Millisecond},
+		{in: "-00:01:00", want: -time.Minute},
+		{in: "1 day", want: 24 * time.Hour},
+		{in: "2 days 00:00:01", want: 48*time.Hour + time.Second},
+		{in: "1 mon 1 day 00:00:00", want: 31 * 24 * time.Hour},
+		{in: "01:02", wantErr: true},
+		{in: "1 year", wantErr: true},
+		{in: "foo", wantErr: true},
+	} {
+		got, err := parseInterval(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseInterval(%q): unexpected error %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseInterval(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit provides gRPC interceptors recording who accessed or changed
+// which API resources.
+package audit
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+)
+
+const (
+	// DecisionAllow is the decision of requests which passed authorization.
+	DecisionAllow = "allow"
+	// DecisionDeny is the decision of requests rejected by authorization.
+	DecisionDeny = "deny"
+)
+
+// Event describes a single API call.
+type Event struct {
+	Time             time.Time `json:"time"`
+	Method           string    `json:"method"`
+	Verb             string    `json:"verb"`
+	Resource         string    `json:"resource,omitempty"`
+	Namespace        string    `json:"namespace,omitempty"`
+	User             string    `json:"user,omitempty"`
+	ImpersonatedUser string    `json:"impersonatedUser,omitempty"`
+	Decision         string    `json:"decision"`
+	Code             string    `json:"code"`
+	LatencyMillis    int64     `json:"latencyMillis"`
+	EtagBefore       string    `json:"etagBefore,omitempty"`
+	EtagAfter        string    `json:"etagAfter,omitempty"`
+}
+
+// EtagFunc returns the current etag of the named resource.
+type EtagFunc func(ctx context.Context, name string) (string, error)
+
+// Auditor emits an Event to its Sink for every intercepted call.
+type Auditor struct {
+	sink          Sink
+	logger        *zap.SugaredLogger
+	authn         auth.Authenticator
+	etag          EtagFunc
+	mutationsOnly bool
+}
+
+// Option is customization for Auditor configuration.
+type Option func(*Auditor)
+
+// WithAuthenticator is an option to resolve the caller of each request.
+// Without it, events carry no user.
+func WithAuthenticator(authn auth.Authenticator) Option {
+	return func(a *Auditor) {
+		a.authn = authn
+	}
+}
+
+// WithEtagLookup is an option to record the etag of resources changed by
+// calls whose responses don't carry it. The etag is looked up once the call
+// succeeded, the etag of resources before they are changed being reported by
+// the handlers with SetEtagBefore.
+func WithEtagLookup(f EtagFunc) Option {
+	return func(a *Auditor) {
+		a.etag = f
+	}
+}
+
+// WithMutationsOnly is an option to only audit calls changing resources.
+func WithMutationsOnly(enabled bool) Option {
+	return func(a *Auditor) {
+		a.mutationsOnly = enabled
+	}
+}
+
+// New returns an Auditor writing events to sink.
+func New(sink Sink, logger *zap.SugaredLogger, opts ...Option) *Auditor {
+	a := &Auditor{
+		sink:   sink,
+		logger: logger,
+	}
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+// Close flushes the events emitted so far and closes the sink. A nil Auditor
+// has nothing to close.
+func (a *Auditor) Close() error {
+	if a == nil {
+		return nil
+	}
+	return a.sink.Close()
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor auditing
+// calls. A nil Auditor audits nothing.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if a == nil || !a.audited(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		c := a.begin(info.FullMethod, req)
+		ctx = context.WithValue(ctx, callKey{}, c)
+		resp, err := handler(ctx, req)
+		a.end(ctx, c, resp, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor auditing
+// calls. The first message received from the client is used to describe the
+// call. A nil Auditor audits nothing.
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil || !a.audited(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		c := a.begin(info.FullMethod, nil)
+		s := &auditedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), callKey{}, c),
+			call:         c,
+		}
+		err := handler(srv, s)
+		a.end(s.ctx, c, nil, err)
+		return err
+	}
+}
+
+type auditedStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	call     *call
+	received bool
+}
+
+func (s *auditedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditedStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && !s.received {
+		s.received = true
+		s.call.describe(m)
+	}
+	return err
+}
+
+// call holds the state of an audited call between its start and its end.
+type call struct {
+	event *Event
+	start time.Time
+}
+
+type callKey struct{}
+
+// describe sets the resource of the call from its request, or its response
+// for calls whose request doesn't name it.
+func (c *call) describe(m any) {
+	if c.event.Resource != "" {
+		return
+	}
+	c.event.Resource = resourceName(m)
+	c.event.Namespace, _, _ = strings.Cut(c.event.Resource, "/")
+}
+
+// SetEtagBefore reports the etag of the resource changed by the audited call
+// of ctx, before it is changed. Handlers report it once the call is authorized
+// and the resource loaded, so that auditing doesn't read resources on behalf of
+// callers who aren't allowed to. Only the first etag reported is kept.
+func SetEtagBefore(ctx context.Context, etag string) {
+	if c, ok := ctx.Value(callKey{}).(*call); ok && c.event.EtagBefore == "" {
+		c.event.EtagBefore = etag
+	}
+}
+
+func (a *Auditor) audited(method string) bool {
+	if strings.HasPrefix(method, "/grpc.health.") || strings.HasPrefix(method, "/grpc.reflection.") {
+		return false
+	}
+	return !a.mutationsOnly || isMutation(verb(method))
+}
+
+func (a *Auditor) begin(method string, req any) *call {
+	e := &Event{
+		Time:   time.Now(),
+		Method: method,
+		Verb:   verb(method),
+	}
+	c := &call{event: e, start: e.Time}
+	c.describe(req)
+	return c
+}
+
+func (a *Auditor) end(ctx context.Context, c *call, resp any, err error) {
+	e := c.event
+	e.LatencyMillis = time.Since(c.start).Milliseconds()
+	code := status.Code(err)
+	e.Code = code.String()
+	e.Decision = DecisionAllow
+	if code == codes.Unauthenticated || code == codes.PermissionDenied {
+		e.Decision = DecisionDeny
+	}
+
+	c.describe(resp)
+	// The caller is resolved once the handler ran, so that the identity
+	// established by the auth checker is reused.
+	e.User, e.ImpersonatedUser = a.users(ctx)
+	if err == nil && isMutation(e.Verb) && e.Verb != auth.PermissionDelete {
+		if r, ok := resp.(interface{ GetEtag() string }); ok {
+			e.EtagAfter = r.GetEtag()
+		} else if a.etag != nil && e.Resource != "" {
+			e.EtagAfter, _ = a.etag(ctx, e.Resource)
+		}
+	}
+
+	if err := a.sink.Write(e); err != nil {
+		a.logger.Errorf("failed to write audit event for %s: %v", e.Method, err)
+	}
+}
+
+// users returns the name of the caller and, if the request impersonates
+// another user, the name of the impersonated user.
+func (a *Auditor) users(ctx context.Context) (string, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	var impersonated string
+	if v := md.Get(authnv1.ImpersonateUserHeader); len(v) > 0 {
+		impersonated = v[0]
+	}
+	if a.authn == nil {
+		return "", impersonated
+	}
+	user, err := auth.Caller(ctx, a.authn)
+	if err != nil {
+		return "", impersonated
+	}
+	return user.Username, impersonated
+}
+
+// verb maps a method such as "/tekton.results.v1alpha2.Results/DeleteRecord"
+// to the auth verb it requires.
+func verb(method string) string {
+	name := path.Base(method)
+	for _, v := range []string{auth.PermissionCreate, auth.PermissionGet, auth.PermissionList, auth.PermissionUpdate, auth.PermissionDelete} {
+		if strings.HasPrefix(strings.ToLower(name), v) {
+			return v
+		}
+	}
+	return strings.ToLower(name)
+}
+
+func isMutation(verb string) bool {
+	return verb == auth.PermissionCreate || verb == auth.PermissionUpdate || verb == auth.PermissionDelete
+}
+
+// resourceName returns the name of the resource a request or response refers
+// to, falling back to its parent for collection requests.
+func resourceName(m any) string {
+	if m, ok := m.(interface{ GetName() string }); ok && m.GetName() != "" {
+		return m.GetName()
+	}
+	if m, ok := m.(interface{ GetRecord() *pb.Record }); ok && m.GetRecord().GetName() != "" {
+		return m.GetRecord().GetName()
+	}
+	if m, ok := m.(interface{ GetResult() *pb.Result }); ok && m.GetResult().GetName() != "" {
+		return m.GetResult().GetName()
+	}
+	if m, ok := m.(interface{ GetParent() string }); ok {
+		return m.GetParent()
+	}
+	return ""
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+)
+
+type fakeSink struct {
+	events []*Event
+}
+
+func (s *fakeSink) Write(e *Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	return nil
+}
+
+// tokenAuthenticator uses the bearer token as the username, and rejects
+// impersonation metadata so that tests notice if it isn't stripped.
+type tokenAuthenticator struct{}
+
+func (tokenAuthenticator) Authenticate(ctx context.Context) (*authnv1.UserInfo, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(authnv1.ImpersonateUserHeader)) > 0 {
+		return nil, status.Error(codes.Unauthenticated, "impersonation")
+	}
+	token := md.Get("authorization")
+	if len(token) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no token")
+	}
+	return &authnv1.UserInfo{Username: token[0]}, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	etags := map[string]string{"foo/results/bar/logs/baz": "etag-5"}
+	var lookups int
+	sink := &fakeSink{}
+	a := New(sink, zap.NewNop().Sugar(),
+		WithAuthenticator(tokenAuthenticator{}),
+		WithEtagLookup(func(_ context.Context, name string) (string, error) {
+			lookups++
+			return etags[name], nil
+		}),
+	)
+	interceptor := a.UnaryServerInterceptor()
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		md      metadata.MD
+		req     any
+		handler grpc.UnaryHandler
+		want    *Event
+		// lookups is the number of etags looked up for the call.
+		lookups int
+	}{
+		{
+			name:   "update",
+			method: "/tekton.results.v1alpha2.Results/UpdateRecord",
+			md:     metadata.Pairs("authorization", "alice", authnv1.ImpersonateUserHeader, "bob"),
+			req:    &pb.UpdateRecordRequest{Record: &pb.Record{Name: "foo/results/bar/records/baz"}},
+			handler: func(ctx context.Context, _ any) (any, error) {
+				SetEtagBefore(ctx, "etag-1")
+				return &pb.Record{Name: "foo/results/bar/records/baz", Etag: "etag-2"}, nil
+			},
+			want: &Event{
+				Method:           "/tekton.results.v1alpha2.Results/UpdateRecord",
+				Verb:             "update",
+				Resource:         "foo/results/bar/records/baz",
+				Namespace:        "foo",
+				User:             "alice",
+				ImpersonatedUser: "bob",
+				Decision:         DecisionAllow,
+				Code:             "OK",
+				EtagBefore:       "etag-1",
+				EtagAfter:        "etag-2",
+			},
+		},
+		{
+			name:   "delete",
+			method: "/tekton.results.v1alpha2.Results/DeleteRecord",
+			md:     metadata.Pairs("authorization", "alice"),
+			req:    &pb.DeleteRecordRequest{Name: "foo/results/bar/records/baz"},
+			handler: func(ctx context.Context, _ any) (any, error) {
+				SetEtagBefore(ctx, "etag-1")
+				return nil, nil
+			},
+			want: &Event{
+				Method:     "/tekton.results.v1alpha2.Results/DeleteRecord",
+				Verb:       "delete",
+				Resource:   "foo/results/bar/records/baz",
+				Namespace:  "foo",
+				User:       "alice",
+				Decision:   DecisionAllow,
+				Code:       "OK",
+				EtagBefore: "etag-1",
+			},
+		},
+		{
+			name:   "denied",
+			method: "/tekton.results.v1alpha2.Results/ListResults",
+			md:     metadata.Pairs("authorization", "mallory"),
+			req:    &pb.ListResultsRequest{Parent: "foo"},
+			handler: func(_ context.Context, _ any) (any, error) {
+				return nil, status.Error(codes.Unauthenticated, "permission denied")
+			},
+			want: &Event{
+				Method:    "/tekton.results.v1alpha2.Results/ListResults",
+				Verb:      "list",
+				Resource:  "foo",
+				Namespace: "foo",
+				User:      "mallory",
+				Decision:  DecisionDeny,
+				Code:      "Unauthenticated",
+			},
+		},
+		{
+			name:   "denied update",
+			method: "/tekton.results.v1alpha2.Results/UpdateRecord",
+			md:     metadata.Pairs("authorization", "mallory"),
+			req:    &pb.UpdateRecordRequest{Record: &pb.Record{Name: "foo/results/bar/records/baz"}},
+			handler: func(_ context.Context, _ any) (any, error) {
+				return nil, status.Error(codes.Unauthenticated, "permission denied")
+			},
+			want: &Event{
+				Method:    "/tekton.results.v1alpha2.Results/UpdateRecord",
+				Verb:      "update",
+				Resource:  "foo/results/bar/records/baz",
+				Namespace: "foo",
+				User:      "mallory",
+				Decision:  DecisionDeny,
+				Code:      "Unauthenticated",
+			},
+		},
+		{
+			name:   "update without etag in the response",
+			method: "/tekton.results.v1alpha2.Logs/UpdateLog",
+			md:     metadata.Pairs("authorization", "alice"),
+			req:    &pb.Log{Name: "foo/results/bar/logs/baz"},
+			handler: func(ctx context.Context, _ any) (any, error) {
+				SetEtagBefore(ctx, "etag-4")
+				return &pb.LogSummary{Record: "foo/results/bar/logs/baz"}, nil
+			},
+			want: &Event{
+				Method:     "/tekton.results.v1alpha2.Logs/UpdateLog",
+				Verb:       "update",
+				Resource:   "foo/results/bar/logs/baz",
+				Namespace:  "foo",
+				User:       "alice",
+				Decision:   DecisionAllow,
+				Code:       "OK",
+				EtagBefore: "etag-4",
+				EtagAfter:  "etag-5",
+			},
+			lookups: 1,
+		},
+		{
+			name:   "create",
+			method: "/tekton.results.v1alpha2.Results/CreateResult",
+			md:     metadata.Pairs("authorization", "alice"),
+			req:    &pb.CreateResultRequest{Parent: "foo", Result: &pb.Result{Name: "foo/results/bar"}},
+			handler: func(_ context.Context, _ any) (any, error) {
+				return &pb.Result{Name: "foo/results/bar", Etag: "etag-3"}, nil
+			},
+			want: &Event{
+				Method:    "/tekton.results.v1alpha2.Results/CreateResult",
+				Verb:      "create",
+				Resource:  "foo/results/bar",
+				Namespace: "foo",
+				User:      "alice",
+				Decision:  DecisionAllow,
+				Code:      "OK",
+				EtagAfter: "etag-3",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink.events, lookups = nil, 0
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			// Handler errors are returned unchanged and reflected in the event.
+			_, _ = interceptor(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, tc.handler)
+			if len(sink.events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(sink.events))
+			}
+			if diff := cmp.Diff(tc.want, sink.events[0], cmpopts.IgnoreFields(Event{}, "Time", "LatencyMillis")); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+			if lookups != tc.lookups {
+				t.Errorf("etag lookups: got %d, want %d", lookups, tc.lookups)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_mutationsOnly(t *testing.T) {
+	sink := &fakeSink{}
+	interceptor := New(sink, zap.NewNop().Sugar(), WithMutationsOnly(true)).UnaryServerInterceptor()
+	handler := func(_ context.Context, _ any) (any, error) { return nil, nil }
+
+	for _, method := range []string{
+		"/tekton.results.v1alpha2.Results/GetRecord",
+		"/tekton.results.v1alpha2.Results/DeleteResult",
+		"/grpc.health.v1.Health/Check",
+	} {
+		if _, err := interceptor(context.Background(), &pb.GetRecordRequest{}, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(sink.events) != 1 || sink.events[0].Verb != "delete" {
+		t.Errorf("expected a single delete event, got %+v", sink.events)
+	}
+}
+
+func TestNilAuditor(t *testing.T) {
+	var a *Auditor
+	called := false
+	_, err := a.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/tekton.results.v1alpha2.Results/DeleteResult"},
+		func(_ context.Context, _ any) (any, error) {
+			called = true
+			return nil, nil
+		})
+	if err != nil || !called {
+		t.Errorf("handler not called: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SinkStdout writes events to the standard output.
+	SinkStdout = "stdout"
+	// SinkFile appends events to a file.
+	SinkFile = "file"
+	// SinkWebhook posts events to an HTTP endpoint.
+	SinkWebhook = "webhook"
+
+	webhookQueueSize = 1024
+	webhookTimeout   = 10 * time.Second
+	// webhookDrainTimeout bounds the time spent posting the queued events
+	// when the sink is closed.
+	webhookDrainTimeout = 30 * time.Second
+)
+
+// Sink is the destination of audit events.
+type Sink interface {
+	Write(e *Event) error
+	// Close flushes the events written so far and releases the resources of
+	// the sink. Events written after Close are rejected.
+	Close() error
+}
+
+// writerSink writes events as JSON lines.
+type writerSink struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	closed bool
+}
+
+// NewWriterSink returns a Sink writing events to w, one JSON document per
+// line.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+// NewStdoutSink returns a Sink writing events to the standard output.
+func NewStdoutSink() Sink {
+	return NewWriterSink(os.Stdout)
+}
+
+// NewFileSink returns a Sink appending events to the file at path.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	return &writerSink{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+func (s *writerSink) Write(e *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("audit sink is closed, dropping event")
+	}
+	return s.enc.Encode(e)
+}
+
+// Close closes the file of file sinks. The writers of other sinks, such as
+// the standard output, are left open.
+func (s *writerSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// webhookSink posts events to an HTTP endpoint in the background, so that a
+// slow endpoint doesn't delay API calls. Events are dropped when the queue is
+// full.
+type webhookSink struct {
+	url    string
+	client *http.Client
+	logger *zap.SugaredLogger
+	queue  chan *Event
+	// ctx is canceled once the queue couldn't be drained in time on Close.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewWebhookSink returns a Sink posting each event as JSON to url.
+func NewWebhookSink(url string, logger *zap.SugaredLogger) Sink {
+	s := &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+		queue:  make(chan *Event, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) Write(e *Event) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return fmt.Errorf("audit webhook sink is closed, dropping event")
+	}
+	select {
+	case s.queue <- e:
+		return nil
+	default:
+		return fmt.Errorf("audit webhook queue is full, dropping event")
+	}
+}
+
+// Close posts the queued events, giving up after webhookDrainTimeout.
+func (s *webhookSink) Close() error {
+	return s.close(webhookDrainTimeout)
+}
+
+func (s *webhookSink) close(timeout time.Duration) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+		return nil
+	case <-timer.C:
+		dropped := len(s.queue)
+		s.cancel()
+		<-s.done
+		return fmt.Errorf("timed out posting the queued audit events, dropped %d", dropped)
+	}
+}
+
+func (s *webhookSink) run() {
+	defer close(s.done)
+	for e := range s.queue {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err := s.post(e); err != nil {
+			s.logger.Errorf("failed to post audit event for %s: %v", e.Method, err)
+		}
+	}
+}
+
+func (s *webhookSink) post(e *Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestFileSink_Close(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink: %v", err)
+	}
+	if err := s.Write(&Event{Method: "CreateResult"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if err := s.Write(&Event{Method: "DeleteResult"}); err == nil {
+		t.Error("expected Write to fail once closed")
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "CreateResult") {
+		t.Errorf("unexpected audit log %q", b)
+	}
+}
+
+func TestWebhookSink_Close(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		paths []string
+	)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	s := NewWebhookSink(srv.URL+"/events", zap.NewNop().Sugar())
+	for i := 0; i < 3; i++ {
+		if err := s.Write(&Event{Method: "CreateResult"}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	// Let the endpoint respond once Close is waiting for the queue.
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 3 {
+		t.Errorf("expected the 3 queued events to be posted, got %d", len(paths))
+	}
+	if err := s.Write(&Event{Method: "DeleteResult"}); err == nil {
+		t.Error("expected Write to fail once closed")
+	}
+}
+
+func TestWebhookSink_closeTimeout(t *testing.T) {
+	stop := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	defer srv.Close()
+	defer close(stop)
+
+	s := NewWebhookSink(srv.URL, zap.NewNop().Sugar()).(*webhookSink)
+	for i := 0; i < 3; i++ {
+		if err := s.Write(&Event{Method: "CreateResult"}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	start := time.Now()
+	if err := s.close(100 * time.Millisecond); err == nil {
+		t.Error("expected Close to time out")
+	}
+	if d := time.Since(start); d > webhookTimeout {
+		t.Errorf("Close took %v", d)
+	}
+}
@@ -33,6 +33,7 @@ func NewResultsEnv() (*cel.Env, error) {
 			cel.ObjectType("google.protobuf.Timestamp")),
 		cel.Variable("update_time",
 			cel.ObjectType("google.protobuf.Timestamp")),
+		cel.Variable("cluster", cel.StringType),
 	)
 }
 
@@ -46,5 +47,6 @@ func NewRecordsEnv() (*cel.Env, error) {
 		cel.Variable("name", cel.StringType),
 		cel.Variable("data_type", cel.StringType),
 		cel.Variable("data", cel.AnyType),
+		cel.Variable("cluster", cel.StringType),
 	)
 }
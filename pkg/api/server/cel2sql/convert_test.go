@@ -34,6 +34,11 @@ func TestConvertRecordExpressions(t *testing.T) {
 			in:   `name == "foo"`,
 			want: "(name = 'foo')",
 		},
+		{
+			name: "cluster",
+			in:   `cluster in ["", "build-eu-1"]`,
+			want: "(cluster IN ('', 'build-eu-1'))",
+		},
 		{
 			name: "select expression",
 			in:   `data.metadata.namespace == "default"`,
@@ -191,6 +196,11 @@ func TestConvertResultExpressions(t *testing.T) {
 			in:   `uid == "foo"`,
 			want: "(id = 'foo')",
 		},
+		{
+			name: "Result.Cluster field",
+			in:   `cluster == "build-eu-1"`,
+			want: "(cluster = 'build-eu-1')",
+		},
 		{
 			name: "Result.Annotations field",
 			in:   `annotations["repo"] == "tektoncd/results"`,
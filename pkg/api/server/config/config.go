@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -32,8 +33,41 @@ type Config struct {
 	K8S_QPS          int `mapstructure:"K8S_QPS"`
 	K8S_BURST        int `mapstructure:"K8S_BURST"`
 
-	AUTH_DISABLE     bool `mapstructure:"AUTH_DISABLE"`
-	AUTH_IMPERSONATE bool `mapstructure:"AUTH_IMPERSONATE"`
+	AUTH_DISABLE         bool          `mapstructure:"AUTH_DISABLE"`
+	AUTH_IMPERSONATE     bool          `mapstructure:"AUTH_IMPERSONATE"`
+	AUTH_POLICY_PATH     string        `mapstructure:"AUTH_POLICY_PATH"`
+	AUTH_CACHE_SIZE      int           `mapstructure:"AUTH_CACHE_SIZE"`
+	AUTH_CACHE_ALLOW_TTL time.Duration `mapstructure:"AUTH_CACHE_ALLOW_TTL"`
+	AUTH_CACHE_DENY_TTL  time.Duration `mapstructure:"AUTH_CACHE_DENY_TTL"`
+
+	CLUSTER_NAME                 string `mapstructure:"CLUSTER_NAME"`
+	AUTH_CLUSTERS_KUBECONFIG_DIR string `mapstructure:"AUTH_CLUSTERS_KUBECONFIG_DIR"`
+
+	AUTH_OIDC_ISSUER_URL     string `mapstructure:"AUTH_OIDC_ISSUER_URL"`
+	AUTH_OIDC_AUDIENCE       string `mapstructure:"AUTH_OIDC_AUDIENCE"`
+	AUTH_OIDC_JWKS_URL       string `mapstructure:"AUTH_OIDC_JWKS_URL"`
+	AUTH_OIDC_USERNAME_CLAIM string `mapstructure:"AUTH_OIDC_USERNAME_CLAIM"`
+	AUTH_OIDC_GROUPS_CLAIM   string `mapstructure:"AUTH_OIDC_GROUPS_CLAIM"`
+	AUTH_OIDC_RULES_PATH     string `mapstructure:"AUTH_OIDC_RULES_PATH"`
+
+	AUDIT_SINK           string `mapstructure:"AUDIT_SINK"`
+	AUDIT_FILE_PATH      string `mapstructure:"AUDIT_FILE_PATH"`
+	AUDIT_WEBHOOK_URL    string `mapstructure:"AUDIT_WEBHOOK_URL"`
+	AUDIT_MUTATIONS_ONLY bool   `mapstructure:"AUDIT_MUTATIONS_ONLY"`
+
+	NOTIFY_SUBSCRIPTIONS_PATH string `mapstructure:"NOTIFY_SUBSCRIPTIONS_PATH"`
+	NOTIFY_RETRIES            int    `mapstructure:"NOTIFY_RETRIES"`
+	NOTIFY_DEAD_LETTER_PATH   string `mapstructure:"NOTIFY_DEAD_LETTER_PATH"`
+
+	RATE_LIMIT_QPS              float64 `mapstructure:"RATE_LIMIT_QPS"`
+	RATE_LIMIT_BURST            int     `mapstructure:"RATE_LIMIT_BURST"`
+	RATE_LIMIT_SUMMARY_QPS      float64 `mapstructure:"RATE_LIMIT_SUMMARY_QPS"`
+	RATE_LIMIT_SUMMARY_BURST    int     `mapstructure:"RATE_LIMIT_SUMMARY_BURST"`
+	RATE_LIMIT_LARGE_PAGE_SIZE  int32   `mapstructure:"RATE_LIMIT_LARGE_PAGE_SIZE"`
+	RATE_LIMIT_LARGE_PAGE_QPS   float64 `mapstructure:"RATE_LIMIT_LARGE_PAGE_QPS"`
+	RATE_LIMIT_LARGE_PAGE_BURST int     `mapstructure:"RATE_LIMIT_LARGE_PAGE_BURST"`
+
+	LIST_TOTAL_SIZE_EXACT_LIMIT int `mapstructure:"LIST_TOTAL_SIZE_EXACT_LIMIT"`
 
 	LOGS_API         bool   `mapstructure:"LOGS_API"`
 	LOGS_TYPE        string `mapstructure:"LOGS_TYPE"`
@@ -41,6 +75,17 @@ type Config struct {
 	LOGS_PATH        string `mapstructure:"LOGS_PATH"`
 	LOGS_TIMESTAMPS  bool   `mapstructure:"LOGS_TIMESTAMPS"`
 
+	LOGS_REDACTION_RULES_PATH string `mapstructure:"LOGS_REDACTION_RULES_PATH"`
+
+	TRACING_PROTOCOL      string  `mapstructure:"TRACING_PROTOCOL"`
+	TRACING_ENDPOINT      string  `mapstructure:"TRACING_ENDPOINT"`
+	TRACING_SAMPLING_RATE float64 `mapstructure:"TRACING_SAMPLING_RATE"`
+
+	METRICS_AGGREGATES_INTERVAL time.Duration `mapstructure:"METRICS_AGGREGATES_INTERVAL"`
+	METRICS_AGGREGATES_WINDOW   time.Duration `mapstructure:"METRICS_AGGREGATES_WINDOW"`
+	METRICS_AGGREGATES_GROUP_BY string        `mapstructure:"METRICS_AGGREGATES_GROUP_BY"`
+	METRICS_AGGREGATES_FILTER   string        `mapstructure:"METRICS_AGGREGATES_FILTER"`
+
 	PROFILING      bool   `mapstructure:"PROFILING"`
 	PROFILING_PORT string `mapstructure:"PROFILING_PORT"`
 
@@ -55,8 +100,11 @@ type Config struct {
 	S3_SECRET_ACCESS_KEY  string `mapstructure:"S3_SECRET_ACCESS_KEY"`
 	S3_MULTI_PART_SIZE    int64  `mapstructure:"S3_MULTI_PART_SIZE"`
 
-	CONVERTER_ENABLE   bool `mapstructure:"CONVERTER_ENABLE"`
-	CONVERTER_DB_LIMIT int  `mapstructure:"CONVERTER_DB_LIMIT"`
+	CONVERTER_ENABLE     bool    `mapstructure:"CONVERTER_ENABLE"`
+	CONVERTER_DB_LIMIT   int     `mapstructure:"CONVERTER_DB_LIMIT"`
+	CONVERTER_MIGRATIONS string  `mapstructure:"CONVERTER_MIGRATIONS"`
+	CONVERTER_RATE_LIMIT float64 `mapstructure:"CONVERTER_RATE_LIMIT"`
+	CONVERTER_DRY_RUN    bool    `mapstructure:"CONVERTER_DRY_RUN"`
 
 	LOGGING_PLUGIN_API_URL                  string `mapstructure:"LOGGING_PLUGIN_API_URL"`
 	LOGGING_PLUGIN_NAMESPACE_KEY            string `mapstructure:"LOGGING_PLUGIN_NAMESPACE_KEY"`
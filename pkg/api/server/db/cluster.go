@@ -0,0 +1,96 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// legacyIndexes are the indexes of Results and Records by name which predate
+// clusters. They are superseded by the results_by_cluster_name and
+// records_by_cluster_name indexes, which also include the cluster so that the
+// namespaces of different clusters may have Results and Records of the same
+// name.
+var legacyIndexes = []struct {
+	model any
+	name  string
+}{
+	{model: &Result{}, name: "results_by_name"},
+	{model: &Record{}, name: "records_by_name"},
+}
+
+// PrepareClusters readies the tables of Results and Records migrated before
+// clusters for the indexes by cluster and name, and must be run before the
+// tables are migrated. Resources stored without a cluster are moved to the
+// local one, as NULL clusters aren't matched by lookups and aren't unique.
+// Records of the same name in the same Result, which only the index of Results
+// prevented, are deduplicated, the last updated one being kept. It returns the
+// number of Records deleted.
+func PrepareClusters(gdb *gorm.DB) (int64, error) {
+	m := gdb.Migrator()
+	if !m.HasTable(&Record{}) || m.HasIndex(&Record{}, "records_by_cluster_name") {
+		return 0, nil
+	}
+	sameCluster := ""
+	for _, model := range []any{&Result{}, &Record{}} {
+		if !m.HasColumn(model, "cluster") {
+			continue
+		}
+		if err := gdb.Model(model).Where("cluster IS NULL").Update("cluster", "").Error; err != nil {
+			return 0, fmt.Errorf("error backfilling clusters: %w", err)
+		}
+		sameCluster = " AND newer.cluster = records.cluster"
+	}
+	q := gdb.Exec(`DELETE FROM records WHERE EXISTS (SELECT 1 FROM records newer
+		WHERE newer.parent = records.parent AND newer.result_name = records.result_name AND newer.name = records.name` + sameCluster + `
+		AND (newer.updated_time > records.updated_time OR newer.updated_time = records.updated_time AND newer.id > records.id))`)
+	if q.Error != nil {
+		return 0, fmt.Errorf("error deduplicating records: %w", q.Error)
+	}
+	return q.RowsAffected, nil
+}
+
+// DropLegacyIndexes drops the indexes by name which don't include the
+// cluster. It must be run after the tables are migrated, so that names stay
+// unique within a cluster meanwhile.
+func DropLegacyIndexes(gdb *gorm.DB) error {
+	m := gdb.Migrator()
+	for _, idx := range legacyIndexes {
+		if !m.HasIndex(idx.model, idx.name) {
+			continue
+		}
+		if err := m.DropIndex(idx.model, idx.name); err != nil {
+			return fmt.Errorf("error dropping index %s: %w", idx.name, err)
+		}
+	}
+	return nil
+}
+
+// InCluster scopes the lookup of a Result or Record by name of tx to the given
+// cluster, the resources of the local cluster being stored without a cluster.
+// If the cluster isn't known, all the clusters are looked up, the local one
+// first. Unlike gorm scopes, the lookup is scoped right away so that the order
+// takes precedence over the one First adds.
+func InCluster(tx *gorm.DB, cluster, local string) *gorm.DB {
+	switch cluster {
+	case "":
+		return tx.Order("cluster")
+	case local:
+		return tx.Where("cluster IN ?", []string{"", local})
+	}
+	return tx.Where("cluster = ?", cluster)
+}
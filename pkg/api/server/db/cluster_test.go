@@ -0,0 +1,181 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/test"
+)
+
+func TestDropLegacyIndexes(t *testing.T) {
+	gdb := test.NewDB(t)
+	if err := gdb.AutoMigrate(&Result{}, &Record{}); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+	// Databases migrated before clusters have unique indexes by name.
+	for _, stmt := range []string{
+		"CREATE UNIQUE INDEX results_by_name ON results(parent, name)",
+		"CREATE UNIQUE INDEX records_by_name ON records(parent, result_name, name)",
+	} {
+		if err := gdb.Exec(stmt).Error; err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	// Dropping them twice is a no-op.
+	for range 2 {
+		if err := DropLegacyIndexes(gdb); err != nil {
+			t.Fatalf("DropLegacyIndexes: %v", err)
+		}
+	}
+	m := gdb.Migrator()
+	if m.HasIndex(&Result{}, "results_by_name") || m.HasIndex(&Record{}, "records_by_name") {
+		t.Error("DropLegacyIndexes: legacy indexes not dropped")
+	}
+
+	// Results and Records of different clusters may have the same name, but
+	// not those of the same cluster.
+	for _, cluster := range []string{"", "build-eu-1"} {
+		res := &Result{Parent: "ci", ID: "result-" + cluster, Name: "release", Cluster: cluster}
+		if err := gdb.Create(res).Error; err != nil {
+			t.Fatalf("Create Result of cluster %q: %v", cluster, err)
+		}
+		rec := &Record{Parent: "ci", ResultID: res.ID, ResultName: res.Name, ID: "record-" + cluster, Name: "release", Cluster: cluster}
+		if err := gdb.Create(rec).Error; err != nil {
+			t.Fatalf("Create Record of cluster %q: %v", cluster, err)
+		}
+	}
+	if err := gdb.Create(&Result{Parent: "ci", ID: "other", Name: "release", Cluster: "build-eu-1"}).Error; err == nil {
+		t.Error("Create Result: want a unique constraint error")
+	}
+}
+
+// baselineResult and baselineRecord are the models of Results and Records
+// before clusters.
+type baselineResult struct {
+	Parent      string      `gorm:"primaryKey;uniqueIndex:results_by_name,priority:1;size:64;"`
+	ID          string      `gorm:"primaryKey;size:64;"`
+	Name        string      `gorm:"uniqueIndex:results_by_name,priority:2;size:64;"`
+	Annotations Annotations `gorm:"type:jsonb;"`
+
+	CreatedTime time.Time `gorm:"default:current_timestamp;"`
+	UpdatedTime time.Time `gorm:"default:current_timestamp;"`
+
+	Summary RecordSummary `gorm:"embedded;embeddedPrefix:recordsummary_;"`
+
+	Etag string `gorm:"size:128;"`
+}
+
+func (baselineResult) TableName() string { return "results" }
+
+type baselineRecord struct {
+	Parent     string `gorm:"primaryKey;uniqueIndex:records_by_name,priority:1;size:64;"`
+	ResultID   string `gorm:"primaryKey;size:64;"`
+	ResultName string `gorm:"uniqueIndex:records_by_name,priority:2;size:64;"`
+
+	ID   string `gorm:"primaryKey;size:64;"`
+	Name string `gorm:"index:records_by_name,priority:3;size:64;"`
+
+	Type string `gorm:"size:768;"`
+	Data []byte `gorm:"type:jsonb;"`
+
+	CreatedTime time.Time `gorm:"default:current_timestamp;"`
+	UpdatedTime time.Time `gorm:"default:current_timestamp;"`
+
+	Etag string `gorm:"size:128;"`
+}
+
+func (baselineRecord) TableName() string { return "records" }
+
+func TestPrepareClusters(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// nullableClusters adds the cluster columns without a default, as
+		// earlier migrations did.
+		nullableClusters bool
+	}{
+		{name: "baseline"},
+		{name: "nullable clusters", nullableClusters: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gdb := test.NewDB(t)
+			if err := gdb.AutoMigrate(&baselineResult{}, &baselineRecord{}); err != nil {
+				t.Fatalf("AutoMigrate: %v", err)
+			}
+			// Databases whose index of Records by name isn't unique may have
+			// Records of the same name.
+			if err := gdb.Migrator().DropIndex(&baselineRecord{}, "records_by_name"); err != nil {
+				t.Fatalf("DropIndex: %v", err)
+			}
+			if tc.nullableClusters {
+				for _, stmt := range []string{
+					"ALTER TABLE results ADD cluster varchar(64)",
+					"ALTER TABLE records ADD cluster varchar(64)",
+				} {
+					if err := gdb.Exec(stmt).Error; err != nil {
+						t.Fatalf("%s: %v", stmt, err)
+					}
+				}
+			}
+			now := time.Now()
+			for _, v := range []any{
+				&baselineResult{Parent: "ci", ID: "result", Name: "release"},
+				&baselineRecord{Parent: "ci", ResultID: "result", ResultName: "release", ID: "run", Name: "run", UpdatedTime: now},
+				&baselineRecord{Parent: "ci", ResultID: "result", ResultName: "release", ID: "stale", Name: "log", UpdatedTime: now},
+				&baselineRecord{Parent: "ci", ResultID: "result", ResultName: "release", ID: "log", Name: "log", UpdatedTime: now.Add(time.Second)},
+			} {
+				if err := gdb.Create(v).Error; err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			deleted, err := PrepareClusters(gdb)
+			if err != nil {
+				t.Fatalf("PrepareClusters: %v", err)
+			}
+			if deleted != 1 {
+				t.Errorf("PrepareClusters: deleted %d Records, want 1", deleted)
+			}
+			if err := gdb.AutoMigrate(&Result{}, &Record{}); err != nil {
+				t.Fatalf("AutoMigrate: %v", err)
+			}
+			if err := DropLegacyIndexes(gdb); err != nil {
+				t.Fatalf("DropLegacyIndexes: %v", err)
+			}
+
+			// Existing resources belong to the local cluster.
+			res := &Result{}
+			if err := InCluster(gdb, "hub", "hub").Where("parent = ? AND name = ?", "ci", "release").First(res).Error; err != nil {
+				t.Fatalf("Result not found in the local cluster: %v", err)
+			}
+			var ids []string
+			if err := InCluster(gdb, "hub", "hub").Model(&Record{}).Order("id").Pluck("id", &ids).Error; err != nil {
+				t.Fatalf("Pluck: %v", err)
+			}
+			if diff := cmp.Diff([]string{"log", "run"}, ids); diff != "" {
+				t.Errorf("Records in the local cluster (-want, +got): %s", diff)
+			}
+			if err := gdb.Create(&Record{Parent: "ci", ResultID: "result", ResultName: "release", ID: "other", Name: "run"}).Error; err == nil {
+				t.Error("Create Record: want a unique constraint error")
+			}
+			if err := gdb.Create(&Result{Parent: "ci", ID: "other", Name: "release"}).Error; err == nil {
+				t.Error("Create Result: want a unique constraint error")
+			}
+		})
+	}
+}
@@ -25,9 +25,9 @@ import (
 
 // Result is the database model of a Result.
 type Result struct {
-	Parent      string      `gorm:"primaryKey;uniqueIndex:results_by_name,priority:1;size:64;"`
+	Parent      string      `gorm:"primaryKey;uniqueIndex:results_by_cluster_name,priority:1;size:64;"`
 	ID          string      `gorm:"primaryKey;size:64;"`
-	Name        string      `gorm:"uniqueIndex:results_by_name,priority:2;size:64;"`
+	Name        string      `gorm:"uniqueIndex:results_by_cluster_name,priority:2;size:64;"`
 	Annotations Annotations `gorm:"type:jsonb;"`
 
 	CreatedTime time.Time `gorm:"default:current_timestamp;"`
@@ -36,6 +36,11 @@ type Result struct {
 	Summary RecordSummary `gorm:"embedded;embeddedPrefix:recordsummary_;"`
 
 	Etag string `gorm:"size:128;"`
+
+	// Cluster is the name of the cluster the Result was created in, empty
+	// for the cluster of the API server. Results of different clusters may
+	// have the same name.
+	Cluster string `gorm:"index:results_by_cluster;uniqueIndex:results_by_cluster_name,priority:3;size:64;not null;default:'';"`
 }
 
 // RecordSummary is the database model of a Result.RecordSummary.
@@ -60,12 +65,12 @@ type Record struct {
 	// table. Data will not be returned here during reads. Use the foreign key
 	// fields instead.
 	Result     Result `gorm:"foreignKey:Parent,ResultID;references:Parent,ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Parent     string `gorm:"primaryKey;uniqueIndex:records_by_name,priority:1;size:64;"`
+	Parent     string `gorm:"primaryKey;uniqueIndex:records_by_cluster_name,priority:1;size:64;"`
 	ResultID   string `gorm:"primaryKey;size:64;"`
-	ResultName string `gorm:"uniqueIndex:records_by_name,priority:2;size:64;"`
+	ResultName string `gorm:"uniqueIndex:records_by_cluster_name,priority:2;size:64;"`
 
 	ID   string `gorm:"primaryKey;size:64;"`
-	Name string `gorm:"index:records_by_name,priority:3;size:64;"`
+	Name string `gorm:"uniqueIndex:records_by_cluster_name,priority:3;size:64;"`
 
 	// Napkin Math (with a bit of buffer): 256 (DNS Subdomain) * 3 (Group +
 	// Version + Kind).
@@ -76,6 +81,62 @@ type Record struct {
 	UpdatedTime time.Time `gorm:"default:current_timestamp;"`
 
 	Etag string `gorm:"size:128;"`
+
+	// Cluster is the name of the cluster the Record was created in, empty
+	// for the cluster of the API server.
+	Cluster string `gorm:"index:records_by_cluster;uniqueIndex:records_by_cluster_name,priority:4;size:64;not null;default:'';"`
+}
+
+// RunResult is the database model of a result of the TaskRun or PipelineRun
+// stored in a Record, indexed so that runs can be searched by the values of
+// their results. Array results have a row per element, and object results a
+// row per key, named after the result and the key, e.g. ARTIFACT.uri.
+type RunResult struct {
+	// Record is used to create the relationship between the Records and
+	// RunResults table, so that results are deleted along with their Record.
+	Record   Record `gorm:"foreignKey:Parent,ResultID,RecordID;references:Parent,ResultID,ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Parent   string `gorm:"primaryKey;index:run_results_by_value,priority:3;size:64;"`
+	ResultID string `gorm:"primaryKey;size:64;"`
+	RecordID string `gorm:"primaryKey;size:64;"`
+
+	Name  string `gorm:"primaryKey;index:run_results_by_value,priority:1;size:256;"`
+	Value string `gorm:"primaryKey;index:run_results_by_value,priority:2;size:512;"`
+}
+
+// ProvenanceDigest is the database model of the digest of an artifact built
+// by a run, as recorded in its provenance Record, indexed so that runs can be
+// searched by the digests of the artifacts they built. Digests are formatted
+// as algorithm:hex, with the hex part in lower case.
+type ProvenanceDigest struct {
+	// Record is used to create the relationship between the Records and
+	// ProvenanceDigests table, so that digests are deleted along with their
+	// Record.
+	Record   Record `gorm:"foreignKey:Parent,ResultID,RecordID;references:Parent,ResultID,ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Parent   string `gorm:"primaryKey;index:provenance_digests_by_digest,priority:2;size:64;"`
+	ResultID string `gorm:"primaryKey;size:64;"`
+	RecordID string `gorm:"primaryKey;size:64;"`
+
+	Digest string `gorm:"primaryKey;index:provenance_digests_by_digest,priority:1;size:256;"`
+}
+
+// Migration is the database model of the progress of a migration of Record
+// data, checkpointed after each batch of Records so that interrupted
+// migrations resume where they stopped.
+type Migration struct {
+	Name   string `gorm:"primaryKey;size:256;"`
+	DryRun bool   `gorm:"primaryKey;"`
+
+	State string `gorm:"size:32;"`
+	// LastRecordID is the ID of the last Record the migration went
+	// through. Records are migrated in the order of their IDs.
+	LastRecordID  string `gorm:"size:64;"`
+	MigratedCount int64
+	FailedCount   int64
+	Error         string
+
+	StartedTime   time.Time
+	UpdatedTime   time.Time
+	CompletedTime *time.Time
 }
 
 // Annotations is a custom-defined type of a gorm model field.
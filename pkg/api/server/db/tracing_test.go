@@ -0,0 +1,97 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"gorm.io/gorm"
+)
+
+func TestTracingPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	gdb := test.NewDB(t)
+	if err := gdb.AutoMigrate(&Result{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.Use(TracingPlugin{}); err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	gdb = gdb.WithContext(ctx)
+	if err := gdb.Create(&Result{Parent: "foo", ID: "1", Name: "bar"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.Where("parent = ?", "foo").First(&Result{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := gdb.Where("parent = ?", "baz").First(&Result{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+	if err := gdb.Create(&Result{Parent: "foo", ID: "1", Name: "bar"}).Error; err == nil {
+		t.Fatal("expected error creating a duplicate Result")
+	}
+	parent.End()
+
+	type span struct {
+		Name   string
+		Table  string
+		Query  string
+		Status codes.Code
+	}
+	var got []span
+	for _, s := range recorder.Ended() {
+		if s.Name() == "parent" {
+			continue
+		}
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the request span", s.Name())
+		}
+		got = append(got, span{Name: s.Name(), Status: s.Status().Code})
+		for _, a := range s.Attributes() {
+			switch a.Key {
+			case "db.collection.name":
+				got[len(got)-1].Table = a.Value.AsString()
+			case "db.query.text":
+				got[len(got)-1].Query = strings.Fields(a.Value.AsString())[0]
+			}
+		}
+	}
+	want := []span{
+		{Name: "gorm.create", Table: "results", Query: "INSERT"},
+		{Name: "gorm.query", Table: "results", Query: "SELECT"},
+		// Missing records are not errors.
+		{Name: "gorm.query", Table: "results", Query: "SELECT"},
+		{Name: "gorm.create", Table: "results", Query: "INSERT", Status: codes.Error},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify sends CloudEvents to HTTP subscribers when Results and
+// Records change.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/cel-go/cel"
+	"github.com/google/uuid"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// Types of the events sent to subscribers.
+const (
+	EventResultCreated = "dev.tekton.results.result.created.v1"
+	EventResultUpdated = "dev.tekton.results.result.updated.v1"
+	EventResultDeleted = "dev.tekton.results.result.deleted.v1"
+	EventRecordCreated = "dev.tekton.results.record.created.v1"
+	EventRecordUpdated = "dev.tekton.results.record.updated.v1"
+	EventRecordDeleted = "dev.tekton.results.record.deleted.v1"
+	// EventRunCompleted is sent, along with the creation or update event, when
+	// the Record of a PipelineRun or TaskRun is first stored as completed.
+	EventRunCompleted = "dev.tekton.results.run.completed.v1"
+
+	queueSize      = 1024
+	defaultRetries = 5
+	retryPeriod    = time.Second
+	sendTimeout    = 10 * time.Second
+)
+
+var eventTypes = []string{
+	EventResultCreated, EventResultUpdated, EventResultDeleted,
+	EventRecordCreated, EventRecordUpdated, EventRecordDeleted,
+	EventRunCompleted,
+}
+
+// Subscription selects the events sent to an HTTP endpoint.
+type Subscription struct {
+	// Name identifies the subscription in logs and error messages.
+	Name string `json:"name"`
+	// URL is the endpoint events are posted to.
+	URL string `json:"url"`
+	// Types restricts the subscription to the given event types. An empty
+	// list means all types.
+	Types []string `json:"types,omitempty"`
+	// Filter is a CEL expression, in the same environment as the Records
+	// filter, selecting the Results and Records the subscription is notified
+	// of. An empty filter selects all of them.
+	Filter string `json:"filter,omitempty"`
+}
+
+type subscriptions struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// LoadSubscriptions reads the subscriptions stored in the YAML file at path.
+// The file is typically mounted from a ConfigMap.
+func LoadSubscriptions(path string) ([]Subscription, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := new(subscriptions)
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, fmt.Errorf("error parsing subscriptions from %s: %w", path, err)
+	}
+	return s.Subscriptions, nil
+}
+
+// Notifier sends the events matching each Subscription in the background, so
+// that slow subscribers don't delay API calls. Events that can't be delivered
+// after all retries, or that don't fit in the queue of a subscriber, are
+// written to the dead-letter log.
+type Notifier struct {
+	logger      *zap.SugaredLogger
+	client      cloudevents.Client
+	subscribers []*subscriber
+	retries     int
+	retryPeriod time.Duration
+
+	deadLetterMu sync.Mutex
+	deadLetter   *json.Encoder
+	wg           sync.WaitGroup
+
+	// mu guards the queues of the subscribers against being closed while
+	// events are queued.
+	mu     sync.RWMutex
+	closed bool
+}
+
+type subscriber struct {
+	Subscription
+	filter cel.Program
+	queue  chan cloudevents.Event
+}
+
+// Option is customization for Notifier configuration.
+type Option func(*Notifier)
+
+// WithRetries is an option to set the number of times the delivery of an
+// event is retried before it is written to the dead-letter log.
+func WithRetries(retries int) Option {
+	return func(n *Notifier) {
+		n.retries = retries
+	}
+}
+
+// WithDeadLetter is an option to write the events which couldn't be delivered
+// to w, one JSON document per line. Without it, they are logged as errors.
+func WithDeadLetter(w io.Writer) Option {
+	return func(n *Notifier) {
+		n.deadLetter = json.NewEncoder(w)
+	}
+}
+
+func withRetryPeriod(period time.Duration) Option {
+	return func(n *Notifier) {
+		n.retryPeriod = period
+	}
+}
+
+// New returns a Notifier sending events to the given subscriptions.
+func New(subs []Subscription, logger *zap.SugaredLogger, opts ...Option) (*Notifier, error) {
+	env, err := resultscel.NewRecordsEnv()
+	if err != nil {
+		return nil, err
+	}
+	client, err := cloudevents.NewClientHTTP(cehttp.WithIsRetriableFunc(retriable), cehttp.WithClient(http.Client{Timeout: sendTimeout}))
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		logger:      logger,
+		client:      client,
+		retries:     defaultRetries,
+		retryPeriod: retryPeriod,
+	}
+	for _, o := range opts {
+		o(n)
+	}
+	for _, s := range subs {
+		if u, err := url.Parse(s.URL); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("subscription %q: invalid url %q", s.Name, s.URL)
+		}
+		for _, t := range s.Types {
+			if !slices.Contains(eventTypes, t) {
+				return nil, fmt.Errorf("subscription %q: unknown event type %q", s.Name, t)
+			}
+		}
+		filter, err := resultscel.ParseFilter(env, strings.TrimSpace(s.Filter))
+		if err != nil {
+			return nil, fmt.Errorf("subscription %q: invalid filter expression: %w", s.Name, err)
+		}
+		n.subscribers = append(n.subscribers, &subscriber{
+			Subscription: s,
+			filter:       filter,
+			queue:        make(chan cloudevents.Event, queueSize),
+		})
+	}
+
+	for _, s := range n.subscribers {
+		n.wg.Add(1)
+		go n.run(s)
+	}
+	return n, nil
+}
+
+// Close stops accepting events and waits for the queued ones to be sent.
+// Events notified afterwards are written to the dead-letter log.
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		for _, s := range n.subscribers {
+			close(s.queue)
+		}
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+// ResultCreated notifies the subscribers of the creation of r. A nil Notifier
+// notifies nobody, as do all the other notification methods.
+func (n *Notifier) ResultCreated(r *pb.Result) {
+	n.notifyResult(EventResultCreated, r)
+}
+
+// ResultUpdated notifies the subscribers of the update of r.
+func (n *Notifier) ResultUpdated(r *pb.Result) {
+	n.notifyResult(EventResultUpdated, r)
+}
+
+// ResultDeleted notifies the subscribers of the deletion of r.
+func (n *Notifier) ResultDeleted(r *pb.Result) {
+	n.notifyResult(EventResultDeleted, r)
+}
+
+// RecordCreated notifies the subscribers of the creation of r, and of the
+// completion of its run if it is already completed.
+func (n *Notifier) RecordCreated(r *pb.Record) {
+	n.notifyRecord(EventRecordCreated, nil, r)
+}
+
+// RecordUpdated notifies the subscribers of the update of prev to r, and of
+// the completion of its run if r completed it.
+func (n *Notifier) RecordUpdated(prev, r *pb.Record) {
+	n.notifyRecord(EventRecordUpdated, prev, r)
+}
+
+// RecordDeleted notifies the subscribers of the deletion of r.
+func (n *Notifier) RecordDeleted(r *pb.Record) {
+	n.notifyRecord(EventRecordDeleted, nil, r)
+}
+
+func (n *Notifier) notifyResult(eventType string, r *pb.Result) {
+	if n == nil || len(n.subscribers) == 0 {
+		return
+	}
+	parent, name, err := result.ParseName(r.GetName())
+	if err != nil {
+		n.logger.Errorf("failed to notify %s of %s: %v", eventType, r.GetName(), err)
+		return
+	}
+	n.notify(eventType, parent, r, map[string]any{
+		"parent":      parent,
+		"result_name": name,
+		"name":        "",
+		"data_type":   "",
+		"data":        map[string]any{},
+		"cluster":     r.GetCluster(),
+	})
+}
+
+func (n *Notifier) notifyRecord(eventType string, prev, r *pb.Record) {
+	if n == nil || len(n.subscribers) == 0 {
+		return
+	}
+	parent, resultName, name, err := record.ParseName(r.GetName())
+	if err != nil {
+		n.logger.Errorf("failed to notify %s of %s: %v", eventType, r.GetName(), err)
+		return
+	}
+	data := map[string]any{}
+	if d := r.GetData().GetValue(); d != nil {
+		// Records that aren't JSON objects can still match filters which
+		// don't refer to their data.
+		_ = json.Unmarshal(d, &data)
+	}
+	vars := map[string]any{
+		"parent":      parent,
+		"result_name": resultName,
+		"name":        name,
+		"data_type":   r.GetData().GetType(),
+		"data":        data,
+		"cluster":     r.GetCluster(),
+	}
+	n.notify(eventType, parent, r, vars)
+	if eventType != EventRecordDeleted && completed(r) && (prev == nil || !completed(prev)) {
+		n.notify(EventRunCompleted, parent, r, vars)
+	}
+}
+
+// notify queues the event for the subscribers whose filter matches vars.
+func (n *Notifier) notify(eventType, parent string, m proto.Message, vars map[string]any) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	var event *cloudevents.Event
+	for _, s := range n.subscribers {
+		if len(s.Types) > 0 && !slices.Contains(s.Types, eventType) {
+			continue
+		}
+		// Filters can fail to evaluate for some resources, e.g. when they
+		// refer to data fields that Results don't have, in which case they
+		// don't match.
+		if ok, err := resultscel.Match(s.filter, vars); err != nil || !ok {
+			continue
+		}
+		if event == nil {
+			e, err := newEvent(eventType, parent, m)
+			if err != nil {
+				n.logger.Errorf("failed to create %s event: %v", eventType, err)
+				return
+			}
+			event = e
+		}
+		if n.closed {
+			n.dead(s, *event, fmt.Errorf("notifier is closed"))
+			continue
+		}
+		select {
+		case s.queue <- *event:
+		default:
+			n.dead(s, *event, fmt.Errorf("queue is full"))
+		}
+	}
+}
+
+func newEvent(eventType, parent string, m proto.Message) (*cloudevents.Event, error) {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	e := cloudevents.NewEvent()
+	e.SetID(uuid.New().String())
+	e.SetType(eventType)
+	e.SetSource("/apis/results.tekton.dev/v1alpha2/parents/" + parent)
+	e.SetTime(time.Now())
+	if r, ok := m.(interface{ GetName() string }); ok {
+		e.SetSubject(r.GetName())
+	}
+	if err := e.SetData(cloudevents.ApplicationJSON, json.RawMessage(b)); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (n *Notifier) run(s *subscriber) {
+	defer n.wg.Done()
+	for e := range s.queue {
+		ctx, cancel := context.WithCancel(context.Background())
+		ctx = cloudevents.ContextWithTarget(ctx, s.URL)
+		if n.retries > 0 {
+			ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, n.retryPeriod, n.retries)
+		}
+		if res := n.client.Send(ctx, e); !cloudevents.IsACK(res) {
+			n.dead(s, e, res)
+		}
+		cancel()
+	}
+}
+
+// deadLetter is an entry of the dead-letter log.
+type deadLetter struct {
+	Time         time.Time         `json:"time"`
+	Subscription string            `json:"subscription"`
+	Error        string            `json:"error"`
+	Event        cloudevents.Event `json:"event"`
+}
+
+func (n *Notifier) dead(s *subscriber, e cloudevents.Event, err error) {
+	if n.deadLetter == nil {
+		n.logger.Errorw("failed to deliver event", "subscription", s.Name, "type", e.Type(), "subject", e.Subject(), "id", e.ID(), "error", err)
+		return
+	}
+	n.deadLetterMu.Lock()
+	defer n.deadLetterMu.Unlock()
+	if err := n.deadLetter.Encode(deadLetter{Time: time.Now(), Subscription: s.Name, Error: err.Error(), Event: e}); err != nil {
+		n.logger.Errorf("failed to write %s event %s to the dead-letter log: %v", e.Type(), e.ID(), err)
+	}
+}
+
+// retriable reports whether a delivery failing with the given HTTP status
+// should be retried.
+func retriable(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+)
+
+// receiver records the type and subject of the events posted to it.
+type receiver struct {
+	mu     sync.Mutex
+	events []string
+	// failures is the number of calls to fail before accepting events.
+	failures int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	r.events = append(r.events, req.Header.Get("ce-type")+" "+req.Header.Get("ce-subject"))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func run(name, status string) *pb.Record {
+	return &pb.Record{
+		Name: "foo/results/bar/records/" + name,
+		Data: &pb.Any{
+			Type:  "tekton.dev/v1.PipelineRun",
+			Value: []byte(`{"metadata":{"name":"` + name + `"},"status":{"conditions":[{"type":"Succeeded","status":"` + status + `"}]}}`),
+		},
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	all, runs, completions := &receiver{}, &receiver{}, &receiver{}
+	allSrv, runsSrv, completionsSrv := httptest.NewServer(all), httptest.NewServer(runs), httptest.NewServer(completions)
+	defer allSrv.Close()
+	defer runsSrv.Close()
+	defer completionsSrv.Close()
+
+	n, err := New([]Subscription{
+		{Name: "all", URL: allSrv.URL},
+		{Name: "runs", URL: runsSrv.URL, Filter: `data_type == PIPELINE_RUN && data.metadata.name.startsWith("deploy")`},
+		{Name: "completions", URL: completionsSrv.URL, Types: []string{EventRunCompleted}},
+	}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	n.ResultCreated(&pb.Result{Name: "foo/results/bar"})
+	n.RecordCreated(run("deploy-1", "Unknown"))
+	n.RecordUpdated(run("deploy-1", "Unknown"), run("deploy-1", "True"))
+	// Already completed.
+	n.RecordUpdated(run("deploy-1", "True"), run("deploy-1", "True"))
+	n.RecordCreated(run("build-1", "False"))
+	n.RecordDeleted(run("deploy-1", "True"))
+	n.ResultDeleted(&pb.Result{Name: "foo/results/bar"})
+	n.Close()
+
+	for _, tc := range []struct {
+		name string
+		r    *receiver
+		want []string
+	}{
+		{
+			name: "all",
+			r:    all,
+			want: []string{
+				EventResultCreated + " foo/results/bar",
+				EventRecordCreated + " foo/results/bar/records/deploy-1",
+				EventRecordUpdated + " foo/results/bar/records/deploy-1",
+				EventRunCompleted + " foo/results/bar/records/deploy-1",
+				EventRecordUpdated + " foo/results/bar/records/deploy-1",
+				EventRecordCreated + " foo/results/bar/records/build-1",
+				EventRunCompleted + " foo/results/bar/records/build-1",
+				EventRecordDeleted + " foo/results/bar/records/deploy-1",
+				EventResultDeleted + " foo/results/bar",
+			},
+		},
+		{
+			name: "filter",
+			r:    runs,
+			want: []string{
+				EventRecordCreated + " foo/results/bar/records/deploy-1",
+				EventRecordUpdated + " foo/results/bar/records/deploy-1",
+				EventRunCompleted + " foo/results/bar/records/deploy-1",
+				EventRecordUpdated + " foo/results/bar/records/deploy-1",
+				EventRecordDeleted + " foo/results/bar/records/deploy-1",
+			},
+		},
+		{
+			name: "types",
+			r:    completions,
+			want: []string{
+				EventRunCompleted + " foo/results/bar/records/deploy-1",
+				EventRunCompleted + " foo/results/bar/records/build-1",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.r.events); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifier_retries(t *testing.T) {
+	flaky, down := &receiver{failures: 2}, &receiver{failures: 10}
+	flakySrv, downSrv := httptest.NewServer(flaky), httptest.NewServer(down)
+	defer flakySrv.Close()
+	defer downSrv.Close()
+
+	deadLetters := &bytes.Buffer{}
+	n, err := New([]Subscription{
+		{Name: "flaky", URL: flakySrv.URL},
+		{Name: "down", URL: downSrv.URL},
+	}, zap.NewNop().Sugar(), WithRetries(3), WithDeadLetter(deadLetters), withRetryPeriod(time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	n.ResultCreated(&pb.Result{Name: "foo/results/bar"})
+	n.Close()
+
+	if want := []string{EventResultCreated + " foo/results/bar"}; !cmp.Equal(want, flaky.events) {
+		t.Errorf("expected %v to be delivered after retries, got %v", want, flaky.events)
+	}
+	if len(down.events) != 0 {
+		t.Errorf("expected no events to be delivered, got %v", down.events)
+	}
+
+	var entry struct {
+		Subscription string `json:"subscription"`
+		Event        struct {
+			Type    string `json:"type"`
+			Subject string `json:"subject"`
+		} `json:"event"`
+	}
+	lines := strings.Split(strings.TrimSpace(deadLetters.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 dead letter, got %q", lines)
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("failed to decode dead letter: %v", err)
+	}
+	if entry.Subscription != "down" || entry.Event.Type != EventResultCreated || entry.Event.Subject != "foo/results/bar" {
+		t.Errorf("unexpected dead letter %s", lines[0])
+	}
+}
+
+func TestNotifier_closed(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	deadLetters := &bytes.Buffer{}
+	n, err := New([]Subscription{{Name: "all", URL: srv.URL}}, zap.NewNop().Sugar(), WithDeadLetter(deadLetters))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	n.Close()
+	// Events notified by requests still in flight once closed aren't sent.
+	n.ResultCreated(&pb.Result{Name: "foo/results/bar"})
+	n.Close()
+
+	if len(r.events) != 0 {
+		t.Errorf("expected no events to be delivered, got %v", r.events)
+	}
+	if lines := strings.Split(strings.TrimSpace(deadLetters.String()), "\n"); len(lines) != 1 {
+		t.Errorf("expected 1 dead letter, got %q", lines)
+	}
+}
+
+func TestNotifier_nil(t *testing.T) {
+	var n *Notifier
+	n.ResultCreated(&pb.Result{Name: "foo/results/bar"})
+	n.RecordCreated(run("deploy-1", "True"))
+	n.Close()
+}
+
+func TestNew_invalid(t *testing.T) {
+	for _, sub := range []Subscription{
+		{Name: "url", URL: "not a url"},
+		{Name: "type", URL: "http://example.com", Types: []string{"dev.tekton.results.unknown.v1"}},
+		{Name: "filter", URL: "http://example.com", Filter: "data_type =="},
+	} {
+		if _, err := New([]Subscription{sub}, zap.NewNop().Sugar()); err == nil {
+			t.Errorf("New(%s): expected error", sub.Name)
+		}
+	}
+}
+
+func TestLoadSubscriptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.yaml")
+	if err := os.WriteFile(path, []byte(`
+subscriptions:
+  - name: chatops
+    url: http://chatops.default.svc/events
+    types: ["dev.tekton.results.run.completed.v1"]
+    filter: 'data_type == PIPELINE_RUN'
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadSubscriptions(path)
+	if err != nil {
+		t.Fatalf("LoadSubscriptions: %v", err)
+	}
+	want := []Subscription{{
+		Name:   "chatops",
+		URL:    "http://chatops.default.svc/events",
+		Types:  []string{EventRunCompleted},
+		Filter: "data_type == PIPELINE_RUN",
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"slices"
+
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var runTypes = []string{
+	"tekton.dev/v1.PipelineRun",
+	"tekton.dev/v1.TaskRun",
+	"tekton.dev/v1beta1.PipelineRun",
+	"tekton.dev/v1beta1.TaskRun",
+}
+
+// completed reports whether r is the Record of a PipelineRun or TaskRun whose
+// Succeeded condition is no longer Unknown.
+func completed(r *pb.Record) bool {
+	if !slices.Contains(runTypes, r.GetData().GetType()) {
+		return false
+	}
+	run := struct {
+		Status duckv1.Status `json:"status"`
+	}{}
+	if err := json.Unmarshal(r.GetData().GetValue(), &run); err != nil {
+		return false
+	}
+	c := run.Status.GetCondition(apis.ConditionSucceeded)
+	return c != nil && c.Status != corev1.ConditionUnknown
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides gRPC interceptors limiting the rate at which each
+// client can call the API.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// RetryAfterHeader is the response metadata holding the number of seconds
+	// to wait before retrying a rejected call.
+	RetryAfterHeader = "retry-after"
+
+	// maxBuckets bounds the number of clients tracked at once. Buckets of
+	// clients idle for bucketTTL are evicted, by which time they are full
+	// again anyway.
+	maxBuckets = 10000
+	bucketTTL  = 10 * time.Minute
+)
+
+var rejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "results_api_rate_limited_total",
+	Help: "Number of calls rejected because the caller exceeded its rate limit, by method.",
+}, []string{"method"})
+
+func init() {
+	prometheus.MustRegister(rejected)
+}
+
+// Budget is the token bucket granted to each client: QPS tokens are added
+// every second, up to Burst. A zero QPS means no limit.
+type Budget struct {
+	QPS   float64
+	Burst int
+}
+
+// Limiter rejects calls of clients which exceed their budget. Each client
+// gets a separate bucket per method, so that a client hammering one method
+// can still call the others.
+type Limiter struct {
+	authn         auth.Authenticator
+	budget        Budget
+	summary       Budget
+	largePage     Budget
+	largePageSize int32
+
+	mu      sync.Mutex
+	buckets *cache.LRUExpireCache
+}
+
+// Option is customization for Limiter configuration.
+type Option func(*Limiter)
+
+// WithAuthenticator is an option to identify clients by the user or
+// ServiceAccount calling, once their token passed the limit of the callers
+// using it. Without it, clients are identified by their address. Requests are
+// expected to carry an auth.NewRequestContext, so that the tokens reviewed to
+// identify the caller aren't reviewed again by the auth checker.
+func WithAuthenticator(authn auth.Authenticator) Option {
+	return func(l *Limiter) {
+		l.authn = authn
+	}
+}
+
+// WithSummaryBudget is an option to limit GetRecordListSummary,
+// ListFlakyTasks and GetDeliveryMetrics calls with a separate budget, as they
+// aggregate over many records.
+func WithSummaryBudget(b Budget) Option {
+	return func(l *Limiter) {
+		l.summary = b
+	}
+}
+
+// WithLargePageBudget is an option to limit list calls requesting pageSize
+// items or more with a separate budget.
+func WithLargePageBudget(pageSize int32, b Budget) Option {
+	return func(l *Limiter) {
+		l.largePageSize = pageSize
+		l.largePage = b
+	}
+}
+
+// New returns a Limiter granting budget to each client and method.
+func New(budget Budget, opts ...Option) *Limiter {
+	l := &Limiter{
+		budget:  budget,
+		buckets: cache.NewLRUExpireCache(maxBuckets),
+	}
+	for _, o := range opts {
+		o(l)
+	}
+	return l
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor limiting the
+// rate of calls. A nil Limiter doesn't limit anything.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if l == nil {
+			return handler(ctx, req)
+		}
+		if delay, err := l.wait(ctx, info.FullMethod, req); err != nil {
+			grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, retryAfter(delay))) //nolint:errcheck
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor limiting the
+// rate of calls. Streams are accounted for when they are opened. A nil Limiter
+// doesn't limit anything.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if l == nil {
+			return handler(srv, ss)
+		}
+		if delay, err := l.wait(ss.Context(), info.FullMethod, nil); err != nil {
+			ss.SetHeader(metadata.Pairs(RetryAfterHeader, retryAfter(delay))) //nolint:errcheck
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// wait takes a token from the bucket of the caller for method. If none is
+// available, it returns how long the caller should wait and a
+// ResourceExhausted error.
+func (l *Limiter) wait(ctx context.Context, method string, req any) (time.Duration, error) {
+	if strings.HasPrefix(method, "/grpc.health.") || strings.HasPrefix(method, "/grpc.reflection.") {
+		return 0, nil
+	}
+	key, budget := method, l.budget
+	switch {
+	case isSummary(method) && l.summary.QPS > 0:
+		budget = l.summary
+	case l.largePage.QPS > 0 && isLargePage(req, l.largePageSize):
+		key, budget = method+"#large", l.largePage
+	}
+	if budget.QPS <= 0 {
+		return 0, nil
+	}
+
+	// Callers are limited by their credentials before their tokens are
+	// reviewed, so that floods of calls, with valid tokens or not, don't
+	// reach the Kubernetes API server.
+	if delay, err := l.take(method, l.credentials(ctx)+"/"+key, budget); err != nil {
+		return delay, err
+	}
+	if client := l.client(ctx); client != "" {
+		return l.take(method, client+"/"+key, budget)
+	}
+	return 0, nil
+}
+
+// take takes a token from the bucket of key, or returns how long to wait for
+// one and a ResourceExhausted error.
+func (l *Limiter) take(method, key string, budget Budget) (time.Duration, error) {
+	r := l.bucket(key, budget).Reserve()
+	delay := r.Delay()
+	if delay == 0 {
+		return 0, nil
+	}
+	r.Cancel()
+	rejected.WithLabelValues(method).Inc()
+	return delay, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", delay.Round(time.Millisecond))
+}
+
+func (l *Limiter) bucket(key string, b Budget) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lim, ok := l.buckets.Get(key)
+	if !ok {
+		lim = rate.NewLimiter(rate.Limit(b.QPS), max(b.Burst, 1))
+	}
+	// Buckets only expire once idle, lest clients calling continuously get a
+	// full burst again every bucketTTL.
+	l.buckets.Add(key, lim, bucketTTL)
+	return lim.(*rate.Limiter)
+}
+
+// credentials identifies the caller of the request without reviewing its
+// token: by a hash of its token if it has one, or by its address.
+func (l *Limiter) credentials(ctx context.Context) string {
+	if l.authn != nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if token := md.Get("authorization"); len(token) > 0 {
+			sum := sha256.Sum256([]byte(token[0]))
+			return "token:" + hex.EncodeToString(sum[:])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "addr:" + host
+	}
+	return "unknown"
+}
+
+// client identifies the user calling, once the token of the request is
+// reviewed, so that users calling with many tokens aren't granted more budget.
+// It returns an empty string if the caller isn't authenticated.
+func (l *Limiter) client(ctx context.Context) string {
+	if l.authn == nil {
+		return ""
+	}
+	// Impersonating many users must not grant a client more budget.
+	user, err := auth.Caller(ctx, l.authn)
+	if err != nil {
+		return ""
+	}
+	return "user:" + user.Username
+}
+
+// isSummary returns whether method aggregates over many records.
+func isSummary(method string) bool {
+	switch method {
+	case pb.Results_GetRecordListSummary_FullMethodName, pb.Results_ListFlakyTasks_FullMethodName, pb.Results_GetDeliveryMetrics_FullMethodName:
+		return true
+	}
+	return false
+}
+
+func isLargePage(req any, size int32) bool {
+	r, ok := req.(interface{ GetPageSize() int32 })
+	return ok && size > 0 && r.GetPageSize() >= size
+}
+
+// retryAfter formats delay as a whole number of seconds, rounded up.
+func retryAfter(delay time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(delay.Seconds())))
+}
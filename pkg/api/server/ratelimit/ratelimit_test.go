@@ -0,0 +1,180 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// tokenAuthenticator uses the bearer token as the username.
+type tokenAuthenticator struct{}
+
+func (tokenAuthenticator) Authenticate(ctx context.Context) (*authnv1.UserInfo, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := md.Get("authorization")
+	if len(token) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no token")
+	}
+	return &authnv1.UserInfo{Username: token[0]}, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	// A near zero rate, so that buckets are never refilled during the test.
+	l := New(Budget{QPS: 0.001, Burst: 2},
+		WithAuthenticator(tokenAuthenticator{}),
+		WithSummaryBudget(Budget{QPS: 0.001, Burst: 1}),
+		WithLargePageBudget(100, Budget{QPS: 0.001, Burst: 1}),
+	)
+	interceptor := l.UnaryServerInterceptor()
+	handler := func(_ context.Context, _ any) (any, error) { return "ok", nil }
+	call := func(user, method string, req any) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", user))
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	for _, tc := range []struct {
+		name   string
+		user   string
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{name: "first call", user: "alice", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{}, want: codes.OK},
+		{name: "within burst", user: "alice", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{}, want: codes.OK},
+		{name: "budget exhausted", user: "alice", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{}, want: codes.ResourceExhausted},
+		{name: "other user", user: "bob", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{}, want: codes.OK},
+		{name: "other method", user: "alice", method: pb.Results_GetRecord_FullMethodName, req: &pb.GetRecordRequest{}, want: codes.OK},
+		{name: "large page", user: "alice", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{PageSize: 1000}, want: codes.OK},
+		{name: "large page exhausted", user: "alice", method: pb.Results_ListRecords_FullMethodName, req: &pb.ListRecordsRequest{PageSize: 100}, want: codes.ResourceExhausted},
+		{name: "summary", user: "alice", method: pb.Results_GetRecordListSummary_FullMethodName, req: &pb.RecordListSummaryRequest{}, want: codes.OK},
+		{name: "summary exhausted", user: "alice", method: pb.Results_GetRecordListSummary_FullMethodName, req: &pb.RecordListSummaryRequest{}, want: codes.ResourceExhausted},
+		{name: "flakes", user: "alice", method: pb.Results_ListFlakyTasks_FullMethodName, req: &pb.ListFlakyTasksRequest{}, want: codes.OK},
+		{name: "flakes exhausted", user: "alice", method: pb.Results_ListFlakyTasks_FullMethodName, req: &pb.ListFlakyTasksRequest{}, want: codes.ResourceExhausted},
+		{name: "delivery metrics", user: "alice", method: pb.Results_GetDeliveryMetrics_FullMethodName, req: &pb.DeliveryMetricsRequest{}, want: codes.OK},
+		{name: "delivery metrics exhausted", user: "alice", method: pb.Results_GetDeliveryMetrics_FullMethodName, req: &pb.DeliveryMetricsRequest{}, want: codes.ResourceExhausted},
+	} {
+		if err := call(tc.user, tc.method, tc.req); status.Code(err) != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor_impersonation(t *testing.T) {
+	interceptor := New(Budget{QPS: 0.001, Burst: 1}, WithAuthenticator(tokenAuthenticator{})).UnaryServerInterceptor()
+	handler := func(_ context.Context, _ any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.Results_ListResults_FullMethodName}
+
+	// Impersonating another user draws from the budget of the caller.
+	for i, impersonated := range []string{"bob", "carol"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "alice", authnv1.ImpersonateUserHeader, impersonated))
+		_, err := interceptor(ctx, &pb.ListResultsRequest{}, info, handler)
+		if want := []codes.Code{codes.OK, codes.ResourceExhausted}[i]; status.Code(err) != want {
+			t.Errorf("call as %s: got %v, want %v", impersonated, err, want)
+		}
+	}
+}
+
+// countingAuthenticator counts the tokens it reviews, and rejects them all.
+type countingAuthenticator struct {
+	reviews int
+}
+
+func (a *countingAuthenticator) Authenticate(context.Context) (*authnv1.UserInfo, error) {
+	a.reviews++
+	return nil, status.Error(codes.Unauthenticated, "invalid token")
+}
+
+func TestUnaryServerInterceptor_invalidTokens(t *testing.T) {
+	authn := &countingAuthenticator{}
+	interceptor := New(Budget{QPS: 0.001, Burst: 2}, WithAuthenticator(authn)).UnaryServerInterceptor()
+	handler := func(_ context.Context, _ any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.Results_ListResults_FullMethodName}
+
+	// Calls with the same invalid token are limited before it is reviewed.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer invalid"))
+	for i := range 10 {
+		_, err := interceptor(ctx, &pb.ListResultsRequest{}, info, handler)
+		if want := i >= 2; (status.Code(err) == codes.ResourceExhausted) != want {
+			t.Errorf("call %d: got %v", i, err)
+		}
+	}
+	if authn.reviews != 2 {
+		t.Errorf("got %d token reviews, want 2", authn.reviews)
+	}
+}
+
+func TestBucketExpiry(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	l := New(Budget{QPS: 0.001, Burst: 1})
+	l.buckets = cache.NewLRUExpireCacheWithClock(maxBuckets, clock)
+	ctx := context.Background()
+	wait := func() error {
+		_, err := l.wait(ctx, pb.Results_ListResults_FullMethodName, nil)
+		return err
+	}
+
+	if err := wait(); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	// Clients calling continuously don't get a full burst again once the
+	// bucket was created bucketTTL ago.
+	for range 2 {
+		clock.Step(bucketTTL * 2 / 3)
+		if err := wait(); status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("got %v, want ResourceExhausted", err)
+		}
+	}
+	// Idle clients do.
+	clock.Step(bucketTTL + time.Second)
+	if err := wait(); err != nil {
+		t.Errorf("call after idling: %v", err)
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	var l *Limiter
+	resp, err := l.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.Results_ListRecords_FullMethodName},
+		func(_ context.Context, _ any) (any, error) { return "ok", nil })
+	if err != nil || resp != "ok" {
+		t.Errorf("got (%v, %v), want handler response", resp, err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	l := New(Budget{QPS: 0.5, Burst: 1})
+	ctx := context.Background()
+	if _, err := l.wait(ctx, pb.Results_ListResults_FullMethodName, nil); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	delay, err := l.wait(ctx, pb.Results_ListResults_FullMethodName, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call: got %v, want ResourceExhausted", err)
+	}
+	if got := retryAfter(delay); got != "2" {
+		t.Errorf("retryAfter: got %s, want 2", got)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact masks secrets in log data before it is stored.
+package redact
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/tektoncd/results/pkg/logs"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// mask replaces every byte of redacted secrets, so that the size of log
+	// data doesn't change and offsets in stored logs still match the source.
+	mask = '*'
+
+	// maxLine is the size past which a line without a newline is processed
+	// anyway, cut after its last word.
+	maxLine = 64 * 1024
+)
+
+// Rule masks the matches of a regular expression.
+type Rule struct {
+	// Name identifies the rule in error messages.
+	Name string `json:"name"`
+	// Pattern is a regular expression in RE2 syntax. If it has a capture
+	// group, only the first group of each match is masked.
+	Pattern string `json:"pattern"`
+}
+
+type rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads the rules stored in the YAML file at path. The file is
+// typically mounted from a ConfigMap.
+func LoadRules(path string) ([]Rule, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := new(rules)
+	if err := yaml.UnmarshalStrict(b, r); err != nil {
+		return nil, fmt.Errorf("error parsing redaction rules from %s: %w", path, err)
+	}
+	return r.Rules, nil
+}
+
+// Redactor holds the compiled rules applied to every log.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a Redactor applying rules.
+func New(rules []Rule) (*Redactor, error) {
+	r := &Redactor{}
+	for _, rule := range rules {
+		p, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule %q: %w", rule.Name, err)
+		}
+		r.patterns = append(r.patterns, p)
+	}
+	return r, nil
+}
+
+// Session redacts the data of a single log, as it is received in chunks.
+// Data is processed one line at a time, so that secrets split across chunks
+// are still found; Redact holds back the last incomplete line until the next
+// chunk or Flush.
+type Session struct {
+	patterns []*regexp.Regexp
+	hints    map[string]struct{}
+	pending  []byte
+	count    int64
+}
+
+// NewSession returns a Session applying the rules of r, and masking the
+// values whose hints, as computed by logs.RedactionHint, are given. A nil
+// Redactor applies no rules.
+func (r *Redactor) NewSession(hints []string) *Session {
+	s := &Session{hints: map[string]struct{}{}}
+	if r != nil {
+		s.patterns = r.patterns
+	}
+	s.AddHints(hints)
+	return s
+}
+
+// AddHints adds hints of values to mask in the data redacted next.
+func (s *Session) AddHints(hints []string) {
+	for _, h := range hints {
+		s.hints[h] = struct{}{}
+	}
+}
+
+// Count returns the number of secrets masked so far.
+func (s *Session) Count() int64 {
+	return s.count
+}
+
+// Redact returns the redacted complete lines of the data received so far.
+func (s *Session) Redact(p []byte) []byte {
+	s.pending = append(s.pending, p...)
+	end := bytes.LastIndexByte(s.pending, '\n') + 1
+	if end == 0 && len(s.pending) >= maxLine {
+		end = bytes.LastIndexFunc(s.pending, isDelimiter) + 1
+		if end == 0 {
+			end = len(s.pending)
+		}
+	}
+	if end == 0 {
+		return nil
+	}
+	out := s.redact(s.pending[:end])
+	s.pending = append([]byte(nil), s.pending[end:]...)
+	return out
+}
+
+// Flush returns the redacted data held back by Redact.
+func (s *Session) Flush() []byte {
+	out := s.redact(s.pending)
+	s.pending = nil
+	return out
+}
+
+// span is the range of bytes of a secret in redacted data.
+type span struct {
+	start, end int
+}
+
+// redact masks the secrets found in p by the rules and the hints. Secrets are
+// all found in the original data, and overlapping ones are masked and counted
+// once.
+func (s *Session) redact(p []byte) []byte {
+	if len(p) == 0 {
+		return nil
+	}
+	var spans []span
+	for _, pattern := range s.patterns {
+		for _, m := range pattern.FindAllSubmatchIndex(p, -1) {
+			start, end := m[0], m[1]
+			if len(m) > 2 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			if start < end {
+				spans = append(spans, span{start, end})
+			}
+		}
+	}
+	if len(s.hints) > 0 {
+		spans = s.hinted(p, spans)
+	}
+
+	out := append([]byte(nil), p...)
+	for _, sp := range mergeSpans(spans) {
+		maskRange(out[sp.start:sp.end])
+		s.count++
+	}
+	return out
+}
+
+// hinted appends to spans the words of p whose hint is known. Values are also
+// found after "key=" or "key:".
+func (s *Session) hinted(p []byte, spans []span) []span {
+	for start := 0; start < len(p); {
+		if isDelimiter(rune(p[start])) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(p) && !isDelimiter(rune(p[end])) {
+			end++
+		}
+		word := p[start:end]
+		for i := 0; i < len(word); i++ {
+			if i > 0 && word[i-1] != '=' && word[i-1] != ':' {
+				continue
+			}
+			if len(word)-i < logs.MinRedactionLength {
+				break
+			}
+			if _, ok := s.hints[logs.RedactionHint(string(word[i:]))]; ok {
+				spans = append(spans, span{start + i, end})
+				break
+			}
+		}
+		start = end
+	}
+	return spans
+}
+
+// mergeSpans returns spans sorted, with the overlapping ones merged.
+func mergeSpans(spans []span) []span {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var merged []span
+	for _, sp := range spans {
+		if last := len(merged) - 1; last >= 0 && sp.start < merged[last].end {
+			merged[last].end = max(merged[last].end, sp.end)
+			continue
+		}
+		merged = append(merged, sp)
+	}
+	return merged
+}
+
+func isDelimiter(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '"', '\'', '`', ',', ';', '(', ')', '[', ']', '{', '}', '<', '>':
+		return true
+	}
+	return false
+}
+
+func maskRange(p []byte) {
+	for i := range p {
+		p[i] = mask
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/logs"
+)
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(`
+rules:
+- name: github-token
+  pattern: 'ghp_[A-Za-z0-9]{36}'
+- name: password
+  pattern: 'password=(\S+)'
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	want := []Rule{
+		{Name: "github-token", Pattern: `ghp_[A-Za-z0-9]{36}`},
+		{Name: "password", Pattern: `password=(\S+)`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n- name: x\n  regex: y\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRules(path); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New([]Rule{{Name: "invalid", Pattern: "("}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestSession(t *testing.T) {
+	r, err := New([]Rule{
+		{Name: "github-token", Pattern: `ghp_[A-Za-z0-9]{8}`},
+		{Name: "password", Pattern: `password=(\S+)`},
+		{Name: "secret", Pattern: `secret=(\S+)`},
+		{Name: "masked", Pattern: `=(\*+)`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		hints  []string
+		chunks []string
+		want   string
+		count  int64
+	}{
+		{
+			name:   "no secret",
+			chunks: []string{"hello ", "world\n", "no newline"},
+			want:   "hello world\nno newline",
+		},
+		{
+			name:   "rule",
+			chunks: []string{"token ghp_abcd1234 used\n"},
+			want:   "token ************ used\n",
+			count:  1,
+		},
+		{
+			name:   "capture group",
+			chunks: []string{"login password=hunter22 ok\n"},
+			want:   "login password=******** ok\n",
+			count:  1,
+		},
+		{
+			name:   "rule across chunks",
+			chunks: []string{"token ghp_ab", "cd1234 used\n"},
+			want:   "token ************ used\n",
+			count:  1,
+		},
+		{
+			name:   "hint",
+			hints:  []string{logs.RedactionHint("s3cr3t-value")},
+			chunks: []string{"echo s3cr3t-value\n", `export TOKEN="s3cr3t-value"` + "\n", "TOKEN=s3cr3t-value\n", "s3cr3t-valueX\n"},
+			want:   "echo ************\nexport TOKEN=\"************\"\nTOKEN=************\ns3cr3t-valueX\n",
+			count:  3,
+		},
+		{
+			// The secret is matched by a rule, the mask of the other rule
+			// and its hint, but masked once.
+			name:   "overlapping rules",
+			hints:  []string{logs.RedactionHint("ghp_abcd1234")},
+			chunks: []string{"login secret=ghp_abcd1234 ok\n"},
+			want:   "login secret=************ ok\n",
+			count:  1,
+		},
+		{
+			name:   "hint across chunks",
+			hints:  []string{logs.RedactionHint("s3cr3t-value")},
+			chunks: []string{"echo s3cr", "3t-", "value"},
+			want:   "echo ************",
+			count:  1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := r.NewSession(tc.hints)
+			var got strings.Builder
+			for _, c := range tc.chunks {
+				got.Write(s.Redact([]byte(c)))
+			}
+			got.Write(s.Flush())
+			if got.String() != tc.want {
+				t.Errorf("got %q, want %q", got.String(), tc.want)
+			}
+			if s.Count() != tc.count {
+				t.Errorf("count: got %d, want %d", s.Count(), tc.count)
+			}
+		})
+	}
+}
+
+func TestSession_longLine(t *testing.T) {
+	s := (*Redactor)(nil).NewSession([]string{logs.RedactionHint("s3cr3t-value")})
+	line := strings.Repeat("x", maxLine) + " s3cr3t-val"
+	out := s.Redact([]byte(line))
+	// The line is cut after its last word, so that the secret is kept whole.
+	if want := strings.Repeat("x", maxLine) + " "; string(out) != want {
+		t.Fatalf("got %d bytes, want %d", len(out), len(want))
+	}
+	if got := string(s.Redact([]byte("ue\n"))); got != "************\n" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -15,7 +15,13 @@
 // Package auth provides authentication and authorization utilities for the API server.
 package auth
 
-import "context"
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	authnv1 "k8s.io/api/authentication/v1"
+)
 
 const (
 	// ResourceResults - api results resource name
@@ -26,6 +32,9 @@ const (
 	ResourceLogs = "logs"
 	// ResourceSummary - api summary
 	ResourceSummary = "summary"
+	// ResourceMigrations - api record data migrations, which are cluster
+	// scoped
+	ResourceMigrations = "migrations"
 
 	// PermissionCreate - permission name to "create" resource
 	PermissionCreate = "create"
@@ -37,6 +46,10 @@ const (
 	PermissionDelete = "delete"
 	// PermissionUpdate - permission name to "update" resource
 	PermissionUpdate = "update"
+
+	// ClusterHeader is the gRPC metadata key with which clients, such as the
+	// watcher, tell the cluster of the Results and Records they act on.
+	ClusterHeader = "tekton-results-cluster"
 )
 
 // Checker handles authentication and authorization checks for an action on
@@ -44,3 +57,66 @@ const (
 type Checker interface {
 	Check(ctx context.Context, parent, resource, verb string) error
 }
+
+// Authenticator resolves the identity of the caller of a request.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*authnv1.UserInfo, error)
+}
+
+// Filterer is implemented by Checkers which, besides allowing or denying an
+// action, can narrow down the resources the caller is allowed to see. Filter
+// returns a CEL expression to be applied on top of the caller provided filter,
+// or an empty string if no further restriction applies.
+type Filterer interface {
+	Filter(ctx context.Context, parent, resource string) (string, error)
+}
+
+// ClusterChecker is implemented by Checkers which authorize actions on the
+// resources of several clusters. CheckCluster checks an action on a resource
+// of the given cluster, on top of Check which doesn't know the cluster of the
+// resource.
+type ClusterChecker interface {
+	CheckCluster(ctx context.Context, cluster, parent, resource, verb string) error
+}
+
+// Caller returns the identity of the user actually making the request, as
+// opposed to the user it may impersonate.
+func Caller(ctx context.Context, authn Authenticator) (*authnv1.UserInfo, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return authn.Authenticate(ctx)
+	}
+	// Authenticators resolve impersonated identities, so drop the
+	// impersonation metadata before authenticating.
+	md = md.Copy()
+	for key := range md {
+		if strings.HasPrefix(key, "impersonate-") {
+			delete(md, key)
+		}
+	}
+	return authn.Authenticate(metadata.NewIncomingContext(ctx, md))
+}
+
+type clusterKey struct{}
+
+// WithCluster returns a copy of ctx telling that the request acts on the
+// resources of the given cluster, in place of the ClusterHeader.
+func WithCluster(ctx context.Context, cluster string) context.Context {
+	return context.WithValue(ctx, clusterKey{}, cluster)
+}
+
+// RequestCluster returns the cluster of the resources the request acts on, or
+// an empty string if it isn't known.
+func RequestCluster(ctx context.Context) string {
+	if cluster, ok := ctx.Value(clusterKey{}).(string); ok {
+		return cluster
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(ClusterHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
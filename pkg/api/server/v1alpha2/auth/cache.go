@@ -0,0 +1,168 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth/impersonation"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	cacheAuthn = "authn"
+	cacheAuthz = "authz"
+)
+
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "results_api_auth_cache_requests_total",
+	Help: "Number of lookups in the authentication and authorization decision cache, by decision type and result.",
+}, []string{"type", "result"})
+
+func init() {
+	prometheus.MustRegister(cacheRequests)
+}
+
+// decisionCache is a bounded cache of TokenReview and SubjectAccessReview
+// outcomes. Allowed and denied decisions expire after different TTLs, so that
+// revoked permissions can be picked up faster than newly granted ones, or the
+// other way around.
+type decisionCache struct {
+	cache    *cache.LRUExpireCache
+	allowTTL time.Duration
+	denyTTL  time.Duration
+}
+
+// authnEntry is the cached outcome of reviewing a single token. A nil user
+// means that the token was rejected.
+type authnEntry struct {
+	user *authnv1.UserInfo
+	err  error
+}
+
+func newDecisionCache(size int, allowTTL, denyTTL time.Duration) *decisionCache {
+	return &decisionCache{
+		cache:    cache.NewLRUExpireCache(size),
+		allowTTL: allowTTL,
+		denyTTL:  denyTTL,
+	}
+}
+
+func (c *decisionCache) get(kind, key string) (any, bool) {
+	v, ok := c.cache.Get(kind + "/" + key)
+	if ok {
+		cacheRequests.WithLabelValues(kind, "hit").Inc()
+	} else {
+		cacheRequests.WithLabelValues(kind, "miss").Inc()
+	}
+	return v, ok
+}
+
+func (c *decisionCache) add(kind, key string, value any, allowed bool) {
+	ttl := c.denyTTL
+	if allowed {
+		ttl = c.allowTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	c.cache.Add(kind+"/"+key, value, ttl)
+}
+
+// authnKey identifies a token, together with the impersonation attributes of
+// the request if any. The token itself is hashed so that it isn't kept in
+// memory longer than needed.
+func authnKey(token string, impersonator *impersonation.Impersonation) string {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	if impersonator == nil {
+		return key
+	}
+
+	info := impersonator.GetUserInfo()
+	groups := slices.Clone(info.GetGroups())
+	slices.Sort(groups)
+	extra := make([]string, 0, len(info.GetExtra()))
+	for k, v := range info.GetExtra() {
+		values := slices.Clone(v)
+		slices.Sort(values)
+		extra = append(extra, fmt.Sprintf("%q=%q", k, values))
+	}
+	slices.Sort(extra)
+	return fmt.Sprintf("%s/%q/%q/%q/%s", key, info.GetName(), info.GetUID(), groups, strings.Join(extra, ","))
+}
+
+// authzKey identifies an action performed by an authenticated caller.
+func authzKey(authnKey, namespace, resource, verb string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", authnKey, namespace, resource, verb)
+}
+
+type reviewsKey struct{}
+
+// reviews are the outcomes of the token reviews made while handling a single
+// request. Unlike the decision cache, they are kept whatever the cache TTLs,
+// so that the interceptors identifying the caller and the checker authorizing
+// the request review each token once.
+type reviews struct {
+	mu sync.Mutex
+	// users are the reviewed identities, nil for rejected tokens.
+	users map[reviewKey]*authnv1.UserInfo
+}
+
+// reviewKey identifies a token reviewed by a given reviewer, such as the RBAC
+// checker of a cluster.
+type reviewKey struct {
+	reviewer any
+	token    string
+}
+
+// NewRequestContext returns a context remembering the tokens reviewed while
+// handling a request, to be set up before any interceptor or handler
+// authenticates the caller.
+func NewRequestContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reviewsKey{}, &reviews{users: map[reviewKey]*authnv1.UserInfo{}})
+}
+
+// reviewed returns the identity a token was reviewed as by reviewer while
+// handling the request of ctx.
+func reviewed(ctx context.Context, reviewer any, token string) (*authnv1.UserInfo, bool) {
+	r, ok := ctx.Value(reviewsKey{}).(*reviews)
+	if !ok {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[reviewKey{reviewer, token}]
+	return user, ok
+}
+
+// rememberReview records the identity, or nil if rejected, a token was
+// reviewed as by reviewer while handling the request of ctx.
+func rememberReview(ctx context.Context, reviewer any, token string, user *authnv1.UserInfo) {
+	if r, ok := ctx.Value(reviewsKey{}).(*reviews); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.users[reviewKey{reviewer, token}] = user
+	}
+}
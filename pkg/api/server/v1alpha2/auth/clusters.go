@@ -0,0 +1,213 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Clusters delegates authorization to a Checker per cluster, so that a single
+// API server can store the Results of several clusters while each cluster
+// keeps authorizing access to its own Results. Results and Records without a
+// cluster belong to the local cluster, the one the API server runs in.
+//
+// Requests which tell the cluster of the resources they act on, see
+// RequestCluster, are only checked by the Checker of that cluster. Otherwise
+// Check allows an action if the Checker of any cluster allows it, calling the
+// Checkers concurrently. Filter then hides the Results and Records of the
+// clusters whose Checker doesn't allow the caller to see them, and
+// CheckCluster checks actions on the resources of a given cluster with the
+// Checker of that cluster only.
+type Clusters struct {
+	local    string
+	checkers map[string]Checker
+	// names are the names of the clusters, the local cluster first and the
+	// other ones sorted, so that decisions are made in a stable order.
+	names []string
+}
+
+// NewClusters returns a Checker delegating the actions on the resources of the
+// local cluster, named local, to localChecker, and those on the resources of
+// the other clusters to the Checkers of remotes, keyed by cluster name.
+func NewClusters(local string, localChecker Checker, remotes map[string]Checker) (*Clusters, error) {
+	if local == "" {
+		return nil, fmt.Errorf("the local cluster must be named")
+	}
+	if _, ok := remotes[local]; ok {
+		return nil, fmt.Errorf("cluster %q is both the local cluster and a remote one", local)
+	}
+	c := &Clusters{
+		local:    local,
+		checkers: map[string]Checker{local: localChecker},
+	}
+	for name, checker := range remotes {
+		if name == "" {
+			return nil, fmt.Errorf("remote clusters must be named")
+		}
+		c.checkers[name] = checker
+		c.names = append(c.names, name)
+	}
+	sort.Strings(c.names)
+	c.names = append([]string{local}, c.names...)
+	return c, nil
+}
+
+// Check implements Checker. Migrations, which aren't specific to a cluster,
+// are only authorized by the local cluster.
+func (c *Clusters) Check(ctx context.Context, parent, resource, verb string) error {
+	if resource == ResourceMigrations {
+		return c.checkers[c.local].Check(ctx, parent, resource, verb)
+	}
+	if cluster := RequestCluster(ctx); cluster != "" {
+		return c.CheckCluster(ctx, cluster, parent, resource, verb)
+	}
+	errs := c.checkAll(ctx, c.names, parent, resource, verb)
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errs[0]
+}
+
+// CheckCluster implements ClusterChecker. Actions on the resources of clusters
+// without a Checker are denied.
+func (c *Clusters) CheckCluster(ctx context.Context, cluster, parent, resource, verb string) error {
+	if cluster == "" {
+		cluster = c.local
+	}
+	checker, ok := c.checkers[cluster]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "unknown cluster %q", cluster)
+	}
+	return checker.Check(ctx, parent, resource, verb)
+}
+
+// Filter implements Filterer. Results and Records are only visible to callers
+// allowed to get them in their cluster, or when listing across parents, to
+// list them. Requests which tell their cluster only see the resources of that
+// cluster.
+func (c *Clusters) Filter(ctx context.Context, parent, resource string) (string, error) {
+	if resource != ResourceResults && resource != ResourceRecords {
+		return "", nil
+	}
+	verb := PermissionGet
+	if parent == "-" {
+		verb = PermissionList
+	}
+	names := c.names
+	if cluster := RequestCluster(ctx); cluster != "" {
+		if _, ok := c.checkers[cluster]; !ok {
+			return "false", nil
+		}
+		names = []string{cluster}
+	}
+	var allowed []string
+	for i, err := range c.checkAll(ctx, names, parent, resource, verb) {
+		if err != nil {
+			continue
+		}
+		if names[i] == c.local {
+			allowed = append(allowed, strconv.Quote(""))
+		}
+		allowed = append(allowed, strconv.Quote(names[i]))
+	}
+	switch {
+	case len(allowed) == 0:
+		return "false", nil
+	// The local cluster is allowed under both of its names.
+	case len(allowed) == len(c.names)+1:
+		return "", nil
+	}
+	return fmt.Sprintf("cluster in [%s]", strings.Join(allowed, ", ")), nil
+}
+
+// checkAll calls the Checkers of the named clusters concurrently, and returns
+// their errors in the order of names.
+func (c *Clusters) checkAll(ctx context.Context, names []string, parent, resource, verb string) []error {
+	errs := make([]error, len(names))
+	if len(names) == 1 {
+		errs[0] = c.checkers[names[0]].Check(ctx, parent, resource, verb)
+		return errs
+	}
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.checkers[name].Check(ctx, parent, resource, verb)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// Authenticate implements Authenticator with the first Checker of a cluster
+// which authenticates the caller.
+func (c *Clusters) Authenticate(ctx context.Context) (*authnv1.UserInfo, error) {
+	err := status.Error(codes.Unauthenticated, "permission denied")
+	for _, name := range c.names {
+		authn, ok := c.checkers[name].(Authenticator)
+		if !ok {
+			continue
+		}
+		var user *authnv1.UserInfo
+		if user, err = authn.Authenticate(ctx); err == nil {
+			return user, nil
+		}
+	}
+	return nil, err
+}
+
+// LoadKubeconfigs reads the kubeconfig files of the directory at dir, each
+// named after the cluster it gives access to. The directory is typically
+// mounted from a Secret. Hidden files, such as the ones Kubernetes manages in
+// volumes, are skipped.
+func LoadKubeconfigs(dir string) (map[string]*rest.Config, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	configs := map[string]*rest.Config{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid cluster name %q: %s", name, strings.Join(errs, ", "))
+		}
+		config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("error loading the kubeconfig of cluster %s: %w", name, err)
+		}
+		configs[name] = config
+	}
+	return configs, nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// namespaceChecker allows actions in its namespaces only.
+type namespaceChecker []string
+
+func (c namespaceChecker) Check(_ context.Context, parent, _, _ string) error {
+	for _, ns := range c {
+		if ns == parent {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+func TestClusters(t *testing.T) {
+	clusters, err := auth.NewClusters("hub", namespaceChecker{"ops"}, map[string]auth.Checker{
+		"build-eu-1": namespaceChecker{"ci", "ops"},
+		"build-us-1": namespaceChecker{"ops"},
+	})
+	if err != nil {
+		t.Fatalf("NewClusters: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		cluster, parent string
+		want            codes.Code
+	}{
+		{cluster: "", parent: "ops", want: codes.OK},
+		{cluster: "hub", parent: "ci", want: codes.Unauthenticated},
+		{cluster: "build-eu-1", parent: "ci", want: codes.OK},
+		{cluster: "build-us-1", parent: "ci", want: codes.Unauthenticated},
+		{cluster: "build-ap-1", parent: "ops", want: codes.PermissionDenied},
+	} {
+		err := clusters.CheckCluster(ctx, tc.cluster, tc.parent, auth.ResourceResults, auth.PermissionGet)
+		if status.Code(err) != tc.want {
+			t.Errorf("CheckCluster(%q, %q): want %v, got %v", tc.cluster, tc.parent, tc.want, err)
+		}
+	}
+
+	if err := clusters.Check(ctx, "ci", auth.ResourceResults, auth.PermissionGet); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+	if err := clusters.Check(ctx, "ci", auth.ResourceMigrations, auth.PermissionCreate); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Check of migrations: want %v, got %v", codes.Unauthenticated, err)
+	}
+
+	for _, tc := range []struct {
+		parent, resource, want string
+	}{
+		{parent: "ops", resource: auth.ResourceResults, want: ""},
+		{parent: "ci", resource: auth.ResourceRecords, want: `cluster in ["build-eu-1"]`},
+		{parent: "dev", resource: auth.ResourceResults, want: "false"},
+		{parent: "dev", resource: auth.ResourceLogs, want: ""},
+	} {
+		got, err := clusters.Filter(ctx, tc.parent, tc.resource)
+		if err != nil {
+			t.Fatalf("Filter: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("Filter(%q, %q): want %q, got %q", tc.parent, tc.resource, tc.want, got)
+		}
+	}
+
+	clusters, err = auth.NewClusters("hub", namespaceChecker{"ci"}, map[string]auth.Checker{"build-eu-1": namespaceChecker{}})
+	if err != nil {
+		t.Fatalf("NewClusters: %v", err)
+	}
+	if got, _ := clusters.Filter(ctx, "ci", auth.ResourceResults); got != `cluster in ["", "hub"]` {
+		t.Errorf("Filter: want the local cluster under both of its names, got %q", got)
+	}
+}
+
+// countingChecker counts the calls of its Checker.
+type countingChecker struct {
+	auth.Checker
+	calls *atomic.Int32
+}
+
+func (c countingChecker) Check(ctx context.Context, parent, resource, verb string) error {
+	c.calls.Add(1)
+	return c.Checker.Check(ctx, parent, resource, verb)
+}
+
+func TestClusters_requestCluster(t *testing.T) {
+	calls := map[string]*atomic.Int32{"hub": {}, "build-eu-1": {}, "build-us-1": {}}
+	clusters, err := auth.NewClusters("hub", countingChecker{namespaceChecker{"ops"}, calls["hub"]}, map[string]auth.Checker{
+		"build-eu-1": countingChecker{namespaceChecker{"ci", "ops"}, calls["build-eu-1"]},
+		"build-us-1": countingChecker{namespaceChecker{"ops"}, calls["build-us-1"]},
+	})
+	if err != nil {
+		t.Fatalf("NewClusters: %v", err)
+	}
+	inCluster := func(cluster string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.ClusterHeader, cluster))
+	}
+
+	for _, tc := range []struct {
+		ctx    context.Context
+		parent string
+		want   codes.Code
+		filter string
+		called string
+	}{
+		{ctx: inCluster("build-eu-1"), parent: "ci", want: codes.OK, filter: `cluster in ["build-eu-1"]`, called: "build-eu-1"},
+		{ctx: inCluster("build-us-1"), parent: "ci", want: codes.Unauthenticated, filter: "false", called: "build-us-1"},
+		{ctx: inCluster("hub"), parent: "ops", want: codes.OK, filter: `cluster in ["", "hub"]`, called: "hub"},
+		// The cluster of the context takes precedence over the header.
+		{ctx: auth.WithCluster(inCluster("build-us-1"), "build-eu-1"), parent: "ci", want: codes.OK, filter: `cluster in ["build-eu-1"]`, called: "build-eu-1"},
+		{ctx: inCluster("build-ap-1"), parent: "ops", want: codes.PermissionDenied, filter: "false"},
+	} {
+		for _, c := range calls {
+			c.Store(0)
+		}
+		cluster := auth.RequestCluster(tc.ctx)
+		if err := clusters.Check(tc.ctx, tc.parent, auth.ResourceResults, auth.PermissionGet); status.Code(err) != tc.want {
+			t.Errorf("Check in cluster %s: want %v, got %v", cluster, tc.want, err)
+		}
+		got, err := clusters.Filter(tc.ctx, tc.parent, auth.ResourceResults)
+		if err != nil {
+			t.Fatalf("Filter: %v", err)
+		}
+		if got != tc.filter {
+			t.Errorf("Filter in cluster %s: want %q, got %q", cluster, tc.filter, got)
+		}
+		// Only the Checker of the cluster of the request is called.
+		for name, c := range calls {
+			want := int32(0)
+			if name == tc.called {
+				want = 2
+			}
+			if n := c.Load(); n != want {
+				t.Errorf("Check and Filter in cluster %s: Checker of cluster %s called %d times, want %d", cluster, name, n, want)
+			}
+		}
+	}
+}
+
+// barrierChecker only decides once all the Checkers sharing its barrier are
+// called, which doesn't happen unless they are called concurrently.
+type barrierChecker struct {
+	barrier *sync.WaitGroup
+	allow   bool
+}
+
+func (c barrierChecker) Check(context.Context, string, string, string) error {
+	c.barrier.Done()
+	done := make(chan struct{})
+	go func() {
+		c.barrier.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		return status.Error(codes.DeadlineExceeded, "Checkers called one after another")
+	}
+	if !c.allow {
+		return status.Error(codes.Unauthenticated, "permission denied")
+	}
+	return nil
+}
+
+func TestClusters_concurrent(t *testing.T) {
+	var barrier sync.WaitGroup
+	barrier.Add(3)
+	clusters, err := auth.NewClusters("hub", barrierChecker{barrier: &barrier}, map[string]auth.Checker{
+		"build-eu-1": barrierChecker{barrier: &barrier},
+		"build-us-1": barrierChecker{barrier: &barrier, allow: true},
+	})
+	if err != nil {
+		t.Fatalf("NewClusters: %v", err)
+	}
+	if err := clusters.Check(context.Background(), "ci", auth.ResourceResults, auth.PermissionGet); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+
+	barrier.Add(3)
+	got, err := clusters.Filter(context.Background(), "ci", auth.ResourceResults)
+	if err != nil {
+		t.Fatalf("Filter: %v", err)
+	}
+	if want := `cluster in ["build-us-1"]`; got != want {
+		t.Errorf("Filter: want %q, got %q", want, got)
+	}
+}
+
+func TestNewClusters_invalid(t *testing.T) {
+	for name, remotes := range map[string]map[string]auth.Checker{
+		"unnamed local": nil,
+		"duplicate":     {"hub": auth.AllowAll{}},
+		"unnamed":       {"": auth.AllowAll{}},
+	} {
+		local := "hub"
+		if name == "unnamed local" {
+			local = ""
+		}
+		if _, err := auth.NewClusters(local, auth.AllowAll{}, remotes); err == nil {
+			t.Errorf("NewClusters with %s cluster: want an error", name)
+		}
+	}
+}
+
+func TestLoadKubeconfigs(t *testing.T) {
+	const kubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: build
+  cluster:
+    server: https://build.example.com
+contexts:
+- name: build
+  context:
+    cluster: build
+    user: results
+current-context: build
+users:
+- name: results
+  user:
+    token: secret
+`
+	dir := t.TempDir()
+	for _, name := range []string{"build-eu-1", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(kubeconfig), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "..data"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	configs, err := auth.LoadKubeconfigs(dir)
+	if err != nil {
+		t.Fatalf("LoadKubeconfigs: %v", err)
+	}
+	if len(configs) != 1 || configs["build-eu-1"].Host != "https://build.example.com" {
+		t.Errorf("LoadKubeconfigs: unexpected configs %v", configs)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "Build_US"), []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.LoadKubeconfigs(dir); err == nil {
+		t.Error("LoadKubeconfigs: want an error for an invalid cluster name")
+	}
+}
@@ -0,0 +1,446 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/cel-go/cel"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// jwksRefreshInterval is the minimum time between two fetches of the
+	// issuer keys, so that tokens signed with unknown keys can't be used to
+	// flood the issuer.
+	jwksRefreshInterval = time.Minute
+	// jwksRetryInterval is the minimum time between two fetches of the issuer
+	// keys after a failed one. Meanwhile, the error of the failed fetch is
+	// returned rather than piling up requests to an unavailable issuer.
+	jwksRetryInterval = 10 * time.Second
+	// issuerTimeout bounds the requests made to the issuer, so that a hung
+	// issuer doesn't hold the requests authenticated meanwhile.
+	issuerTimeout = 10 * time.Second
+	// clockSkew is the leeway allowed when validating time based claims.
+	clockSkew = time.Minute
+)
+
+var supportedAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// Rule grants the callers it matches permissions on resources of the given
+// namespaces.
+type Rule struct {
+	// Name identifies the rule in error messages.
+	Name string `json:"name"`
+	// Match is a CEL expression deciding whether the rule applies to the
+	// caller. The variables username, groups and claims are available.
+	Match string `json:"match"`
+	// Namespaces the rule grants access to. "*" grants access to all
+	// namespaces, including listing across namespaces.
+	Namespaces []string `json:"namespaces"`
+	// Resources the rule grants access to, or "*" for all of them.
+	Resources []string `json:"resources"`
+	// Verbs the rule allows, or "*" for all of them.
+	Verbs []string `json:"verbs"`
+}
+
+type rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+type compiledRule struct {
+	Rule
+	match cel.Program
+}
+
+// OIDC is an auth checker validating bearer JWTs issued by an OpenID Connect
+// provider. Tokens are verified locally against the keys published by the
+// issuer, and permissions are granted by a set of rules matching the token
+// claims, so no Kubernetes API server is involved.
+type OIDC struct {
+	issuer        string
+	audience      string
+	jwksURL       string
+	usernameClaim string
+	groupsClaim   string
+	client        *http.Client
+	rules         []compiledRule
+
+	mu        sync.Mutex
+	keys      *jose.JSONWebKeySet
+	fetchedAt time.Time
+	fetchErr  error
+	// fetches lets concurrent callers share a single fetch of the keys.
+	fetches singleflight.Group
+}
+
+// OIDCOption is configuration option for OIDC checker.
+type OIDCOption func(*OIDC)
+
+// WithJWKSURL is an option function to fetch the issuer keys from url
+// instead of the one advertised in the issuer discovery document.
+func WithJWKSURL(url string) OIDCOption {
+	return func(o *OIDC) {
+		o.jwksURL = url
+	}
+}
+
+// WithClaims is an option function to set the claims the username and groups
+// of the caller are read from. They default to "sub" and "groups".
+func WithClaims(username, groups string) OIDCOption {
+	return func(o *OIDC) {
+		if username != "" {
+			o.usernameClaim = username
+		}
+		if groups != "" {
+			o.groupsClaim = groups
+		}
+	}
+}
+
+// WithHTTPClient is an option function to set the client used to reach the
+// issuer.
+func WithHTTPClient(client *http.Client) OIDCOption {
+	return func(o *OIDC) {
+		o.client = client
+	}
+}
+
+// LoadRules reads the rules stored in the YAML file at path.
+func LoadRules(path string) ([]Rule, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := new(rules)
+	if err := yaml.UnmarshalStrict(b, r); err != nil {
+		return nil, fmt.Errorf("error parsing rules from %s: %w", path, err)
+	}
+	return r.Rules, nil
+}
+
+// NewOIDC returns new instance of the OpenID Connect based auth checker.
+// Tokens must be issued by issuer for audience, and are granted permissions
+// by the given rules. Both the issuer and the audience are required, as no
+// token would be accepted without them.
+func NewOIDC(ctx context.Context, issuer, audience string, rules []Rule, options ...OIDCOption) (*OIDC, error) {
+	if issuer == "" {
+		return nil, fmt.Errorf("the issuer of tokens must be set")
+	}
+	if audience == "" {
+		return nil, fmt.Errorf("the audience of tokens issued by %s must be set", issuer)
+	}
+	o := &OIDC{
+		issuer:        strings.TrimSuffix(issuer, "/"),
+		audience:      audience,
+		usernameClaim: "sub",
+		groupsClaim:   "groups",
+		client:        &http.Client{Timeout: issuerTimeout},
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("username", cel.StringType),
+		cel.Variable("groups", cel.ListType(cel.StringType)),
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if strings.TrimSpace(r.Match) == "" {
+			return nil, fmt.Errorf("rule %q: match must be set", r.Name)
+		}
+		match, err := resultscel.ParseFilter(env, r.Match)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid match expression: %w", r.Name, err)
+		}
+		o.rules = append(o.rules, compiledRule{Rule: r, match: match})
+	}
+
+	if o.jwksURL == "" {
+		if o.jwksURL, err = o.discoverJWKSURL(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// Check determines if resource can be accessed by the caller identified by
+// the bearer token stored in the context.
+func (o *OIDC) Check(ctx context.Context, namespace, resource, verb string) error {
+	users, err := o.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		for _, r := range o.rules {
+			if !r.allows(namespace, resource, verb) {
+				continue
+			}
+			ok, err := resultscel.Match(r.match, u.vars())
+			if err != nil {
+				log.Printf("rule %q: %v", r.Name, err)
+				continue
+			}
+			if ok {
+				return nil
+			}
+		}
+	}
+	// Return Unauthenticated like the RBAC checker so as not to leak
+	// whether the token or the permissions were at fault.
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+// Authenticate returns the identity of the caller.
+func (o *OIDC) Authenticate(ctx context.Context) (*authnv1.UserInfo, error) {
+	users, err := o.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "permission denied")
+	}
+	return &authnv1.UserInfo{
+		Username: users[0].username,
+		UID:      users[0].subject,
+		Groups:   users[0].groups,
+	}, nil
+}
+
+type oidcUser struct {
+	subject  string
+	username string
+	groups   []string
+	claims   map[string]any
+}
+
+func (u *oidcUser) vars() map[string]any {
+	return map[string]any{
+		"username": u.username,
+		"groups":   u.groups,
+		"claims":   u.claims,
+	}
+}
+
+// authenticate verifies every bearer token found in the context metadata and
+// returns the callers of the valid ones.
+func (o *OIDC) authenticate(ctx context.Context) ([]*oidcUser, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unable to get context metadata")
+	}
+	v := md.Get("authorization")
+	if len(v) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "unable to find token")
+	}
+
+	var users []*oidcUser
+	for _, raw := range v {
+		// We expect tokens to be in the form "Bearer <token>". Parse the token out.
+		s := strings.SplitN(raw, " ", 2)
+		if len(s) < 2 {
+			log.Println("unknown auth token format")
+			continue
+		}
+		u, err := o.verify(ctx, s[1])
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (o *OIDC) verify(ctx context.Context, raw string) (*oidcUser, error) {
+	token, err := jwt.ParseSigned(raw, supportedAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token: %w", err)
+	}
+	if len(token.Headers) == 0 {
+		return nil, fmt.Errorf("token has no signature")
+	}
+	keys, err := o.keySet(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	std := jwt.Claims{}
+	claims := map[string]any{}
+	if err := token.Claims(keys, &std, &claims); err != nil {
+		return nil, fmt.Errorf("error verifying token: %w", err)
+	}
+	if err := std.ValidateWithLeeway(jwt.Expected{
+		Issuer:      o.issuer,
+		AnyAudience: jwt.Audience{o.audience},
+		Time:        time.Now(),
+	}, clockSkew); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+
+	username, _ := claims[o.usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("token has no %q claim", o.usernameClaim)
+	}
+	u := &oidcUser{
+		subject:  std.Subject,
+		username: username,
+		claims:   claims,
+	}
+	switch groups := claims[o.groupsClaim].(type) {
+	case string:
+		u.groups = []string{groups}
+	case []any:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				u.groups = append(u.groups, s)
+			}
+		}
+	}
+	return u, nil
+}
+
+// keySet returns the issuer keys, fetching them again if none matches kid.
+// The keys are fetched without holding the lock, by a single caller at a
+// time, and the others wait for the fetch unless their context ends.
+func (o *OIDC) keySet(ctx context.Context, kid string) (*jose.JSONWebKeySet, error) {
+	o.mu.Lock()
+	switch {
+	case o.keys != nil && (kid == "" || len(o.keys.Key(kid)) > 0):
+		defer o.mu.Unlock()
+		return o.keys, nil
+	case o.fetchErr != nil && time.Since(o.fetchedAt) < jwksRetryInterval:
+		defer o.mu.Unlock()
+		return nil, o.fetchErr
+	case o.fetchErr == nil && o.keys != nil && time.Since(o.fetchedAt) < jwksRefreshInterval:
+		defer o.mu.Unlock()
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	o.mu.Unlock()
+
+	fetch := o.fetches.DoChan("keys", func() (any, error) {
+		return o.fetchKeys(ctx)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-fetch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*jose.JSONWebKeySet), nil
+	}
+}
+
+// fetchKeys fetches the issuer keys and caches them, or the error of the
+// fetch. The fetch isn't bound to ctx, which is only the context of the caller
+// which started it, so that it isn't cancelled along with the request.
+func (o *OIDC) fetchKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), issuerTimeout)
+	defer cancel()
+	keys := new(jose.JSONWebKeySet)
+	err := o.get(ctx, o.jwksURL, keys)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.fetchedAt = time.Now()
+	if err != nil {
+		err = fmt.Errorf("error fetching issuer keys: %w", err)
+		// Cancellations don't tell anything about the issuer, and aren't
+		// returned to the next callers.
+		if !errors.Is(err, context.Canceled) {
+			o.fetchErr = err
+		}
+		return nil, err
+	}
+	o.keys = keys
+	o.fetchErr = nil
+	return keys, nil
+}
+
+func (o *OIDC) discoverJWKSURL(ctx context.Context) (string, error) {
+	discovery := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURL string `json:"jwks_uri"`
+	}{}
+	if err := o.get(ctx, o.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return "", fmt.Errorf("error discovering OIDC issuer %s: %w", o.issuer, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != o.issuer {
+		return "", fmt.Errorf("OIDC issuer mismatch: expected %s, got %s", o.issuer, discovery.Issuer)
+	}
+	if discovery.JWKSURL == "" {
+		return "", fmt.Errorf("OIDC issuer %s advertises no jwks_uri", o.issuer)
+	}
+	return discovery.JWKSURL, nil
+}
+
+func (o *OIDC) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (r *compiledRule) allows(namespace, resource, verb string) bool {
+	if !slices.Contains(r.Namespaces, "*") {
+		// Listing across namespaces requires access to all of them.
+		if namespace == "-" || !slices.Contains(r.Namespaces, namespace) {
+			return false
+		}
+	}
+	return matchesAny(r.Resources, resource) && matchesAny(r.Verbs, verb)
+}
+
+func matchesAny(values []string, value string) bool {
+	return slices.Contains(values, "*") || slices.Contains(values, value)
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestOIDC(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk := jose.JSONWebKey{Key: key.Public(), KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"}
+
+	mux := http.NewServeMux()
+	issuer := httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+			"issuer":   issuer.URL,
+			"jwks_uri": issuer.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}}) //nolint:errcheck
+	})
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(t *testing.T, claims map[string]any) string {
+		t.Helper()
+		std := jwt.Claims{
+			Issuer:   issuer.URL,
+			Subject:  "1234",
+			Audience: jwt.Audience{"results"},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+		token, err := jwt.Signed(signer).Claims(std).Claims(claims).Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	oidc, err := auth.NewOIDC(context.Background(), issuer.URL, "results", []auth.Rule{
+		{
+			Name:       "readers",
+			Match:      `"readers" in groups`,
+			Namespaces: []string{"foo"},
+			Resources:  []string{auth.ResourceResults, auth.ResourceRecords},
+			Verbs:      []string{auth.PermissionGet, auth.PermissionList},
+		},
+		{
+			Name:       "admins",
+			Match:      `claims.email.endsWith("@admin.example.com")`,
+			Namespaces: []string{"*"},
+			Resources:  []string{"*"},
+			Verbs:      []string{"*"},
+		},
+	}, auth.WithClaims("email", ""))
+	if err != nil {
+		t.Fatalf("NewOIDC: %v", err)
+	}
+
+	reader := sign(t, map[string]any{"email": "reader@example.com", "groups": []string{"readers"}})
+	admin := sign(t, map[string]any{"email": "root@admin.example.com"})
+	for _, tc := range []struct {
+		name      string
+		token     string
+		namespace string
+		verb      string
+		want      codes.Code
+	}{
+		{
+			name:      "allowed by group",
+			token:     reader,
+			namespace: "foo",
+			verb:      auth.PermissionList,
+			want:      codes.OK,
+		},
+		{
+			name:      "verb not granted",
+			token:     reader,
+			namespace: "foo",
+			verb:      auth.PermissionDelete,
+			want:      codes.Unauthenticated,
+		},
+		{
+			name:      "namespace not granted",
+			token:     reader,
+			namespace: "bar",
+			verb:      auth.PermissionGet,
+			want:      codes.Unauthenticated,
+		},
+		{
+			name:      "list across namespaces not granted",
+			token:     reader,
+			namespace: "-",
+			verb:      auth.PermissionList,
+			want:      codes.Unauthenticated,
+		},
+		{
+			name:      "allowed by claim",
+			token:     admin,
+			namespace: "-",
+			verb:      auth.PermissionList,
+			want:      codes.OK,
+		},
+		{
+			name:      "invalid token",
+			token:     "invalid",
+			namespace: "foo",
+			verb:      auth.PermissionGet,
+			want:      codes.Unauthenticated,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				token, err := jwt.Signed(signer).Claims(jwt.Claims{
+					Issuer:   issuer.URL,
+					Audience: jwt.Audience{"other"},
+					Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				}).Claims(map[string]any{"email": "root@admin.example.com"}).Serialize()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return token
+			}(),
+			namespace: "foo",
+			verb:      auth.PermissionGet,
+			want:      codes.Unauthenticated,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tc.token))
+			if err := oidc.Check(ctx, tc.namespace, auth.ResourceRecords, tc.verb); status.Code(err) != tc.want {
+				t.Fatalf("Check: %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	t.Run("authenticate", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+reader))
+		user, err := oidc.Authenticate(ctx)
+		if err != nil {
+			t.Fatalf("Authenticate: %v", err)
+		}
+		if user.Username != "reader@example.com" || user.UID != "1234" || len(user.Groups) != 1 || user.Groups[0] != "readers" {
+			t.Errorf("Authenticate: unexpected user %+v", user)
+		}
+	})
+}
+
+func TestOIDC_issuerUnavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fetches atomic.Int32
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(issuer.Close)
+
+	oidc, err := auth.NewOIDC(context.Background(), issuer.URL, "results", nil, auth.WithJWKSURL(issuer.URL+"/keys"))
+	if err != nil {
+		t.Fatalf("NewOIDC: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   issuer.URL,
+		Audience: jwt.Audience{"results"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests made while the issuer is unavailable don't all fetch its keys.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	for range 5 {
+		if _, err := oidc.Authenticate(ctx); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Authenticate: %v, want %v", err, codes.Unauthenticated)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("got %d fetches of the issuer keys, want 1", got)
+	}
+}
+
+func TestOIDC_issuerHung(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk := jose.JSONWebKey{Key: key.Public(), KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"}
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		<-release
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}}) //nolint:errcheck
+	}))
+	t.Cleanup(issuer.Close)
+
+	oidc, err := auth.NewOIDC(context.Background(), issuer.URL, "results", nil, auth.WithJWKSURL(issuer.URL+"/keys"))
+	if err != nil {
+		t.Fatalf("NewOIDC: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   issuer.URL,
+		Subject:  "1234",
+		Audience: jwt.Audience{"results"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", "Bearer "+token)
+
+	// A cancelled request stops waiting for the keys, without failing the
+	// requests made meanwhile.
+	cancelled, cancel := context.WithCancel(metadata.NewIncomingContext(context.Background(), md))
+	errs := make(chan error, 2)
+	go func() {
+		_, err := oidc.Authenticate(cancelled)
+		errs <- err
+	}()
+	for start := time.Now(); fetches.Load() == 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for the keys to be fetched")
+		}
+	}
+	go func() {
+		_, err := oidc.Authenticate(metadata.NewIncomingContext(context.Background(), md))
+		errs <- err
+	}()
+	cancel()
+	if err := <-errs; err == nil {
+		t.Error("Authenticate: want an error for the cancelled request")
+	}
+	close(release)
+	if err := <-errs; err != nil {
+		t.Errorf("Authenticate: %v", err)
+	}
+	if _, err := oidc.Authenticate(metadata.NewIncomingContext(context.Background(), md)); err != nil {
+		t.Errorf("Authenticate: %v", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("got %d fetches of the issuer keys, want 1", got)
+	}
+}
+
+func TestNewOIDC_invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name, issuer, audience string
+	}{
+		{name: "no issuer", audience: "results"},
+		{name: "no audience", issuer: "https://issuer.example.com"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := auth.NewOIDC(context.Background(), tc.issuer, tc.audience, nil); err == nil {
+				t.Error("NewOIDC: want an error")
+			}
+		})
+	}
+}
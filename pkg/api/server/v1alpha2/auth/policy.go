@@ -0,0 +1,211 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy grants the callers it matches access to the subset of Records
+// selected by its filter.
+type Policy struct {
+	// Name identifies the policy in error messages.
+	Name string `json:"name"`
+	// Namespaces restricts the policy to the given namespaces. An empty list
+	// means that the policy applies to all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Match is a CEL expression deciding whether the policy applies to the
+	// caller. The variables username, uid, groups and extra are available.
+	Match string `json:"match"`
+	// Filter is a CEL expression, in the same environment as the Records
+	// filter, selecting the Records visible to the matched callers.
+	Filter string `json:"filter"`
+}
+
+type policies struct {
+	Policies []Policy `json:"policies"`
+}
+
+type compiledPolicy struct {
+	Policy
+	match cel.Program
+}
+
+// PolicyChecker layers fine-grained policies on top of another Checker. The
+// wrapped Checker allows or denies each action as usual. Callers matched by
+// at least one policy applying to a namespace are then only shown the Records
+// of that namespace selected by the filters of those policies; callers who
+// aren't matched by any policy are not restricted further.
+type PolicyChecker struct {
+	Checker
+	authn    Authenticator
+	policies []compiledPolicy
+}
+
+// LoadPolicies reads the policies stored in the YAML file at path. The file
+// is typically mounted from a ConfigMap.
+func LoadPolicies(path string) ([]Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := new(policies)
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing policies from %s: %w", path, err)
+	}
+	return p.Policies, nil
+}
+
+// NewPolicyChecker returns a Checker enforcing the given policies on top of
+// checker. The authenticator is used to resolve the caller the policies are
+// matched against.
+func NewPolicyChecker(checker Checker, authn Authenticator, policies []Policy) (*PolicyChecker, error) {
+	userEnv, err := cel.NewEnv(
+		cel.Variable("username", cel.StringType),
+		cel.Variable("uid", cel.StringType),
+		cel.Variable("groups", cel.ListType(cel.StringType)),
+		cel.Variable("extra", cel.MapType(cel.StringType, cel.ListType(cel.StringType))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	recordsEnv, err := resultscel.NewRecordsEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	pc := &PolicyChecker{
+		Checker: checker,
+		authn:   authn,
+	}
+	for _, p := range policies {
+		if strings.TrimSpace(p.Match) == "" || strings.TrimSpace(p.Filter) == "" {
+			return nil, fmt.Errorf("policy %q: match and filter must be set", p.Name)
+		}
+		match, err := resultscel.ParseFilter(userEnv, p.Match)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: invalid match expression: %w", p.Name, err)
+		}
+		// Make sure the filter can be turned into SQL now rather than
+		// failing every list request later on.
+		if _, err := cel2sql.Convert(recordsEnv, p.Filter); err != nil {
+			return nil, fmt.Errorf("policy %q: invalid filter expression: %w", p.Name, err)
+		}
+		pc.policies = append(pc.policies, compiledPolicy{Policy: p, match: match})
+	}
+	return pc, nil
+}
+
+// CheckCluster implements ClusterChecker by delegating to the wrapped Checker,
+// if it authorizes the resources of several clusters.
+func (pc *PolicyChecker) CheckCluster(ctx context.Context, cluster, parent, resource, verb string) error {
+	if c, ok := pc.Checker.(ClusterChecker); ok {
+		return c.CheckCluster(ctx, cluster, parent, resource, verb)
+	}
+	return nil
+}
+
+// Filter implements Filterer. Only Records are subject to policies, on top of
+// the restrictions of the wrapped Checker.
+func (pc *PolicyChecker) Filter(ctx context.Context, parent, resource string) (string, error) {
+	var checkerFilter string
+	if f, ok := pc.Checker.(Filterer); ok {
+		var err error
+		if checkerFilter, err = f.Filter(ctx, parent, resource); err != nil {
+			return "", err
+		}
+	}
+	policyFilter, err := pc.policyFilter(ctx, parent, resource)
+	if err != nil {
+		return "", err
+	}
+	if checkerFilter == "" || policyFilter == "" {
+		return checkerFilter + policyFilter, nil
+	}
+	return fmt.Sprintf("(%s) && (%s)", checkerFilter, policyFilter), nil
+}
+
+func (pc *PolicyChecker) policyFilter(ctx context.Context, parent, resource string) (string, error) {
+	if resource != ResourceRecords || len(pc.policies) == 0 {
+		return "", nil
+	}
+
+	user, err := pc.authn.Authenticate(ctx)
+	if err != nil {
+		return "", err
+	}
+	extra := make(map[string][]string, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = value
+	}
+	vars := map[string]any{
+		"username": user.Username,
+		"uid":      user.UID,
+		"groups":   user.Groups,
+		"extra":    extra,
+	}
+
+	var clauses, scoped []string
+	unscoped := false
+	for _, p := range pc.policies {
+		if parent != "-" && len(p.Namespaces) > 0 && !slices.Contains(p.Namespaces, parent) {
+			continue
+		}
+		ok, err := resultscel.Match(p.match, vars)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+
+		// When listing across parents, each policy only constrains the
+		// namespaces it is scoped to.
+		if parent == "-" && len(p.Namespaces) > 0 {
+			clauses = append(clauses, fmt.Sprintf("(%s && (%s))", inNamespaces(p.Namespaces), p.Filter))
+			scoped = append(scoped, p.Namespaces...)
+			continue
+		}
+		clauses = append(clauses, fmt.Sprintf("(%s)", p.Filter))
+		unscoped = true
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	// Records of namespaces none of the matched policies apply to remain
+	// visible.
+	if !unscoped {
+		clauses = append(clauses, fmt.Sprintf("!(%s)", inNamespaces(scoped)))
+	}
+	return strings.Join(clauses, " || "), nil
+}
+
+func inNamespaces(namespaces []string) string {
+	quoted := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		quoted = append(quoted, strconv.Quote(ns))
+	}
+	return fmt.Sprintf("parent in [%s]", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	authnv1 "k8s.io/api/authentication/v1"
+)
+
+type fakeAuthenticator authnv1.UserInfo
+
+func (f fakeAuthenticator) Authenticate(context.Context) (*authnv1.UserInfo, error) {
+	u := authnv1.UserInfo(f)
+	return &u, nil
+}
+
+func TestLoadPolicies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	if err := os.WriteFile(path, []byte(`
+policies:
+- name: team-a
+  namespaces: ["foo"]
+  match: '"team-a" in groups'
+  filter: 'data.metadata.labels["team"] == "a"'
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := auth.LoadPolicies(path)
+	if err != nil {
+		t.Fatalf("LoadPolicies: %v", err)
+	}
+	want := []auth.Policy{{
+		Name:       "team-a",
+		Namespaces: []string{"foo"},
+		Match:      `"team-a" in groups`,
+		Filter:     `data.metadata.labels["team"] == "a"`,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	if err := os.WriteFile(path, []byte("policies:\n- nme: typo\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.LoadPolicies(path); err == nil {
+		t.Error("LoadPolicies: expected error for unknown field")
+	}
+}
+
+func TestNewPolicyChecker(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy auth.Policy
+	}{
+		{
+			name:   "missing filter",
+			policy: auth.Policy{Name: "p", Match: "true"},
+		},
+		{
+			name:   "invalid match",
+			policy: auth.Policy{Name: "p", Match: "unknown == 1", Filter: "true"},
+		},
+		{
+			name:   "non boolean filter",
+			policy: auth.Policy{Name: "p", Match: "true", Filter: `"foo"`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := auth.NewPolicyChecker(auth.AllowAll{}, fakeAuthenticator{}, []auth.Policy{tc.policy}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestPolicyCheckerFilter(t *testing.T) {
+	policies := []auth.Policy{
+		{
+			Name:       "team-a",
+			Namespaces: []string{"foo"},
+			Match:      `"team-a" in groups`,
+			Filter:     `data.metadata.labels["team"] == "a"`,
+		},
+		{
+			Name:   "pipeline-owner",
+			Match:  `username == "owner"`,
+			Filter: `data.metadata.labels["tekton.dev/pipeline"] == "build"`,
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		user     authnv1.UserInfo
+		parent   string
+		resource string
+		want     string
+	}{
+		{
+			name:     "no matching policy",
+			user:     authnv1.UserInfo{Username: "someone"},
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			want:     "",
+		},
+		{
+			name:     "namespaced policy",
+			user:     authnv1.UserInfo{Username: "someone", Groups: []string{"team-a"}},
+			parent:   "foo",
+			resource: auth.ResourceRecords,
+			want:     `(data.metadata.labels["team"] == "a")`,
+		},
+		{
+			name:     "namespaced policy in other namespace",
+			user:     authnv1.UserInfo{Username: "someone", Groups: []string{"team-a"}},
+			parent:   "bar",
+			resource: auth.ResourceRecords,
+			want:     "",
+		},
+		{
+			name:     "namespaced policy across parents",
+			user:     authnv1.UserInfo{Username: "someone", Groups: []string{"team-a"}},
+			parent:   "-",
+			resource: auth.ResourceRecords,
+			want:     `(parent in ["foo"] && (data.metadata.labels["team"] == "a")) || !(parent in ["foo"])`,
+		},
+		{
+			name:     "all policies across parents",
+			user:     authnv1.UserInfo{Username: "owner", Groups: []string{"team-a"}},
+			parent:   "-",
+			resource: auth.ResourceRecords,
+			want:     `(parent in ["foo"] && (data.metadata.labels["team"] == "a")) || (data.metadata.labels["tekton.dev/pipeline"] == "build")`,
+		},
+		{
+			name:     "results are not filtered",
+			user:     authnv1.UserInfo{Username: "owner"},
+			parent:   "foo",
+			resource: auth.ResourceResults,
+			want:     "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pc, err := auth.NewPolicyChecker(auth.AllowAll{}, fakeAuthenticator(tc.user), policies)
+			if err != nil {
+				t.Fatalf("NewPolicyChecker: %v", err)
+			}
+			got, err := pc.Filter(context.Background(), tc.parent, tc.resource)
+			if err != nil {
+				t.Fatalf("Filter: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Filter: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
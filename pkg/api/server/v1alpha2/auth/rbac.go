@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth/impersonation"
 
@@ -45,6 +46,15 @@ type RBAC struct {
 	allowImpersonation bool
 	authn              authnclient.AuthenticationV1Interface
 	authz              authzclient.AuthorizationV1Interface
+	cache              *decisionCache
+}
+
+// identity is a caller authenticated from one of the tokens of a request.
+type identity struct {
+	authnv1.UserInfo
+	// key identifies the token and impersonation attributes the identity
+	// was derived from in the decision cache.
+	key string
 }
 
 // Option is configuration option for RBAC checker.
@@ -64,9 +74,81 @@ func NewRBAC(client kubernetes.Interface, options ...Option) *RBAC {
 
 // Check determines if resource can be accessed with impersonation metadata stored in the context.
 func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) error {
+	users, err := r.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	if verb == PermissionList && namespace == "-" {
+		// In list operations `-` means that the caller wants to list
+		// resources across all parents. Thus, let's assume all
+		// namespaces here.
+		namespace = corev1.NamespaceAll
+	}
+
+	retMsg := "permission denied"
+	for _, user := range users {
+		key := authzKey(user.key, namespace, resource, verb)
+		if r.cache != nil {
+			if allowed, ok := r.cache.get(cacheAuthz, key); ok {
+				if allowed.(bool) {
+					return nil
+				}
+				continue
+			}
+		}
+
+		// Authorize the request by checking the RBAC permissions for the resource.
+		sar, err := r.authz.SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+			Spec: authzv1.SubjectAccessReviewSpec{
+				User:   user.Username,
+				UID:    user.UID,
+				Groups: user.Groups,
+				Extra:  convertExtra(user.Extra),
+				ResourceAttributes: &authzv1.ResourceAttributes{
+					Namespace: namespace,
+					Group:     "results.tekton.dev",
+					Resource:  resource,
+					Verb:      verb,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			retMsg = fmt.Sprintf("%s user %q in groups %q employing %q against %q: %s", retMsg, user.Username, user.Groups, verb, resource, err.Error())
+			log.Println(err)
+			continue
+		}
+		if r.cache != nil {
+			r.cache.add(cacheAuthz, key, sar.Status.Allowed, sar.Status.Allowed)
+		}
+		if sar.Status.Allowed {
+			return nil
+		}
+	}
+	// Return Unauthenticated - we don't know if we failed because of invalid
+	// token or unauthorized user, so this is safer to not leak any state.
+	return status.Error(codes.Unauthenticated, retMsg)
+}
+
+// Authenticate returns the identity of the caller. If the request carries
+// impersonation metadata, the impersonated identity is returned instead.
+func (r *RBAC) Authenticate(ctx context.Context) (*authnv1.UserInfo, error) {
+	users, err := r.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "permission denied")
+	}
+	return &users[0].UserInfo, nil
+}
+
+// authenticate reviews every bearer token found in the context metadata and
+// returns the identities of the accepted ones.
+func (r *RBAC) authenticate(ctx context.Context) ([]identity, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Error(codes.Unauthenticated, "unable to get context metadata")
+		return nil, status.Error(codes.Unauthenticated, "unable to get context metadata")
 	}
 
 	// Parse Impersonation header if the feature is enabled
@@ -78,23 +160,16 @@ func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) erro
 		// impersonation headers and should be processed normally.
 		if err != nil && !errors.Is(err, impersonation.ErrNoImpersonationData) {
 			log.Println(err)
-			return status.Error(codes.Unauthenticated, "invalid impersonation data")
+			return nil, status.Error(codes.Unauthenticated, "invalid impersonation data")
 		}
 	}
 
 	v := md.Get("authorization")
 	if len(v) == 0 {
-		return status.Error(codes.Unauthenticated, "unable to find token")
+		return nil, status.Error(codes.Unauthenticated, "unable to find token")
 	}
 
-	if verb == PermissionList && namespace == "-" {
-		// In list operations `-` means that the caller wants to list
-		// resources across all parents. Thus, let's assume all
-		// namespaces here.
-		namespace = corev1.NamespaceAll
-	}
-
-	retMsg := "permission denied"
+	var users []identity
 	for _, raw := range v {
 		// We expect tokens to be in the form "Bearer <token>". Parse the token out.
 		s := strings.SplitN(raw, " ", 2)
@@ -104,71 +179,90 @@ func (r *RBAC) Check(ctx context.Context, namespace, resource, verb string) erro
 		}
 		t := s[1]
 
-		// Authenticate the token by sending it to the API Server for review.
-		tr, err := r.authn.TokenReviews().Create(ctx, &authnv1.TokenReview{
-			Spec: authnv1.TokenReviewSpec{
-				Token: t,
-			},
-		}, metav1.CreateOptions{})
+		key := authnKey(t, impersonator)
+		if r.cache != nil {
+			if v, ok := r.cache.get(cacheAuthn, key); ok {
+				entry := v.(authnEntry)
+				if entry.err != nil {
+					return nil, entry.err
+				}
+				if entry.user != nil {
+					users = append(users, identity{UserInfo: *entry.user, key: key})
+				}
+				continue
+			}
+		}
+
+		reviewedUser, err := r.review(ctx, t)
 		if err != nil {
 			log.Println(err)
 			continue
 		}
-		if !tr.Status.Authenticated {
+		if reviewedUser == nil {
+			if r.cache != nil {
+				r.cache.add(cacheAuthn, key, authnEntry{}, false)
+			}
 			continue
 		}
 
-		user := tr.Status.User.Username
-		UID := tr.Status.User.UID
-		groups := tr.Status.User.Groups
-		extra := convertExtra[authnv1.ExtraValue](tr.Status.User.Extra)
+		user := *reviewedUser
 
 		// Check whether the authenticated user has permission to impersonate
 		if impersonator != nil {
-			if err := impersonator.Check(ctx, r.authz, user); err != nil {
+			if err := impersonator.Check(ctx, r.authz, user.Username); err != nil {
 				log.Println(err)
-				retMsg = fmt.Sprintf("%s: %s", retMsg, err.Error())
-				return status.Error(codes.Unauthenticated, retMsg)
+				err = status.Error(codes.Unauthenticated, fmt.Sprintf("permission denied: %s", err.Error()))
+				if r.cache != nil {
+					r.cache.add(cacheAuthn, key, authnEntry{err: err}, false)
+				}
+				return nil, err
 			}
 			// Change user data to impersonated user
 			userInfo := impersonator.GetUserInfo()
-			user = userInfo.GetName()
-			UID = userInfo.GetUID()
-			groups = userInfo.GetGroups()
-			extra = convertExtra[[]string](userInfo.GetExtra())
-		}
-
-		// Authorize the request by checking the RBAC permissions for the resource.
-		sar, err := r.authz.SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
-			Spec: authzv1.SubjectAccessReviewSpec{
-				User:   user,
-				UID:    UID,
-				Groups: groups,
-				Extra:  extra,
-				ResourceAttributes: &authzv1.ResourceAttributes{
-					Namespace: namespace,
-					Group:     "results.tekton.dev",
-					Resource:  resource,
-					Verb:      verb,
-				},
-			},
-		}, metav1.CreateOptions{})
-		if err != nil {
-			retMsg = fmt.Sprintf("%s user %q in groups %q employing %q against %q: %s", retMsg, user, groups, verb, resource, err.Error())
-			log.Println(err)
-			continue
+			user = authnv1.UserInfo{
+				Username: userInfo.GetName(),
+				UID:      userInfo.GetUID(),
+				Groups:   userInfo.GetGroups(),
+				Extra:    make(map[string]authnv1.ExtraValue),
+			}
+			for key, value := range userInfo.GetExtra() {
+				user.Extra[key] = value
+			}
 		}
-		if sar.Status.Allowed {
-			return nil
+		if r.cache != nil {
+			r.cache.add(cacheAuthn, key, authnEntry{user: &user}, true)
 		}
+		users = append(users, identity{UserInfo: user, key: key})
 	}
-	// Return Unauthenticated - we don't know if we failed because of invalid
-	// token or unauthorized user, so this is safer to not leak any state.
-	return status.Error(codes.Unauthenticated, retMsg)
+	return users, nil
+}
+
+// review authenticates the token t by sending it to the API Server for review,
+// unless it was already reviewed while handling the request. It returns a nil
+// user if the token is rejected.
+func (r *RBAC) review(ctx context.Context, t string) (*authnv1.UserInfo, error) {
+	token := authnKey(t, nil)
+	if user, ok := reviewed(ctx, r, token); ok {
+		return user, nil
+	}
+	tr, err := r.authn.TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token: t,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var user *authnv1.UserInfo
+	if tr.Status.Authenticated {
+		user = &tr.Status.User
+	}
+	rememberReview(ctx, r, token, user)
+	return user, nil
 }
 
-// convertExtra converts the map[string][]string or authnv1.ExtraValue to map[string]ExtraValue for Subject Access Review.
-func convertExtra[T []string | authnv1.ExtraValue](extra map[string]T) map[string]authzv1.ExtraValue {
+// convertExtra converts the map[string]authnv1.ExtraValue to map[string]ExtraValue for Subject Access Review.
+func convertExtra(extra map[string]authnv1.ExtraValue) map[string]authzv1.ExtraValue {
 	var newExtra = make(map[string]authzv1.ExtraValue)
 	for key, value := range extra {
 		newExtra[key] = authzv1.ExtraValue(value)
@@ -182,3 +276,15 @@ func WithImpersonation(enabled bool) Option {
 		r.allowImpersonation = enabled
 	}
 }
+
+// WithCache is an option function to cache authentication and authorization
+// decisions. At most size decisions are kept; allowed ones expire after
+// allowTTL and denied ones after denyTTL. A non-positive size disables the
+// cache.
+func WithCache(size int, allowTTL, denyTTL time.Duration) Option {
+	return func(r *RBAC) {
+		if size > 0 {
+			r.cache = newDecisionCache(size, allowTTL, denyTTL)
+		}
+	}
+}
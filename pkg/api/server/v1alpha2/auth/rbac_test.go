@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"k8s.io/utils/strings/slices"
@@ -220,3 +221,133 @@ func TestRBAC(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACCache(t *testing.T) {
+	var tokenReviews, accessReviews int
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tokenReviews++
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{
+			Authenticated: tr.Spec.Token != "invalid",
+			User:          authnv1.UserInfo{Username: tr.Spec.Token},
+		}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		accessReviews++
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		sar.Status = authzv1.SubjectAccessReviewStatus{
+			Allowed: sar.Spec.User == "authorized" && sar.Spec.ResourceAttributes.Verb == auth.PermissionGet,
+		}
+		return true, sar, nil
+	})
+	rbac := auth.NewRBAC(k8s, auth.WithCache(10, time.Minute, time.Minute))
+
+	for _, tc := range []struct {
+		name              string
+		token             string
+		verb              string
+		want              codes.Code
+		wantTokenReviews  int
+		wantAccessReviews int
+	}{
+		{
+			name:              "allowed",
+			token:             "authorized",
+			verb:              auth.PermissionGet,
+			want:              codes.OK,
+			wantTokenReviews:  1,
+			wantAccessReviews: 1,
+		},
+		{
+			name:              "cached allowed",
+			token:             "authorized",
+			verb:              auth.PermissionGet,
+			want:              codes.OK,
+			wantTokenReviews:  1,
+			wantAccessReviews: 1,
+		},
+		{
+			name:              "denied verb",
+			token:             "authorized",
+			verb:              auth.PermissionDelete,
+			want:              codes.Unauthenticated,
+			wantTokenReviews:  1,
+			wantAccessReviews: 2,
+		},
+		{
+			name:              "cached denied verb",
+			token:             "authorized",
+			verb:              auth.PermissionDelete,
+			want:              codes.Unauthenticated,
+			wantTokenReviews:  1,
+			wantAccessReviews: 2,
+		},
+		{
+			name:              "invalid token",
+			token:             "invalid",
+			verb:              auth.PermissionGet,
+			want:              codes.Unauthenticated,
+			wantTokenReviews:  2,
+			wantAccessReviews: 2,
+		},
+		{
+			name:              "cached invalid token",
+			token:             "invalid",
+			verb:              auth.PermissionGet,
+			want:              codes.Unauthenticated,
+			wantTokenReviews:  2,
+			wantAccessReviews: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tc.token))
+			if err := rbac.Check(ctx, "foo", auth.ResourceRecords, tc.verb); status.Code(err) != tc.want {
+				t.Fatalf("Check: %v, want %v", err, tc.want)
+			}
+			if tokenReviews != tc.wantTokenReviews {
+				t.Errorf("TokenReviews: got %d, want %d", tokenReviews, tc.wantTokenReviews)
+			}
+			if accessReviews != tc.wantAccessReviews {
+				t.Errorf("SubjectAccessReviews: got %d, want %d", accessReviews, tc.wantAccessReviews)
+			}
+		})
+	}
+}
+
+func TestRBACRequestContext(t *testing.T) {
+	var tokenReviews int
+	k8s := fake.NewSimpleClientset()
+	k8s.PrependReactor("create", "tokenreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		tokenReviews++
+		tr := action.(test.CreateActionImpl).Object.(*authnv1.TokenReview)
+		tr.Status = authnv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          authnv1.UserInfo{Username: tr.Spec.Token},
+		}
+		return true, tr, nil
+	})
+	k8s.PrependReactor("create", "subjectaccessreviews", func(action test.Action) (handled bool, ret runtime.Object, err error) {
+		sar := action.(test.CreateActionImpl).Object.(*authzv1.SubjectAccessReview)
+		sar.Status = authzv1.SubjectAccessReviewStatus{Allowed: true}
+		return true, sar, nil
+	})
+	// Without a decision cache, tokens are still reviewed once per request.
+	rbac := auth.NewRBAC(k8s)
+	md := metadata.Pairs("authorization", "Bearer alice")
+
+	for i := 1; i <= 2; i++ {
+		ctx := auth.NewRequestContext(metadata.NewIncomingContext(context.Background(), md))
+		user, err := auth.Caller(ctx, rbac)
+		if err != nil || user.Username != "alice" {
+			t.Fatalf("Caller: got (%v, %v), want alice", user, err)
+		}
+		if err := rbac.Check(ctx, "foo", auth.ResourceRecords, auth.PermissionGet); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if tokenReviews != i {
+			t.Errorf("request %d: TokenReviews: got %d, want %d", i, tokenReviews, i)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Sections of the differences between runs.
+const (
+	sectionStatus = "status"
+	sectionParam  = "param"
+	sectionSpec   = "spec"
+	sectionResult = "result"
+	sectionTask   = "task"
+	sectionImage  = "image"
+	sectionDigest = "digest"
+)
+
+// DiffRuns compares two stored runs of the same kind.
+func (s *Server) DiffRuns(ctx context.Context, req *pb.DiffRunsRequest) (*pb.DiffRunsResponse, error) {
+	if req.GetName() == "" || req.GetOther() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and other must be set")
+	}
+	base, err := s.runView(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	other, err := s.runView(ctx, req.GetOther())
+	if err != nil {
+		return nil, err
+	}
+	if base.summary.GetKind() != other.summary.GetKind() {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot compare a %s with a %s", base.summary.GetKind(), other.summary.GetKind())
+	}
+	return diffRuns(base, other), nil
+}
+
+// runView is the comparable form of a run.
+type runView struct {
+	summary *pb.DiffedRun
+	status  map[string]string
+	params  map[string]string
+	spec    map[string]string
+	results map[string]string
+	// tasks are the tasks of PipelineRuns, or the steps of TaskRuns, by
+	// start time.
+	tasks []taskView
+}
+
+type taskView struct {
+	name   string
+	status string
+	// duration is nil if the task didn't run or complete.
+	duration *durationpb.Duration
+	// images and digests are the images of the steps of the task and their
+	// digests, by step. The steps of TaskRuns are keyed by an empty name.
+	images  map[string]string
+	digests map[string]string
+}
+
+// runView reads the run stored in the Record named name, with the TaskRuns
+// of PipelineRuns.
+func (s *Server) runView(ctx context.Context, name string) (*runView, error) {
+	run, err := s.getRun(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if tr := run.taskRun; tr != nil {
+		v := newRunView(name, run.kind(), tr.ObjectMeta, &tr.Status.Status, tr.Status.StartTime, tr.Status.CompletionTime)
+		v.params = paramValues(tr.Spec.Params)
+		v.spec = flattenSpec(tr.Status.TaskSpec)
+		for _, r := range tr.Status.Results {
+			v.results[r.Name] = formatParamValue(r.Value)
+		}
+		images, digests := stepImages(tr)
+		for _, step := range tr.Status.Steps {
+			t := taskView{
+				name:    step.Name,
+				images:  map[string]string{"": images[step.Name]},
+				digests: map[string]string{"": digests[step.Name]},
+			}
+			if term := step.Terminated; term != nil {
+				t.status = term.Reason
+				t.duration = durationBetween(&term.StartedAt, &term.FinishedAt)
+			}
+			v.tasks = append(v.tasks, t)
+		}
+		return v, nil
+	}
+
+	pr := run.pipelineRun
+	v := newRunView(name, run.kind(), pr.ObjectMeta, &pr.Status.Status, pr.Status.StartTime, pr.Status.CompletionTime)
+	v.params = paramValues(pr.Spec.Params)
+	if pr.Status.PipelineSpec != nil {
+		v.spec = flattenSpec(pr.Status.PipelineSpec)
+	} else {
+		v.spec = flattenSpec(pr.Spec.PipelineSpec)
+	}
+	for _, r := range pr.Status.Results {
+		v.results[r.Name] = formatParamValue(r.Value)
+	}
+	children, err := s.childTaskRuns(ctx, name, pr)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range children {
+		images, digests := stepImages(c.TaskRun)
+		v.tasks = append(v.tasks, taskView{
+			name:     c.task,
+			status:   conditionReason(c.Status.GetCondition(apis.ConditionSucceeded)),
+			duration: durationBetween(c.Status.StartTime, c.Status.CompletionTime),
+			images:   images,
+			digests:  digests,
+		})
+	}
+	for _, skipped := range pr.Status.SkippedTasks {
+		v.tasks = append(v.tasks, taskView{name: skipped.Name, status: "Skipped"})
+	}
+	return v, nil
+}
+
+func newRunView(record, kind string, meta metav1.ObjectMeta, st conditionGetter, start, completion *metav1.Time) *runView {
+	cond := st.GetCondition(apis.ConditionSucceeded)
+	v := &runView{
+		summary: &pb.DiffedRun{
+			Record:   record,
+			Kind:     kind,
+			Name:     meta.Name,
+			Uid:      string(meta.UID),
+			Status:   conditionReason(cond),
+			Duration: durationBetween(start, completion),
+		},
+		status:  map[string]string{"reason": conditionReason(cond)},
+		results: map[string]string{},
+	}
+	if start != nil {
+		v.summary.StartTime = timestamppb.New(start.Time)
+	}
+	if cond != nil {
+		v.status["message"] = cond.Message
+	}
+	return v
+}
+
+type conditionGetter interface {
+	GetCondition(t apis.ConditionType) *apis.Condition
+}
+
+// conditionReason returns the reason of a condition, or its status if it has
+// no reason.
+func conditionReason(c *apis.Condition) string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Reason != "":
+		return c.Reason
+	default:
+		return string(c.Status)
+	}
+}
+
+func durationBetween(start, end *metav1.Time) *durationpb.Duration {
+	if start == nil || end == nil || start.IsZero() || end.IsZero() {
+		return nil
+	}
+	return durationpb.New(end.Sub(start.Time))
+}
+
+func paramValues(params pipelinev1.Params) map[string]string {
+	values := map[string]string{}
+	for _, p := range params {
+		values[p.Name] = formatParamValue(p.Value)
+	}
+	return values
+}
+
+// formatParamValue formats string values as is, and array and object values
+// as JSON.
+func formatParamValue(v pipelinev1.ParamValue) string {
+	var b []byte
+	switch v.Type {
+	case pipelinev1.ParamTypeArray:
+		b, _ = json.Marshal(v.ArrayVal)
+	case pipelinev1.ParamTypeObject:
+		b, _ = json.Marshal(v.ObjectVal)
+	default:
+		return v.StringVal
+	}
+	return string(b)
+}
+
+// stepImages returns the images of the steps of a TaskRun and their digests,
+// by step.
+func stepImages(tr *pipelinev1.TaskRun) (map[string]string, map[string]string) {
+	images, digests := map[string]string{}, map[string]string{}
+	if tr.Status.TaskSpec != nil {
+		for _, step := range tr.Status.TaskSpec.Steps {
+			images[step.Name] = step.Image
+		}
+	}
+	for _, step := range tr.Status.Steps {
+		if _, digest, ok := strings.Cut(step.ImageID, "@"); ok {
+			digests[step.Name] = digest
+		}
+	}
+	return images, digests
+}
+
+// flattenSpec returns the leaves of a spec by path, e.g.
+// tasks[build].taskRef.name. Elements of lists are identified by their name
+// when they have one, and by their index otherwise.
+func flattenSpec(spec any) map[string]string {
+	out := map[string]string{}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return out
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return out
+	}
+	flatten("", v, out)
+	return out
+}
+
+func flatten(path string, v any, out map[string]string) {
+	switch v := v.(type) {
+	case nil:
+	case map[string]any:
+		for k, e := range v {
+			if path != "" {
+				k = path + "." + k
+			}
+			flatten(k, e, out)
+		}
+	case []any:
+		named := true
+		for _, e := range v {
+			m, ok := e.(map[string]any)
+			if _, isString := m["name"].(string); !ok || !isString {
+				named = false
+				break
+			}
+		}
+		for i, e := range v {
+			key := fmt.Sprint(i)
+			if named {
+				key = e.(map[string]any)["name"].(string)
+			}
+			flatten(path+"["+key+"]", e, out)
+		}
+	case string:
+		out[path] = v
+	default:
+		b, _ := json.Marshal(v)
+		out[path] = string(b)
+	}
+}
+
+func diffRuns(base, other *runView) *pb.DiffRunsResponse {
+	resp := &pb.DiffRunsResponse{Base: base.summary, Other: other.summary}
+	resp.Differences = append(resp.Differences, diffValues(sectionStatus, "", base.status, other.status)...)
+	resp.Differences = append(resp.Differences, diffValues(sectionParam, "", base.params, other.params)...)
+	resp.Differences = append(resp.Differences, diffValues(sectionSpec, "", base.spec, other.spec)...)
+	resp.Differences = append(resp.Differences, diffValues(sectionResult, "", base.results, other.results)...)
+
+	otherTasks := map[string]taskView{}
+	for _, t := range other.tasks {
+		otherTasks[t.name] = t
+	}
+	baseTasks := map[string]bool{}
+	for _, b := range base.tasks {
+		baseTasks[b.name] = true
+		durations := &pb.TaskDurations{Task: b.name, Base: b.duration}
+		resp.TaskDurations = append(resp.TaskDurations, durations)
+		o, ok := otherTasks[b.name]
+		if !ok {
+			resp.Differences = append(resp.Differences, &pb.RunDifference{
+				Section: sectionTask, Path: b.name, Base: b.status, Change: pb.RunDifference_REMOVED,
+			})
+			continue
+		}
+		durations.Other = o.duration
+		if b.status != o.status {
+			resp.Differences = append(resp.Differences, &pb.RunDifference{
+				Section: sectionTask, Path: b.name, Base: b.status, Other: o.status,
+			})
+		}
+		resp.Differences = append(resp.Differences, diffValues(sectionImage, b.name, b.images, o.images)...)
+		resp.Differences = append(resp.Differences, diffValues(sectionDigest, b.name, b.digests, o.digests)...)
+	}
+	for _, o := range other.tasks {
+		if baseTasks[o.name] {
+			continue
+		}
+		resp.TaskDurations = append(resp.TaskDurations, &pb.TaskDurations{Task: o.name, Other: o.duration})
+		resp.Differences = append(resp.Differences, &pb.RunDifference{
+			Section: sectionTask, Path: o.name, Other: o.status, Change: pb.RunDifference_ADDED,
+		})
+	}
+	return resp
+}
+
+// diffValues returns the differences between the values of a section by path,
+// sorted by path. Paths are prefixed by prefix, if set. Empty values are
+// considered unset.
+func diffValues(section, prefix string, base, other map[string]string) []*pb.RunDifference {
+	paths := map[string]bool{}
+	for p, v := range base {
+		paths[p] = v != ""
+	}
+	for p, v := range other {
+		paths[p] = paths[p] || v != ""
+	}
+	var diffs []*pb.RunDifference
+	for p, set := range paths {
+		b, o := base[p], other[p]
+		if !set || b == o {
+			continue
+		}
+		d := &pb.RunDifference{Section: section, Path: p, Base: b, Other: o}
+		if prefix != "" {
+			d.Path = strings.TrimSuffix(prefix+"."+p, ".")
+		}
+		switch {
+		case b == "":
+			d.Change = pb.RunDifference_ADDED
+		case o == "":
+			d.Change = pb.RunDifference_REMOVED
+		}
+		diffs = append(diffs, d)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
@@ -0,0 +1,285 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// runsFixture stores PipelineRuns and their TaskRuns in a test server.
+type runsFixture struct {
+	t   *testing.T
+	srv *Server
+	// start is the start time of the runs.
+	start time.Time
+}
+
+func newRunsFixture(t *testing.T) *runsFixture {
+	t.Helper()
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	return &runsFixture{t: t, srv: srv, start: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)}
+}
+
+// time returns the time at offset from the start of the runs.
+func (f *runsFixture) time(offset time.Duration) *metav1.Time {
+	t := metav1.NewTime(f.start.Add(offset))
+	return &t
+}
+
+// create stores o in the Record named name under the Result of the given
+// name, creating the Result if needed.
+func (f *runsFixture) create(result, name, dataType string, o any) {
+	f.t.Helper()
+	ctx := context.Background()
+	parent := "ns/results/" + result
+	if _, err := f.srv.GetResult(ctx, &pb.GetResultRequest{Name: parent}); status.Code(err) == codes.NotFound {
+		if _, err := f.srv.CreateResult(ctx, &pb.CreateResultRequest{Parent: "ns", Result: &pb.Result{Name: parent}}); err != nil {
+			f.t.Fatalf("CreateResult: %v", err)
+		}
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	if _, err := f.srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: parent,
+		Record: &pb.Record{Name: parent + "/records/" + name, Data: &pb.Any{Type: dataType, Value: b}},
+	}); err != nil {
+		f.t.Fatalf("CreateRecord: %v", err)
+	}
+}
+
+func succeeded(status corev1.ConditionStatus, reason, message string) duckv1.Status {
+	return duckv1.Status{Conditions: duckv1.Conditions{{
+		Type: apis.ConditionSucceeded, Status: status, Reason: reason, Message: message,
+	}}}
+}
+
+// taskRun returns a TaskRun of the PipelineRun pr with a step running image.
+func (f *runsFixture) taskRun(pr, name, task string, start, end time.Duration, reason, image, digest string) *pipelinev1.TaskRun {
+	tr := &pipelinev1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels:    map[string]string{labelPipelineRun: pr, labelPipelineTask: task},
+		},
+	}
+	tr.Status.Status = succeeded(corev1.ConditionTrue, reason, "")
+	if reason == "Failed" {
+		tr.Status.Status = succeeded(corev1.ConditionFalse, reason, "")
+	}
+	tr.Status.StartTime = f.time(start)
+	tr.Status.CompletionTime = f.time(end)
+	tr.Status.TaskSpec = &pipelinev1.TaskSpec{Steps: []pipelinev1.Step{{Name: "run", Image: image}}}
+	tr.Status.Steps = []pipelinev1.StepState{{Name: "run", ImageID: image + "@" + digest}}
+	return tr
+}
+
+func TestDiffRuns(t *testing.T) {
+	f := newRunsFixture(t)
+	ctx := context.Background()
+
+	green := &pipelinev1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "green", Namespace: "ns", UID: types.UID("uid-green")},
+		Spec: pipelinev1.PipelineRunSpec{Params: pipelinev1.Params{
+			{Name: "revision", Value: *pipelinev1.NewStructuredValues("abc")},
+			{Name: "platforms", Value: *pipelinev1.NewStructuredValues("linux", "darwin")},
+		}},
+	}
+	green.Status.Status = succeeded(corev1.ConditionTrue, "Succeeded", "Tasks Completed: 1")
+	green.Status.StartTime = f.time(0)
+	green.Status.CompletionTime = f.time(3 * time.Minute)
+	green.Status.PipelineSpec = &pipelinev1.PipelineSpec{Tasks: []pipelinev1.PipelineTask{
+		{Name: "build", TaskRef: &pipelinev1.TaskRef{Name: "build"}},
+	}}
+	green.Status.Results = []pipelinev1.PipelineRunResult{{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues("sha256:1111")}}
+	green.Status.ChildReferences = []pipelinev1.ChildStatusReference{
+		childTaskRunRef("green-build", "build"),
+	}
+	f.create("green", "green", typePipelineRunV1, green)
+	f.create("green", "green-build", typeTaskRunV1, f.taskRun("green", "green-build", "build", time.Minute, 3*time.Minute, "Succeeded", "golang:1.22", "sha256:aaaa"))
+
+	red := &pipelinev1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "ns", UID: types.UID("uid-red")},
+		Spec: pipelinev1.PipelineRunSpec{Params: pipelinev1.Params{
+			{Name: "revision", Value: *pipelinev1.NewStructuredValues("def")},
+			{Name: "platforms", Value: *pipelinev1.NewStructuredValues("linux", "darwin")},
+		}},
+	}
+	red.Status.Status = succeeded(corev1.ConditionFalse, "Failed", "Tasks Completed: 2 (Failed: 1)")
+	red.Status.StartTime = f.time(0)
+	red.Status.CompletionTime = f.time(6 * time.Minute)
+	red.Status.PipelineSpec = &pipelinev1.PipelineSpec{Tasks: []pipelinev1.PipelineTask{
+		{Name: "build", TaskRef: &pipelinev1.TaskRef{Name: "build-v2"}},
+		{Name: "test", TaskRef: &pipelinev1.TaskRef{Name: "test"}, RunAfter: []string{"build"}},
+	}}
+	red.Status.ChildReferences = []pipelinev1.ChildStatusReference{
+		childTaskRunRef("red-build", "build"),
+		childTaskRunRef("red-test", "test"),
+	}
+	f.create("red", "red", typePipelineRunV1, red)
+	f.create("red", "red-build", typeTaskRunV1, f.taskRun("red", "red-build", "build", time.Minute, 4*time.Minute, "Succeeded", "golang:1.23", "sha256:bbbb"))
+	f.create("red", "red-test", typeTaskRunV1, f.taskRun("red", "red-test", "test", 4*time.Minute, 6*time.Minute, "Failed", "golang:1.23", "sha256:bbbb"))
+	// TaskRuns stored under the Result which aren't children of the
+	// PipelineRun are ignored.
+	f.create("red", "other", typeTaskRunV1, f.taskRun("other", "other", "build", 0, time.Minute, "Succeeded", "alpine", "sha256:cccc"))
+
+	got, err := f.srv.DiffRuns(ctx, &pb.DiffRunsRequest{Name: "ns/results/green/records/green", Other: "ns/results/red/records/red"})
+	if err != nil {
+		t.Fatalf("DiffRuns: %v", err)
+	}
+	want := &pb.DiffRunsResponse{
+		Base: &pb.DiffedRun{
+			Record: "ns/results/green/records/green", Kind: "PipelineRun", Name: "green", Uid: "uid-green", Status: "Succeeded",
+			StartTime: timestamppb.New(f.start), Duration: durationpb.New(3 * time.Minute),
+		},
+		Other: &pb.DiffedRun{
+			Record: "ns/results/red/records/red", Kind: "PipelineRun", Name: "red", Uid: "uid-red", Status: "Failed",
+			StartTime: timestamppb.New(f.start), Duration: durationpb.New(6 * time.Minute),
+		},
+		Differences: []*pb.RunDifference{
+			{Section: "status", Path: "message", Base: "Tasks Completed: 1", Other: "Tasks Completed: 2 (Failed: 1)"},
+			{Section: "status", Path: "reason", Base: "Succeeded", Other: "Failed"},
+			{Section: "param", Path: "revision", Base: "abc", Other: "def"},
+			{Section: "spec", Path: "tasks[build].taskRef.name", Base: "build", Other: "build-v2"},
+			{Section: "spec", Path: "tasks[test].name", Other: "test", Change: pb.RunDifference_ADDED},
+			{Section: "spec", Path: "tasks[test].runAfter[0]", Other: "build", Change: pb.RunDifference_ADDED},
+			{Section: "spec", Path: "tasks[test].taskRef.name", Other: "test", Change: pb.RunDifference_ADDED},
+			{Section: "result", Path: "IMAGE_DIGEST", Base: "sha256:1111", Change: pb.RunDifference_REMOVED},
+			{Section: "image", Path: "build.run", Base: "golang:1.22", Other: "golang:1.23"},
+			{Section: "digest", Path: "build.run", Base: "sha256:aaaa", Other: "sha256:bbbb"},
+			{Section: "task", Path: "test", Other: "Failed", Change: pb.RunDifference_ADDED},
+		},
+		TaskDurations: []*pb.TaskDurations{
+			{Task: "build", Base: durationpb.New(2 * time.Minute), Other: durationpb.New(3 * time.Minute)},
+			{Task: "test", Other: durationpb.New(2 * time.Minute)},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	t.Run("same run", func(t *testing.T) {
+		got, err := f.srv.DiffRuns(ctx, &pb.DiffRunsRequest{Name: "ns/results/green/records/green", Other: "ns/results/green/records/green"})
+		if err != nil {
+			t.Fatalf("DiffRuns: %v", err)
+		}
+		if len(got.GetDifferences()) != 0 {
+			t.Errorf("differences between a run and itself: %v", got.GetDifferences())
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		req    *pb.DiffRunsRequest
+		status codes.Code
+	}{
+		{
+			name:   "missing other",
+			req:    &pb.DiffRunsRequest{Name: "ns/results/green/records/green"},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "different kinds",
+			req:    &pb.DiffRunsRequest{Name: "ns/results/green/records/green", Other: "ns/results/green/records/green-build"},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "not found",
+			req:    &pb.DiffRunsRequest{Name: "ns/results/green/records/green", Other: "ns/results/green/records/missing"},
+			status: codes.NotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := f.srv.DiffRuns(ctx, tc.req); status.Code(err) != tc.status {
+				t.Errorf("DiffRuns: %v, want %v", err, tc.status)
+			}
+		})
+	}
+}
+
+func TestDiffTaskRuns(t *testing.T) {
+	f := newRunsFixture(t)
+
+	base := f.taskRun("", "base", "", 0, 2*time.Minute, "Succeeded", "golang:1.22", "sha256:aaaa")
+	base.Status.Steps[0].Terminated = &corev1.ContainerStateTerminated{
+		Reason: "Completed", StartedAt: *f.time(0), FinishedAt: *f.time(time.Minute),
+	}
+	base.Status.Results = []pipelinev1.TaskRunResult{{Name: "IMAGES", Value: *pipelinev1.NewStructuredValues("a", "b")}}
+	f.create("base", "base", typeTaskRunV1, base)
+
+	// v1beta1 TaskRuns are converted before they are compared.
+	other := f.taskRun("", "other", "", 0, 3*time.Minute, "Succeeded", "golang:1.22", "sha256:aaaa")
+	other.Status.Steps[0].Terminated = &corev1.ContainerStateTerminated{
+		Reason: "Completed", StartedAt: *f.time(0), FinishedAt: *f.time(2 * time.Minute),
+	}
+	other.Status.Results = []pipelinev1.TaskRunResult{{Name: "IMAGES", Value: *pipelinev1.NewStructuredValues("a", "c")}}
+	v1beta1 := map[string]any{}
+	b, _ := json.Marshal(other)
+	_ = json.Unmarshal(b, &v1beta1)
+	st := v1beta1["status"].(map[string]any)
+	st["taskResults"], st["results"] = st["results"], nil
+	f.create("other", "other", "tekton.dev/v1beta1.TaskRun", v1beta1)
+
+	got, err := f.srv.DiffRuns(context.Background(), &pb.DiffRunsRequest{Name: "ns/results/base/records/base", Other: "ns/results/other/records/other"})
+	if err != nil {
+		t.Fatalf("DiffRuns: %v", err)
+	}
+	wantDifferences := []*pb.RunDifference{
+		{Section: "result", Path: "IMAGES", Base: `["a","b"]`, Other: `["a","c"]`},
+	}
+	if diff := cmp.Diff(wantDifferences, got.GetDifferences(), protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+	wantDurations := []*pb.TaskDurations{
+		{Task: "run", Base: durationpb.New(time.Minute), Other: durationpb.New(2 * time.Minute)},
+	}
+	if diff := cmp.Diff(wantDurations, got.GetTaskDurations(), protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+// childTaskRunRef returns the child reference of a TaskRun of a PipelineRun.
+func childTaskRunRef(name, task string) pipelinev1.ChildStatusReference {
+	return pipelinev1.ChildStatusReference{
+		TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+		Name:             name,
+		PipelineTaskName: task,
+	}
+}
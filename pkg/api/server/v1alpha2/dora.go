@@ -0,0 +1,336 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultDeliveryWindow = 30 * 24 * time.Hour
+	// maxDeployments bounds the number of PipelineRuns analyzed by a call.
+	maxDeployments = 50000
+)
+
+// GetDeliveryMetrics computes the deployment frequency, lead time for changes,
+// change failure rate and mean time to restore of the deployment PipelineRuns
+// matching the request filter.
+func (s *Server) GetDeliveryMetrics(ctx context.Context, req *pb.DeliveryMetricsRequest) (*pb.DeliveryMetricsResponse, error) {
+	if req.GetParent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "parent missing")
+	}
+	parent, resultName, err := result.ParseName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	if req.GetCommitTimeAnnotation() != "" && req.GetCommitTimeParam() != "" {
+		return nil, status.Error(codes.InvalidArgument, "only one of commit_time_annotation and commit_time_param can be set")
+	}
+	start, end, err := timeWindow(req, defaultDeliveryWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentsAggregator, err := lister.OfDeployments(s.recordsEnv, parent, resultName, deploymentFilter(req.GetFilter()), req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	deploymentsAggregator.Constrain(constraint)
+
+	db := s.db.Where("created_time >= ? AND created_time <= ?", start, end)
+	rows, err := deploymentsAggregator.Deployments(ctx, db, maxDeployments+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > maxDeployments {
+		return nil, status.Errorf(codes.InvalidArgument, "more than %d PipelineRuns to analyze, narrow the time window or filter", maxDeployments)
+	}
+
+	unit, byTime := lister.TimeGroup(req.GetGroupBy())
+	var deployments []deployment
+	for _, row := range rows {
+		if d, ok := toDeployment(row, req, byTime); ok {
+			deployments = append(deployments, d)
+		}
+	}
+	metrics := deliveryMetrics(deployments, start, end, unit, byTime)
+	if len(metrics) == 0 && req.GetGroupBy() == "" {
+		metrics = []*pb.DeliveryMetrics{{PeriodStart: timestamppb.New(start), PeriodEnd: timestamppb.New(end)}}
+	}
+	return &pb.DeliveryMetricsResponse{Metrics: metrics}, nil
+}
+
+// timeWindow returns the window of a request, which defaults to the given
+// duration before the end time, which defaults to now.
+func timeWindow(req interface {
+	GetStartTime() *timestamppb.Timestamp
+	GetEndTime() *timestamppb.Timestamp
+}, duration time.Duration) (time.Time, time.Time, error) {
+	end := clock.Now()
+	if req.GetEndTime() != nil {
+		end = req.GetEndTime().AsTime()
+	}
+	start := end.Add(-duration)
+	if req.GetStartTime() != nil {
+		start = req.GetStartTime().AsTime()
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "start_time must be before end_time")
+	}
+	return start, end, nil
+}
+
+// deploymentFilter returns the CEL filter selecting the deployment
+// PipelineRuns.
+func deploymentFilter(filter string) string {
+	f := `(data_type == PIPELINE_RUN || data_type == "tekton.dev/v1beta1.PipelineRun")`
+	if filter = strings.TrimSpace(filter); filter != "" {
+		f += " && (" + filter + ")"
+	}
+	return f
+}
+
+// deployment is a completed deployment PipelineRun.
+type deployment struct {
+	group deliveryGroup
+	// pipeline identifies the deployments restoring failed ones.
+	pipeline  string
+	succeeded bool
+	completed time.Time
+	// commit is the time of the deployed commit, zero if unknown.
+	commit time.Time
+}
+
+type deliveryGroup struct {
+	name string
+	// period is the start of the time group.
+	period time.Time
+}
+
+// toDeployment returns the analyzed form of a deployment, or false if it isn't
+// completed or was cancelled.
+func toDeployment(row *lister.Deployment, req *pb.DeliveryMetricsRequest, byTime bool) (deployment, bool) {
+	if row.Status == string(corev1.ConditionUnknown) || row.Status == "" || row.Reason == pipelinev1.PipelineRunReasonCancelled.String() {
+		return deployment{}, false
+	}
+	completed, err := time.Parse(time.RFC3339, row.CompletionTime)
+	if err != nil {
+		return deployment{}, false
+	}
+
+	d := deployment{
+		pipeline:  row.Namespace + "/" + jsonField(row.Labels, "tekton.dev/pipeline"),
+		succeeded: row.Status == string(corev1.ConditionTrue),
+		completed: completed,
+	}
+	if byTime {
+		epoch, err := strconv.ParseFloat(row.Group, 64)
+		if err != nil {
+			return deployment{}, false
+		}
+		d.group.period = time.Unix(int64(epoch), 0).UTC()
+	} else {
+		d.group.name = row.Group
+	}
+
+	var commit string
+	if a := req.GetCommitTimeAnnotation(); a != "" {
+		commit = jsonField(row.Annotations, a)
+	} else if p := req.GetCommitTimeParam(); p != "" {
+		commit = paramValue(row.Params, p)
+	}
+	if commit != "" {
+		d.commit, _ = time.Parse(time.RFC3339, commit)
+	}
+	return d, true
+}
+
+// jsonField returns the value of key in a JSON object of strings.
+func jsonField(object, key string) string {
+	var m map[string]string
+	_ = json.Unmarshal([]byte(object), &m)
+	return m[key]
+}
+
+// paramValue returns the value of the param at path in JSON params, where path
+// is the name of a string param, or "name.key" for a key of an object param.
+func paramValue(params, path string) string {
+	var ps pipelinev1.Params
+	if err := json.Unmarshal([]byte(params), &ps); err != nil {
+		return ""
+	}
+	name, key, _ := strings.Cut(path, ".")
+	for _, p := range ps {
+		switch {
+		case p.Name == path && p.Value.Type == pipelinev1.ParamTypeString:
+			return p.Value.StringVal
+		case p.Name == name && p.Value.Type == pipelinev1.ParamTypeObject:
+			return p.Value.ObjectVal[key]
+		}
+	}
+	return ""
+}
+
+// deliveryMetrics computes the metrics of each group of deployments completed
+// between start and end.
+func deliveryMetrics(deployments []deployment, start, end time.Time, unit string, byTime bool) []*pb.DeliveryMetrics {
+	sort.SliceStable(deployments, func(i, j int) bool { return deployments[i].completed.Before(deployments[j].completed) })
+
+	type stats struct {
+		metrics   *pb.DeliveryMetrics
+		leadTimes []time.Duration
+		restores  []time.Duration
+	}
+	groups := map[deliveryGroup]*stats{}
+	get := func(g deliveryGroup) *stats {
+		st, ok := groups[g]
+		if !ok {
+			periodStart, periodEnd := start, end
+			if byTime {
+				periodStart, periodEnd = maxTime(start, g.period), minTime(end, nextPeriod(g.period, unit))
+			}
+			st = &stats{metrics: &pb.DeliveryMetrics{
+				Group:       g.name,
+				PeriodStart: timestamppb.New(periodStart),
+				PeriodEnd:   timestamppb.New(periodEnd),
+			}}
+			groups[g] = st
+		}
+		return st
+	}
+
+	// First failed deployment of each Pipeline not restored yet.
+	failing := map[string]deployment{}
+	for _, d := range deployments {
+		st := get(d.group)
+		if !d.succeeded {
+			st.metrics.FailedDeployments++
+			if _, ok := failing[d.pipeline]; !ok {
+				failing[d.pipeline] = d
+			}
+			continue
+		}
+		st.metrics.Deployments++
+		if !d.commit.IsZero() && !d.commit.After(d.completed) {
+			st.leadTimes = append(st.leadTimes, d.completed.Sub(d.commit))
+		}
+		if f, ok := failing[d.pipeline]; ok {
+			failed := get(f.group)
+			failed.restores = append(failed.restores, d.completed.Sub(f.completed))
+			delete(failing, d.pipeline)
+		}
+	}
+
+	metrics := make([]*pb.DeliveryMetrics, 0, len(groups))
+	for _, st := range groups {
+		m := st.metrics
+		if days := m.PeriodEnd.AsTime().Sub(m.PeriodStart.AsTime()).Hours() / 24; days > 0 {
+			m.DeploymentFrequency = float64(m.Deployments) / days
+		}
+		if completed := m.Deployments + m.FailedDeployments; completed > 0 {
+			m.ChangeFailureRate = float64(m.FailedDeployments) / float64(completed)
+		}
+		if len(st.leadTimes) > 0 {
+			m.LeadTime = durationpb.New(median(st.leadTimes))
+		}
+		if len(st.restores) > 0 {
+			m.MeanTimeToRestore = durationpb.New(mean(st.restores))
+		}
+		metrics = append(metrics, m)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		a, b := metrics[i], metrics[j]
+		if !a.PeriodStart.AsTime().Equal(b.PeriodStart.AsTime()) {
+			return a.PeriodStart.AsTime().Before(b.PeriodStart.AsTime())
+		}
+		return a.Group < b.Group
+	})
+	return metrics
+}
+
+// nextPeriod returns the start of the period following the one starting at
+// start, for units of time accepted by group_by.
+func nextPeriod(start time.Time, unit string) time.Time {
+	switch unit {
+	case "minute":
+		return start.Add(time.Minute)
+	case "hour":
+		return start.Add(time.Hour)
+	case "day":
+		return start.AddDate(0, 0, 1)
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
+func median(durations []time.Duration) time.Duration {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	n := len(durations)
+	if n%2 == 1 {
+		return durations[n/2]
+	}
+	return (durations[n/2-1] + durations[n/2]) / 2
+}
+
+func mean(durations []time.Duration) time.Duration {
+	var sum float64
+	for _, d := range durations {
+		sum += float64(d)
+	}
+	return time.Duration(math.Round(sum / float64(len(durations))))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
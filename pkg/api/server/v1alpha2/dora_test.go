@@ -0,0 +1,269 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestGetDeliveryMetrics(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+
+	type run struct {
+		name      string
+		namespace string
+		pipeline  string
+		status    corev1.ConditionStatus
+		reason    string
+		// commit is how long before the completion of the run its commit was
+		// made.
+		commit time.Duration
+	}
+	// Runs are created in order, one hour apart, and complete when created.
+	runs := []run{
+		{name: "deploy-1", namespace: "prod", pipeline: "deploy", status: corev1.ConditionTrue, commit: 2 * time.Hour},
+		{name: "deploy-2", namespace: "prod", pipeline: "deploy", status: corev1.ConditionFalse, commit: time.Hour},
+		{name: "deploy-3", namespace: "prod", pipeline: "deploy", status: corev1.ConditionFalse},
+		{name: "deploy-4", namespace: "prod", pipeline: "deploy", status: corev1.ConditionTrue, commit: 4 * time.Hour},
+		// Cancelled and running PipelineRuns are ignored.
+		{name: "deploy-5", namespace: "prod", pipeline: "deploy", status: corev1.ConditionFalse, reason: pipelinev1.PipelineRunReasonCancelled.String()},
+		{name: "deploy-6", namespace: "prod", pipeline: "deploy", status: corev1.ConditionUnknown},
+		// Failures are only restored by deployments of the same Pipeline.
+		{name: "other-1", namespace: "staging", pipeline: "deploy", status: corev1.ConditionFalse},
+		{name: "deploy-7", namespace: "prod", pipeline: "deploy", status: corev1.ConditionTrue, commit: 3 * time.Hour},
+		{name: "other-2", namespace: "staging", pipeline: "deploy", status: corev1.ConditionTrue},
+	}
+	var results = map[string]*pb.Result{}
+	create := func(r run) {
+		t.Helper()
+		fakeClock.Advance(time.Hour)
+		res, ok := results[r.namespace]
+		if !ok {
+			res, err = srv.CreateResult(ctx, &pb.CreateResultRequest{
+				Parent: r.namespace,
+				Result: &pb.Result{Name: r.namespace + "/results/deliveries"},
+			})
+			if err != nil {
+				t.Fatalf("CreateResult: %v", err)
+			}
+			results[r.namespace] = res
+		}
+		pr := &pipelinev1.PipelineRun{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      r.name,
+				Namespace: r.namespace,
+				Labels:    map[string]string{"tekton.dev/pipeline": r.pipeline},
+			},
+			Spec: pipelinev1.PipelineRunSpec{Params: pipelinev1.Params{{
+				Name: "git",
+				Value: *pipelinev1.NewObject(map[string]string{
+					"commit-time": fakeClock.Now().Add(-r.commit / 2).Format(time.RFC3339),
+				}),
+			}}},
+			Status: pipelinev1.PipelineRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: r.status,
+					Reason: r.reason,
+				}}},
+				PipelineRunStatusFields: pipelinev1.PipelineRunStatusFields{
+					CompletionTime: &v1.Time{Time: fakeClock.Now()},
+				},
+			},
+		}
+		if r.commit != 0 {
+			pr.Annotations = map[string]string{"commit-time": fakeClock.Now().Add(-r.commit).Format(time.RFC3339)}
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: fmt.Sprintf("%s/records/%s", res.GetName(), r.name),
+				Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: jsonutil.AnyBytes(t, pr)},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord(%s): %v", r.name, err)
+		}
+	}
+	// Outside of the window.
+	create(run{name: "deploy-0", namespace: "prod", pipeline: "deploy", status: corev1.ConditionFalse})
+	start := fakeClock.Now().Add(time.Minute)
+	for _, r := range runs {
+		create(r)
+	}
+	end := fakeClock.Now()
+	days := end.Sub(start).Hours() / 24
+
+	prod := &pb.DeliveryMetrics{
+		PeriodStart:         timestamppb.New(start),
+		PeriodEnd:           timestamppb.New(end),
+		Deployments:         3,
+		FailedDeployments:   2,
+		DeploymentFrequency: 3 / days,
+		LeadTime:            durationpb.New(3 * time.Hour),
+		ChangeFailureRate:   0.4,
+		// From deploy-2 to deploy-4.
+		MeanTimeToRestore: durationpb.New(2 * time.Hour),
+	}
+	for _, tc := range []struct {
+		name string
+		req  *pb.DeliveryMetricsRequest
+		want []*pb.DeliveryMetrics
+	}{
+		{
+			name: "filter",
+			req: &pb.DeliveryMetricsRequest{Parent: "-/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end),
+				Filter: `data.metadata.namespace == "prod"`, CommitTimeAnnotation: "commit-time"},
+			want: []*pb.DeliveryMetrics{prod},
+		},
+		{
+			name: "commit time param",
+			req: &pb.DeliveryMetricsRequest{Parent: "prod/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end),
+				CommitTimeParam: "git.commit-time"},
+			want: []*pb.DeliveryMetrics{{
+				PeriodStart:         timestamppb.New(start),
+				PeriodEnd:           timestamppb.New(end),
+				Deployments:         3,
+				FailedDeployments:   2,
+				DeploymentFrequency: 3 / days,
+				// Params hold commit times closer to the deployments.
+				LeadTime:          durationpb.New(90 * time.Minute),
+				ChangeFailureRate: 0.4,
+				MeanTimeToRestore: durationpb.New(2 * time.Hour),
+			}},
+		},
+		{
+			name: "group by namespace",
+			req: &pb.DeliveryMetricsRequest{Parent: "-/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end),
+				GroupBy: "namespace"},
+			want: []*pb.DeliveryMetrics{
+				{
+					Group:               "prod",
+					PeriodStart:         timestamppb.New(start),
+					PeriodEnd:           timestamppb.New(end),
+					Deployments:         3,
+					FailedDeployments:   2,
+					DeploymentFrequency: 3 / days,
+					ChangeFailureRate:   0.4,
+					MeanTimeToRestore:   durationpb.New(2 * time.Hour),
+				},
+				{
+					Group:               "staging",
+					PeriodStart:         timestamppb.New(start),
+					PeriodEnd:           timestamppb.New(end),
+					Deployments:         1,
+					FailedDeployments:   1,
+					DeploymentFrequency: 1 / days,
+					ChangeFailureRate:   0.5,
+					MeanTimeToRestore:   durationpb.New(2 * time.Hour),
+				},
+			},
+		},
+		{
+			name: "no deployments",
+			req: &pb.DeliveryMetricsRequest{Parent: "-/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end),
+				Filter: `data.metadata.namespace == "dev"`},
+			want: []*pb.DeliveryMetrics{{PeriodStart: timestamppb.New(start), PeriodEnd: timestamppb.New(end)}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.GetDeliveryMetrics(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("GetDeliveryMetrics: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.GetMetrics(), protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDeliveryMetrics_byTime(t *testing.T) {
+	day := func(d int, hour int) time.Time {
+		return time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC)
+	}
+	deploy := func(period time.Time, completed time.Time, succeeded bool) deployment {
+		return deployment{group: deliveryGroup{period: period}, pipeline: "ns/deploy", succeeded: succeeded, completed: completed}
+	}
+	deployments := []deployment{
+		deploy(day(2, 0), day(2, 12), true),
+		deploy(day(2, 0), day(2, 18), false),
+		deploy(day(3, 0), day(3, 6), true),
+	}
+	// The window starts and ends in the middle of a day.
+	got := deliveryMetrics(deployments, day(2, 12), day(3, 12), "day", true)
+	want := []*pb.DeliveryMetrics{
+		{
+			PeriodStart:         timestamppb.New(day(2, 12)),
+			PeriodEnd:           timestamppb.New(day(3, 0)),
+			Deployments:         1,
+			FailedDeployments:   1,
+			DeploymentFrequency: 2,
+			ChangeFailureRate:   0.5,
+			MeanTimeToRestore:   durationpb.New(12 * time.Hour),
+		},
+		{
+			PeriodStart:         timestamppb.New(day(3, 0)),
+			PeriodEnd:           timestamppb.New(day(3, 12)),
+			Deployments:         1,
+			DeploymentFrequency: 2,
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestGetDeliveryMetrics_invalid(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	now := timestamppb.New(fakeClock.Now())
+	for _, req := range []*pb.DeliveryMetricsRequest{
+		{},
+		{Parent: "ns/results/-", CommitTimeAnnotation: "a", CommitTimeParam: "p"},
+		{Parent: "ns/results/-", StartTime: now, EndTime: now},
+		{Parent: "ns/results/-", GroupBy: "status"},
+		{Parent: "ns/results/-", Filter: "data_type =="},
+	} {
+		if _, err := srv.GetDeliveryMetrics(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetDeliveryMetrics(%v): expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	defaultFlakeWindow   = 7 * 24 * time.Hour
+	defaultFlakyTasks    = 20
+	maxFlakyTasks        = 1000
+	flakeRecordsPageSize = 1000
+	// maxFlakeRecords bounds the number of TaskRuns analyzed by a call.
+	maxFlakeRecords  = 50000
+	maxFlakeExamples = 3
+
+	labelPipeline     = "tekton.dev/pipeline"
+	labelPipelineTask = "tekton.dev/pipelineTask"
+	labelTask         = "tekton.dev/task"
+	labelSHA          = "pipelinesascode.tekton.dev/sha"
+)
+
+// ListFlakyTasks ranks the tasks whose TaskRuns failed and then succeeded on
+// the same commit, or with the same params when the commit is unknown.
+func (s *Server) ListFlakyTasks(ctx context.Context, req *pb.ListFlakyTasksRequest) (*pb.ListFlakyTasksResponse, error) {
+	if req.GetParent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "parent missing")
+	}
+	parent, resultName, err := result.ParseName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0 || limit > maxFlakyTasks:
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", maxFlakyTasks)
+	case limit == 0:
+		limit = defaultFlakyTasks
+	}
+	start, end, err := timeWindow(req, defaultFlakeWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.listTaskRuns(ctx, parent, resultName, flakeFilter(req), constraint, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := findFlakyTasks(runs)
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return &pb.ListFlakyTasksResponse{Tasks: tasks}, nil
+}
+
+// flakeFilter returns the CEL filter selecting the TaskRuns to analyze.
+func flakeFilter(req *pb.ListFlakyTasksRequest) string {
+	filters := []string{`(data_type == TASK_RUN || data_type == "tekton.dev/v1beta1.TaskRun")`}
+	if p := req.GetPipeline(); p != "" {
+		filters = append(filters, fmt.Sprintf("data.metadata.labels[%q] == %s", labelPipeline, strconv.Quote(p)))
+	}
+	if t := req.GetTask(); t != "" {
+		filters = append(filters, fmt.Sprintf("(data.metadata.labels[%q] == %s || data.metadata.labels[%q] == %s)",
+			labelPipelineTask, strconv.Quote(t), labelTask, strconv.Quote(t)))
+	}
+	if f := strings.TrimSpace(req.GetFilter()); f != "" {
+		filters = append(filters, "("+f+")")
+	}
+	return strings.Join(filters, " && ")
+}
+
+// taskRun is a completed TaskRun analyzed for flakes.
+type taskRun struct {
+	record string
+	task   flakyTaskKey
+	// inputs identifies the commit or params of the TaskRun.
+	inputs    string
+	succeeded bool
+	completed time.Time
+}
+
+type flakyTaskKey struct {
+	cluster, namespace, pipeline, task string
+}
+
+// listTaskRuns returns the completed TaskRuns matching filter whose Records
+// were created between start and end, inclusive.
+func (s *Server) listTaskRuns(ctx context.Context, parent, resultName, filter, constraint string, start, end time.Time) ([]taskRun, error) {
+	db := s.db.WithContext(ctx).Where("created_time >= ? AND created_time <= ?", start, end)
+	req := &pb.ListRecordsRequest{
+		Parent:   parent + "/results/" + resultName,
+		Filter:   filter,
+		OrderBy:  "create_time asc",
+		PageSize: flakeRecordsPageSize,
+	}
+	var runs []taskRun
+	for read := 0; ; {
+		recordsLister, err := lister.OfRecords(s.recordsEnv, parent, resultName, req)
+		if err != nil {
+			return nil, err
+		}
+		recordsLister.Constrain(constraint)
+		records, next, err := recordsLister.List(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			_, data, err := v1Data(ctx, r)
+			if err != nil {
+				s.logger.Warnf("Skipping TaskRun Record %s in flake analysis: %v", r.GetName(), err)
+				continue
+			}
+			tr := &pipelinev1.TaskRun{}
+			if err := json.Unmarshal(data, tr); err != nil {
+				s.logger.Warnf("Skipping TaskRun Record %s in flake analysis: %v", r.GetName(), err)
+				continue
+			}
+			cluster := r.GetCluster()
+			if s.sameCluster(cluster, "") {
+				cluster = ""
+			}
+			if run, ok := toTaskRun(r.GetName(), cluster, tr); ok {
+				runs = append(runs, run)
+			}
+		}
+		if read += len(records); next == "" {
+			return runs, nil
+		} else if read >= maxFlakeRecords {
+			return nil, status.Errorf(codes.InvalidArgument, "more than %d TaskRuns to analyze, narrow the time window or filter", maxFlakeRecords)
+		}
+		req.PageToken = next
+	}
+}
+
+// toTaskRun returns the analyzed form of a TaskRun, or false if it isn't
+// completed, was cancelled or can't be attributed to a task. cluster is the
+// cluster the TaskRun ran in, empty for the cluster of the API server.
+func toTaskRun(name, cluster string, tr *pipelinev1.TaskRun) (taskRun, bool) {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if c == nil || c.IsUnknown() || c.Reason == pipelinev1.TaskRunReasonCancelled.String() {
+		return taskRun{}, false
+	}
+	task := tr.Labels[labelPipelineTask]
+	if task == "" {
+		task = tr.Labels[labelTask]
+	}
+	if task == "" && tr.Spec.TaskRef != nil {
+		task = tr.Spec.TaskRef.Name
+	}
+	if task == "" {
+		return taskRun{}, false
+	}
+
+	run := taskRun{
+		record: name,
+		task: flakyTaskKey{
+			cluster:   cluster,
+			namespace: tr.Namespace,
+			pipeline:  tr.Labels[labelPipeline],
+			task:      task,
+		},
+		succeeded: c.IsTrue(),
+		completed: tr.CreationTimestamp.Time,
+	}
+	if tr.Status.CompletionTime != nil {
+		run.completed = tr.Status.CompletionTime.Time
+	}
+	if sha := tr.Labels[labelSHA]; sha != "" {
+		run.inputs = "sha:" + sha
+	} else if sha := tr.Annotations[labelSHA]; sha != "" {
+		run.inputs = "sha:" + sha
+	} else {
+		run.inputs = "params:" + paramsKey(tr.Spec.Params)
+	}
+	return run, true
+}
+
+// paramsKey returns a representation of params independent of their order.
+func paramsKey(params pipelinev1.Params) string {
+	sorted := append(pipelinev1.Params(nil), params...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	b, _ := json.Marshal(sorted)
+	return string(b)
+}
+
+// findFlakyTasks returns the tasks with flakes, ranked by decreasing flake
+// rate. A failed TaskRun is a flake if a TaskRun of the same task with the
+// same inputs succeeded after it.
+func findFlakyTasks(runs []taskRun) []*pb.FlakyTask {
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].completed.Before(runs[j].completed) })
+
+	type inputsKey struct {
+		task   flakyTaskKey
+		inputs string
+	}
+	stats := map[flakyTaskKey]*pb.FlakyTask{}
+	flakes := map[flakyTaskKey][]taskRun{}
+	// Failures not followed by a success yet, by task and inputs.
+	pending := map[inputsKey][]taskRun{}
+	for _, run := range runs {
+		st, ok := stats[run.task]
+		if !ok {
+			st = &pb.FlakyTask{
+				Cluster:   run.task.cluster,
+				Namespace: run.task.namespace,
+				Pipeline:  run.task.pipeline,
+				Task:      run.task.task,
+			}
+			stats[run.task] = st
+		}
+		st.Runs++
+
+		key := inputsKey{task: run.task, inputs: run.inputs}
+		if !run.succeeded {
+			st.Failures++
+			pending[key] = append(pending[key], run)
+			continue
+		}
+		flakes[run.task] = append(flakes[run.task], pending[key]...)
+		delete(pending, key)
+	}
+
+	var tasks []*pb.FlakyTask
+	for key, runs := range flakes {
+		if len(runs) == 0 {
+			continue
+		}
+		st := stats[key]
+		st.Flakes = int64(len(runs))
+		st.FlakeRate = float64(st.Flakes) / float64(st.Runs)
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].completed.After(runs[j].completed) })
+		for i := 0; i < len(runs) && i < maxFlakeExamples; i++ {
+			st.Examples = append(st.Examples, runs[i].record)
+		}
+		tasks = append(tasks, st)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if a.FlakeRate != b.FlakeRate {
+			return a.FlakeRate > b.FlakeRate
+		}
+		if a.Flakes != b.Flakes {
+			return a.Flakes > b.Flakes
+		}
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Pipeline != b.Pipeline {
+			return a.Pipeline < b.Pipeline
+		}
+		return a.Task < b.Task
+	})
+	return tasks
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestListFlakyTasks(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "ns",
+		Result: &pb.Result{Name: "ns/results/flakes"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+
+	type run struct {
+		name    string
+		labels  map[string]string
+		taskRef string
+		params  pipelinev1.Params
+		status  corev1.ConditionStatus
+		reason  string
+		// v1beta1 stores the run in a Record of the v1beta1 type.
+		v1beta1 bool
+	}
+	ci := func(task, sha string) map[string]string {
+		return map[string]string{
+			"tekton.dev/pipeline":            "ci",
+			"tekton.dev/pipelineTask":        task,
+			"pipelinesascode.tekton.dev/sha": sha,
+		}
+	}
+	param := func(name, value string) pipelinev1.Param {
+		return pipelinev1.Param{Name: name, Value: *pipelinev1.NewStructuredValues(value)}
+	}
+	// Runs are created in order, one second apart.
+	runs := []run{
+		{name: "build-1", labels: ci("build", "abc"), status: corev1.ConditionFalse},
+		{name: "build-2", labels: ci("build", "abc"), status: corev1.ConditionTrue},
+		{name: "build-3", labels: ci("build", "def"), status: corev1.ConditionTrue},
+		{name: "test-1", labels: ci("test", "abc"), status: corev1.ConditionFalse},
+		{name: "test-2", labels: ci("test", "abc"), status: corev1.ConditionFalse, v1beta1: true},
+		{name: "test-3", labels: ci("test", "abc"), status: corev1.ConditionTrue},
+		// Never retried successfully.
+		{name: "test-4", labels: ci("test", "xyz"), status: corev1.ConditionFalse},
+		// Failed after succeeding.
+		{name: "lint-1", labels: ci("lint", "abc"), status: corev1.ConditionTrue},
+		{name: "lint-2", labels: ci("lint", "abc"), status: corev1.ConditionFalse},
+		// Cancelled and running TaskRuns are ignored.
+		{name: "lint-3", labels: ci("lint", "abc"), status: corev1.ConditionFalse, reason: pipelinev1.TaskRunReasonCancelled.String()},
+		{name: "lint-4", labels: ci("lint", "abc"), status: corev1.ConditionUnknown},
+		{name: "lint-5", labels: ci("lint", "def"), status: corev1.ConditionTrue},
+		// Standalone TaskRuns are compared by params, regardless of their order.
+		{name: "unit-1", taskRef: "unit", params: pipelinev1.Params{param("a", "1"), param("b", "2")}, status: corev1.ConditionFalse},
+		{name: "unit-2", taskRef: "unit", params: pipelinev1.Params{param("a", "2"), param("b", "2")}, status: corev1.ConditionTrue},
+		{name: "unit-3", taskRef: "unit", params: pipelinev1.Params{param("b", "2"), param("a", "1")}, status: corev1.ConditionTrue},
+	}
+	create := func(r run) {
+		t.Helper()
+		fakeClock.Advance(time.Second)
+		tr := &pipelinev1.TaskRun{
+			ObjectMeta: v1.ObjectMeta{Name: r.name, Namespace: "ns", Labels: r.labels},
+			Spec:       pipelinev1.TaskRunSpec{Params: r.params},
+			Status: pipelinev1.TaskRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: r.status,
+					Reason: r.reason,
+				}}},
+				TaskRunStatusFields: pipelinev1.TaskRunStatusFields{
+					CompletionTime: &v1.Time{Time: fakeClock.Now()},
+				},
+			},
+		}
+		if r.taskRef != "" {
+			tr.Spec.TaskRef = &pipelinev1.TaskRef{Name: r.taskRef}
+		}
+		dataType := "tekton.dev/v1.TaskRun"
+		if r.v1beta1 {
+			dataType = "tekton.dev/v1beta1.TaskRun"
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: fmt.Sprintf("%s/records/%s", res.GetName(), r.name),
+				Data: &pb.Any{Type: dataType, Value: jsonutil.AnyBytes(t, tr)},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord(%s): %v", r.name, err)
+		}
+	}
+	longAgo := fakeClock.Now()
+	// Outside of the default window.
+	create(run{name: "build-0", labels: ci("build", "ghi"), status: corev1.ConditionFalse})
+	fakeClock.Advance(8 * 24 * time.Hour)
+	start := fakeClock.Now()
+	for _, r := range runs {
+		create(r)
+	}
+	end := fakeClock.Now()
+	create(run{name: "build-4", labels: ci("build", "ghi"), status: corev1.ConditionTrue})
+
+	name := func(n string) string {
+		return res.GetName() + "/records/" + n
+	}
+	flakyTest := &pb.FlakyTask{Namespace: "ns", Pipeline: "ci", Task: "test", Runs: 4, Failures: 3, Flakes: 2, FlakeRate: 0.5,
+		Examples: []string{name("test-2"), name("test-1")}}
+	flakyUnit := &pb.FlakyTask{Namespace: "ns", Task: "unit", Runs: 3, Failures: 1, Flakes: 1, FlakeRate: 1.0 / 3,
+		Examples: []string{name("unit-1")}}
+	flakyBuild := &pb.FlakyTask{Namespace: "ns", Pipeline: "ci", Task: "build", Runs: 3, Failures: 1, Flakes: 1, FlakeRate: 1.0 / 3,
+		Examples: []string{name("build-1")}}
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.ListFlakyTasksRequest
+		want []*pb.FlakyTask
+	}{
+		{
+			name: "all",
+			req:  &pb.ListFlakyTasksRequest{Parent: "ns/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end)},
+			want: []*pb.FlakyTask{flakyTest, flakyUnit, flakyBuild},
+		},
+		{
+			name: "limit",
+			req:  &pb.ListFlakyTasksRequest{Parent: "ns/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end), Limit: 1},
+			want: []*pb.FlakyTask{flakyTest},
+		},
+		{
+			name: "filter",
+			req: &pb.ListFlakyTasksRequest{Parent: "ns/results/-", StartTime: timestamppb.New(start), EndTime: timestamppb.New(end),
+				Filter: `data.metadata.name.startsWith("unit")`},
+			want: []*pb.FlakyTask{flakyUnit},
+		},
+		{
+			// The default window ends now and excludes build-0.
+			name: "default window",
+			req:  &pb.ListFlakyTasksRequest{Parent: "ns/results/-", Filter: `data.metadata.name.startsWith("build")`},
+			want: []*pb.FlakyTask{{Namespace: "ns", Pipeline: "ci", Task: "build", Runs: 4, Failures: 1, Flakes: 1, FlakeRate: 0.25,
+				Examples: []string{name("build-1")}}},
+		},
+		{
+			name: "all time",
+			req: &pb.ListFlakyTasksRequest{Parent: "ns/results/-", StartTime: timestamppb.New(longAgo),
+				Filter: `data.metadata.name.startsWith("build")`},
+			want: []*pb.FlakyTask{{Namespace: "ns", Pipeline: "ci", Task: "build", Runs: 5, Failures: 2, Flakes: 2, FlakeRate: 0.4,
+				Examples: []string{name("build-1"), name("build-0")}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.ListFlakyTasks(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("ListFlakyTasks: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.GetTasks(), protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFlakeFilter(t *testing.T) {
+	// Label filters can't be run against the test database, as SQLite reads
+	// label keys as JSON paths.
+	got := flakeFilter(&pb.ListFlakyTasksRequest{Pipeline: "ci", Task: "build", Filter: "name != 'foo'"})
+	want := `(data_type == TASK_RUN || data_type == "tekton.dev/v1beta1.TaskRun") && data.metadata.labels["tekton.dev/pipeline"] == "ci" && ` +
+		`(data.metadata.labels["tekton.dev/pipelineTask"] == "build" || data.metadata.labels["tekton.dev/task"] == "build") && (name != 'foo')`
+	if got != want {
+		t.Errorf("flakeFilter() = %s, want %s", got, want)
+	}
+	env, err := resultscel.NewRecordsEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cel2sql.Convert(env, got); err != nil {
+		t.Errorf("Convert(%s): %v", got, err)
+	}
+}
+
+func TestListFlakyTasks_invalid(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	now := timestamppb.New(fakeClock.Now())
+	for _, req := range []*pb.ListFlakyTasksRequest{
+		{},
+		{Parent: "ns/results/-", Limit: -1},
+		{Parent: "ns/results/-", Limit: maxFlakyTasks + 1},
+		{Parent: "ns/results/-", StartTime: now, EndTime: now},
+		{Parent: "ns/results/-", Filter: "data_type =="},
+	} {
+		if _, err := srv.ListFlakyTasks(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListFlakyTasks(%v): expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
+
+func TestFindFlakyTasks_clusters(t *testing.T) {
+	now := time.Now()
+	run := func(record, cluster string, succeeded bool, at int) taskRun {
+		return taskRun{
+			record:    record,
+			task:      flakyTaskKey{cluster: cluster, namespace: "ns", pipeline: "ci", task: "deploy"},
+			inputs:    "sha:abc",
+			succeeded: succeeded,
+			completed: now.Add(time.Duration(at) * time.Second),
+		}
+	}
+	got := findFlakyTasks([]taskRun{
+		// Failing in a cluster and succeeding in another isn't a flake.
+		run("eu-1", "eu", false, 1),
+		run("us-1", "us", true, 2),
+		run("us-2", "us", false, 3),
+		run("us-3", "us", true, 4),
+	})
+	want := []*pb.FlakyTask{{Cluster: "us", Namespace: "ns", Pipeline: "ci", Task: "deploy", Runs: 3, Failures: 1, Flakes: 1, FlakeRate: 1.0 / 3,
+		Examples: []string{"us-2"}}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
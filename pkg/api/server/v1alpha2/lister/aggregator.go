@@ -23,11 +23,13 @@ import (
 
 	"github.com/google/cel-go/cel"
 	tdb "github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -50,6 +52,7 @@ type summaryRequest interface {
 
 // Aggregator contains the query builders for filters and aggregate functions for summary
 type Aggregator struct {
+	env           *cel.Env
 	queryBuilders []queryBuilder
 	aggregators   []aggregateFunc
 }
@@ -94,6 +97,7 @@ func newAggregator(env *cel.Env, aggregateObjectRequest summaryRequest, clauses
 	}
 
 	return &Aggregator{
+		env:         env,
 		aggregators: aggregators,
 		queryBuilders: []queryBuilder{
 			filters,
@@ -101,6 +105,15 @@ func newAggregator(env *cel.Env, aggregateObjectRequest summaryRequest, clauses
 	}, nil
 }
 
+// Constrain restricts the aggregated Records to those matching the given CEL
+// expression, regardless of the filter requested by the caller. Empty
+// expressions are ignored.
+func (a *Aggregator) Constrain(expr string) {
+	if expr = strings.TrimSpace(expr); expr != "" {
+		a.queryBuilders = append(a.queryBuilders, &constraint{env: a.env, expr: expr})
+	}
+}
+
 // Aggregate function runs the aggregation tasks and returns Summary
 func (a *Aggregator) Aggregate(ctx context.Context, db *gorm.DB) (*pb.RecordListSummary, error) {
 	var err error
@@ -112,7 +125,9 @@ func (a *Aggregator) Aggregate(ctx context.Context, db *gorm.DB) (*pb.RecordList
 	}
 
 	db = a.applyAggregateFunc(ctx, db)
-	db.Scan(&summary)
+	if err := errors.Wrap(db.Scan(&summary).Error); err != nil {
+		return nil, err
+	}
 
 	sm, err := toSummary(summary)
 	if err != nil {
@@ -164,6 +179,7 @@ var summaryFuncs = map[string]aggregateFunc{
 	"max_duration":   getDuration("MAX", durationQuery, "max_duration"),
 	"total_duration": getDuration("SUM", durationQuery, "total_duration"),
 	"min_duration":   getDuration("MIN", durationQuery, "min_duration"),
+	"p95_duration":   getPercentile(0.95, durationQuery, "p95_duration"),
 	"last_runtime":   getTime("MAX", startTimeQuery, "last_runtime"),
 	"succeeded":      getStatus(statusQuery, "Succeeded"),
 	"failed":         getStatus(statusQuery, "Failed"),
@@ -222,6 +238,12 @@ func getDuration(fn, query, value string) aggregateFunc {
 	}
 }
 
+func getPercentile(fraction float64, query, value string) aggregateFunc {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Select(db.Statement.Selects, fmt.Sprintf("PERCENTILE_CONT(%g) WITHIN GROUP (ORDER BY %s) AS %s", fraction, query, value))
+	}
+}
+
 func getTime(fn, query, as string) aggregateFunc {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Select(db.Statement.Selects, fmt.Sprintf("%s(EXTRACT(EPOCH FROM %s)) AS %s", fn, query, as))
@@ -238,6 +260,8 @@ var validGroups = map[string]bool{
 	"pipeline":   false,
 	"namespace":  false,
 	"repository": false,
+	"label":      false,
+	"cluster":    false,
 }
 
 func groupBy(groupSelect string) aggregateFunc {
@@ -261,6 +285,11 @@ func checkAndBuildGroupQuery(query string) (string, error) {
 			return "", status.Errorf(codes.InvalidArgument, "group_by does not recognize %s", parts[1])
 		}
 		return fmt.Sprintf("EXTRACT(EPOCH FROM DATE_TRUNC('%s', (data->'status'->>'%s')::TIMESTAMP WITH TIME ZONE)) AS group_value", parts[0], parts[1]), nil
+	case parts[0] == "label":
+		if len(parts) != 2 || len(validation.IsQualifiedName(parts[1])) > 0 {
+			return "", status.Errorf(codes.InvalidArgument, "group_by does not recognize %s, label keys must be qualified names", query)
+		}
+		return fmt.Sprintf("data->'metadata'->'labels'->>'%s' AS group_value", parts[1]), nil
 	case !isTime && len(parts) == 1:
 		switch parts[0] {
 		case "namespace":
@@ -270,6 +299,8 @@ func checkAndBuildGroupQuery(query string) (string, error) {
 			return fmt.Sprintf("CONCAT(%s, '/', %s) AS group_value", groupByParentQuery, groupByPipelineQuery), nil
 		case "repository":
 			return fmt.Sprintf("CONCAT(%s, '/', %s) AS group_value", groupByParentQuery, groupByRepositoryQuery), nil
+		case "cluster":
+			return "cluster AS group_value", nil
 		}
 	default:
 		return "", status.Errorf(codes.InvalidArgument, "group_by does not recognize %s", query)
@@ -61,6 +61,30 @@ func TestCheckAndBuildGroupQuery(t *testing.T) {
 			want:    "CONCAT(data->'metadata'->>'namespace', '/', data->'metadata'->'annotations'->>'pipelinesascode.tekton.dev/repository') AS group_value",
 			wantErr: false,
 		},
+		{
+			name:    "valid non-time query with cluster",
+			query:   "cluster",
+			want:    "cluster AS group_value",
+			wantErr: false,
+		},
+		{
+			name:    "valid non-time query with label",
+			query:   "label app.kubernetes.io/part-of",
+			want:    "data->'metadata'->'labels'->>'app.kubernetes.io/part-of' AS group_value",
+			wantErr: false,
+		},
+		{
+			name:    "label query without key",
+			query:   "label",
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "label query with invalid key",
+			query:   "label foo'bar",
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name:    "invalid query",
 			query:   "invalid",
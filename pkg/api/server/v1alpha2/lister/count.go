@@ -0,0 +1,113 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lister
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Count returns the number of resources matching the filter and constraints
+// of the Lister, in all pages. Resources are counted exactly up to
+// exactLimit; beyond it, the count is estimated by the query planner of the
+// database, which is much cheaper for large tables, and the returned bool is
+// true.
+func (l *Lister[M, W]) Count(ctx context.Context, db *gorm.DB, exactLimit int) (int64, bool, error) {
+	query := db.WithContext(ctx).Model(new(M))
+	for _, builder := range l.queryBuilders {
+		// Only filters and constraints restrict the counted resources,
+		// pagination and ordering don't.
+		switch builder.(type) {
+		case *filter, *constraint:
+		default:
+			continue
+		}
+		var err error
+		query, err = builder.build(query)
+		if err != nil {
+			return 0, false, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if exactLimit > 0 {
+		// Count one more resource than the limit to find out whether
+		// there are more resources than the limit, without counting
+		// them all.
+		var count int64
+		q := db.WithContext(ctx).Table("(?) AS matches", query.Session(&gorm.Session{}).Select("1").Limit(exactLimit+1)).Count(&count)
+		if err := errors.Wrap(q.Error); err != nil {
+			return 0, false, err
+		}
+		if count <= int64(exactLimit) {
+			return count, false, nil
+		}
+	}
+
+	estimate, err := estimateCount(ctx, query)
+	if err != nil {
+		return 0, false, err
+	}
+	// Planner estimates are based on statistics and can be lower than
+	// the number of resources already counted.
+	if exactLimit > 0 && estimate <= int64(exactLimit) {
+		estimate = int64(exactLimit) + 1
+	}
+	return estimate, true, nil
+}
+
+// estimateCount returns the number of rows the query planner expects the
+// query to return. Databases other than Postgres count the rows instead.
+func estimateCount(ctx context.Context, query *gorm.DB) (int64, error) {
+	if query.Dialector.Name() != "postgres" {
+		var count int64
+		q := query.Session(&gorm.Session{}).Count(&count)
+		if err := errors.Wrap(q.Error); err != nil {
+			return 0, err
+		}
+		return count, nil
+	}
+
+	// Build the query without running it, so that the filter is
+	// explained with the same bind variables as it would be run with.
+	stmt := query.Session(&gorm.Session{DryRun: true}).Select("1").Find(&[]map[string]any{}).Statement
+	var plan []byte
+	row := stmt.ConnPool.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...)
+	if err := row.Scan(&plan); err != nil {
+		return 0, errors.Wrap(err)
+	}
+	return planRows(plan)
+}
+
+// planRows returns the estimated number of rows of the root node of a query
+// plan in the JSON format of Postgres EXPLAIN.
+func planRows(plan []byte) (int64, error) {
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to parse query plan: %v", err)
+	}
+	if len(plans) == 0 {
+		return 0, status.Error(codes.Internal, "failed to parse query plan: no plan")
+	}
+	return int64(plans[0].Plan.Rows), nil
+}
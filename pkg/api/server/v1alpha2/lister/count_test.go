@@ -0,0 +1,106 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lister
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/cel"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	resultspb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+func TestCount(t *testing.T) {
+	gdb := test.NewDB(t)
+	if err := gdb.AutoMigrate(&db.Result{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		r := &db.Result{Parent: "foo", ID: fmt.Sprint(i), Name: fmt.Sprint(i)}
+		if err := gdb.Create(r).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	env, err := cel.NewResultsEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		parent        string
+		exactLimit    int
+		want          int64
+		wantEstimated bool
+	}{
+		{name: "exact", parent: "foo", exactLimit: 10, want: 3},
+		{name: "exact at the limit", parent: "foo", exactLimit: 3, want: 3},
+		{name: "estimated past the limit", parent: "foo", exactLimit: 2, want: 3, wantEstimated: true},
+		{name: "estimated without exact count", parent: "foo", want: 3, wantEstimated: true},
+		// Nothing was counted, so an estimate of no resources is kept.
+		{name: "estimated none without exact count", parent: "bar", want: 0, wantEstimated: true},
+		{name: "exact none", parent: "bar", exactLimit: 10, want: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := OfResults(env, &resultspb.ListResultsRequest{Parent: tc.parent})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, estimated, err := l.Count(context.Background(), gdb, tc.exactLimit)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if got != tc.want || estimated != tc.wantEstimated {
+				t.Errorf("Count() = (%d, %t), want (%d, %t)", got, estimated, tc.want, tc.wantEstimated)
+			}
+		})
+	}
+}
+
+func TestPlanRows(t *testing.T) {
+	plan := `[
+  {
+    "Plan": {
+      "Node Type": "Seq Scan",
+      "Parallel Aware": false,
+      "Relation Name": "records",
+      "Alias": "records",
+      "Startup Cost": 0.00,
+      "Total Cost": 1283.51,
+      "Plan Rows": 14207,
+      "Plan Width": 4,
+      "Filter": "((parent)::text = 'default'::text)"
+    }
+  }
+]`
+	got, err := planRows([]byte(plan))
+	if err != nil {
+		t.Fatalf("planRows: %v", err)
+	}
+	if got != 14207 {
+		t.Errorf("want 14207 rows, got %d", got)
+	}
+}
+
+func TestPlanRowsErrors(t *testing.T) {
+	for _, plan := range []string{"", "[]", `{"Plan": {}}`} {
+		if _, err := planRows([]byte(plan)); err == nil {
+			t.Errorf("planRows(%q): want error", plan)
+		}
+	}
+}
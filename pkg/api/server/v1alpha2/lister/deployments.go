@@ -0,0 +1,111 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lister
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	tdb "github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"gorm.io/gorm"
+)
+
+// deploymentColumns selects the fields of the PipelineRuns from which delivery
+// metrics are computed. Labels, annotations and params are selected as JSON,
+// as their keys can't be used in JSON paths in every database.
+const deploymentColumns = "data->'metadata'->>'namespace' AS namespace, " +
+	"data->'metadata'->'labels' AS labels, " +
+	"data->'metadata'->'annotations' AS annotations, " +
+	"data->'spec'->'params' AS params, " +
+	"data->'status'->'conditions'->0->>'status' AS status, " +
+	"data->'status'->'conditions'->0->>'reason' AS reason, " +
+	"data->'status'->>'completionTime' AS completion_time"
+
+// Deployment is a PipelineRun selected by an Aggregator of deployments.
+type Deployment struct {
+	// Group is the value of the group_by expression, empty if not grouped.
+	Group     string `gorm:"column:group_value"`
+	Namespace string
+	// Labels, Annotations and Params are the JSON of the PipelineRun fields.
+	Labels      string
+	Annotations string
+	Params      string
+	// Status and Reason are those of the Succeeded condition.
+	Status         string
+	Reason         string
+	CompletionTime string
+}
+
+// OfDeployments returns a new Aggregator selecting the PipelineRun Records
+// matching filter, along with the group they belong to for group_by.
+func OfDeployments(env *cel.Env, resultParent, resultName, filterExpr, group string) (*Aggregator, error) {
+	aggregators := []aggregateFunc{selectColumns(deploymentColumns)}
+	if group = strings.TrimSpace(group); group != "" {
+		groupQuery, err := checkAndBuildGroupQuery(group)
+		if err != nil {
+			return nil, err
+		}
+		aggregators = append(aggregators, selectColumns(groupQuery))
+	}
+	return &Aggregator{
+		env:         env,
+		aggregators: aggregators,
+		queryBuilders: []queryBuilder{
+			&filter{
+				env:  env,
+				expr: strings.TrimSpace(filterExpr),
+				equalityClauses: []equalityClause{{
+					columnName: "parent",
+					value:      resultParent,
+				}, {
+					columnName: "result_name",
+					value:      resultName,
+				}},
+			},
+		},
+	}, nil
+}
+
+// Deployments returns at most limit deployments selected by the Aggregator.
+func (a *Aggregator) Deployments(ctx context.Context, db *gorm.DB, limit int) ([]*Deployment, error) {
+	var err error
+	db = db.Model(&tdb.Record{})
+	db, err = a.buildQuery(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []*Deployment
+	db = a.applyAggregateFunc(ctx, db).Limit(limit)
+	if err := errors.Wrap(db.Scan(&deployments).Error); err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+// TimeGroup returns the unit of time by which group_by groups Records, or
+// false if it doesn't group them by time.
+func TimeGroup(group string) (string, bool) {
+	unit := strings.Split(strings.TrimSpace(group), " ")[0]
+	return unit, validGroups[unit]
+}
+
+func selectColumns(columns string) aggregateFunc {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Select(db.Statement.Selects, columns)
+	}
+}
@@ -15,6 +15,7 @@
 package lister
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -22,6 +23,8 @@ import (
 
 	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/results/pkg/api/server/cel2sql"
+	"github.com/tektoncd/results/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
@@ -57,7 +60,7 @@ func (f *filter) build(db *gorm.DB) (*gorm.DB, error) {
 	}
 
 	if expr := strings.TrimSpace(f.expr); expr != "" {
-		sql, err := cel2sql.Convert(f.env, expr)
+		sql, err := convert(db.Statement.Context, f.env, expr)
 		if err != nil {
 			return nil, err
 		}
@@ -65,3 +68,49 @@ func (f *filter) build(db *gorm.DB) (*gorm.DB, error) {
 	}
 	return db, nil
 }
+
+// constraint restricts a query with a CEL expression imposed by the server
+// rather than requested by the caller, such as the one derived from
+// authorization policies. Constraints are not recorded in page tokens, so
+// tampering with a token can't lift them.
+type constraint struct {
+	env  *cel.Env
+	expr string
+}
+
+// validateToken implements the queryBuilder interface.
+func (c *constraint) validateToken(*pagetokenpb.PageToken) error {
+	return nil
+}
+
+// build implements the queryBuilder interface.
+func (c *constraint) build(db *gorm.DB) (*gorm.DB, error) {
+	sql, err := convert(db.Statement.Context, c.env, c.expr)
+	if err != nil {
+		return nil, err
+	}
+	return db.Where(sql), nil
+}
+
+// Constrain restricts a query of Records or Results not built by a Lister to
+// those matching the given CEL expression of env, like Lister.Constrain. Empty
+// expressions are ignored.
+func Constrain(db *gorm.DB, env *cel.Env, expr string) (*gorm.DB, error) {
+	if expr = strings.TrimSpace(expr); expr == "" {
+		return db, nil
+	}
+	return (&constraint{env: env, expr: expr}).build(db)
+}
+
+// convert converts a CEL expression into a SQL filter in a span of its own, as
+// complex expressions can take a significant part of the time of a query.
+func convert(ctx context.Context, env *cel.Env, expr string) (string, error) {
+	// Statements built without db.WithContext have no context.
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, span := tracing.Start(ctx, "cel2sql.Convert", attribute.String("cel.expression", expr))
+	sql, err := cel2sql.Convert(env, expr)
+	tracing.End(span, err)
+	return sql, err
+}
@@ -66,6 +66,7 @@ type PageTokenGenerator func(wireObject) (string, error)
 // Lister is a generic utility to list, filter, sort and paginate Results and
 // Records in a uniform and consistent manner.
 type Lister[M any, W wireObject] struct {
+	env              *cel.Env
 	queryBuilders    []queryBuilder
 	pageSize         int
 	pageToken        *pagetokenpb.PageToken
@@ -130,15 +131,24 @@ func (l *Lister[M, W]) List(ctx context.Context, db *gorm.DB) ([]W, string, erro
 	return wire, nextPageToken, err
 }
 
+// Constrain restricts the listed resources to those matching the given CEL
+// expression, regardless of the filter requested by the caller. Empty
+// expressions are ignored.
+func (l *Lister[M, W]) Constrain(expr string) {
+	if expr = strings.TrimSpace(expr); expr != "" {
+		l.queryBuilders = append(l.queryBuilders, &constraint{env: l.env, expr: expr})
+	}
+}
+
 // OfResults creates a Lister for Result objects.
 func OfResults(env *cel.Env, request *resultspb.ListResultsRequest) (*Lister[*db.Result, *resultspb.Result], error) {
-	return newLister(env, resultFieldsToColumns, request, result.ToAPI, equalityClause{
+	return newLister(env, resultFields, request, result.ToAPI, equalityClause{
 		columnName: "parent",
 		value:      strings.TrimSpace(request.GetParent()),
 	})
 }
 
-func newLister[M any, W wireObject](env *cel.Env, fieldsToColumns map[string]string, listObjectsRequest request, convert Converter[M, W], clauses ...equalityClause) (*Lister[M, W], error) {
+func newLister[M any, W wireObject](env *cel.Env, fields *orderFields, listObjectsRequest request, convert Converter[M, W], clauses ...equalityClause) (*Lister[M, W], error) {
 	pageToken, err := decodePageToken(strings.TrimSpace(listObjectsRequest.GetPageToken()))
 	if err != nil {
 		return nil, err
@@ -149,7 +159,7 @@ func newLister[M any, W wireObject](env *cel.Env, fieldsToColumns map[string]str
 		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: provided parent (%s) differs from the parent used in the previous query (%s)", parent, pageToken.Parent)
 	}
 
-	order, err := newOrder(strings.TrimSpace(listObjectsRequest.GetOrderBy()), fieldsToColumns)
+	order, err := newOrder(strings.TrimSpace(listObjectsRequest.GetOrderBy()), fields)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +176,7 @@ func newLister[M any, W wireObject](env *cel.Env, fieldsToColumns map[string]str
 	}
 
 	return &Lister[M, W]{
+		env: env,
 		queryBuilders: []queryBuilder{
 			&offset{order: order, pageToken: pageToken},
 			filter,
@@ -189,12 +200,8 @@ func makePageTokenGenerator(parent, filter string, order *order) PageTokenGenera
 			},
 		}
 
-		if fieldName := order.fieldName; fieldName != "" {
-			pageToken.LastItem.OrderBy = &pagetokenpb.Order{
-				FieldName: fieldName,
-				Value:     getTimestamp(obj, fieldName),
-				Direction: pagetokenpb.Order_Direction(pagetokenpb.Order_Direction_value[order.direction]),
-			}
+		for _, key := range order.keys {
+			pageToken.LastItem.OrderBy = append(pageToken.LastItem.OrderBy, key.value(obj))
 		}
 
 		return encodePageToken(pageToken)
@@ -228,7 +235,7 @@ func getTimestamp(in wireObject, fieldName string) (timestamp *timestamppb.Times
 
 // OfRecords creates a Lister for Record objects.
 func OfRecords(env *cel.Env, resultParent, resultName string, request *resultspb.ListRecordsRequest) (*Lister[*db.Record, *resultspb.Record], error) {
-	return newLister(env, recordFieldsToColumns, request, record.ToAPI, equalityClause{
+	return newLister(env, recordFields, request, record.ToAPI, equalityClause{
 		columnName: "parent",
 		value:      resultParent,
 	},
@@ -43,20 +43,21 @@ func TestBuildQuery(t *testing.T) {
 
 	now := time.Now()
 
-	order := &order{
+	order := &order{keys: []sortKey{{
+		fieldName:  "create_time",
 		columnName: "created_time",
 		direction:  "DESC",
-	}
+	}}}
 
 	token := &pagetokenpb.PageToken{
 		Filter: `summary.status == SUCCESS`,
 		LastItem: &pagetokenpb.Item{
 			Uid: "bar",
-			OrderBy: &pagetokenpb.Order{
+			OrderBy: []*pagetokenpb.Order{{
 				FieldName: "create_time",
 				Value:     timestamppb.New(now),
 				Direction: pagetokenpb.Order_DESC,
-			},
+			}},
 		},
 	}
 
@@ -17,6 +17,7 @@ package lister
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	pagetokenpb "github.com/tektoncd/results/pkg/api/server/v1alpha2/lister/proto/pagetoken_go_proto"
 	"gorm.io/gorm"
@@ -41,25 +42,61 @@ func (o *offset) validateToken(token *pagetokenpb.PageToken) error {
 
 // build implements the queryBuilder interface.
 func (o *offset) build(db *gorm.DB) (*gorm.DB, error) {
-	if o.pageToken != nil {
-		if lastItem := o.pageToken.LastItem; lastItem != nil {
-			var leftHandSideExpression, rightHandSideExpression string
-			comparisonOperator := ">"
-			values := []any{}
-			if orderBy := lastItem.OrderBy; orderBy != nil {
-				leftHandSideExpression = fmt.Sprintf("(%s, %s)", o.order.columnName, defaultOrderByColumn)
-				rightHandSideExpression = "(?, ?)"
-				values = append(values, orderBy.Value.AsTime())
-				if orderBy.Direction == pagetokenpb.Order_DESC {
-					comparisonOperator = "<"
-				}
-			} else {
-				leftHandSideExpression = defaultOrderByColumn
-				rightHandSideExpression = "?"
-			}
-			values = append(values, lastItem.Uid)
-			db = db.Where(fmt.Sprintf("%s %s %s", leftHandSideExpression, comparisonOperator, rightHandSideExpression), values...)
+	if o.pageToken == nil {
+		return db, nil
+	}
+	lastItem := o.pageToken.LastItem
+	if lastItem == nil {
+		return db, nil
+	}
+	if len(lastItem.OrderBy) == 0 || o.order == nil || len(o.order.keys) == 0 {
+		return db.Where(defaultOrderByColumn+" > ?", lastItem.Uid), nil
+	}
+	if len(lastItem.OrderBy) != len(o.order.keys) {
+		return nil, errors.New("the page token doesn't match the order by clause")
+	}
+
+	columns := make([]string, 0, len(o.order.keys)+1)
+	operators := make([]string, 0, len(o.order.keys)+1)
+	values := make([]any, 0, len(o.order.keys)+1)
+	sameDirection := true
+	for i, key := range o.order.keys {
+		columns = append(columns, key.column(db))
+		operators = append(operators, comparisonOperator(key.direction))
+		values = append(values, key.tokenValue(lastItem.OrderBy[i]))
+		sameDirection = sameDirection && key.direction == o.order.keys[0].direction
+	}
+	columns = append(columns, defaultOrderByColumn)
+	operators = append(operators, comparisonOperator(o.order.direction()))
+	values = append(values, lastItem.Uid)
+
+	// Rows are compared as tuples when all the keys are sorted in the same
+	// direction, which databases can match against multicolumn indexes.
+	if sameDirection {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return db.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), operators[0], placeholders), values...), nil
+	}
+
+	// Otherwise the next rows are those after the last item for the first
+	// key, or equal for the first keys and after it for the next one.
+	var clauses []string
+	var args []any
+	for i := range columns {
+		var conditions []string
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, columns[j]+" = ?")
+			args = append(args, values[j])
 		}
+		conditions = append(conditions, fmt.Sprintf("%s %s ?", columns[i], operators[i]))
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return db.Where("("+strings.Join(clauses, " OR ")+")", args...), nil
+}
+
+func comparisonOperator(direction string) string {
+	if direction == "DESC" {
+		return "<"
 	}
-	return db, nil
+	return ">"
 }
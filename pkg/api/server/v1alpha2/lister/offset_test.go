@@ -105,17 +105,17 @@ func TestOffsetBuild(t *testing.T) {
 
 	t.Run("use more than one field to determine the page offset", func(t *testing.T) {
 		offset := &offset{
-			order: &order{
+			order: &order{keys: []sortKey{{
 				columnName: "created_time",
-			},
+			}}},
 			pageToken: &pagetokenpb.PageToken{
 				LastItem: &pagetokenpb.Item{
 					Uid: "foo",
-					OrderBy: &pagetokenpb.Order{
+					OrderBy: []*pagetokenpb.Order{{
 						FieldName: "create_time",
 						Value:     timestamppb.New(time.Now()),
 						Direction: pagetokenpb.Order_ASC,
-					},
+					}},
 				},
 			},
 		}
@@ -132,7 +132,7 @@ func TestOffsetBuild(t *testing.T) {
 			t.Errorf("Want %q, but got %q", want, got)
 		}
 
-		wantVars := []any{offset.pageToken.LastItem.OrderBy.Value.AsTime(), "foo"}
+		wantVars := []any{offset.pageToken.LastItem.OrderBy[0].Value.AsTime(), "foo"}
 		if diff := cmp.Diff(wantVars, testDB.Statement.Vars); diff != "" {
 			t.Errorf("Mismatch in the statement's vars (-want +got):\n%s", diff)
 		}
@@ -140,17 +140,18 @@ func TestOffsetBuild(t *testing.T) {
 
 	t.Run("paginating results using descending order", func(t *testing.T) {
 		offset := &offset{
-			order: &order{
+			order: &order{keys: []sortKey{{
 				columnName: "created_time",
-			},
+				direction:  "DESC",
+			}}},
 			pageToken: &pagetokenpb.PageToken{
 				LastItem: &pagetokenpb.Item{
 					Uid: "foo",
-					OrderBy: &pagetokenpb.Order{
+					OrderBy: []*pagetokenpb.Order{{
 						FieldName: "create_time",
 						Value:     timestamppb.New(time.Now()),
 						Direction: pagetokenpb.Order_DESC,
-					},
+					}},
 				},
 			},
 		}
@@ -167,7 +168,84 @@ func TestOffsetBuild(t *testing.T) {
 			t.Errorf("Want %q, but got %q", want, got)
 		}
 
-		wantVars := []any{offset.pageToken.LastItem.OrderBy.Value.AsTime(), "foo"}
+		wantVars := []any{offset.pageToken.LastItem.OrderBy[0].Value.AsTime(), "foo"}
+		if diff := cmp.Diff(wantVars, testDB.Statement.Vars); diff != "" {
+			t.Errorf("Mismatch in the statement's vars (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("multiple keys sorted in the same direction", func(t *testing.T) {
+		offset := &offset{
+			order: &order{keys: []sortKey{
+				{columnName: "recordsummary_status", direction: "DESC", kind: intValue},
+				{columnName: "created_time", direction: "DESC"},
+			}},
+			pageToken: &pagetokenpb.PageToken{
+				LastItem: &pagetokenpb.Item{
+					Uid: "foo",
+					OrderBy: []*pagetokenpb.Order{
+						{FieldName: "summary.status", IntValue: 2, Direction: pagetokenpb.Order_DESC},
+						{FieldName: "create_time", Value: timestamppb.New(time.Now()), Direction: pagetokenpb.Order_DESC},
+					},
+				},
+			},
+		}
+
+		testDB, err := offset.build(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testDB.Statement.Build("WHERE")
+
+		want := "WHERE (recordsummary_status, created_time, id) < (?, ?, ?)"
+		if got := testDB.Statement.SQL.String(); want != got {
+			t.Errorf("Want %q, but got %q", want, got)
+		}
+
+		wantVars := []any{int64(2), offset.pageToken.LastItem.OrderBy[1].Value.AsTime(), "foo"}
+		if diff := cmp.Diff(wantVars, testDB.Statement.Vars); diff != "" {
+			t.Errorf("Mismatch in the statement's vars (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("multiple keys sorted in different directions", func(t *testing.T) {
+		offset := &offset{
+			order: &order{keys: []sortKey{
+				{columnName: "COALESCE(data->'status'->>'completionTime', '')", direction: "DESC", kind: stringValue},
+				{columnName: "created_time", direction: "ASC"},
+			}},
+			pageToken: &pagetokenpb.PageToken{
+				LastItem: &pagetokenpb.Item{
+					Uid: "foo",
+					OrderBy: []*pagetokenpb.Order{
+						{FieldName: "data.status.completionTime", StringValue: "2023-01-01T00:00:00Z", Direction: pagetokenpb.Order_DESC},
+						{FieldName: "create_time", Value: timestamppb.New(time.Now()), Direction: pagetokenpb.Order_ASC},
+					},
+				},
+			},
+		}
+
+		testDB, err := offset.build(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testDB.Statement.Build("WHERE")
+
+		want := "WHERE ((COALESCE(data->'status'->>'completionTime', '') < ?) OR " +
+			"(COALESCE(data->'status'->>'completionTime', '') = ? AND created_time > ?) OR " +
+			"(COALESCE(data->'status'->>'completionTime', '') = ? AND created_time = ? AND id > ?))"
+		if got := testDB.Statement.SQL.String(); want != got {
+			t.Errorf("Want %q, but got %q", want, got)
+		}
+
+		createTime := offset.pageToken.LastItem.OrderBy[1].Value.AsTime()
+		wantVars := []any{
+			"2023-01-01T00:00:00Z",
+			"2023-01-01T00:00:00Z", createTime,
+			"2023-01-01T00:00:00Z", createTime, "foo",
+		}
 		if diff := cmp.Diff(wantVars, testDB.Statement.Vars); diff != "" {
 			t.Errorf("Mismatch in the statement's vars (-want +got):\n%s", diff)
 		}
@@ -15,13 +15,17 @@
 package lister
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	pagetokenpb "github.com/tektoncd/results/pkg/api/server/v1alpha2/lister/proto/pagetoken_go_proto"
+	resultspb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
@@ -32,109 +36,434 @@ const (
 	defaultOrderByDirection = "ASC"
 )
 
+// valueKind is the type of the values of a sort key, which determines how
+// they are stored in page tokens.
+type valueKind int
+
+const (
+	timestampValue valueKind = iota
+	stringValue
+	intValue
+)
+
+// sortColumn is a column, or SQL expression, objects can be sorted by.
+type sortColumn struct {
+	name string
+	kind valueKind
+	// dialects maps the names of databases to the expression they run
+	// instead of name, for expressions only Postgres can run.
+	dialects map[string]string
+}
+
+// orderFields describes the fields objects can be sorted by.
+type orderFields struct {
+	// columns maps field names to their column.
+	columns map[string]sortColumn
+	// jsonColumns maps the names of JSON fields to their column. Keys within
+	// these fields are selected with CEL-style paths, like data.status.startTime.
+	jsonColumns map[string]jsonColumn
+}
+
+// jsonColumn is a JSON column objects can be sorted by.
+type jsonColumn struct {
+	name string
+	// depth is the number of keys allowed in paths within the column, or 0
+	// for any number of keys.
+	depth int
+}
+
 var (
-	resultFieldsToColumns = map[string]string{
-		"create_time": "created_time",
-		"update_time": "updated_time",
+	resultFields = &orderFields{
+		columns: map[string]sortColumn{
+			"create_time": {name: "created_time"},
+			"update_time": {name: "updated_time"},
 
-		// Fields of RecordSummary type.
-		"summary.start_time": "recordsummary_start_time",
-		"summary.end_time":   "recordsummary_end_time",
+			// Fields of RecordSummary type.
+			"summary.start_time": {name: "recordsummary_start_time"},
+			"summary.end_time":   {name: "recordsummary_end_time"},
+			"summary.status":     {name: "recordsummary_status", kind: intValue},
+			// Durations are sorted in milliseconds. Runs that haven't
+			// completed are sorted as if they took no time.
+			"summary.duration": {
+				name: "COALESCE(FLOOR(EXTRACT(EPOCH FROM recordsummary_end_time - recordsummary_start_time) * 1000), 0)",
+				kind: intValue,
+				dialects: map[string]string{
+					"sqlite": "COALESCE(CAST(ROUND((JULIANDAY(recordsummary_end_time) - JULIANDAY(recordsummary_start_time)) * 86400000) AS INTEGER), 0)",
+				},
+			},
+		},
+		jsonColumns: map[string]jsonColumn{
+			"annotations":         {name: "annotations", depth: 1},
+			"summary.annotations": {name: "recordsummary_annotations", depth: 1},
+		},
 	}
 
-	recordFieldsToColumns = map[string]string{
-		"create_time": "created_time",
-		"update_time": "updated_time",
+	recordFields = &orderFields{
+		columns: map[string]sortColumn{
+			"create_time": {name: "created_time"},
+			"update_time": {name: "updated_time"},
+		},
+		jsonColumns: map[string]jsonColumn{
+			"data": {name: "data"},
+		},
 	}
 
-	orderByPattern = regexp.MustCompile(`^([\w\.]+)\s*(ASC|asc|DESC|desc)?$`)
+	orderByPattern = regexp.MustCompile(`^(.+?)(?:\s+(ASC|asc|DESC|desc))?$`)
+
+	// fieldPattern matches CEL-style field paths, made of identifiers
+	// separated by dots, array indexes and quoted map keys.
+	fieldPattern   = regexp.MustCompile(`^[A-Za-z_]\w*(?:\.[A-Za-z_]\w*|\[\d+\]|\["[^"]*"\]|\['[^']*'\])*$`)
+	segmentPattern = regexp.MustCompile(`\.?([A-Za-z_]\w*)|\[(\d+)\]|\["([^"]*)"\]|\['([^']*)'\]`)
 )
 
-type order struct {
-	columnName string
+// pathSegment is a key, or an array index, in a field path.
+type pathSegment struct {
+	key   string
+	index int
+	// isIndex reports whether the segment is an array index.
+	isIndex bool
+}
+
+// sortKey is a field objects are sorted by.
+type sortKey struct {
 	fieldName  string
+	columnName string
 	direction  string
+	kind       valueKind
+	// dialects maps the names of databases to the expression they run
+	// instead of columnName.
+	dialects map[string]string
+	// path is the path of JSON fields in their column.
+	path []pathSegment
+}
+
+// column returns the column, or SQL expression, of the key in the database.
+func (k *sortKey) column(db *gorm.DB) string {
+	if name, ok := k.dialects[db.Dialector.Name()]; ok {
+		return name
+	}
+	return k.columnName
+}
+
+type order struct {
+	keys []sortKey
 }
 
 // validateToken implements the queryBuilder interface.
 func (o *order) validateToken(token *pagetokenpb.PageToken) error {
 	// Validate the token only if the caller wants to sort the collection by
-	// a custom field.
-	if fieldName := o.fieldName; fieldName != "" {
-		orderBy := token.LastItem.OrderBy
-		if orderBy == nil {
-			return errors.New("last_item.order_by: missing required field")
-		}
-		tokenDirection := pagetokenpb.Order_Direction_name[int32(orderBy.Direction)]
-		if orderBy.FieldName != fieldName || tokenDirection != o.direction {
-			return fmt.Errorf("the provided order by clause differs from the value passed in the previous query\nexpected: %s %s\ngot: %s %s",
-				orderBy.FieldName, tokenDirection,
-				fieldName, o.direction)
-		}
+	// custom fields.
+	if len(o.keys) == 0 {
+		return nil
+	}
+	orderBy := token.LastItem.OrderBy
+	if len(orderBy) == 0 {
+		return errors.New("last_item.order_by: missing required field")
+	}
+	if len(orderBy) != len(o.keys) || !o.matches(orderBy) {
+		return fmt.Errorf("the provided order by clause differs from the value passed in the previous query\nexpected: %s\ngot: %s",
+			formatTokenOrder(orderBy), o)
 	}
 	return nil
 }
 
+// matches reports whether the fields and directions of the keys in the token
+// are those of the order.
+func (o *order) matches(orderBy []*pagetokenpb.Order) bool {
+	for i, key := range o.keys {
+		tokenDirection := pagetokenpb.Order_Direction_name[int32(orderBy[i].Direction)]
+		if orderBy[i].FieldName != key.fieldName || tokenDirection != key.direction {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the order by clause of the order.
+func (o *order) String() string {
+	keys := make([]string, 0, len(o.keys))
+	for _, key := range o.keys {
+		keys = append(keys, key.fieldName+" "+key.direction)
+	}
+	return strings.Join(keys, ", ")
+}
+
+func formatTokenOrder(orderBy []*pagetokenpb.Order) string {
+	keys := make([]string, 0, len(orderBy))
+	for _, o := range orderBy {
+		keys = append(keys, o.FieldName+" "+pagetokenpb.Order_Direction_name[int32(o.Direction)])
+	}
+	return strings.Join(keys, ", ")
+}
+
+// direction returns the direction of the id column, which breaks ties
+// between objects with the same values for all the keys.
+func (o *order) direction() string {
+	if len(o.keys) == 0 {
+		return defaultOrderByDirection
+	}
+	return o.keys[len(o.keys)-1].direction
+}
+
 // build implements the queryBuilder interface.
 func (o *order) build(db *gorm.DB) (*gorm.DB, error) {
-	direction := defaultOrderByDirection
-	if o.direction != "" {
-		direction = o.direction
+	for _, key := range o.keys {
+		db = db.Order(key.column(db) + " " + key.direction)
+	}
+	return db.Order(defaultOrderByColumn + " " + o.direction()), nil
+}
+
+// value returns the value of the key for the given object, to be stored in
+// a page token.
+func (k *sortKey) value(obj wireObject) *pagetokenpb.Order {
+	out := &pagetokenpb.Order{
+		FieldName: k.fieldName,
+		Direction: pagetokenpb.Order_Direction(pagetokenpb.Order_Direction_value[k.direction]),
 	}
-	if o.columnName != "" {
-		db = db.Order(o.columnName + " " + direction)
+	switch {
+	case k.path != nil:
+		out.StringValue = jsonValue(obj, k)
+	case k.kind == intValue:
+		out.IntValue = getInt(obj, k.fieldName)
+	default:
+		out.Value = getTimestamp(obj, k.fieldName)
 	}
-	return db.Order(defaultOrderByColumn + " " + direction), nil
+	return out
 }
 
-// parseOrderBy attempts to parse the input into a suitable tuple of column and
-// direction to be used in the sql order by clause.
-func parseOrderBy(in string, fieldsToColumns map[string]string) (columnName string, fieldName string, direction string, err error) {
+// tokenValue returns the value of the key stored in a page token, as a SQL
+// query argument.
+func (k *sortKey) tokenValue(o *pagetokenpb.Order) any {
+	switch k.kind {
+	case stringValue:
+		return o.StringValue
+	case intValue:
+		return o.IntValue
+	default:
+		return o.Value.AsTime()
+	}
+}
+
+func getInt(in wireObject, fieldName string) int64 {
+	result, ok := in.(*resultspb.Result)
+	if !ok {
+		return 0
+	}
+	summary := result.GetSummary()
+	switch fieldName {
+	case "summary.status":
+		return int64(summary.GetStatus())
+	case "summary.duration":
+		if summary.GetStartTime() == nil || summary.GetEndTime() == nil {
+			return 0
+		}
+		return summary.GetEndTime().AsTime().Sub(summary.GetStartTime().AsTime()).Milliseconds()
+	}
+	return 0
+}
+
+// jsonValue returns the text the database selects for the JSON field of the
+// key in the given object, or an empty string if the field is not set or is
+// an object or an array.
+func jsonValue(in wireObject, k *sortKey) string {
+	var raw []byte
+	switch obj := in.(type) {
+	case *resultspb.Record:
+		raw = obj.GetData().GetValue()
+	case *resultspb.Result:
+		annotations := obj.GetAnnotations()
+		if strings.HasPrefix(k.fieldName, "summary.") {
+			annotations = obj.GetSummary().GetAnnotations()
+		}
+		return annotations[k.path[0].key]
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return ""
+	}
+	for _, segment := range k.path {
+		switch node := v.(type) {
+		case map[string]any:
+			if segment.isIndex {
+				return ""
+			}
+			v = node[segment.key]
+		case []any:
+			if !segment.isIndex || segment.index >= len(node) {
+				return ""
+			}
+			v = node[segment.index]
+		default:
+			return ""
+		}
+	}
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// parseOrderBy attempts to parse the input, a comma-separated list of fields
+// with an optional direction, into sort keys to be used in the sql order by
+// clause.
+func parseOrderBy(in string, fields *orderFields) ([]sortKey, error) {
 	in = strings.TrimSpace(in)
 	if in == "" {
-		return "", "", "", nil
+		return nil, nil
+	}
+
+	var keys []sortKey
+	for _, part := range splitOrderBy(in) {
+		matches := orderByPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if matches == nil || !fieldPattern.MatchString(matches[1]) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order by statement:\n%s", explainOrderByFormat(fields))
+		}
+
+		key, ok := fields.key(matches[1])
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: field is unknown or cannot be used in the order by clause\n%s", matches[1], explainOrderByFormat(fields))
+		}
+		key.direction = defaultOrderByDirection
+		if desiredDirection := matches[2]; desiredDirection != "" {
+			key.direction = strings.ToUpper(desiredDirection)
+		}
+		keys = append(keys, key)
 	}
+	return keys, nil
+}
 
-	matches := orderByPattern.FindStringSubmatch(in)
-	if matches == nil {
-		return "", "", "", status.Errorf(codes.InvalidArgument, "invalid order by statement:\n%s", explainOrderByFormat(fieldsToColumns))
+// splitOrderBy splits the order by clause on the commas outside of quoted map
+// keys.
+func splitOrderBy(in string) []string {
+	var parts []string
+	var quote rune
+	start := 0
+	for i, c := range in {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			parts = append(parts, in[start:i])
+			start = i + 1
+		}
 	}
+	return append(parts, in[start:])
+}
 
-	fieldName = matches[1]
-	columnName = fieldsToColumns[fieldName]
-	if columnName == "" {
-		return "", "", "", status.Errorf(codes.InvalidArgument, "%s: field is unknown or cannot be used in the order by clause\n%s", fieldName, explainOrderByFormat(fieldsToColumns))
+// key returns the sort key of the given field.
+func (f *orderFields) key(fieldName string) (sortKey, bool) {
+	if column, ok := f.columns[fieldName]; ok {
+		return sortKey{fieldName: fieldName, columnName: column.name, kind: column.kind, dialects: column.dialects}, true
 	}
 
-	if desiredDirection := matches[2]; desiredDirection == "" {
-		direction = defaultOrderByDirection
-	} else {
-		direction = strings.ToUpper(strings.TrimSpace(matches[2]))
+	// Find the longest JSON field the path starts with.
+	var prefix string
+	for name := range f.jsonColumns {
+		if strings.HasPrefix(fieldName, name) && len(name) > len(prefix) {
+			prefix = name
+		}
+	}
+	if prefix == "" {
+		return sortKey{}, false
+	}
+	column := f.jsonColumns[prefix]
+	path := parsePath(fieldName[len(prefix):])
+	if len(path) == 0 || column.depth > 0 && len(path) > column.depth || path[0].isIndex && column.depth > 0 {
+		return sortKey{}, false
+	}
+	// The first key must be separated from the column name.
+	if rest := fieldName[len(prefix):]; rest[0] != '.' && rest[0] != '[' {
+		return sortKey{}, false
 	}
 
-	return
+	columnName := "COALESCE(" + jsonPath(column.name, path, "->>") + ", '')"
+	if column.depth == 0 {
+		// Objects and arrays are sorted as if the field was not set, as their
+		// text can't be stored in page tokens the way the database selects it.
+		// They are told from scalars by the first character of their JSON
+		// text, which both Postgres and SQLite select with ->.
+		columnName = fmt.Sprintf("CASE WHEN SUBSTR(CAST(%s AS TEXT), 1, 1) IN ('{', '[') THEN '' ELSE %s END", jsonPath(column.name, path, "->"), columnName)
+	}
+	return sortKey{fieldName: fieldName, columnName: columnName, kind: stringValue, path: path}, true
+}
+
+// jsonPath returns the SQL expression selecting the field at path in the JSON
+// column, with the given operator selecting the last key.
+func jsonPath(column string, path []pathSegment, last string) string {
+	var sb strings.Builder
+	sb.WriteString(column)
+	for i, segment := range path {
+		operator := "->"
+		if i == len(path)-1 {
+			operator = last
+		}
+		if segment.isIndex {
+			fmt.Fprintf(&sb, "%s%d", operator, segment.index)
+		} else {
+			fmt.Fprintf(&sb, "%s'%s'", operator, strings.ReplaceAll(segment.key, "'", "''"))
+		}
+	}
+	return sb.String()
+}
+
+// parsePath returns the segments of a field path, following the name of its
+// column.
+func parsePath(in string) []pathSegment {
+	var path []pathSegment
+	for _, m := range segmentPattern.FindAllStringSubmatch(in, -1) {
+		switch {
+		case m[1] != "":
+			path = append(path, pathSegment{key: m[1]})
+		case m[2] != "":
+			index, _ := strconv.Atoi(m[2])
+			path = append(path, pathSegment{index: index, isIndex: true})
+		case m[3] != "" || strings.HasPrefix(m[0], `["`):
+			path = append(path, pathSegment{key: m[3]})
+		default:
+			path = append(path, pathSegment{key: m[4]})
+		}
+	}
+	return path
 }
 
 // explainOrderByFormat returns a descriptive message to inform callers on the
 // OrderBy field's correct format.
-func explainOrderByFormat(fieldsToColumns map[string]string) string {
-	validFields := make([]string, 0, len(fieldsToColumns))
-	for field := range fieldsToColumns {
+func explainOrderByFormat(fields *orderFields) string {
+	validFields := make([]string, 0, len(fields.columns)+len(fields.jsonColumns))
+	for field := range fields.columns {
 		validFields = append(validFields, field)
 	}
+	for field, column := range fields.jsonColumns {
+		if column.depth == 1 {
+			validFields = append(validFields, field+".<key>")
+		} else {
+			validFields = append(validFields, field+".<path>")
+		}
+	}
 	sort.Strings(validFields)
-	return fmt.Sprintf("the value must obey the format <%s> [asc|desc]", strings.Join(validFields, "|"))
+	return fmt.Sprintf("the value must be a comma-separated list of <%s> [asc|desc]", strings.Join(validFields, "|"))
 }
 
 // newOrder creates a new order object from the provided orderBy value.
-func newOrder(orderBy string, fieldsToColumns map[string]string) (*order, error) {
-	column, field, direction, err := parseOrderBy(orderBy, fieldsToColumns)
+func newOrder(orderBy string, fields *orderFields) (*order, error) {
+	keys, err := parseOrderBy(orderBy, fields)
 	if err != nil {
 		return nil, err
 	}
-	return &order{
-		columnName: column,
-		fieldName:  field,
-		direction:  direction,
-	}, nil
+	return &order{keys: keys}, nil
 }
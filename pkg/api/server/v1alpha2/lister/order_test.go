@@ -16,13 +16,17 @@ package lister
 
 import (
 	"testing"
+	"time"
 
 	"gorm.io/gorm/utils/tests"
 
 	"github.com/google/go-cmp/cmp"
 	pagetokenpb "github.com/tektoncd/results/pkg/api/server/v1alpha2/lister/proto/pagetoken_go_proto"
+	resultspb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -38,16 +42,16 @@ func TestOrderByValidateToken(t *testing.T) {
 	})
 
 	t.Run("the provided ordering values match the ordering values in the token", func(t *testing.T) {
-		order := &order{
+		order := &order{keys: []sortKey{{
 			fieldName: "create_time",
 			direction: defaultOrderByDirection,
-		}
+		}}}
 		token := &pagetokenpb.PageToken{
 			LastItem: &pagetokenpb.Item{
-				OrderBy: &pagetokenpb.Order{
+				OrderBy: []*pagetokenpb.Order{{
 					FieldName: "create_time",
 					Direction: pagetokenpb.Order_ASC,
-				},
+				}},
 			},
 		}
 
@@ -57,7 +61,7 @@ func TestOrderByValidateToken(t *testing.T) {
 	})
 
 	t.Run("missing LastItem.OrderBy field", func(t *testing.T) {
-		order := &order{fieldName: "create_time"}
+		order := &order{keys: []sortKey{{fieldName: "create_time"}}}
 		token := &pagetokenpb.PageToken{LastItem: &pagetokenpb.Item{}}
 
 		if err := order.validateToken(token); err == nil {
@@ -66,16 +70,16 @@ func TestOrderByValidateToken(t *testing.T) {
 	})
 
 	t.Run("the provided field name differs from the field name in the token", func(t *testing.T) {
-		order := &order{
+		order := &order{keys: []sortKey{{
 			fieldName: "create_time",
 			direction: defaultOrderByDirection,
-		}
+		}}}
 		token := &pagetokenpb.PageToken{
 			LastItem: &pagetokenpb.Item{
-				OrderBy: &pagetokenpb.Order{
+				OrderBy: []*pagetokenpb.Order{{
 					FieldName: "update_time",
 					Direction: pagetokenpb.Order_ASC,
-				},
+				}},
 			},
 		}
 
@@ -85,16 +89,16 @@ func TestOrderByValidateToken(t *testing.T) {
 	})
 
 	t.Run("the provided direction differs from the direction in the token", func(t *testing.T) {
-		order := &order{
+		order := &order{keys: []sortKey{{
 			fieldName: "create_time",
 			direction: "DESC",
-		}
+		}}}
 		token := &pagetokenpb.PageToken{
 			LastItem: &pagetokenpb.Item{
-				OrderBy: &pagetokenpb.Order{
+				OrderBy: []*pagetokenpb.Order{{
 					FieldName: "create_time",
 					Direction: pagetokenpb.Order_ASC,
-				},
+				}},
 			},
 		}
 
@@ -126,10 +130,10 @@ func TestOrderByBuild(t *testing.T) {
 	})
 
 	t.Run("order by a given column", func(t *testing.T) {
-		order := &order{
+		order := &order{keys: []sortKey{{
 			columnName: "created_time",
 			direction:  "DESC",
-		}
+		}}}
 
 		testDB, err := order.build(db)
 		if err != nil {
@@ -147,114 +151,196 @@ func TestOrderByBuild(t *testing.T) {
 
 func TestParseOrderBy(t *testing.T) {
 	tests := []struct {
-		name      string
-		in        string
-		column    string
-		field     string
-		direction string
+		name   string
+		in     string
+		fields *orderFields
+		want   []sortKey
 	}{{
-		name:      "valid order by statement",
-		in:        "create_time DESC",
-		column:    "created_time",
-		field:     "create_time",
-		direction: "DESC",
+		name:   "valid order by statement",
+		in:     "create_time DESC",
+		fields: resultFields,
+		want:   []sortKey{{fieldName: "create_time", columnName: "created_time", direction: "DESC"}},
 	},
 		{
-			name:      "sort in ascending order",
-			in:        "create_time ASC",
-			column:    "created_time",
-			field:     "create_time",
-			direction: "ASC",
+			name:   "sort in ascending order",
+			in:     "create_time ASC",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "create_time", columnName: "created_time", direction: "ASC"}},
 		},
 		{
-			name:      "update_time field omitting the direction",
-			in:        "update_time",
-			column:    "updated_time",
-			field:     "update_time",
-			direction: "ASC",
+			name:   "update_time field omitting the direction",
+			in:     "update_time",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "update_time", columnName: "updated_time", direction: "ASC"}},
 		},
 		{
-			name:      "summary.start_time field",
-			in:        "summary.start_time asc",
-			column:    "recordsummary_start_time",
-			field:     "summary.start_time",
-			direction: "ASC",
+			name:   "summary.start_time field",
+			in:     "summary.start_time asc",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "summary.start_time", columnName: "recordsummary_start_time", direction: "ASC"}},
 		},
 		{
-			name:      "summary.end_time field",
-			in:        "summary.end_time desc",
-			column:    "recordsummary_end_time",
-			field:     "summary.end_time",
-			direction: "DESC",
+			name:   "summary.end_time field",
+			in:     "summary.end_time desc",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "summary.end_time", columnName: "recordsummary_end_time", direction: "DESC"}},
 		},
 		{
-			name:      "trailing and leading spaces",
-			in:        "  summary.start_time   asc ",
-			column:    "recordsummary_start_time",
-			field:     "summary.start_time",
-			direction: "ASC",
+			name:   "trailing and leading spaces",
+			in:     "  summary.start_time   asc ",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "summary.start_time", columnName: "recordsummary_start_time", direction: "ASC"}},
 		},
 		{
-			name:      "trailing and leading spaces with no direction",
-			in:        "  summary.start_time   ",
-			column:    "recordsummary_start_time",
-			field:     "summary.start_time",
-			direction: "ASC",
+			name:   "trailing and leading spaces with no direction",
+			in:     "  summary.start_time   ",
+			fields: resultFields,
+			want:   []sortKey{{fieldName: "summary.start_time", columnName: "recordsummary_start_time", direction: "ASC"}},
+		},
+		{
+			name:   "multiple fields",
+			in:     "summary.status, summary.duration desc,create_time",
+			fields: resultFields,
+			want: []sortKey{
+				{fieldName: "summary.status", columnName: "recordsummary_status", direction: "ASC", kind: intValue},
+				{
+					fieldName:  "summary.duration",
+					columnName: "COALESCE(FLOOR(EXTRACT(EPOCH FROM recordsummary_end_time - recordsummary_start_time) * 1000), 0)",
+					direction:  "DESC",
+					kind:       intValue,
+					dialects: map[string]string{
+						"sqlite": "COALESCE(CAST(ROUND((JULIANDAY(recordsummary_end_time) - JULIANDAY(recordsummary_start_time)) * 86400000) AS INTEGER), 0)",
+					},
+				},
+				{fieldName: "create_time", columnName: "created_time", direction: "ASC"},
+			},
+		},
+		{
+			name:   "result annotations",
+			in:     `annotations.repo, summary.annotations["tekton.dev/branch"] desc`,
+			fields: resultFields,
+			want: []sortKey{
+				{
+					fieldName:  "annotations.repo",
+					columnName: "COALESCE(annotations->>'repo', '')",
+					direction:  "ASC",
+					kind:       stringValue,
+					path:       []pathSegment{{key: "repo"}},
+				},
+				{
+					fieldName:  `summary.annotations["tekton.dev/branch"]`,
+					columnName: "COALESCE(recordsummary_annotations->>'tekton.dev/branch', '')",
+					direction:  "DESC",
+					kind:       stringValue,
+					path:       []pathSegment{{key: "tekton.dev/branch"}},
+				},
+			},
+		},
+		{
+			name:   "record data",
+			in:     `data.status.completionTime desc, data.status.conditions[0].reason, data.metadata.labels["it's, quoted"]`,
+			fields: recordFields,
+			want: []sortKey{
+				{
+					fieldName:  "data.status.completionTime",
+					columnName: "CASE WHEN SUBSTR(CAST(data->'status'->'completionTime' AS TEXT), 1, 1) IN ('{', '[') THEN '' ELSE COALESCE(data->'status'->>'completionTime', '') END",
+					direction:  "DESC",
+					kind:       stringValue,
+					path:       []pathSegment{{key: "status"}, {key: "completionTime"}},
+				},
+				{
+					fieldName:  "data.status.conditions[0].reason",
+					columnName: "CASE WHEN SUBSTR(CAST(data->'status'->'conditions'->0->'reason' AS TEXT), 1, 1) IN ('{', '[') THEN '' ELSE COALESCE(data->'status'->'conditions'->0->>'reason', '') END",
+					direction:  "ASC",
+					kind:       stringValue,
+					path:       []pathSegment{{key: "status"}, {key: "conditions"}, {index: 0, isIndex: true}, {key: "reason"}},
+				},
+				{
+					fieldName:  `data.metadata.labels["it's, quoted"]`,
+					columnName: "CASE WHEN SUBSTR(CAST(data->'metadata'->'labels'->'it''s, quoted' AS TEXT), 1, 1) IN ('{', '[') THEN '' ELSE COALESCE(data->'metadata'->'labels'->>'it''s, quoted', '') END",
+					direction:  "ASC",
+					kind:       stringValue,
+					path:       []pathSegment{{key: "metadata"}, {key: "labels"}, {key: "it's, quoted"}},
+				},
+			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			gotColumn, gotField, gotDirection, err := parseOrderBy(test.in, resultFieldsToColumns)
+			got, err := parseOrderBy(test.in, test.fields)
 			if err != nil {
 				t.Fatal(err)
 			}
-
-			if test.column != gotColumn {
-				t.Errorf("Want column %q, but got %q", test.column, gotColumn)
-			}
-
-			if test.field != gotField {
-				t.Errorf("Want field %q, but got %q", test.field, gotField)
-			}
-
-			if test.direction != gotDirection {
-				t.Errorf("Want direction %q, but got %q", test.direction, gotDirection)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(sortKey{}, pathSegment{})); diff != "" {
+				t.Errorf("Mismatch in the sort keys (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
 func TestParseOrderByErrors(t *testing.T) {
+	const resultsFormat = "the value must be a comma-separated list of <annotations.<key>|create_time|summary.annotations.<key>|summary.duration|summary.end_time|summary.start_time|summary.status|update_time> [asc|desc]"
+
 	tests := []struct {
-		name string
-		in   string
-		err  error
+		name   string
+		in     string
+		fields *orderFields
+		err    error
 	}{{
-		name: "disallowed field in the order by clause",
-		in:   "id",
-		err:  status.Error(codes.InvalidArgument, "id: field is unknown or cannot be used in the order by clause\nthe value must obey the format <create_time|summary.end_time|summary.start_time|update_time> [asc|desc]"),
+		name:   "disallowed field in the order by clause",
+		in:     "id",
+		fields: resultFields,
+		err:    status.Error(codes.InvalidArgument, "id: field is unknown or cannot be used in the order by clause\n"+resultsFormat),
 	},
 		{
-			name: "invalid order by",
-			in:   "this is invalid",
-			err:  status.Error(codes.InvalidArgument, "invalid order by statement:\nthe value must obey the format <create_time|summary.end_time|summary.start_time|update_time> [asc|desc]"),
+			name:   "invalid order by",
+			in:     "this is invalid",
+			fields: resultFields,
+			err:    status.Error(codes.InvalidArgument, "invalid order by statement:\n"+resultsFormat),
+		},
+		{
+			name:   "invalid direction",
+			in:     "create_time ASCC",
+			fields: resultFields,
+			err:    status.Error(codes.InvalidArgument, "invalid order by statement:\n"+resultsFormat),
 		},
 		{
-			name: "invalid direction",
-			in:   "create_time ASCC",
-			err:  status.Error(codes.InvalidArgument, "invalid order by statement:\nthe value must obey the format <create_time|summary.end_time|summary.start_time|update_time> [asc|desc]"),
+			name:   "empty field in a list",
+			in:     "create_time,,update_time",
+			fields: resultFields,
+			err:    status.Error(codes.InvalidArgument, "invalid order by statement:\n"+resultsFormat),
+		},
+		{
+			name:   "nested annotation key",
+			in:     "annotations.foo.bar",
+			fields: resultFields,
+			err:    status.Error(codes.InvalidArgument, "annotations.foo.bar: field is unknown or cannot be used in the order by clause\n"+resultsFormat),
+		},
+		{
+			name:   "whole JSON column",
+			in:     "data desc",
+			fields: recordFields,
+			err: status.Error(codes.InvalidArgument, "data: field is unknown or cannot be used in the order by clause\n"+
+				"the value must be a comma-separated list of <create_time|data.<path>|update_time> [asc|desc]"),
+		},
+		{
+			name:   "field starting with the name of a JSON column",
+			in:     "data_type",
+			fields: recordFields,
+			err: status.Error(codes.InvalidArgument, "data_type: field is unknown or cannot be used in the order by clause\n"+
+				"the value must be a comma-separated list of <create_time|data.<path>|update_time> [asc|desc]"),
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, _, _, err := parseOrderBy(test.in, resultFieldsToColumns)
+			_, err := parseOrderBy(test.in, test.fields)
 			if err == nil {
 				t.Fatal("want error, but got nil")
 			}
 
-			if gotCode := status.Code(test.err); gotCode != status.Code(test.err) {
+			if gotCode := status.Code(err); gotCode != status.Code(test.err) {
 				t.Fatalf("Want code %d, but got %d", status.Code(test.err), gotCode)
 			}
 
@@ -264,3 +350,129 @@ func TestParseOrderByErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSortKeyValue(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &resultspb.Result{
+		CreateTime:  timestamppb.New(start),
+		Annotations: map[string]string{"repo": "results"},
+		Summary: &resultspb.RecordSummary{
+			Status:      resultspb.RecordSummary_FAILURE,
+			StartTime:   timestamppb.New(start),
+			EndTime:     timestamppb.New(start.Add(90 * time.Second)),
+			Annotations: map[string]string{"branch": "main"},
+		},
+	}
+	record := &resultspb.Record{
+		Data: &resultspb.Any{Value: []byte(`{
+			"metadata": {"labels": {"app": "web"}},
+			"spec": {"timeout": 1.50, "debug": true},
+			"status": {"conditions": [{"reason": "Succeeded"}]}
+		}`)},
+	}
+
+	tests := []struct {
+		in     string
+		fields *orderFields
+		obj    wireObject
+		want   *pagetokenpb.Order
+	}{
+		{
+			in:     "create_time desc",
+			fields: resultFields,
+			obj:    result,
+			want:   &pagetokenpb.Order{FieldName: "create_time", Value: timestamppb.New(start), Direction: pagetokenpb.Order_DESC},
+		},
+		{
+			in:     "summary.status",
+			fields: resultFields,
+			obj:    result,
+			want:   &pagetokenpb.Order{FieldName: "summary.status", IntValue: 2},
+		},
+		{
+			in:     "summary.duration",
+			fields: resultFields,
+			obj:    result,
+			want:   &pagetokenpb.Order{FieldName: "summary.duration", IntValue: 90000},
+		},
+		{
+			in:     "summary.duration",
+			fields: resultFields,
+			obj:    &resultspb.Result{},
+			want:   &pagetokenpb.Order{FieldName: "summary.duration"},
+		},
+		{
+			in:     "annotations.repo",
+			fields: resultFields,
+			obj:    result,
+			want:   &pagetokenpb.Order{FieldName: "annotations.repo", StringValue: "results"},
+		},
+		{
+			in:     "summary.annotations.branch",
+			fields: resultFields,
+			obj:    result,
+			want:   &pagetokenpb.Order{FieldName: "summary.annotations.branch", StringValue: "main"},
+		},
+		{
+			in:     `data.metadata.labels["app"]`,
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: `data.metadata.labels["app"]`, StringValue: "web"},
+		},
+		{
+			in:     "data.spec.timeout",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.spec.timeout", StringValue: "1.50"},
+		},
+		{
+			in:     "data.spec.debug",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.spec.debug", StringValue: "true"},
+		},
+		{
+			in:     "data.status.conditions[0].reason",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.status.conditions[0].reason", StringValue: "Succeeded"},
+		},
+		{
+			in:     "data.status.conditions[1].reason",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.status.conditions[1].reason"},
+		},
+		{
+			in:     "data.status.completionTime",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.status.completionTime"},
+		},
+		// Objects and arrays are sorted as if they were not set.
+		{
+			in:     "data.metadata.labels",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.metadata.labels"},
+		},
+		{
+			in:     "data.status.conditions",
+			fields: recordFields,
+			obj:    record,
+			want:   &pagetokenpb.Order{FieldName: "data.status.conditions"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			keys, err := parseOrderBy(test.in, test.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, keys[0].value(test.obj), protocmp.Transform()); diff != "" {
+				t.Errorf("Mismatch in the value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
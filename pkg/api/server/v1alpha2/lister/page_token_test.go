@@ -31,11 +31,11 @@ func TestEncodeAndDecodePageToken(t *testing.T) {
 		Filter: "summary.status == SUCCESS",
 		LastItem: &pagetokenpb.Item{
 			Uid: "42",
-			OrderBy: &pagetokenpb.Order{
+			OrderBy: []*pagetokenpb.Order{{
 				FieldName: "create_at",
 				Value:     timestamppb.New(time.Now()),
 				Direction: pagetokenpb.Order_ASC,
-			},
+			}},
 		},
 	}
 
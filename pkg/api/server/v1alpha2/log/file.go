@@ -48,6 +48,11 @@ func (*fileStream) Type() string {
 // WriteTo reads the contents of the TaskRun log file and writes them to the provided writer, such
 // as os.Stdout.
 func (fs *fileStream) WriteTo(w io.Writer) (n int64, err error) {
+	return fs.WriteRangeTo(w, 0)
+}
+
+// WriteRangeTo writes the log contents past offset to w.
+func (fs *fileStream) WriteRangeTo(w io.Writer, offset int64) (n int64, err error) {
 	_, err = os.Stat(fs.path)
 	if err != nil {
 		return 0, fmt.Errorf("failed to stat %s: %w", fs.path, err)
@@ -62,6 +67,9 @@ func (fs *fileStream) WriteTo(w io.Writer) (n int64, err error) {
 			err = closeErr
 		}
 	}()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek file %s: %w", fs.path, err)
+	}
 	// Use the buffered reader to ensure file contents are not read entirely into memory
 	reader := bufio.NewReaderSize(file, fs.size)
 	n, err = reader.WriteTo(w)
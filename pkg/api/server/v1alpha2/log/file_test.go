@@ -35,6 +35,25 @@ func TestFileStream_WriteTo(t *testing.T) {
 	}
 }
 
+func TestFileStream_WriteRangeTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("first\nsecond\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := fileStream{
+		path: path,
+	}
+	buffer := &bytes.Buffer{}
+	n, err := stream.WriteRangeTo(buffer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "second\n"; buffer.String() != want || n != int64(len(want)) {
+		t.Errorf("want: %q, got: %q (%d bytes)", want, buffer.String(), n)
+	}
+}
+
 func TestFileStream_ReadFrom(t *testing.T) {
 	want := []byte("test data")
 	buffer := &bytes.Buffer{}
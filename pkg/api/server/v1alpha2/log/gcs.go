@@ -29,7 +29,9 @@ import (
 	server "github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 
+	"gocloud.dev/blob"
 	"gocloud.dev/blob/gcsblob"
+	"gocloud.dev/gcerrors"
 	"gocloud.dev/gcp"
 )
 
@@ -38,6 +40,15 @@ type gcsStream struct {
 	config *server.Config
 	key    string
 	client *gcp.HTTPClient
+	// existing is the size of the log data already stored, which the writer
+	// appends to.
+	existing int64
+	// status is the status of the Log, recording the segments of its data.
+	status *v1alpha3.LogStatus
+
+	bucket *blob.Bucket
+	writer *blob.Writer
+	cancel context.CancelFunc
 }
 
 // NewGCSStream returns a log streamer for the GCS storage type.
@@ -63,10 +74,12 @@ func NewGCSStream(ctx context.Context, log *v1alpha3.Log, config *server.Config)
 	}
 
 	gcs := &gcsStream{
-		ctx:    ctx,
-		config: config,
-		key:    filePath,
-		client: client,
+		ctx:      ctx,
+		config:   config,
+		key:      filePath,
+		client:   client,
+		existing: log.Status.Size,
+		status:   &log.Status,
 	}
 
 	return gcs, nil
@@ -95,6 +108,12 @@ func (*gcsStream) Type() string {
 }
 
 func (gcs *gcsStream) WriteTo(w io.Writer) (n int64, err error) {
+	return gcs.WriteRangeTo(w, 0)
+}
+
+// WriteRangeTo writes the log data past offset to w, reading only the
+// segments holding it.
+func (gcs *gcsStream) WriteRangeTo(w io.Writer, offset int64) (n int64, err error) {
 	bucket, err := gcsblob.OpenBucket(gcs.ctx, gcs.client, gcs.config.GCS_BUCKET_NAME, nil)
 	if err != nil {
 		return 0, fmt.Errorf("could not open bucket: %v", err)
@@ -105,47 +124,122 @@ func (gcs *gcsStream) WriteTo(w io.Writer) (n int64, err error) {
 		}
 	}()
 
-	r, err := bucket.NewReader(gcs.ctx, gcs.key, nil)
-	if err != nil {
-		return 0, fmt.Errorf("could not create bucket reader: %v for the key: %s", err, gcs.key)
-	}
-	n, err = r.WriteTo(w)
-	if err != nil {
-		return 0, fmt.Errorf("could not read data from bucket: %v for the key: %s", err, gcs.key)
+	for i, r := range segmentRanges(gcs.key, gcs.segments(), offset) {
+		reader, err := bucket.NewRangeReader(gcs.ctx, r.key, r.offset, -1, nil)
+		if i > 0 && gcerrors.Code(err) == gcerrors.NotFound {
+			// The last segment is recorded before it is written.
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("could not create bucket reader: %v for the key: %s", err, r.key)
+		}
+		written, err := reader.WriteTo(w)
+		reader.Close() //nolint:errcheck
+		n += written
+		if err != nil {
+			return n, fmt.Errorf("could not read data from bucket: %v for the key: %s", err, r.key)
+		}
 	}
 	return n, nil
 }
 
-func (gcs *gcsStream) ReadFrom(r io.Reader) (n int64, err error) {
-	bucket, err := gcsblob.OpenBucket(gcs.ctx, gcs.client, gcs.config.GCS_BUCKET_NAME, nil)
-	if err != nil {
-		return 0, fmt.Errorf("could not open bucket: %v for the key: %s", err, gcs.key)
+// segments returns the offsets at which the segments of the log data start,
+// past the first object.
+func (gcs *gcsStream) segments() []int64 {
+	if gcs.status == nil {
+		return nil
 	}
-	defer func() {
-		if cerr := bucket.Close(); cerr != nil && err == nil {
-			err = fmt.Errorf("could not close bucket: %w for the key: %s", cerr, gcs.key)
-		}
-	}()
+	return gcs.status.Segments
+}
 
-	w, err := bucket.NewWriter(gcs.ctx, gcs.key, nil)
-	if err != nil {
-		return 0, fmt.Errorf("could not create bucket writer: %v for the key: %s", err, gcs.key)
+// segmentKey returns the key of the object holding the log data written from
+// start on. The data written first is stored at key.
+func segmentKey(key string, start int64) string {
+	if start == 0 {
+		return key
 	}
-	defer func() {
-		err = w.Close()
-		if err != nil {
-			err = fmt.Errorf("could not flush data to bucket: %v for the key: %s", err, gcs.key)
+	return fmt.Sprintf("%s.segment-%d", key, start)
+}
+
+// segmentRange is the part of a segment object to read.
+type segmentRange struct {
+	key    string
+	offset int64
+}
+
+// segmentRanges returns the parts of the objects to read for the log data
+// past offset, given the offsets at which its segments start.
+func segmentRanges(key string, segments []int64, offset int64) []segmentRange {
+	starts := append([]int64{0}, segments...)
+	var ranges []segmentRange
+	for i, start := range starts {
+		if i+1 < len(starts) && starts[i+1] <= offset {
+			continue
 		}
-	}()
+		ranges = append(ranges, segmentRange{key: segmentKey(key, start), offset: max(offset-start, 0)})
+	}
+	return ranges
+}
 
-	n, err = w.ReadFrom(r)
+// ReadFrom appends the log contents read from r to the log. The data is only
+// stored once the stream is flushed.
+func (gcs *gcsStream) ReadFrom(r io.Reader) (n int64, err error) {
+	if gcs.writer == nil {
+		if err := gcs.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err = gcs.writer.ReadFrom(r)
 	if err != nil {
 		return 0, fmt.Errorf("could not write data to bucket: %v for the key: %s", err, gcs.key)
 	}
 	return n, nil
 }
 
+// open creates the writer of the data appended to the log. GCS objects can't
+// be appended to, so data appended to a stored log is written to a new
+// segment object, which is recorded in the status of the log and joined with
+// the previous ones on read.
+func (gcs *gcsStream) open() error {
+	bucket, err := gcsblob.OpenBucket(gcs.ctx, gcs.client, gcs.config.GCS_BUCKET_NAME, nil)
+	if err != nil {
+		return fmt.Errorf("could not open bucket: %v for the key: %s", err, gcs.key)
+	}
+	key := segmentKey(gcs.key, gcs.existing)
+	// Canceling the context of the writer discards what was written.
+	ctx, cancel := context.WithCancel(gcs.ctx)
+	w, err := bucket.NewWriter(ctx, key, nil)
+	if err != nil {
+		cancel()
+		bucket.Close() //nolint:errcheck
+		return fmt.Errorf("could not create bucket writer: %v for the key: %s", err, key)
+	}
+	if gcs.existing > 0 && gcs.status != nil {
+		// A segment left over by a failed attempt is replaced.
+		if segments := gcs.status.Segments; len(segments) == 0 || segments[len(segments)-1] != gcs.existing {
+			gcs.status.Segments = append(segments, gcs.existing)
+		}
+	}
+	gcs.bucket = bucket
+	gcs.writer = w
+	gcs.cancel = cancel
+	return nil
+}
+
+// Flush writes the object, if anything was read.
 func (gcs *gcsStream) Flush() error {
+	if gcs.writer == nil {
+		return nil
+	}
+	err := gcs.writer.Close()
+	gcs.writer = nil
+	gcs.cancel()
+	if cerr := gcs.bucket.Close(); cerr != nil && err == nil {
+		return fmt.Errorf("could not close bucket: %w for the key: %s", cerr, gcs.key)
+	}
+	if err != nil {
+		return fmt.Errorf("could not flush data to bucket: %v for the key: %s", err, gcs.key)
+	}
 	return nil
 }
 
@@ -163,5 +257,11 @@ func (gcs *gcsStream) Delete() (err error) {
 	if err := bucket.Delete(gcs.ctx, gcs.key); err != nil {
 		return fmt.Errorf("could not delete bucket data: %v for the key: %s", err, gcs.key)
 	}
+	for _, start := range gcs.segments() {
+		key := segmentKey(gcs.key, start)
+		if err := bucket.Delete(gcs.ctx, key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return fmt.Errorf("could not delete bucket data: %v for the key: %s", err, key)
+		}
+	}
 	return nil
 }
@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-replayers/httpreplay"
 	hrgoog "github.com/google/go-replayers/httpreplay/google"
 	server "github.com/tektoncd/results/pkg/api/server/config"
@@ -138,6 +139,9 @@ func TestGCSReadFrom(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to write to gcs: %v", err)
 	}
+	if err := gcs.Flush(); err != nil {
+		t.Fatalf("failed to flush to gcs: %v", err)
+	}
 }
 
 func TestGCSWriteTo(t *testing.T) {
@@ -179,3 +183,47 @@ func TestGCSDelete(t *testing.T) {
 		t.Fatalf("failed to delete key: %s", gcs.key)
 	}
 }
+
+func TestSegmentRanges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		segments []int64
+		offset   int64
+		want     []segmentRange
+	}{{
+		name: "single object",
+		want: []segmentRange{{key: gcsTestKey}},
+	}, {
+		name:   "single object past offset",
+		offset: 5,
+		want:   []segmentRange{{key: gcsTestKey, offset: 5}},
+	}, {
+		name:     "all segments",
+		segments: []int64{10, 25},
+		want: []segmentRange{
+			{key: gcsTestKey},
+			{key: gcsTestKey + ".segment-10"},
+			{key: gcsTestKey + ".segment-25"},
+		},
+	}, {
+		name:     "offset within a segment",
+		segments: []int64{10, 25},
+		offset:   12,
+		want: []segmentRange{
+			{key: gcsTestKey + ".segment-10", offset: 2},
+			{key: gcsTestKey + ".segment-25"},
+		},
+	}, {
+		name:     "offset at the last segment",
+		segments: []int64{10, 25},
+		offset:   25,
+		want:     []segmentRange{{key: gcsTestKey + ".segment-25"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := segmentRanges(gcsTestKey, tc.segments, tc.offset)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(segmentRange{})); diff != "" {
+				t.Errorf("segmentRanges() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
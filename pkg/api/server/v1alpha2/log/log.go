@@ -53,6 +53,44 @@ type Stream interface {
 	Flush() error
 }
 
+// RangeWriter is implemented by Streams which can write the log data past an
+// offset without reading the data before it.
+type RangeWriter interface {
+	WriteRangeTo(w io.Writer, offset int64) (int64, error)
+}
+
+// WriteRangeTo writes the data of stream past offset to w, and returns the
+// number of bytes written.
+func WriteRangeTo(stream Stream, w io.Writer, offset int64) (int64, error) {
+	if r, ok := stream.(RangeWriter); ok {
+		return r.WriteRangeTo(w, offset)
+	}
+	sw := &skipWriter{w: w, skip: offset}
+	n, err := stream.WriteTo(sw)
+	// The bytes skipped are not reported as written.
+	return n - (offset - sw.skip), err
+}
+
+// skipWriter discards the first skip bytes written to it.
+type skipWriter struct {
+	w    io.Writer
+	skip int64
+}
+
+func (s *skipWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if s.skip >= int64(n) {
+		s.skip -= int64(n)
+		return n, nil
+	}
+	p = p[s.skip:]
+	s.skip = 0
+	if _, err := s.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
 // NewStream returns a LogStreamer for the given Log.
 // LogStreamers do the following:
 //
@@ -243,3 +243,38 @@ func TestToStream(t *testing.T) {
 		})
 	}
 }
+
+// dataStream is a Stream which can only write its data from the start.
+type dataStream struct {
+	mockStream
+	data string
+}
+
+func (s *dataStream) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, s.data)
+	return int64(n), err
+}
+
+func TestWriteRangeTo(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		offset int64
+		want   string
+	}{
+		{name: "from start", want: "first\nsecond\n"},
+		{name: "past offset", offset: 6, want: "second\n"},
+		{name: "at end", offset: 13},
+		{name: "past end", offset: 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			n, err := WriteRangeTo(&dataStream{data: "first\nsecond\n"}, &buffer, tc.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buffer.String() != tc.want || n != int64(len(tc.want)) {
+				t.Errorf("want: %q, got: %q (%d bytes)", tc.want, buffer.String(), n)
+			}
+		})
+	}
+}
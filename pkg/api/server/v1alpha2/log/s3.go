@@ -8,6 +8,7 @@ import (
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -30,6 +31,7 @@ type s3Client interface {
 	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
 	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	UploadPart(context.Context, *s3.UploadPartInput, ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	UploadPartCopy(context.Context, *s3.UploadPartCopyInput, ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
 }
 
 type s3Stream struct {
@@ -45,6 +47,9 @@ type s3Stream struct {
 	uploadID      string
 	parts         []types.CompletedPart
 	multiPartSize int64
+	// existing is the size of the log data already stored, which the upload
+	// appends to.
+	existing int64
 }
 
 // NewS3Stream returns a log streamer for the S3 log storage type.
@@ -95,6 +100,7 @@ func NewS3Stream(ctx context.Context, log *v1alpha3.Log, config *server.Config)
 		client:        client,
 		partNumber:    1,
 		multiPartSize: multiPartSize,
+		existing:      log.Status.Size,
 	}
 
 	return s3s, nil
@@ -135,10 +141,20 @@ func (*s3Stream) Type() string {
 }
 
 func (s3s *s3Stream) WriteTo(w io.Writer) (n int64, err error) {
-	outPut, err := s3s.client.GetObject(s3s.ctx, &s3.GetObjectInput{
+	return s3s.WriteRangeTo(w, 0)
+}
+
+// WriteRangeTo writes the log contents past offset to w, requesting only that
+// range of the object.
+func (s3s *s3Stream) WriteRangeTo(w io.Writer, offset int64) (n int64, err error) {
+	input := &s3.GetObjectInput{
 		Bucket: &s3s.bucket,
 		Key:    &s3s.key,
-	})
+	}
+	if offset > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+	outPut, err := s3s.client.GetObject(s3s.ctx, input)
 	if err != nil {
 		return 0, err
 	}
@@ -157,7 +173,15 @@ func (s3s *s3Stream) WriteTo(w io.Writer) (n int64, err error) {
 	return
 }
 
+// ReadFrom appends the log contents read from r to the object. S3 objects
+// can't be appended to, so the existing content is copied as the first part of
+// the upload replacing the object.
 func (s3s *s3Stream) ReadFrom(r io.Reader) (int64, error) {
+	if s3s.existing > 0 {
+		if err := s3s.copyExisting(); err != nil {
+			return 0, err
+		}
+	}
 	n, err := s3s.buffer.ReadFrom(r)
 	if err != nil {
 		return 0, err
@@ -175,7 +199,44 @@ func (s3s *s3Stream) ReadFrom(r io.Reader) (int64, error) {
 		s3s.partSize = size
 	}
 
-	return s3s.partSize, err
+	return n, err
+}
+
+// copyExisting adds the content already stored to the upload: large objects
+// are copied server side, while small ones are read back into the buffer as
+// S3 doesn't accept parts smaller than the multipart size except for the last.
+func (s3s *s3Stream) copyExisting() error {
+	existing := s3s.existing
+	s3s.existing = 0
+	if existing < s3s.multiPartSize {
+		out, err := s3s.client.GetObject(s3s.ctx, &s3.GetObjectInput{
+			Bucket: &s3s.bucket,
+			Key:    &s3s.key,
+		})
+		if err != nil {
+			return err
+		}
+		defer out.Body.Close()
+		n, err := s3s.buffer.ReadFrom(out.Body)
+		s3s.partSize += n
+		return err
+	}
+
+	partNumber := s3s.partNumber
+	source := s3s.bucket + "/" + s3s.key
+	part, err := s3s.client.UploadPartCopy(s3s.ctx, &s3.UploadPartCopyInput{
+		UploadId:   &s3s.uploadID,
+		Bucket:     &s3s.bucket,
+		Key:        &s3s.key,
+		PartNumber: &partNumber,
+		CopySource: &source,
+	})
+	if err != nil {
+		return err
+	}
+	s3s.parts = append(s3s.parts, types.CompletedPart{PartNumber: &partNumber, ETag: part.CopyPartResult.ETag})
+	s3s.partNumber++
+	return nil
 }
 
 func (s3s *s3Stream) uploadMultiPart(reader io.Reader, partNumber int32, partSize int64) error {
@@ -213,6 +274,15 @@ func (s3s *s3Stream) Flush() error {
 	if err := s3s.uploadMultiPart(&s3s.buffer, s3s.partNumber, int64(s3s.buffer.Len())); err != nil {
 		return err
 	}
+	if len(s3s.parts) == 0 {
+		// Nothing was written, leave the object as it is.
+		_, err := s3s.client.AbortMultipartUpload(s3s.ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   &s3s.bucket,
+			Key:      &s3s.key,
+			UploadId: &s3s.uploadID,
+		})
+		return err
+	}
 
 	_, err := s3s.client.CompleteMultipartUpload(s3s.ctx, &s3.CompleteMultipartUploadInput{
 		Bucket:   &s3s.bucket,
@@ -3,11 +3,14 @@ package log
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	server "github.com/tektoncd/results/pkg/api/server/config"
 )
 
@@ -15,6 +18,7 @@ type mockS3Client struct {
 	bucket     string
 	key        string
 	body       []byte
+	upload     []byte
 	uploadID   string
 	partNumber int32
 	t          *testing.T
@@ -27,11 +31,13 @@ func (m *mockS3Client) AbortMultipartUpload(ctx context.Context, params *s3.Abor
 
 func (m *mockS3Client) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) { //nolint:revive
 	m.checkParams(params.Bucket, params.Key)
+	m.body = m.upload
 	return &s3.CompleteMultipartUploadOutput{}, nil
 }
 
 func (m *mockS3Client) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) { //nolint:revive
 	m.checkParams(params.Bucket, params.Key)
+	m.upload = nil
 	return &s3.CreateMultipartUploadOutput{UploadId: &m.uploadID}, nil
 }
 
@@ -43,8 +49,16 @@ func (m *mockS3Client) DeleteObject(ctx context.Context, params *s3.DeleteObject
 
 func (m *mockS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) { //nolint:revive
 	m.checkParams(params.Bucket, params.Key)
+	body := m.body
+	if params.Range != nil {
+		var start int
+		if _, err := fmt.Sscanf(*params.Range, "bytes=%d-", &start); err != nil {
+			m.t.Errorf("unexpected range %q", *params.Range)
+		}
+		body = body[min(start, len(body)):]
+	}
 	return &s3.GetObjectOutput{
-		Body: io.NopCloser(bytes.NewReader(m.body)),
+		Body: io.NopCloser(bytes.NewReader(body)),
 	}, nil
 }
 
@@ -60,11 +74,21 @@ func (m *mockS3Client) UploadPart(ctx context.Context, params *s3.UploadPartInpu
 	if *params.ContentLength == 0 {
 		m.t.Errorf("ContentLength must be > 0: got %d", *params.ContentLength)
 	}
-	m.body = append(m.body, buffer.Bytes()...)
+	m.upload = append(m.upload, buffer.Bytes()...)
 	e := strconv.Itoa(int(m.partNumber))
 	return &s3.UploadPartOutput{ETag: &e}, nil
 }
 
+func (m *mockS3Client) UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error) { //nolint:revive
+	m.checkParams(params.Bucket, params.Key)
+	if want := m.bucket + "/" + m.key; *params.CopySource != want {
+		m.t.Errorf("CopySource: got %s, want %s", *params.CopySource, want)
+	}
+	m.upload = append(m.upload, m.body...)
+	e := strconv.Itoa(int(m.partNumber))
+	return &s3.UploadPartCopyOutput{CopyPartResult: &types.CopyPartResult{ETag: &e}}, nil
+}
+
 func (m *mockS3Client) checkParams(bucket, key *string) {
 	m.t.Helper()
 	if bucket == nil {
@@ -109,6 +133,33 @@ func TestS3Stream_WriteTo(t *testing.T) {
 	}
 }
 
+func TestS3Stream_WriteRangeTo(t *testing.T) {
+	c := &server.Config{
+		S3_BUCKET_NAME: "test-bucket",
+	}
+	filePath := "test"
+	s := &s3Stream{
+		config: c,
+		bucket: c.S3_BUCKET_NAME,
+		key:    filePath,
+		client: &mockS3Client{
+			t:      t,
+			bucket: c.S3_BUCKET_NAME,
+			key:    filePath,
+			body:   []byte("first\nsecond\n"),
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	n, err := s.WriteRangeTo(buffer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "second\n"; buffer.String() != want || n != int64(len(want)) {
+		t.Errorf("want: %q, got: %q (%d bytes)", want, buffer.String(), n)
+	}
+}
+
 func TestS3Stream_ReadFrom(t *testing.T) {
 	want := "test body of multi-part upload  40 bytes"
 	const DefaultBufferSize = 10
@@ -175,6 +226,88 @@ func TestS3Stream_ReadFrom(t *testing.T) {
 	}
 }
 
+func TestS3Stream_ReadFromAppend(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		existing      string
+		multiPartSize int64
+	}{
+		{
+			name:          "small log read back",
+			existing:      "first segment\n",
+			multiPartSize: 1024,
+		},
+		{
+			name:          "large log copied",
+			existing:      "first segment\n",
+			multiPartSize: 5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &server.Config{
+				S3_BUCKET_NAME: "test-bucket",
+			}
+			client := &mockS3Client{
+				t:        t,
+				bucket:   c.S3_BUCKET_NAME,
+				key:      "test",
+				body:     []byte(tc.existing),
+				uploadID: "test-upload-id",
+			}
+			s := &s3Stream{
+				config:        c,
+				bucket:        c.S3_BUCKET_NAME,
+				key:           "test",
+				multiPartSize: tc.multiPartSize,
+				partNumber:    1,
+				uploadID:      client.uploadID,
+				client:        client,
+				existing:      int64(len(tc.existing)),
+			}
+
+			n, err := s.ReadFrom(strings.NewReader("second segment\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 15 {
+				t.Errorf("ReadFrom: got %d bytes, want 15", n)
+			}
+			if err := s.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(client.body), tc.existing+"second segment\n"; got != want {
+				t.Errorf("want: %q, got: %q", want, got)
+			}
+		})
+	}
+}
+
+func TestS3Stream_FlushEmpty(t *testing.T) {
+	c := &server.Config{
+		S3_BUCKET_NAME: "test-bucket",
+	}
+	client := &mockS3Client{
+		t:      t,
+		bucket: c.S3_BUCKET_NAME,
+		key:    "test",
+		body:   []byte("stored"),
+	}
+	s := &s3Stream{
+		config:        c,
+		bucket:        c.S3_BUCKET_NAME,
+		key:           "test",
+		multiPartSize: 1024,
+		partNumber:    1,
+		client:        client,
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(client.body); got != "stored" {
+		t.Errorf("object changed to %q", got)
+	}
+}
+
 func TestS3Stream_Delete(t *testing.T) {
 	c := &server.Config{
 		S3_BUCKET_NAME: "test-bucket",
@@ -6,12 +6,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/cel-go/cel"
+	"github.com/tektoncd/results/pkg/api/server/audit"
 	celenv "github.com/tektoncd/results/pkg/api/server/cel"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
+	"github.com/tektoncd/results/pkg/api/server/redact"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,6 +23,7 @@ import (
 
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/apis/v1alpha3"
@@ -27,6 +32,15 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var (
+	// logFollowInterval is how often followed logs are checked for new data.
+	logFollowInterval = time.Second
+	// logFollowIdleTimeout is how long followed logs are checked without
+	// change before they stop being followed, as the run may never stop
+	// streaming to them if the watcher stopped or the run was deleted.
+	logFollowIdleTimeout = 10 * time.Minute
+)
+
 // GetLog streams log record by log request
 func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 	parent, res, name, err := log.ParseName(req.GetName())
@@ -41,14 +55,29 @@ func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 		return err
 	}
 
-	rec, err := getRecord(s.db, parent, res, name)
+	rec, err := getRecord(s.inCluster(srv.Context(), s.db.WithContext(srv.Context())), parent, res, name)
 	if err != nil {
 		s.logger.Error(err)
 		return err
 	}
+	if err := s.checkCluster(srv.Context(), rec.Cluster, parent, auth.ResourceLogs, auth.PermissionGet); err != nil {
+		s.logger.Error(err)
+		return err
+	}
+	if err := s.checkRecordConstraint(srv.Context(), rec); err != nil {
+		s.logger.Error(err)
+		return err
+	}
 	// Check if the input record is referenced in any logs record in the result
 	if rec.Type != v1alpha3.LogRecordType && rec.Type != v1alpha3.LogRecordTypeV2 {
-		rec, err = getLogRecord(s.db, parent, res, name)
+		rec, err = getLogRecord(s.inCluster(srv.Context(), s.db.WithContext(srv.Context())), parent, res, name)
+		if err != nil {
+			s.logger.Error(err)
+			return err
+		}
+	}
+	if req.GetStep() != "" {
+		rec, err = getStepLogRecord(s.inCluster(srv.Context(), s.db.WithContext(srv.Context())), rec, req.GetStep())
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -61,8 +90,13 @@ func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 		return status.Error(codes.Internal, "Error streaming log")
 	}
 
+	// Logs of running runs may be followed before anything is stored.
+	follow := req.GetFollow() && object.Status.IsStreaming
+
 	// Handle v1alpha2 and earlier differently from v1alpha3 until v1alpha2 and earlier are deprecated
-	if object.APIVersion == "results.tekton.dev/v1alpha3" {
+	if follow {
+		s.logger.Debugf("following log %s", req.GetName())
+	} else if object.APIVersion == "results.tekton.dev/v1alpha3" {
 		if !object.Status.IsStored || object.Status.Size == 0 {
 			s.logger.Errorf("no logs exist for %s", req.GetName())
 			return status.Error(codes.NotFound, "Log doesn't exist")
@@ -77,9 +111,21 @@ func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 	}
 
 	writer := logs.NewBufferedHTTPWriter(srv, req.GetName(), s.config.LOGS_BUFFER_SIZE)
-	if _, err = stream.WriteTo(writer); err != nil {
-		s.logger.Error(err)
-		return status.Error(codes.Internal, "Error streaming log")
+	var sent int64
+	if object.Status.Size > 0 {
+		if sent, err = stream.WriteTo(writer); err != nil {
+			s.logger.Error(err)
+			return status.Error(codes.Internal, "Error streaming log")
+		}
+	}
+	if follow {
+		if err := s.followLog(srv.Context(), rec, writer, sent); err != nil {
+			s.logger.Error(err)
+			if status.Code(err) == codes.Canceled {
+				return err
+			}
+			return status.Error(codes.Internal, "Error streaming log")
+		}
 	}
 	_, err = writer.Flush()
 	if err != nil {
@@ -89,11 +135,59 @@ func (s *Server) GetLog(req *pb.GetLogRequest, srv pb.Logs_GetLogServer) error {
 	return nil
 }
 
+// followLog sends the data appended to the log stored in rec, past the sent
+// bytes already sent, until the run stops streaming to it or the log isn't
+// updated for logFollowIdleTimeout. Only the new data is read from the storage.
+func (s *Server) followLog(ctx context.Context, rec *db.Record, w *logs.BufferedLog, sent int64) error {
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	updated, changed := rec.UpdatedTime, time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+
+		current, err := getRecord(s.db.WithContext(ctx).Where("cluster = ?", rec.Cluster), rec.Parent, rec.ResultName, rec.Name)
+		if err != nil {
+			return err
+		}
+		// The stream is created from the current record, as where the data
+		// appended is stored may be recorded in its status.
+		stream, object, err := log.ToStream(ctx, current, s.config)
+		if err != nil {
+			return err
+		}
+		if object.Status.Size > sent {
+			n, err := log.WriteRangeTo(stream, w, sent)
+			if err != nil {
+				return err
+			}
+			sent += n
+			// Send what was read right away rather than once the buffer is full.
+			if _, err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		if !object.Status.IsStreaming {
+			return nil
+		}
+		if !current.UpdatedTime.Equal(updated) {
+			updated, changed = current.UpdatedTime, time.Now()
+		} else if time.Since(changed) > logFollowIdleTimeout {
+			s.logger.Warnf("Stopped following log %s, not updated for %s", rec.Name, logFollowIdleTimeout)
+			return nil
+		}
+	}
+}
+
 func getLogRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error) {
 	store := &db.Record{}
 	q := txn.
 		Where(&db.Record{Result: db.Result{Parent: parent, Name: result}}).
 		Where("data -> 'spec' -> 'resource' ->> 'uid' =  ?", name).
+		Where("data -> 'spec' -> 'step' IS NULL").
 		First(store)
 	if err := errors.Wrap(q.Error); err != nil {
 		return nil, err
@@ -101,6 +195,117 @@ func getLogRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error)
 	return store, nil
 }
 
+// stepLogs returns a query for the logs of the steps of the resource whose
+// log is stored in rec.
+func stepLogs(txn *gorm.DB, rec *db.Record) (*gorm.DB, error) {
+	object := &v1alpha3.Log{}
+	if err := json.Unmarshal(rec.Data, object); err != nil {
+		return nil, status.Errorf(codes.Internal, "could not decode Log record: %v", err)
+	}
+	return txn.
+		Where(&db.Record{Parent: rec.Parent, ResultID: rec.ResultID, Type: v1alpha3.LogRecordType}).
+		Where("data -> 'spec' -> 'resource' ->> 'uid' = ?", string(object.Spec.Resource.UID)).
+		Where("data -> 'spec' -> 'step' IS NOT NULL"), nil
+}
+
+// getStepLogRecord returns the log of the named step of the resource whose
+// log is stored in rec.
+func getStepLogRecord(txn *gorm.DB, rec *db.Record, step string) (*db.Record, error) {
+	q, err := stepLogs(txn, rec)
+	if err != nil {
+		return nil, err
+	}
+	store := &db.Record{}
+	q = q.Where("data -> 'spec' -> 'step' ->> 'name' = ?", step).First(store)
+	if err := errors.Wrap(q.Error); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// ListStepLogs lists the logs of the steps of a run, given the log of the run.
+func (s *Server) ListStepLogs(ctx context.Context, req *pb.ListStepLogsRequest) (*pb.ListStepLogsResponse, error) {
+	parent, res, name, err := log.ParseName(req.GetName())
+	if err != nil {
+		s.logger.Error(err)
+		return nil, status.Error(codes.InvalidArgument, "Invalid Name")
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceLogs, auth.PermissionList); err != nil {
+		s.logger.Debug(err)
+		// unauthenticated status code and debug message produced by Check
+		return nil, err
+	}
+
+	txn := s.db.WithContext(ctx)
+	rec, err := getRecord(s.inCluster(ctx, txn), parent, res, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCluster(ctx, rec.Cluster, parent, auth.ResourceLogs, auth.PermissionList); err != nil {
+		return nil, err
+	}
+	if err := s.checkRecordConstraint(ctx, rec); err != nil {
+		return nil, err
+	}
+	// Check if the input record is referenced in any logs record in the result
+	if rec.Type != v1alpha3.LogRecordType {
+		rec, err = getLogRecord(s.inCluster(ctx, txn), parent, res, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	q, err := stepLogs(txn, rec)
+	if err != nil {
+		return nil, err
+	}
+	var records []*db.Record
+	if err := errors.Wrap(q.Order("created_time").Find(&records).Error); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListStepLogsResponse{}
+	for _, r := range records {
+		stepLog, err := toStepLog(r)
+		if err != nil {
+			s.logger.Error(err)
+			return nil, status.Error(codes.Internal, "Error reading step log")
+		}
+		resp.StepLogs = append(resp.StepLogs, stepLog)
+	}
+	// Steps run one after another, so list them in that order.
+	sort.SliceStable(resp.StepLogs, func(i, j int) bool {
+		a, b := resp.StepLogs[i].GetStartTime(), resp.StepLogs[j].GetStartTime()
+		return a != nil && (b == nil || a.AsTime().Before(b.AsTime()))
+	})
+	return resp, nil
+}
+
+// toStepLog converts the log of a step stored in rec to its API form.
+func toStepLog(rec *db.Record) (*pb.StepLog, error) {
+	object := &v1alpha3.Log{}
+	if err := json.Unmarshal(rec.Data, object); err != nil {
+		return nil, fmt.Errorf("could not decode Log record: %w", err)
+	}
+	step := object.Spec.Step
+	out := &pb.StepLog{
+		Name:      log.FormatName(result.FormatName(rec.Parent, rec.ResultName), rec.Name),
+		Step:      step.Name,
+		Container: step.Container,
+		Size:      object.Status.Size,
+	}
+	if step.ExitCode != nil {
+		out.ExitCode = *step.ExitCode
+	}
+	if step.StartTime != nil {
+		out.StartTime = timestamppb.New(step.StartTime.Time)
+	}
+	if step.FinishTime != nil {
+		out.FinishTime = timestamppb.New(step.FinishTime.Time)
+	}
+	return out, nil
+}
+
 // UpdateLog updates log record content
 func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 	var name, parent, resultName, recordName string
@@ -108,6 +313,7 @@ func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 	var rec *db.Record
 	var object *v1alpha3.Log
 	var stream log.Stream
+	var redaction *redact.Session
 	// fyi we cannot defer the flush call in case we need to return the error
 	// but instead we pass the stream into handleError to preserve the behavior of
 	// calling Flush regardless when we previously called Flush via defer
@@ -116,6 +322,15 @@ func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 		recv, err := srv.Recv()
 		// If we reach the end of the srv, we receive an io.EOF error
 		if err != nil {
+			if redaction != nil {
+				// Store the last line held back for redaction.
+				written, flushErr := stream.ReadFrom(bytes.NewBuffer(redaction.Flush()))
+				bytesWritten += written
+				object.Status.Redactions += redaction.Count()
+				if flushErr != nil && err == io.EOF {
+					err = flushErr
+				}
+			}
 			return s.handleReturn(srv, rec, object, bytesWritten, stream, err, true)
 		}
 		// Ensure that we are receiving logs for the same record
@@ -143,10 +358,17 @@ func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 		}
 
 		if rec == nil {
-			rec, err = getRecord(s.db.WithContext(srv.Context()), parent, resultName, recordName)
+			rec, err = getRecord(s.inCluster(srv.Context(), s.db.WithContext(srv.Context())), parent, resultName, recordName)
 			if err != nil {
 				return s.handleReturn(srv, rec, object, bytesWritten, stream, err, true)
 			}
+			if err = s.checkCluster(srv.Context(), rec.Cluster, parent, auth.ResourceLogs, auth.PermissionUpdate); err != nil {
+				return s.handleReturn(srv, rec, object, bytesWritten, stream, err, false)
+			}
+			if err = s.checkRecordConstraint(srv.Context(), rec); err != nil {
+				return s.handleReturn(srv, rec, object, bytesWritten, stream, err, false)
+			}
+			audit.SetEtagBefore(srv.Context(), rec.Etag)
 		}
 
 		if stream == nil {
@@ -156,7 +378,19 @@ func (s *Server) UpdateLog(srv pb.Logs_UpdateLogServer) error {
 			}
 		}
 
-		buffer := bytes.NewBuffer(recv.GetData())
+		// The last message tells whether the run is still writing logs.
+		object.Status.IsStreaming = recv.GetPartial()
+
+		data := recv.GetData()
+		if redaction == nil && (s.redactor != nil || len(recv.GetRedactionHints()) > 0) {
+			redaction = s.redactor.NewSession(nil)
+		}
+		if redaction != nil {
+			redaction.AddHints(recv.GetRedactionHints())
+			data = redaction.Redact(data)
+		}
+
+		buffer := bytes.NewBuffer(data)
 		var written int64
 		written, err = stream.ReadFrom(buffer)
 		bytesWritten += written
@@ -189,7 +423,8 @@ func (s *Server) handleReturn(srv pb.Logs_UpdateLogServer, rec *db.Record, log *
 	}
 	apiRec := record.ToAPI(rec)
 	apiRec.UpdateTime = timestamppb.Now()
-	log.Status.Size = written
+	// Log data is appended to what previous calls stored.
+	log.Status.Size += written
 	log.Status.IsStored = returnErr == io.EOF
 	if returnErr != nil && returnErr != io.EOF {
 		log.Status.ErrorOnStoreMsg = returnErr.Error()
@@ -298,8 +533,12 @@ func (s *Server) ListLogs(ctx context.Context, req *pb.ListRecordsRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
 	// Fetch n+1 items to get the next token.
-	rec, err := s.getFilteredPaginatedSortedLogRecords(ctx, req.GetParent(), start, userPageSize+1, prg, sortOrder)
+	rec, err := s.getFilteredPaginatedSortedLogRecords(ctx, req.GetParent(), start, userPageSize+1, prg, sortOrder, constraint)
 	if err != nil {
 		return nil, err
 	}
@@ -324,8 +563,9 @@ func (s *Server) ListLogs(ctx context.Context, req *pb.ListRecordsRequest) (*pb.
 }
 
 // getFilteredPaginatedSortedLogRecords returns the specified number of results that
-// match the given CEL program.
-func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, parent, start string, pageSize int, prg cel.Program, sortOrder string) ([]*pb.Record, error) {
+// match the given CEL program, among those matching the constraint imposed on
+// the caller.
+func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, parent, start string, pageSize int, prg cel.Program, sortOrder, constraint string) ([]*pb.Record, error) {
 	parent, resultName, err := result.ParseName(parent)
 	if err != nil {
 		return nil, err
@@ -346,6 +586,10 @@ func (s *Server) getFilteredPaginatedSortedLogRecords(ctx context.Context, paren
 		if resultName != "-" {
 			q = q.Where("result_name = ?", resultName)
 		}
+		q, err := lister.Constrain(q, s.recordsEnv, constraint)
+		if err != nil {
+			return nil, err
+		}
 		if sortOrder != "" {
 			q = q.Order(sortOrder)
 		}
@@ -401,17 +645,24 @@ func (s *Server) DeleteLog(ctx context.Context, req *pb.DeleteLogRequest) (*empt
 	}
 
 	// Check in the input record exists in the database
-	rec, err := getRecord(s.db, parent, res, name)
+	rec, err := getRecord(s.inCluster(ctx, s.db.WithContext(ctx)), parent, res, name)
 	if err != nil {
 		return &empty.Empty{}, err
 	}
+	if err := s.checkCluster(ctx, rec.Cluster, parent, auth.ResourceLogs, auth.PermissionDelete); err != nil {
+		return &empty.Empty{}, err
+	}
+	if err := s.checkRecordConstraint(ctx, rec); err != nil {
+		return &empty.Empty{}, err
+	}
 	// Check if the input record is referenced in any logs record
 	if rec.Type != v1alpha3.LogRecordType {
-		rec, err = getLogRecord(s.db, parent, res, name)
+		rec, err = getLogRecord(s.inCluster(ctx, s.db.WithContext(ctx)), parent, res, name)
 		if err != nil {
 			return &empty.Empty{}, err
 		}
 	}
+	audit.SetEtagBefore(ctx, rec.Etag)
 
 	streamer, _, err := log.ToStream(ctx, rec, s.config)
 	if err != nil {
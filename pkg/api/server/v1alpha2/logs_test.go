@@ -3,6 +3,7 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -18,17 +19,23 @@ import (
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/redact"
 	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
 	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	"github.com/tektoncd/results/pkg/internal/jsonutil"
+	"github.com/tektoncd/results/pkg/logs"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"google.golang.org/grpc"
 )
@@ -56,6 +63,8 @@ type mockUpdateLogServer struct {
 	ctx           context.Context
 	record        *pb.Record
 	logStream     []string
+	partial       bool
+	hints         []string
 	bytesReceived int64
 }
 
@@ -69,8 +78,10 @@ func (m *mockUpdateLogServer) Recv() (*pb.Log, error) {
 		return nil, err
 	}
 	chunk := &pb.Log{
-		Name: log.FormatName(result.FormatName(parent, resultName), recordName),
-		Data: []byte(m.logStream[0]),
+		Name:           log.FormatName(result.FormatName(parent, resultName), recordName),
+		Data:           []byte(m.logStream[0]),
+		Partial:        m.partial,
+		RedactionHints: m.hints,
 	}
 	m.logStream = m.logStream[1:]
 	return chunk, nil
@@ -324,6 +335,267 @@ func TestUpdateLog(t *testing.T) {
 	}
 }
 
+func TestUpdateLog_append(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_TYPE:                "File",
+		LOGS_API:                 true,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "task-run.log")
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz-log"),
+			Data: &pb.Any{
+				Type: v1alpha3.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+					Spec: v1alpha3.LogSpec{
+						Resource: v1alpha3.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha3.FileLogType,
+					},
+					Status: v1alpha3.LogStatus{
+						Path: path,
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	for _, tc := range []struct {
+		data    []string
+		partial bool
+		want    v1alpha3.LogStatus
+	}{
+		{
+			data:    []string{"step one\n"},
+			partial: true,
+			want:    v1alpha3.LogStatus{Path: path, Size: 9, IsStored: true, IsStreaming: true},
+		},
+		{
+			data: []string{"step two\n", "done\n"},
+			want: v1alpha3.LogStatus{Path: path, Size: 23, IsStored: true},
+		},
+	} {
+		if err := srv.UpdateLog(&mockUpdateLogServer{ctx: ctx, record: rec, logStream: tc.data, partial: tc.partial}); err != nil {
+			t.Fatalf("UpdateLog: %v", err)
+		}
+		got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()})
+		if err != nil {
+			t.Fatalf("GetRecord: %v", err)
+		}
+		object := &v1alpha3.Log{}
+		if err := json.Unmarshal(got.GetData().GetValue(), object); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.want, object.Status); diff != "" {
+			t.Errorf("-want, +got: %s", diff)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read data from file: %v", err)
+	}
+	if want := "step one\nstep two\ndone\n"; string(data) != want {
+		t.Errorf("expected %q, got %q", want, data)
+	}
+}
+
+func TestUpdateLog_redaction(t *testing.T) {
+	redactor, err := redact.New([]redact.Rule{{Name: "github-token", Pattern: `ghp_[A-Za-z0-9]{8}`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := New(&config.Config{
+		LOGS_TYPE:                "File",
+		LOGS_API:                 true,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t), WithRedactor(redactor))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "task-run.log")
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz-log"),
+			Data: &pb.Any{
+				Type: v1alpha3.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+					Spec: v1alpha3.LogSpec{
+						Resource: v1alpha3.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha3.FileLogType,
+					},
+					Status: v1alpha3.LogStatus{
+						Path: path,
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	// Secrets are split across messages, and the last line has no newline.
+	if err := srv.UpdateLog(&mockUpdateLogServer{
+		ctx:       ctx,
+		record:    rec,
+		logStream: []string{"cloning with ghp_ab", "cd1234\npassword: hun", "ter22-x"},
+		hints:     []string{logs.RedactionHint("hunter22-x")},
+	}); err != nil {
+		t.Fatalf("UpdateLog: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read data from file: %v", err)
+	}
+	if want := "cloning with ************\npassword: **********"; string(data) != want {
+		t.Errorf("expected %q, got %q", want, data)
+	}
+	got, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	object := &v1alpha3.Log{}
+	if err := json.Unmarshal(got.GetData().GetValue(), object); err != nil {
+		t.Fatal(err)
+	}
+	want := v1alpha3.LogStatus{Path: path, Size: int64(len(data)), IsStored: true, Redactions: 2}
+	if diff := cmp.Diff(want, object.Status); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestGetLog_follow(t *testing.T) {
+	logFollowInterval = 10 * time.Millisecond
+	t.Cleanup(func() { logFollowInterval = time.Second })
+
+	srv, err := New(&config.Config{
+		LOGS_TYPE:                "File",
+		LOGS_API:                 true,
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "task-run.log")
+	if err := os.WriteFile(path, []byte("Hello "), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "baz"),
+			Data: &pb.Any{
+				Type: v1alpha3.LogRecordType,
+				Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+					Spec: v1alpha3.LogSpec{
+						Resource: v1alpha3.Resource{
+							Namespace: "foo",
+							Name:      "baz",
+						},
+						Type: v1alpha3.FileLogType,
+					},
+					Status: v1alpha3.LogStatus{
+						Path:        path,
+						Size:        6,
+						IsStored:    true,
+						IsStreaming: true,
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	t.Run("without follow", func(t *testing.T) {
+		mock := &mockGetLogServer{ctx: ctx}
+		if err := srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), "baz")}, mock); err != nil {
+			t.Fatalf("GetLog: %v", err)
+		}
+		if got := mock.receivedData.String(); got != "Hello " {
+			t.Errorf("expected to have received %q, got %q", "Hello ", got)
+		}
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		// The log is streaming, but isn't updated anymore.
+		logFollowIdleTimeout = 50 * time.Millisecond
+		t.Cleanup(func() { logFollowIdleTimeout = 10 * time.Minute })
+		mock := &mockGetLogServer{ctx: ctx}
+		if err := srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), "baz"), Follow: true}, mock); err != nil {
+			t.Fatalf("GetLog: %v", err)
+		}
+		if got := mock.receivedData.String(); got != "Hello " {
+			t.Errorf("expected to have received %q, got %q", "Hello ", got)
+		}
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		done := make(chan error)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			done <- srv.UpdateLog(&mockUpdateLogServer{ctx: ctx, record: rec, logStream: []string{"World!"}})
+		}()
+		mock := &mockGetLogServer{ctx: ctx}
+		if err := srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), "baz"), Follow: true}, mock); err != nil {
+			t.Fatalf("GetLog: %v", err)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("UpdateLog: %v", err)
+		}
+		if got := mock.receivedData.String(); got != "Hello World!" {
+			t.Errorf("expected to have received %q, got %q", "Hello World!", got)
+		}
+	})
+}
+
 func TestListLogs(t *testing.T) {
 	// Create a temporary database
 	srv, err := New(&config.Config{
@@ -732,3 +1004,339 @@ func TestDeleteLog(t *testing.T) {
 		}
 	})
 }
+
+func TestStepLogs(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+
+	dir := t.TempDir()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exitCode := int32(1)
+	// Logs of the run and of its steps, the later step being stored first.
+	for _, l := range []struct {
+		name string
+		data string
+		step *v1alpha3.LogStep
+	}{
+		{name: "run", data: "[build] compiling\n[test] failed\n"},
+		{name: "test", data: "failed\n", step: &v1alpha3.LogStep{Name: "test", Container: "step-test", ExitCode: &exitCode, StartTime: &metav1.Time{Time: start.Add(time.Minute)}}},
+		{name: "build", data: "compiling\n", step: &v1alpha3.LogStep{Name: "build", Container: "step-build", StartTime: &metav1.Time{Time: start}}},
+	} {
+		path := filepath.Join(dir, l.name)
+		if err := os.WriteFile(path, []byte(l.data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), l.name+"-log"),
+				Data: &pb.Any{
+					Type: v1alpha3.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+						TypeMeta: metav1.TypeMeta{APIVersion: "results.tekton.dev/v1alpha3", Kind: "Log"},
+						Spec: v1alpha3.LogSpec{
+							Resource: v1alpha3.Resource{Namespace: "foo", Name: "run", UID: "run-uid"},
+							Type:     v1alpha3.FileLogType,
+							Step:     l.step,
+						},
+						Status: v1alpha3.LogStatus{Path: path, Size: int64(len(l.data)), IsStored: true},
+					}),
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+	}
+	// The record of the run, whose name can also be used to get its logs.
+	if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: record.FormatName(res.GetName(), "run-uid"),
+			Data: &pb.Any{Type: "tekton.dev/v1.TaskRun", Value: []byte(`{"metadata":{"name":"run"}}`)},
+		},
+	}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	runLog := log.FormatName(res.GetName(), "run-log")
+
+	t.Run("list", func(t *testing.T) {
+		got, err := srv.ListStepLogs(ctx, &pb.ListStepLogsRequest{Name: runLog})
+		if err != nil {
+			t.Fatalf("ListStepLogs: %v", err)
+		}
+		want := &pb.ListStepLogsResponse{StepLogs: []*pb.StepLog{
+			{
+				Name:      log.FormatName(res.GetName(), "build-log"),
+				Step:      "build",
+				Container: "step-build",
+				StartTime: timestamppb.New(start),
+				Size:      int64(len("compiling\n")),
+			},
+			{
+				Name:      log.FormatName(res.GetName(), "test-log"),
+				Step:      "test",
+				Container: "step-test",
+				ExitCode:  1,
+				StartTime: timestamppb.New(start.Add(time.Minute)),
+				Size:      int64(len("failed\n")),
+			},
+		}}
+		if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("-want, +got: %s", diff)
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		got, err := srv.ListLogs(ctx, &pb.ListRecordsRequest{Parent: res.GetName(), Filter: "has(data.spec.step)"})
+		if err != nil {
+			t.Fatalf("ListLogs: %v", err)
+		}
+		if len(got.GetRecords()) != 2 {
+			t.Errorf("expected the 2 step logs, got %v", got.GetRecords())
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.GetLogRequest
+		want string
+		code codes.Code
+	}{
+		{name: "run", req: &pb.GetLogRequest{Name: runLog}, want: "[build] compiling\n[test] failed\n"},
+		{name: "run by resource", req: &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "run-uid")}, want: "[build] compiling\n[test] failed\n"},
+		{name: "step", req: &pb.GetLogRequest{Name: runLog, Step: "test"}, want: "failed\n"},
+		{name: "step by resource", req: &pb.GetLogRequest{Name: log.FormatName(res.GetName(), "run-uid"), Step: "build"}, want: "compiling\n"},
+		{name: "unknown step", req: &pb.GetLogRequest{Name: runLog, Step: "deploy"}, code: codes.NotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockGetLogServer{ctx: ctx}
+			err := srv.GetLog(tc.req, mock)
+			if status.Code(err) != tc.code {
+				t.Fatalf("GetLog: got %v, want %v", err, tc.code)
+			}
+			if err != nil {
+				return
+			}
+			if got := mock.receivedData.String(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogs_policy(t *testing.T) {
+	// Team a sees its runs and their logs.
+	checker, err := auth.NewPolicyChecker(auth.AllowAll{}, staticAuthenticator{Groups: []string{"team-a"}}, []auth.Policy{{
+		Name:   "team-a",
+		Match:  `"team-a" in groups`,
+		Filter: `data.metadata.labels["team"] == "a" || data.spec.resource.name == "a"`,
+	}})
+	if err != nil {
+		t.Fatalf("NewPolicyChecker: %v", err)
+	}
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+	}, logger.Get("info"), test.NewDB(t), WithAuth(checker))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	logFiles := make(map[string]string)
+	logRecords := make(map[string]*pb.Record)
+	for _, team := range []string{"a", "b"} {
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), team),
+				Data: &pb.Any{
+					Type: "TaskRun",
+					Value: jsonutil.AnyBytes(t, &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+						Name:   team,
+						Labels: map[string]string{"team": team},
+					}}),
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		logFiles[team] = filepath.Join(t.TempDir(), team+".log")
+		if err := os.WriteFile(logFiles[team], []byte("logs of "+team), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{
+				Name: record.FormatName(res.GetName(), team+"-log"),
+				Data: &pb.Any{
+					Type: v1alpha3.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+						Spec: v1alpha3.LogSpec{
+							Resource: v1alpha3.Resource{Namespace: "foo", Name: team, UID: types.UID(team)},
+							Type:     v1alpha3.FileLogType,
+						},
+						Status: v1alpha3.LogStatus{
+							Path:     logFiles[team],
+							Size:     int64(len("logs of " + team)),
+							IsStored: true,
+						},
+					}),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		logRecords[team] = r
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mock := &mockGetLogServer{ctx: ctx}
+		if err := srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), "a")}, mock); err != nil {
+			t.Fatalf("GetLog: %v", err)
+		}
+		if got := mock.receivedData.String(); got != "logs of a" {
+			t.Errorf("GetLog: got %q, want %q", got, "logs of a")
+		}
+		// The logs of hidden runs can't be read by the name of the run or of
+		// their Log Record.
+		for _, name := range []string{"b", "b-log"} {
+			if err := srv.GetLog(&pb.GetLogRequest{Name: log.FormatName(res.GetName(), name)}, &mockGetLogServer{ctx: ctx}); status.Code(err) != codes.NotFound {
+				t.Errorf("GetLog(%s): want %v, got %v", name, codes.NotFound, err)
+			}
+		}
+		if _, err := srv.ListStepLogs(ctx, &pb.ListStepLogsRequest{Name: log.FormatName(res.GetName(), "b")}); status.Code(err) != codes.NotFound {
+			t.Errorf("ListStepLogs: want %v, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := srv.ListLogs(ctx, &pb.ListRecordsRequest{Parent: res.GetName()})
+		if err != nil {
+			t.Fatalf("ListLogs: %v", err)
+		}
+		if len(got.GetRecords()) != 1 || got.GetRecords()[0].GetUid() != logRecords["a"].GetUid() {
+			t.Errorf("ListLogs: got %v, want the log of a only", got.GetRecords())
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if _, err := srv.DeleteLog(ctx, &pb.DeleteLogRequest{Name: log.FormatName(res.GetName(), "b")}); status.Code(err) != codes.NotFound {
+			t.Errorf("DeleteLog: want %v, got %v", codes.NotFound, err)
+		}
+		if _, err := os.Stat(logFiles["b"]); err != nil {
+			t.Errorf("log of a hidden run deleted: %v", err)
+		}
+	})
+}
+
+func TestLogs_clusters(t *testing.T) {
+	srv, err := New(&config.Config{
+		LOGS_API:                 true,
+		LOGS_TYPE:                "File",
+		DB_ENABLE_AUTO_MIGRATION: true,
+		CLUSTER_NAME:             "hub",
+	}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	inCluster := func(cluster string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.ClusterHeader, cluster))
+	}
+
+	// Runs of the same name in both clusters have logs of their own.
+	resultName := result.FormatName("ci", "release")
+	logFiles := make(map[string]string)
+	for _, cluster := range []string{"hub", "build-eu-1"} {
+		ctx := inCluster(cluster)
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "ci",
+			Result: &pb.Result{Name: resultName},
+		}); err != nil {
+			t.Fatalf("CreateResult in cluster %s: %v", cluster, err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: resultName,
+			Record: &pb.Record{
+				Name: record.FormatName(resultName, "run"),
+				Data: &pb.Any{Type: "TaskRun", Value: []byte("{}")},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord in cluster %s: %v", cluster, err)
+		}
+		logFiles[cluster] = filepath.Join(t.TempDir(), cluster+".log")
+		if err := os.WriteFile(logFiles[cluster], []byte("logs of "+cluster), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: resultName,
+			Record: &pb.Record{
+				Name: record.FormatName(resultName, "run-log"),
+				Data: &pb.Any{
+					Type: v1alpha3.LogRecordType,
+					Value: jsonutil.AnyBytes(t, &v1alpha3.Log{
+						Spec: v1alpha3.LogSpec{
+							Resource: v1alpha3.Resource{Namespace: "ci", Name: "run", UID: "run"},
+							Type:     v1alpha3.FileLogType,
+						},
+						Status: v1alpha3.LogStatus{
+							Path:     logFiles[cluster],
+							Size:     int64(len("logs of " + cluster)),
+							IsStored: true,
+						},
+					}),
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord in cluster %s: %v", cluster, err)
+		}
+	}
+
+	logName := log.FormatName(resultName, "run")
+	for _, cluster := range []string{"hub", "build-eu-1"} {
+		mock := &mockGetLogServer{ctx: inCluster(cluster)}
+		if err := srv.GetLog(&pb.GetLogRequest{Name: logName}, mock); err != nil {
+			t.Fatalf("GetLog in cluster %s: %v", cluster, err)
+		}
+		if got, want := mock.receivedData.String(), "logs of "+cluster; got != want {
+			t.Errorf("GetLog in cluster %s: got %q, want %q", cluster, got, want)
+		}
+	}
+
+	if _, err := srv.DeleteLog(inCluster("build-eu-1"), &pb.DeleteLogRequest{Name: logName}); err != nil {
+		t.Fatalf("DeleteLog in cluster build-eu-1: %v", err)
+	}
+	if _, err := os.Stat(logFiles["build-eu-1"]); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("log of cluster build-eu-1 not deleted: %v", err)
+	}
+	if _, err := os.Stat(logFiles["hub"]); err != nil {
+		t.Errorf("log of cluster hub deleted: %v", err)
+	}
+}
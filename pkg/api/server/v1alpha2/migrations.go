@@ -0,0 +1,90 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/db/errors"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/migration"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListMigrations returns the progress of the registered migrations of Record
+// data, as checkpointed in the database by the migration runner. Migrations
+// which never ran are pending.
+func (s *Server) ListMigrations(ctx context.Context, _ *pb.ListMigrationsRequest) (*pb.ListMigrationsResponse, error) {
+	// Migrations apply to all the Records, so they are cluster scoped.
+	if err := s.auth.Check(ctx, "", auth.ResourceMigrations, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	var checkpoints []db.Migration
+	if err := errors.Wrap(s.db.WithContext(ctx).Find(&checkpoints).Error); err != nil {
+		return nil, err
+	}
+	type key struct {
+		name   string
+		dryRun bool
+	}
+	byKey := make(map[key]*db.Migration, len(checkpoints))
+	for i := range checkpoints {
+		byKey[key{checkpoints[i].Name, checkpoints[i].DryRun}] = &checkpoints[i]
+	}
+
+	resp := &pb.ListMigrationsResponse{}
+	registered := migration.Registered()
+	for _, m := range registered {
+		resp.Migrations = append(resp.Migrations, migrationToAPI(m, byKey[key{m.Name(), false}]))
+	}
+	for _, m := range registered {
+		if checkpoint, ok := byKey[key{m.Name(), true}]; ok {
+			resp.Migrations = append(resp.Migrations, migrationToAPI(m, checkpoint))
+		}
+	}
+	return resp, nil
+}
+
+func migrationToAPI(m migration.Migration, checkpoint *db.Migration) *pb.Migration {
+	out := &pb.Migration{
+		Name:     m.Name(),
+		FromType: m.FromType(),
+		ToType:   m.ToType(),
+	}
+	if checkpoint == nil {
+		return out
+	}
+	out.DryRun = checkpoint.DryRun
+	switch checkpoint.State {
+	case migration.StateRunning:
+		out.State = pb.Migration_RUNNING
+	case migration.StateCompleted:
+		out.State = pb.Migration_COMPLETED
+	case migration.StateFailed:
+		out.State = pb.Migration_FAILED
+	}
+	out.MigratedCount = checkpoint.MigratedCount
+	out.FailedCount = checkpoint.FailedCount
+	out.Error = checkpoint.Error
+	out.StartTime = timestamppb.New(checkpoint.StartedTime)
+	out.UpdateTime = timestamppb.New(checkpoint.UpdatedTime)
+	if checkpoint.CompletedTime != nil {
+		out.CompletionTime = timestamppb.New(*checkpoint.CompletedTime)
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/migration"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListMigrations(t *testing.T) {
+	gdb := test.NewDB(t)
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), gdb)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	started := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	completed := started.Add(time.Hour)
+	for _, checkpoint := range []*db.Migration{
+		{
+			Name:          "taskrun.tekton.dev/v1beta1-v1",
+			State:         migration.StateCompleted,
+			MigratedCount: 10,
+			FailedCount:   1,
+			StartedTime:   started,
+			UpdatedTime:   completed,
+			CompletedTime: &completed,
+		},
+		{
+			Name:          "taskrun.tekton.dev/v1beta1-v1",
+			DryRun:        true,
+			State:         migration.StateFailed,
+			MigratedCount: 3,
+			Error:         "connection refused",
+			StartedTime:   started,
+			UpdatedTime:   started,
+		},
+	} {
+		if err := gdb.Create(checkpoint).Error; err != nil {
+			t.Fatalf("failed to create checkpoint: %v", err)
+		}
+	}
+
+	got, err := srv.ListMigrations(context.Background(), &pb.ListMigrationsRequest{})
+	if err != nil {
+		t.Fatalf("ListMigrations: %v", err)
+	}
+	want := &pb.ListMigrationsResponse{Migrations: []*pb.Migration{
+		{
+			Name:     "pipelinerun.tekton.dev/v1beta1-v1",
+			FromType: "tekton.dev/v1beta1.PipelineRun",
+			ToType:   "tekton.dev/v1.PipelineRun",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:     "provenance-digests.provenance.results.tekton.dev/v1alpha3",
+			FromType: "results.tekton.dev/v1alpha3.Provenance",
+			ToType:   "results.tekton.dev/v1alpha3.Provenance",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:     "run-results.pipelinerun.tekton.dev/v1",
+			FromType: "tekton.dev/v1.PipelineRun",
+			ToType:   "tekton.dev/v1.PipelineRun",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:     "run-results.pipelinerun.tekton.dev/v1beta1",
+			FromType: "tekton.dev/v1beta1.PipelineRun",
+			ToType:   "tekton.dev/v1beta1.PipelineRun",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:     "run-results.taskrun.tekton.dev/v1",
+			FromType: "tekton.dev/v1.TaskRun",
+			ToType:   "tekton.dev/v1.TaskRun",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:     "run-results.taskrun.tekton.dev/v1beta1",
+			FromType: "tekton.dev/v1beta1.TaskRun",
+			ToType:   "tekton.dev/v1beta1.TaskRun",
+			State:    pb.Migration_PENDING,
+		},
+		{
+			Name:           "taskrun.tekton.dev/v1beta1-v1",
+			FromType:       "tekton.dev/v1beta1.TaskRun",
+			ToType:         "tekton.dev/v1.TaskRun",
+			State:          pb.Migration_COMPLETED,
+			MigratedCount:  10,
+			FailedCount:    1,
+			StartTime:      timestamppb.New(started),
+			UpdateTime:     timestamppb.New(completed),
+			CompletionTime: timestamppb.New(completed),
+		},
+		{
+			Name:          "taskrun.tekton.dev/v1beta1-v1",
+			FromType:      "tekton.dev/v1beta1.TaskRun",
+			ToType:        "tekton.dev/v1.TaskRun",
+			DryRun:        true,
+			State:         pb.Migration_FAILED,
+			MigratedCount: 3,
+			Error:         "connection refused",
+			StartTime:     timestamppb.New(started),
+			UpdateTime:    timestamppb.New(started),
+		},
+	}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"google.golang.org/grpc/codes"
@@ -52,3 +53,12 @@ func pageStart(token, filter string) (string, error) {
 	}
 	return tokenName, nil
 }
+
+// totalSize converts a count of resources to the total_size of a list
+// response, which saturates for counts that don't fit.
+func totalSize(count int64) int32 {
+	if count > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(count)
+}
@@ -93,7 +93,7 @@ func (s *LogServer) GetLog(req *pb3.GetLogRequest, srv pb3.Logs_GetLogServer) er
 		return err
 	}
 
-	rec, err := getRecord(s.db, parent, res, name)
+	rec, err := getRecord(s.inCluster(srv.Context(), s.db), parent, res, name)
 	if err != nil {
 		s.logger.Error(err)
 		return err
@@ -795,7 +795,7 @@ func (s *LogServer) LogMux() http.Handler {
 			http.Error(w, "Not Authorized", http.StatusUnauthorized)
 			return
 		}
-		rec, err := getRecord(s.db, parent, res, recID)
+		rec, err := getRecord(s.inCluster(ctx, s.db), parent, res, recID)
 		if err != nil {
 			s.logger.Error(err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -817,6 +817,12 @@ func (s *LogServer) LogMux() http.Handler {
 	})
 }
 
+// inCluster scopes the lookups of Records by name of tx to the cluster of the
+// request, if known.
+func (s *LogServer) inCluster(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	return db.InCluster(tx, auth.RequestCluster(ctx), s.config.CLUSTER_NAME)
+}
+
 func getRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error) {
 	store := &db.Record{}
 	q := txn.
@@ -0,0 +1,115 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/provenance"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// digestPattern matches digests formatted as algorithm:hex.
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+:[a-fA-F0-9]+$`)
+
+// SearchRunsByDigest finds the runs which built the artifact with the given
+// digest, from their provenance Records.
+func (s *Server) SearchRunsByDigest(ctx context.Context, req *pb.SearchRunsByDigestRequest) (*pb.SearchRunsByDigestResponse, error) {
+	if req.GetParent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "parent missing")
+	}
+	digest := strings.TrimSpace(req.GetDigest())
+	if !digestPattern.MatchString(digest) {
+		return nil, status.Error(codes.InvalidArgument, "digest must be formatted as algorithm:hex, e.g. sha256:abc...")
+	}
+	digest = provenance.Normalize(digest)
+
+	parent, resultName, err := result.ParseName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	recordsLister, err := lister.OfRecords(s.recordsEnv, parent, resultName, &pb.ListRecordsRequest{
+		Parent:    req.GetParent(),
+		Filter:    fmt.Sprintf("data_type == %q", v1alpha3.ProvenanceRecordType),
+		OrderBy:   "create_time asc",
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	recordsLister.Constrain(constraint)
+
+	// The digests of provenance Records are indexed when they are written.
+	records, nextPageToken, err := recordsLister.List(ctx, provenance.Having(s.db, digest))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchRunsByDigestResponse{NextPageToken: nextPageToken}
+	for _, r := range records {
+		p := &v1alpha3.Provenance{}
+		if err := json.Unmarshal(r.GetData().GetValue(), p); err != nil {
+			s.logger.Warnf("Skipping provenance Record %s: %v", r.GetName(), err)
+			continue
+		}
+		resp.Runs = append(resp.Runs, artifactRun(r.GetName(), p, digest))
+	}
+	return resp, nil
+}
+
+// artifactRun returns the run described by the provenance p, stored in the
+// Record named record, which built the artifact with the given digest.
+func artifactRun(record string, p *v1alpha3.Provenance, digest string) *pb.ArtifactRun {
+	run := &pb.ArtifactRun{
+		Record:           p.Spec.Record,
+		ProvenanceRecord: record,
+		Kind:             p.Spec.Resource.Kind,
+		RunName:          p.Spec.Resource.Name,
+		RunUid:           string(p.Spec.Resource.UID),
+		Signed:           p.Spec.Signed,
+	}
+	alg, hex, _ := strings.Cut(digest, ":")
+	for _, subject := range p.Spec.Subjects {
+		if strings.EqualFold(subject.Digest[alg], hex) {
+			run.Subjects = append(run.Subjects, subject.Name)
+		}
+	}
+	for _, a := range p.Spec.Attestations {
+		if a.PredicateType != "" && !slices.Contains(run.PredicateTypes, a.PredicateType) {
+			run.PredicateTypes = append(run.PredicateTypes, a.PredicateType)
+		}
+	}
+	return run
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provenance indexes the digests of the artifacts recorded in
+// provenance Records, so that the runs which built an artifact can be
+// searched by its digest.
+package provenance
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxDigestLength is the size of the indexed digests. Longer digests
+	// aren't indexed.
+	MaxDigestLength = 256
+
+	batchSize = 100
+)
+
+// Index replaces the indexed digests of the Record r by the digests of the
+// provenance it holds, within the transaction tx. Records which don't hold
+// provenance aren't indexed.
+func Index(tx *gorm.DB, r *db.Record) error {
+	if r.Type != v1alpha3.ProvenanceRecordType {
+		return nil
+	}
+	if err := tx.Where(&db.ProvenanceDigest{Parent: r.Parent, ResultID: r.ResultID, RecordID: r.ID}).
+		Delete(&db.ProvenanceDigest{}).Error; err != nil {
+		return err
+	}
+	rows := FromRecord(r)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(rows, batchSize).Error
+}
+
+// FromRecord returns the indexed digests of the provenance held by the Record
+// r, sorted. Records whose data can't be decoded have no digests, so that
+// they are stored regardless.
+func FromRecord(r *db.Record) []*db.ProvenanceDigest {
+	if r.Type != v1alpha3.ProvenanceRecordType {
+		return nil
+	}
+	p := &v1alpha3.Provenance{}
+	if err := json.Unmarshal(r.Data, p); err != nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var rows []*db.ProvenanceDigest
+	for _, digest := range p.Spec.Digests {
+		digest = Normalize(digest)
+		if seen[digest] || len(digest) > MaxDigestLength {
+			continue
+		}
+		seen[digest] = true
+		rows = append(rows, &db.ProvenanceDigest{
+			Parent:   r.Parent,
+			ResultID: r.ResultID,
+			RecordID: r.ID,
+			Digest:   digest,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Digest < rows[j].Digest
+	})
+	return rows
+}
+
+// Normalize returns the digest formatted as algorithm:hex, with the hex part
+// in lower case.
+func Normalize(digest string) string {
+	alg, hex, _ := strings.Cut(strings.TrimSpace(digest), ":")
+	return alg + ":" + strings.ToLower(hex)
+}
+
+// Having constrains a query of Records to the provenance Records of runs
+// which built an artifact with the given digest.
+func Having(q *gorm.DB, digest string) *gorm.DB {
+	return q.Where(`EXISTS (SELECT 1 FROM provenance_digests
+		WHERE provenance_digests.parent = records.parent
+		AND provenance_digests.result_id = records.result_id
+		AND provenance_digests.record_id = records.id
+		AND provenance_digests.digest = ?)`, digest)
+}
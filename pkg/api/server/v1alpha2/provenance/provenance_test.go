@@ -0,0 +1,70 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+)
+
+func TestFromRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		dataType string
+		data     string
+		want     []string
+	}{
+		{
+			name:     "provenance",
+			dataType: v1alpha3.ProvenanceRecordType,
+			data:     `{"spec":{"digests":["sha256:BBBB","sha1:ffff","sha256:aaaa","sha256:bbbb"]}}`,
+			want:     []string{"sha1:ffff", "sha256:aaaa", "sha256:bbbb"},
+		},
+		{
+			name:     "long digest",
+			dataType: v1alpha3.ProvenanceRecordType,
+			data:     `{"spec":{"digests":["sha256:` + strings.Repeat("a", MaxDigestLength) + `","sha256:aaaa"]}}`,
+			want:     []string{"sha256:aaaa"},
+		},
+		{
+			name:     "other type",
+			dataType: "tekton.dev/v1.TaskRun",
+			data:     `{"spec":{"digests":["sha256:aaaa"]}}`,
+		},
+		{
+			name:     "invalid data",
+			dataType: v1alpha3.ProvenanceRecordType,
+			data:     `{`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &db.Record{Parent: "ns", ResultID: "1", ID: "2", Type: tc.dataType, Data: []byte(tc.data)}
+			var got []string
+			for _, row := range FromRecord(r) {
+				if row.Parent != r.Parent || row.ResultID != r.ResultID || row.RecordID != r.ID {
+					t.Errorf("digest %s of Record (%s, %s, %s)", row.Digest, row.Parent, row.ResultID, row.RecordID)
+				}
+				got = append(got, row.Digest)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSearchRunsByDigest(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+
+	const (
+		image    = "sha256:aaaa"
+		material = "sha256:bbbb"
+	)
+	create := func(namespace, name string, p *v1alpha3.Provenance, recordType string) {
+		t.Helper()
+		result := namespace + "/results/" + name
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: namespace,
+			Result: &pb.Result{Name: result},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: result,
+			Record: &pb.Record{
+				Name: result + "/records/provenance",
+				Data: &pb.Any{Type: recordType, Value: b},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+	}
+	provenance := func(name string, digests ...string) *v1alpha3.Provenance {
+		p := &v1alpha3.Provenance{Spec: v1alpha3.ProvenanceSpec{
+			Resource: v1alpha3.Resource{Kind: "PipelineRun", Namespace: "ns", Name: name, UID: types.UID("uid-" + name)},
+			Record:   "ns/results/" + name + "/records/" + name,
+			Signed:   "true",
+			Attestations: []v1alpha3.Attestation{
+				{Key: "pipelinerun-uid-" + name, PredicateType: "https://slsa.dev/provenance/v1"},
+			},
+			Digests: digests,
+		}}
+		for _, d := range digests {
+			p.Spec.Subjects = append(p.Spec.Subjects, v1alpha3.Subject{Name: "registry/" + name, Digest: map[string]string{"sha256": d[len("sha256:"):]}})
+		}
+		p.Default()
+		return p
+	}
+	create("ns", "build", provenance("build", image), v1alpha3.ProvenanceRecordType)
+	create("ns", "rebuild", provenance("rebuild", image, "sha256:cccc"), v1alpha3.ProvenanceRecordType)
+	create("other", "build", provenance("other", image), v1alpha3.ProvenanceRecordType)
+	// The digest only appears in the materials of the payload.
+	materials := provenance("materials")
+	materials.Spec.Attestations[0].Payload = []byte(`{"materials":[{"digest":"` + material + `"}]}`)
+	create("ns", "materials", materials, v1alpha3.ProvenanceRecordType)
+	// Records of other types are ignored.
+	create("ns", "run", provenance("run", image), "tekton.dev/v1.PipelineRun")
+
+	run := func(name string) *pb.ArtifactRun {
+		return &pb.ArtifactRun{
+			Record:           "ns/results/" + name + "/records/" + name,
+			ProvenanceRecord: "ns/results/" + name + "/records/provenance",
+			Kind:             "PipelineRun",
+			RunName:          name,
+			RunUid:           "uid-" + name,
+			Subjects:         []string{"registry/" + name},
+			PredicateTypes:   []string{"https://slsa.dev/provenance/v1"},
+			Signed:           "true",
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		req    *pb.SearchRunsByDigestRequest
+		want   []*pb.ArtifactRun
+		status codes.Code
+	}{
+		{
+			name: "namespace",
+			req:  &pb.SearchRunsByDigestRequest{Parent: "ns/results/-", Digest: image},
+			want: []*pb.ArtifactRun{run("build"), run("rebuild")},
+		},
+		{
+			name: "uppercase",
+			req:  &pb.SearchRunsByDigestRequest{Parent: "ns/results/-", Digest: "sha256:AAAA"},
+			want: []*pb.ArtifactRun{run("build"), run("rebuild")},
+		},
+		{
+			name: "result",
+			req:  &pb.SearchRunsByDigestRequest{Parent: "ns/results/rebuild", Digest: image},
+			want: []*pb.ArtifactRun{run("rebuild")},
+		},
+		{
+			name: "materials",
+			req:  &pb.SearchRunsByDigestRequest{Parent: "ns/results/-", Digest: material},
+		},
+		{
+			name:   "missing parent",
+			req:    &pb.SearchRunsByDigestRequest{Digest: image},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "invalid digest",
+			req:    &pb.SearchRunsByDigestRequest{Parent: "ns/results/-", Digest: "registry/build@sha256:aaaa"},
+			status: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.SearchRunsByDigest(ctx, tc.req)
+			if status.Code(err) != tc.status {
+				t.Fatalf("SearchRunsByDigest: %v, want %v", err, tc.status)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.GetRuns(), protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		const digest = "sha256:dddd"
+		var want []*pb.ArtifactRun
+		for i := range 7 {
+			name := fmt.Sprintf("page-%d", i)
+			create("ns", name, provenance(name, digest), v1alpha3.ProvenanceRecordType)
+			want = append(want, run(name))
+		}
+
+		var got []*pb.ArtifactRun
+		req := &pb.SearchRunsByDigestRequest{Parent: "ns/results/-", Digest: digest, PageSize: 5}
+		for pages := 1; ; pages++ {
+			resp, err := srv.SearchRunsByDigest(ctx, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, resp.GetRuns()...)
+			if resp.GetNextPageToken() == "" {
+				if pages != 2 {
+					t.Errorf("expected 2 pages, got %d", pages)
+				}
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		// Records created within the same instant are ordered by their
+		// random IDs.
+		byName := cmpopts.SortSlices(func(a, b *pb.ArtifactRun) bool { return a.GetRunName() < b.GetRunName() })
+		if diff := cmp.Diff(want, got, protocmp.Transform(), byName); diff != "" {
+			t.Errorf("-want, +got: %s", diff)
+		}
+	})
+
+	t.Run("all namespaces", func(t *testing.T) {
+		got, err := srv.SearchRunsByDigest(ctx, &pb.SearchRunsByDigestRequest{Parent: "-/results/-", Digest: image})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.GetRuns()) != 3 {
+			t.Errorf("expected 3 runs, got %v", got.GetRuns())
+		}
+	})
+}
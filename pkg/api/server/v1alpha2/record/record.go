@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
@@ -32,6 +33,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -66,6 +68,9 @@ func ToStorage(parent, resultName, resultID, name string, r *pb.Record, config *
 	if err := validateData(r.GetData()); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err := ValidateCluster(r.GetCluster()); err != nil {
+		return nil, err
+	}
 
 	id := r.GetUid()
 	if id == "" {
@@ -83,7 +88,8 @@ func ToStorage(parent, resultName, resultID, name string, r *pb.Record, config *
 		Type: r.GetData().GetType(),
 		Data: r.GetData().GetValue(),
 
-		Etag: r.Etag,
+		Etag:    r.Etag,
+		Cluster: r.GetCluster(),
 	}
 
 	if r.CreatedTime.IsValid() {
@@ -113,10 +119,11 @@ func ToStorage(parent, resultName, resultID, name string, r *pb.Record, config *
 // equivalent.
 func ToAPI(r *db.Record) *pb.Record {
 	out := &pb.Record{
-		Name: fmt.Sprintf("%s/results/%s/records/%s", r.Parent, r.ResultName, r.Name),
-		Id:   r.ID,
-		Uid:  r.ID,
-		Etag: r.Etag,
+		Name:    fmt.Sprintf("%s/results/%s/records/%s", r.Parent, r.ResultName, r.Name),
+		Id:      r.ID,
+		Uid:     r.ID,
+		Etag:    r.Etag,
+		Cluster: r.Cluster,
 	}
 
 	if !r.CreatedTime.IsZero() {
@@ -201,3 +208,15 @@ func ValidateType(t string) error {
 	}
 	return nil
 }
+
+// ValidateCluster validates the name c of the cluster of a Result or Record,
+// which must be empty or a DNS label.
+func ValidateCluster(c string) error {
+	if c == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(c); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid cluster %q: %s", c, strings.Join(errs, ", "))
+	}
+	return nil
+}
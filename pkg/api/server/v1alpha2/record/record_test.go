@@ -125,6 +125,7 @@ func TestToStorage(t *testing.T) {
 				CreatedTime: timestamppb.New(clock.Now()),
 				UpdatedTime: timestamppb.New(clock.Now()),
 				Etag:        "tacocat",
+				Cluster:     "build-eu-1",
 			},
 			want: &db.Record{
 				Parent:      "foo",
@@ -136,6 +137,7 @@ func TestToStorage(t *testing.T) {
 				CreatedTime: clock.Now(),
 				UpdatedTime: clock.Now(),
 				Etag:        "tacocat",
+				Cluster:     "build-eu-1",
 			},
 		},
 		{
@@ -247,6 +249,7 @@ func TestToAPI(t *testing.T) {
 				Data:        jsonutil.AnyBytes(t, data),
 				CreatedTime: clock.Now(),
 				Etag:        "etag",
+				Cluster:     "build-eu-1",
 			},
 			want: &pb.Record{
 				Name: "foo/results/bar/records/baz",
@@ -258,6 +261,7 @@ func TestToAPI(t *testing.T) {
 				CreatedTime: timestamppb.New(clock.Now()),
 				CreateTime:  timestamppb.New(clock.Now()),
 				Etag:        "etag",
+				Cluster:     "build-eu-1",
 			},
 		},
 		{
@@ -306,3 +310,16 @@ func TestValidateType(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateCluster(t *testing.T) {
+	for _, c := range []string{"", "build-eu-1"} {
+		if err := ValidateCluster(c); err != nil {
+			t.Errorf("ValidateCluster(%q): %v", c, err)
+		}
+	}
+	for _, c := range []string{"Build", "build/eu", strings.Repeat("a", 64)} {
+		if err := ValidateCluster(c); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ValidateCluster(%q): %v, want InvalidArgument", c, err)
+		}
+	}
+}
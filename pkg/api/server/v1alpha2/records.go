@@ -16,16 +16,21 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
+	"github.com/tektoncd/results/pkg/api/server/audit"
+	celenv "github.com/tektoncd/results/pkg/api/server/cel"
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/provenance"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/runresult"
 	"github.com/tektoncd/results/pkg/internal/protoutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
@@ -47,6 +52,9 @@ func (s *Server) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	if req.GetParent() != result.FormatName(parent, resultName) {
 		return nil, status.Error(codes.InvalidArgument, "requested parent does not match resource name")
 	}
+	if r.GetCluster() != "" {
+		ctx = auth.WithCluster(ctx, r.GetCluster())
+	}
 	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionCreate); err != nil {
 		return nil, err
 	}
@@ -55,10 +63,16 @@ func (s *Server) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	// transactionally with the insert since name<->ID mappings are immutable,
 	// and if the parent result is deleted mid-request, the insert should
 	// fail due to foreign key constraints.
-	resultID, err := s.getResultID(ctx, parent, resultName)
+	res, err := s.getResultID(ctx, parent, resultName)
 	if err != nil {
 		return nil, err
 	}
+	// Records belong to the cluster of their Result, which is looked up in
+	// the cluster of the Record if given.
+	r.Cluster = res.Cluster
+	if err := s.checkCluster(ctx, r.GetCluster(), parent, auth.ResourceRecords, auth.PermissionCreate); err != nil {
+		return nil, err
+	}
 
 	// Populate Result with server provided fields.
 	protoutil.ClearOutputOnly(r)
@@ -70,40 +84,49 @@ func (s *Server) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	r.UpdatedTime = ts
 	r.UpdateTime = ts
 
-	store, err := record.ToStorage(parent, resultName, resultID, name, req.GetRecord(), s.config)
+	store, err := record.ToStorage(parent, resultName, res.ID, name, req.GetRecord(), s.config)
 	if err != nil {
 		return nil, err
 	}
 	if err := record.UpdateEtag(store); err != nil {
 		return nil, err
 	}
-	q := s.db.WithContext(ctx).
-		Model(store).
-		Create(store).Error
-	if err := errors.Wrap(q); err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := errors.Wrap(tx.Model(store).Create(store).Error); err != nil {
+			return err
+		}
+		if err := errors.Wrap(runresult.Index(tx, store)); err != nil {
+			return err
+		}
+		return errors.Wrap(provenance.Index(tx, store))
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return record.ToAPI(store), nil
+	out := record.ToAPI(store)
+	s.notifier.RecordCreated(out)
+	return out, nil
 }
 
 // resultID is a utility struct to extract partial Result data representing
-// Result name <-> ID mappings.
+// Result name <-> ID mappings, along with the cluster of the Result.
 type resultID struct {
-	Name string
-	ID   string
+	Name    string
+	ID      string
+	Cluster string
 }
 
-func (s *Server) getResultIDImpl(ctx context.Context, parent, result string) (string, error) {
+func (s *Server) getResultIDImpl(ctx context.Context, parent, result string) (*resultID, error) {
 	id := new(resultID)
-	q := s.db.WithContext(ctx).
+	q := s.inCluster(ctx, s.db.WithContext(ctx)).
 		Model(&db.Result{}).
 		Where(&db.Result{Parent: parent, Name: result}).
 		First(id)
 	if err := errors.Wrap(q.Error); err != nil {
-		return "", err
+		return nil, err
 	}
-	return id.ID, nil
+	return id, nil
 }
 
 // GetRecord returns a single Record.
@@ -116,17 +139,20 @@ func (s *Server) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.R
 		return nil, err
 	}
 
-	r, err := getRecord(s.db.WithContext(ctx), parent, result, name)
+	r, err := getRecord(s.inCluster(ctx, s.db.WithContext(ctx)), parent, result, name)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkRecordConstraint(ctx, r); err != nil {
+		return nil, err
+	}
 	return record.ToAPI(r), nil
 }
 
 func getRecord(txn *gorm.DB, parent, result, name string) (*db.Record, error) {
 	// Note: set the Parent, ResultName and Name fields in the model used to
-	// query the database to take advantage of the records_by_name composite
-	// index. Although the Name is an unique value as well, leveraging the
+	// query the database to take advantage of the records_by_cluster_name
+	// composite index. Although the Name is an unique value as well, leveraging the
 	// index speeds up the query significantly. See
 	// https://github.com/tektoncd/results/issues/336.
 	store := &db.Record{}
@@ -158,16 +184,30 @@ func (s *Server) ListRecords(ctx context.Context, req *pb.ListRecordsRequest) (*
 	if err != nil {
 		return nil, err
 	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	recordsLister.Constrain(constraint)
 
 	records, nextPageToken, err := recordsLister.List(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.ListRecordsResponse{
+	resp := &pb.ListRecordsResponse{
 		Records:       records,
 		NextPageToken: nextPageToken,
-	}, nil
+	}
+	if req.GetIncludeTotalSize() {
+		total, estimated, err := recordsLister.Count(ctx, s.db, s.config.LIST_TOTAL_SIZE_EXACT_LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		resp.TotalSize = totalSize(total)
+		resp.TotalSizeEstimated = estimated
+	}
+	return resp, nil
 }
 
 // UpdateRecord updates a record in the database.
@@ -184,12 +224,23 @@ func (s *Server) UpdateRecord(ctx context.Context, req *pb.UpdateRecordRequest)
 
 	protoutil.ClearOutputOnly(in)
 
-	var out *pb.Record
+	var prev, out *pb.Record
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		r, err := getRecord(tx, parent, result, name)
+		r, err := getRecord(s.inCluster(ctx, tx), parent, result, name)
 		if err != nil {
 			return err
 		}
+		if err := s.checkCluster(ctx, r.Cluster, parent, auth.ResourceRecords, auth.PermissionUpdate); err != nil {
+			return err
+		}
+		if err := s.checkRecordConstraint(ctx, r); err != nil {
+			return err
+		}
+		audit.SetEtagBefore(ctx, r.Etag)
+		if in.GetCluster() != "" && !s.sameCluster(in.GetCluster(), r.Cluster) {
+			return status.Errorf(codes.FailedPrecondition, "record %s belongs to cluster %q, not %q", in.GetName(), r.Cluster, in.GetCluster())
+		}
+		prev = record.ToAPI(r)
 
 		// If the user provided the Etag field, then make sure the value of this field matches what saved in the database.
 		// See https://google.aip.dev/154 for more information.
@@ -201,6 +252,7 @@ func (s *Server) UpdateRecord(ctx context.Context, req *pb.UpdateRecordRequest)
 		pb := record.ToAPI(r)
 		// TODO: field mask support.
 		proto.Merge(pb, in)
+		pb.Cluster = r.Cluster
 
 		updateTime := timestamppb.New(clock.Now())
 		pb.UpdatedTime = updateTime
@@ -217,12 +269,22 @@ func (s *Server) UpdateRecord(ctx context.Context, req *pb.UpdateRecordRequest)
 		if err := errors.Wrap(tx.Save(s).Error); err != nil {
 			return err
 		}
+		if err := errors.Wrap(runresult.Index(tx, s)); err != nil {
+			return err
+		}
+		if err := errors.Wrap(provenance.Index(tx, s)); err != nil {
+			return err
+		}
 
 		pb.Etag = s.Etag
 		out = pb
 		return nil
 	})
-	return out, err
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.RecordUpdated(prev, out)
+	return out, nil
 }
 
 // DeleteRecord deletes a given record.
@@ -239,11 +301,97 @@ func (s *Server) DeleteRecord(ctx context.Context, req *pb.DeleteRecordRequest)
 	// the entry is already deleted.
 	// This does not need to be done in the same transaction as to delete,
 	// since the identifiers are immutable.
-	r, err := getRecord(s.db, parent, result, name)
+	r, err := getRecord(s.inCluster(ctx, s.db.WithContext(ctx)), parent, result, name)
 	if err != nil {
 		return &empty.Empty{}, err
 	}
-	return &empty.Empty{}, errors.Wrap(s.db.WithContext(ctx).Delete(&db.Record{}, r).Error)
+	if err := s.checkCluster(ctx, r.Cluster, parent, auth.ResourceRecords, auth.PermissionDelete); err != nil {
+		return &empty.Empty{}, err
+	}
+	if err := s.checkRecordConstraint(ctx, r); err != nil {
+		return &empty.Empty{}, err
+	}
+	audit.SetEtagBefore(ctx, r.Etag)
+	if err := errors.Wrap(s.db.WithContext(ctx).Delete(&db.Record{}, r).Error); err != nil {
+		return &empty.Empty{}, err
+	}
+	s.notifier.RecordDeleted(record.ToAPI(r))
+	return &empty.Empty{}, nil
+}
+
+// recordConstraint returns the CEL expression that Records of the given parent
+// must match to be visible to the caller, or an empty string if the auth
+// checker doesn't restrict them.
+func (s *Server) recordConstraint(ctx context.Context, parent string) (string, error) {
+	f, ok := s.auth.(auth.Filterer)
+	if !ok {
+		return "", nil
+	}
+	return f.Filter(ctx, parent, auth.ResourceRecords)
+}
+
+// checkRecordConstraint evaluates the constraint returned by recordConstraint
+// against a single Record. Records hidden from the caller are reported as not
+// found so as not to leak their existence, including to callers updating or
+// deleting them or their logs.
+func (s *Server) checkRecordConstraint(ctx context.Context, r *db.Record) error {
+	constraint, err := s.recordConstraint(ctx, r.Parent)
+	if err != nil || constraint == "" {
+		return err
+	}
+	prg, err := celenv.ParseFilter(s.recordsEnv, constraint)
+	if err != nil {
+		return err
+	}
+	var data map[string]any
+	if r.Data != nil {
+		if err := json.Unmarshal(r.Data, &data); err != nil {
+			return status.Errorf(codes.Internal, "error decoding record data: %v", err)
+		}
+	}
+	ok, err := celenv.Match(prg, map[string]any{
+		"parent":      r.Parent,
+		"result_name": r.ResultName,
+		"name":        r.Name,
+		"data_type":   r.Type,
+		"data":        data,
+		"cluster":     r.Cluster,
+	})
+	// Records missing the fields the constraint tests can't be evaluated, and
+	// are hidden as they are from lists.
+	if err != nil || !ok {
+		return status.Error(codes.NotFound, "record not found")
+	}
+	return nil
+}
+
+// checkCluster checks that the caller may act on a resource of the given
+// cluster, on top of the check of its parent, if the auth checker authorizes
+// the resources of several clusters.
+func (s *Server) checkCluster(ctx context.Context, cluster, parent, resource, verb string) error {
+	c, ok := s.auth.(auth.ClusterChecker)
+	if !ok {
+		return nil
+	}
+	return c.CheckCluster(ctx, cluster, parent, resource, verb)
+}
+
+// inCluster scopes the lookups of Results and Records by name of tx to the
+// cluster of the request, if known.
+func (s *Server) inCluster(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	return db.InCluster(tx, auth.RequestCluster(ctx), s.config.CLUSTER_NAME)
+}
+
+// sameCluster reports whether the clusters a and b are the same, Results and
+// Records without a cluster belonging to the cluster of the API server.
+func (s *Server) sameCluster(a, b string) bool {
+	if a == "" {
+		a = s.config.CLUSTER_NAME
+	}
+	if b == "" {
+		b = s.config.CLUSTER_NAME
+	}
+	return a == b
 }
 
 // recordCEL defines the CEL environment for querying Record data.
@@ -17,9 +17,12 @@ package server
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,7 +31,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/notify"
 	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	recordutil "github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
@@ -37,11 +42,15 @@ import (
 	ppb "github.com/tektoncd/results/proto/pipeline/v1/pipeline_go_proto"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	authnv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 func TestCreateRecord(t *testing.T) {
@@ -160,8 +169,8 @@ func TestCreateRecord_ConcurrentDelete(t *testing.T) {
 		&config.Config{DB_ENABLE_AUTO_MIGRATION: true},
 		logger.Get("info"),
 		test.NewDB(t),
-		withGetResultID(func(context.Context, string, string) (string, error) {
-			return result, nil
+		withGetResultID(func(context.Context, string, string) (*resultID, error) {
+			return &resultID{Name: result, ID: result}, nil
 		}),
 	)
 	if err != nil {
@@ -536,6 +545,16 @@ func TestListRecords(t *testing.T) {
 			t.Run("paginate records sorting by "+test.orderBy, testPagination("", test.orderBy, test.recordsToCompare))
 		}
 	}
+
+	sortedRecordsByName := make([]*pb.Record, len(records))
+	copy(sortedRecordsByName, records)
+	sort.Slice(sortedRecordsByName, func(i, j int) bool {
+		return sortedRecordsByName[i].GetName() > sortedRecordsByName[j].GetName()
+	})
+	t.Run("paginate records sorting by a JSON field", testPagination("", "data.metadata.name desc", sortedRecordsByName))
+	// No record has the first field, so they are sorted by the second one.
+	t.Run("paginate records sorting by fields in different directions",
+		testPagination("", "data.spec.status asc, create_time desc", reversedRecordsByTimestamp))
 }
 
 func TestUpdateRecord(t *testing.T) {
@@ -788,3 +807,457 @@ func TestListRecords_multiresult(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestListRecords_totalSize(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name          string
+		exactLimit    int
+		req           *pb.ListRecordsRequest
+		wantSize      int32
+		wantEstimated bool
+	}{
+		{
+			name:       "not requested",
+			exactLimit: 10,
+			req:        &pb.ListRecordsRequest{Parent: "0/results/-"},
+		},
+		{
+			name:       "exact",
+			exactLimit: 10,
+			req:        &pb.ListRecordsRequest{Parent: "0/results/-", PageSize: 5, IncludeTotalSize: true},
+			wantSize:   6,
+		},
+		{
+			name:       "filter",
+			exactLimit: 10,
+			req:        &pb.ListRecordsRequest{Parent: "-/results/-", Filter: `name == "1"`, IncludeTotalSize: true},
+			wantSize:   4,
+		},
+		{
+			name:       "at the limit",
+			exactLimit: 6,
+			req:        &pb.ListRecordsRequest{Parent: "0/results/-", IncludeTotalSize: true},
+			wantSize:   6,
+		},
+		{
+			name:          "estimated",
+			exactLimit:    2,
+			req:           &pb.ListRecordsRequest{Parent: "-/results/-", Filter: `name == "1"`, IncludeTotalSize: true},
+			wantSize:      4,
+			wantEstimated: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true, LIST_TOTAL_SIZE_EXACT_LIMIT: tc.exactLimit}, logger.Get("info"), test.NewDB(t))
+			if err != nil {
+				t.Fatalf("failed to setup db: %v", err)
+			}
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 2; j++ {
+					result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+						Parent: strconv.Itoa(i),
+						Result: &pb.Result{Name: fmt.Sprintf("%d/results/%d", i, j)},
+					})
+					if err != nil {
+						t.Fatalf("CreateResult(): %v", err)
+					}
+					for k := 0; k < 3; k++ {
+						if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+							Parent: result.GetName(),
+							Record: &pb.Record{Name: recordutil.FormatName(result.GetName(), strconv.Itoa(k))},
+						}); err != nil {
+							t.Fatalf("CreateRecord(): %v", err)
+						}
+					}
+				}
+			}
+
+			got, err := srv.ListRecords(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("ListRecords(): %v", err)
+			}
+			if got.GetTotalSize() != tc.wantSize || got.GetTotalSizeEstimated() != tc.wantEstimated {
+				t.Errorf("want total size %d (estimated: %t), got %d (estimated: %t)", tc.wantSize, tc.wantEstimated, got.GetTotalSize(), got.GetTotalSizeEstimated())
+			}
+		})
+	}
+}
+
+type staticAuthenticator authnv1.UserInfo
+
+func (a staticAuthenticator) Authenticate(context.Context) (*authnv1.UserInfo, error) {
+	u := authnv1.UserInfo(a)
+	return &u, nil
+}
+
+func TestRecords_policy(t *testing.T) {
+	checker, err := auth.NewPolicyChecker(auth.AllowAll{}, staticAuthenticator{Groups: []string{"team-a"}}, []auth.Policy{{
+		Name:   "team-a",
+		Match:  `"team-a" in groups`,
+		Filter: `data.metadata.labels["team"] == "a"`,
+	}})
+	if err != nil {
+		t.Fatalf("NewPolicyChecker: %v", err)
+	}
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t), WithAuth(checker))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{
+			Name: "foo/results/bar",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	records := make(map[string]*pb.Record)
+	for _, team := range []string{"a", "b"} {
+		r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: result.GetName(),
+			Record: &pb.Record{
+				Name: recordutil.FormatName(result.GetName(), team),
+				Data: &pb.Any{
+					Type: "TaskRun",
+					Value: jsonutil.AnyBytes(t, &pipelinev1.TaskRun{ObjectMeta: v1.ObjectMeta{
+						Name:   team,
+						Labels: map[string]string{"team": team},
+					}}),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		records[team] = r
+	}
+
+	t.Run("list", func(t *testing.T) {
+		got, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: "-/results/-"})
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if diff := cmp.Diff([]*pb.Record{records["a"]}, got.GetRecords(), protocmp.Transform()); diff != "" {
+			t.Errorf("-want, +got: %s", diff)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: records["a"].GetName()}); err != nil {
+			t.Errorf("GetRecord: %v", err)
+		}
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: records["b"].GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetRecord: want %v, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		if _, err := srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{Record: records["b"]}); status.Code(err) != codes.NotFound {
+			t.Errorf("UpdateRecord: want %v, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: records["b"].GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("DeleteRecord: want %v, got %v", codes.NotFound, err)
+		}
+		if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: records["a"].GetName()}); err != nil {
+			t.Errorf("DeleteRecord: %v", err)
+		}
+	})
+}
+
+// denyAll is an auth check denying every request.
+type denyAll struct{}
+
+func (denyAll) Check(context.Context, string, string, string) error {
+	return status.Error(codes.Unauthenticated, "permission denied")
+}
+
+func TestRecords_clusters(t *testing.T) {
+	gdb := test.NewDB(t)
+	cfg := &config.Config{DB_ENABLE_AUTO_MIGRATION: true, CLUSTER_NAME: "hub"}
+	srv, err := New(cfg, logger.Get("info"), gdb)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	create := func(name, cluster string) (*pb.Result, *pb.Record) {
+		t.Helper()
+		res, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: "ci",
+			Result: &pb.Result{Name: resultutil.FormatName("ci", name), Cluster: cluster},
+		})
+		if err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		rec, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: res.GetName(),
+			Record: &pb.Record{Name: recordutil.FormatName(res.GetName(), name)},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		return res, rec
+	}
+	hubResult, hubRecord := create("hub", "")
+	euResult, euRecord := create("eu", "build-eu-1")
+	if euRecord.GetCluster() != "build-eu-1" {
+		t.Errorf("Record of cluster %q, want the cluster of its Result", euRecord.GetCluster())
+	}
+
+	t.Run("immutable", func(t *testing.T) {
+		// Records are created in the Result of their cluster.
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: euResult.GetName(),
+			Record: &pb.Record{Name: recordutil.FormatName(euResult.GetName(), "us"), Cluster: "build-us-1"},
+		}); status.Code(err) != codes.NotFound {
+			t.Errorf("CreateRecord: want %v, got %v", codes.NotFound, err)
+		}
+		// Results without a cluster belong to the cluster of the API server.
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: hubResult.GetName(),
+			Record: &pb.Record{Name: recordutil.FormatName(hubResult.GetName(), "named"), Cluster: "hub"},
+		}); err != nil {
+			t.Errorf("CreateRecord: %v", err)
+		}
+		if _, err := srv.UpdateResult(ctx, &pb.UpdateResultRequest{
+			Name:   euResult.GetName(),
+			Result: &pb.Result{Name: euResult.GetName(), Cluster: "build-us-1"},
+		}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("UpdateResult: want %v, got %v", codes.FailedPrecondition, err)
+		}
+		if _, err := srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{
+			Record: &pb.Record{Name: euRecord.GetName(), Cluster: "build-us-1"},
+		}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("UpdateRecord: want %v, got %v", codes.FailedPrecondition, err)
+		}
+	})
+
+	t.Run("same name", func(t *testing.T) {
+		inCluster := func(cluster string) context.Context {
+			return metadata.NewIncomingContext(ctx, metadata.Pairs(auth.ClusterHeader, cluster))
+		}
+		name := resultutil.FormatName("ci", "shared")
+		recordName := recordutil.FormatName(name, "shared")
+		results := map[string]*pb.Result{}
+		for _, cluster := range []string{"hub", "build-eu-1"} {
+			res, err := srv.CreateResult(inCluster(cluster), &pb.CreateResultRequest{
+				Parent: "ci",
+				Result: &pb.Result{Name: name},
+			})
+			if err != nil {
+				t.Fatalf("CreateResult in cluster %s: %v", cluster, err)
+			}
+			if _, err := srv.CreateRecord(inCluster(cluster), &pb.CreateRecordRequest{
+				Parent: name,
+				Record: &pb.Record{Name: recordName},
+			}); err != nil {
+				t.Fatalf("CreateRecord in cluster %s: %v", cluster, err)
+			}
+			results[cluster] = res
+		}
+		// Results of the local cluster are stored without a cluster.
+		if c := results["hub"].GetCluster(); c != "" {
+			t.Errorf("CreateResult in cluster hub: got Result of cluster %q", c)
+		}
+
+		for cluster, want := range results {
+			got, err := srv.GetResult(inCluster(cluster), &pb.GetResultRequest{Name: name})
+			if err != nil {
+				t.Fatalf("GetResult in cluster %s: %v", cluster, err)
+			}
+			if got.GetUid() != want.GetUid() {
+				t.Errorf("GetResult in cluster %s: got Result %s, want %s", cluster, got.GetUid(), want.GetUid())
+			}
+			rec, err := srv.GetRecord(inCluster(cluster), &pb.GetRecordRequest{Name: recordName})
+			if err != nil {
+				t.Fatalf("GetRecord in cluster %s: %v", cluster, err)
+			}
+			if rec.GetCluster() != want.GetCluster() {
+				t.Errorf("GetRecord in cluster %s: got Record of cluster %q", cluster, rec.GetCluster())
+			}
+		}
+		// Requests which don't tell their cluster find the local Result.
+		got, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: name})
+		if err != nil {
+			t.Fatalf("GetResult: %v", err)
+		}
+		if got.GetUid() != results["hub"].GetUid() {
+			t.Errorf("GetResult: got Result %s, want %s", got.GetUid(), results["hub"].GetUid())
+		}
+
+		if _, err := srv.DeleteResult(inCluster("build-eu-1"), &pb.DeleteResultRequest{Name: name}); err != nil {
+			t.Fatalf("DeleteResult in cluster build-eu-1: %v", err)
+		}
+		if _, err := srv.GetResult(inCluster("build-eu-1"), &pb.GetResultRequest{Name: name}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetResult in cluster build-eu-1: want %v, got %v", codes.NotFound, err)
+		}
+		if _, err := srv.DeleteResult(inCluster("hub"), &pb.DeleteResultRequest{Name: name}); err != nil {
+			t.Fatalf("DeleteResult in cluster hub: %v", err)
+		}
+	})
+
+	// The caller is only allowed in the build-eu-1 cluster.
+	clusters, err := auth.NewClusters("hub", denyAll{}, map[string]auth.Checker{"build-eu-1": auth.AllowAll{}})
+	if err != nil {
+		t.Fatalf("NewClusters: %v", err)
+	}
+	srv, err = New(cfg, logger.Get("info"), gdb, WithAuth(clusters))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		results, err := srv.ListResults(ctx, &pb.ListResultsRequest{Parent: "-"})
+		if err != nil {
+			t.Fatalf("ListResults: %v", err)
+		}
+		if diff := cmp.Diff([]*pb.Result{euResult}, results.GetResults(), protocmp.Transform()); diff != "" {
+			t.Errorf("ListResults -want, +got: %s", diff)
+		}
+		records, err := srv.ListRecords(ctx, &pb.ListRecordsRequest{Parent: "-/results/-"})
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if diff := cmp.Diff([]*pb.Record{euRecord}, records.GetRecords(), protocmp.Transform()); diff != "" {
+			t.Errorf("ListRecords -want, +got: %s", diff)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		if _, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: euResult.GetName()}); err != nil {
+			t.Errorf("GetResult: %v", err)
+		}
+		if _, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: hubResult.GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetResult: want %v, got %v", codes.NotFound, err)
+		}
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: euRecord.GetName()}); err != nil {
+			t.Errorf("GetRecord: %v", err)
+		}
+		if _, err := srv.GetRecord(ctx, &pb.GetRecordRequest{Name: hubRecord.GetName()}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetRecord: want %v, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		for _, tc := range []struct {
+			cluster string
+			want    codes.Code
+		}{
+			{cluster: "build-eu-1", want: codes.OK},
+			{cluster: "", want: codes.Unauthenticated},
+			{cluster: "build-us-1", want: codes.PermissionDenied},
+		} {
+			_, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+				Parent: "ci",
+				Result: &pb.Result{Name: "ci/results/new-" + strings.TrimSuffix(tc.cluster+"-", "-"), Cluster: tc.cluster},
+			})
+			if status.Code(err) != tc.want {
+				t.Errorf("CreateResult in cluster %q: want %v, got %v", tc.cluster, tc.want, err)
+			}
+		}
+		if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: hubRecord.GetName()}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("DeleteRecord: want %v, got %v", codes.Unauthenticated, err)
+		}
+		if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: euRecord.GetName()}); err != nil {
+			t.Errorf("DeleteRecord: %v", err)
+		}
+	})
+}
+
+func TestRecords_notifications(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, r.Header.Get("ce-type")+" "+r.Header.Get("ce-subject"))
+	}))
+	defer receiver.Close()
+	notifier, err := notify.New([]notify.Subscription{{Name: "test", URL: receiver.URL, Filter: `name == "" || data_type == PIPELINE_RUN`}}, logger.Get("info"))
+	if err != nil {
+		t.Fatalf("notify.New: %v", err)
+	}
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t), WithNotifier(notifier))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: "foo/results/bar"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	pr := &pipelinev1.PipelineRun{ObjectMeta: v1.ObjectMeta{Name: "baz"}}
+	r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: result.GetName(),
+		Record: &pb.Record{
+			Name: recordutil.FormatName(result.GetName(), "baz"),
+			Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: jsonutil.AnyBytes(t, pr)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	// Not matched by the filter.
+	if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: result.GetName(),
+		Record: &pb.Record{
+			Name: recordutil.FormatName(result.GetName(), "taskrun"),
+			Data: &pb.Any{Type: "tekton.dev/v1.TaskRun", Value: []byte("{}")},
+		},
+	}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	r.Data.Value = jsonutil.AnyBytes(t, pr)
+	if _, err := srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{Record: r}); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: r.GetName()}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	// Deleted in cascade with the Result, and read one at a time.
+	for _, name := range []string{"qux", "quux"} {
+		if _, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: result.GetName(),
+			Record: &pb.Record{
+				Name: recordutil.FormatName(result.GetName(), name),
+				Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: jsonutil.AnyBytes(t, &pipelinev1.PipelineRun{ObjectMeta: v1.ObjectMeta{Name: name}})},
+			},
+		}); err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+	}
+	defer func(size int) { deletedRecordsBatchSize = size }(deletedRecordsBatchSize)
+	deletedRecordsBatchSize = 1
+	if _, err := srv.DeleteResult(ctx, &pb.DeleteResultRequest{Name: result.GetName()}); err != nil {
+		t.Fatalf("DeleteResult: %v", err)
+	}
+	notifier.Close()
+
+	want := []string{
+		notify.EventResultCreated + " foo/results/bar",
+		notify.EventRecordCreated + " foo/results/bar/records/baz",
+		notify.EventRecordUpdated + " foo/results/bar/records/baz",
+		notify.EventRunCompleted + " foo/results/bar/records/baz",
+		notify.EventRecordDeleted + " foo/results/bar/records/baz",
+		notify.EventRecordCreated + " foo/results/bar/records/qux",
+		notify.EventRecordCreated + " foo/results/bar/records/quux",
+		notify.EventRecordDeleted + " foo/results/bar/records/qux",
+		notify.EventRecordDeleted + " foo/results/bar/records/quux",
+		notify.EventResultDeleted + " foo/results/bar",
+	}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
@@ -60,6 +60,9 @@ func ToStorage(r *pb.Result) (*db.Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := record.ValidateCluster(r.GetCluster()); err != nil {
+		return nil, err
+	}
 	id := r.GetUid()
 	if id == "" {
 		id = r.GetId()
@@ -70,6 +73,7 @@ func ToStorage(r *pb.Result) (*db.Result, error) {
 		Name:        name,
 		Annotations: r.Annotations,
 		Etag:        r.Etag,
+		Cluster:     r.GetCluster(),
 	}
 
 	if r.CreatedTime.IsValid() {
@@ -140,6 +144,7 @@ func ToAPI(r *db.Result) *pb.Result {
 		Annotations: r.Annotations,
 		Etag:        r.Etag,
 		Summary:     summary,
+		Cluster:     r.Cluster,
 	}
 }
 
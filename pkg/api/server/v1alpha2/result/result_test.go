@@ -115,6 +115,7 @@ func TestToStorage(t *testing.T) {
 				UpdatedTime: timestamppb.New(clock.Now()),
 				Annotations: map[string]string{"a": "b"},
 				Etag:        "tacocat",
+				Cluster:     "build-eu-1",
 				Summary: &pb.RecordSummary{
 					Record:      "foo/results/bar/records/baz",
 					Type:        "bar",
@@ -132,6 +133,7 @@ func TestToStorage(t *testing.T) {
 				CreatedTime: clock.Now(),
 				UpdatedTime: clock.Now(),
 				Etag:        "tacocat",
+				Cluster:     "build-eu-1",
 				Summary: db.RecordSummary{
 					Record:      "foo/results/bar/records/baz",
 					Type:        "bar",
@@ -226,6 +228,7 @@ func TestToAPI(t *testing.T) {
 		UpdatedTime: clock.Now(),
 		Annotations: ann,
 		Etag:        "etag",
+		Cluster:     "build-eu-1",
 	})
 	want := &pb.Result{
 		Name:        "foo/results/bar",
@@ -237,6 +240,7 @@ func TestToAPI(t *testing.T) {
 		UpdateTime:  timestamppb.New(clock.Now()),
 		Annotations: ann,
 		Etag:        "etag",
+		Cluster:     "build-eu-1",
 	}
 	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
 		t.Errorf("-want,+got: %s", diff)
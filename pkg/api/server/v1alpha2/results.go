@@ -21,10 +21,13 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"gorm.io/gorm"
 
+	"github.com/tektoncd/results/pkg/api/server/audit"
+	celenv "github.com/tektoncd/results/pkg/api/server/cel"
 	"github.com/tektoncd/results/pkg/api/server/db"
 	"github.com/tektoncd/results/pkg/api/server/db/errors"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
 	"github.com/tektoncd/results/pkg/internal/protoutil"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
@@ -45,9 +48,23 @@ func (s *Server) CreateResult(ctx context.Context, req *pb.CreateResultRequest)
 	if req.GetParent() != parent {
 		return nil, status.Error(codes.InvalidArgument, "requested parent does not match resource name")
 	}
+	// Results are created in the cluster of the request unless told otherwise.
+	if r.GetCluster() == "" {
+		r.Cluster = auth.RequestCluster(ctx)
+	} else {
+		ctx = auth.WithCluster(ctx, r.GetCluster())
+	}
 	if err := s.auth.Check(ctx, parent, auth.ResourceResults, auth.PermissionCreate); err != nil {
 		return nil, err
 	}
+	if err := s.checkCluster(ctx, r.GetCluster(), parent, auth.ResourceResults, auth.PermissionCreate); err != nil {
+		return nil, err
+	}
+	// Results of the local cluster are stored without a cluster, so that they
+	// are told apart from those of other clusters whatever it is named.
+	if s.sameCluster(r.GetCluster(), "") {
+		r.Cluster = ""
+	}
 
 	// Populate Result with server provided fields.
 	protoutil.ClearOutputOnly(r)
@@ -72,7 +89,9 @@ func (s *Server) CreateResult(ctx context.Context, req *pb.CreateResultRequest)
 	if err := errors.Wrap(s.db.WithContext(ctx).Create(store).Error); err != nil {
 		return nil, err
 	}
-	return result.ToAPI(store), nil
+	out := result.ToAPI(store)
+	s.notifier.ResultCreated(out)
+	return out, nil
 }
 
 // GetResult returns a single Result.
@@ -84,10 +103,13 @@ func (s *Server) GetResult(ctx context.Context, req *pb.GetResultRequest) (*pb.R
 	if err := s.auth.Check(ctx, parent, auth.ResourceResults, auth.PermissionGet); err != nil {
 		return nil, err
 	}
-	store, err := getResultByParentName(s.db, parent, name)
+	store, err := getResultByParentName(s.inCluster(ctx, s.db.WithContext(ctx)), parent, name)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkResultConstraint(ctx, store); err != nil {
+		return nil, err
+	}
 	return result.ToAPI(store), nil
 }
 
@@ -104,10 +126,17 @@ func (s *Server) UpdateResult(ctx context.Context, req *pb.UpdateResultRequest)
 
 	var out *pb.Result
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		prev, err := getResultByParentName(tx, parent, name)
+		prev, err := getResultByParentName(s.inCluster(ctx, tx), parent, name)
 		if err != nil {
 			return status.Errorf(codes.NotFound, "failed to find a result: %v", err)
 		}
+		if err := s.checkCluster(ctx, prev.Cluster, parent, auth.ResourceResults, auth.PermissionUpdate); err != nil {
+			return err
+		}
+		audit.SetEtagBefore(ctx, prev.Etag)
+		if c := req.GetResult().GetCluster(); c != "" && !s.sameCluster(c, prev.Cluster) {
+			return status.Errorf(codes.FailedPrecondition, "result %s belongs to cluster %q, not %q", req.GetName(), prev.Cluster, c)
+		}
 
 		// If the user provided the Etag field, then make sure the value of this field matches what saved in the database.
 		// See https://google.aip.dev/154 for more information.
@@ -145,9 +174,17 @@ func (s *Server) UpdateResult(ctx context.Context, req *pb.UpdateResultRequest)
 
 		return nil
 	})
-	return out, err
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.ResultUpdated(out)
+	return out, nil
 }
 
+// deletedRecordsBatchSize is how many of the Records deleted in cascade with a
+// Result are read at once to notify their deletion.
+var deletedRecordsBatchSize = 500
+
 // DeleteResult deletes a given result.
 func (s *Server) DeleteResult(ctx context.Context, req *pb.DeleteResultRequest) (*empty.Empty, error) {
 	parent, name, err := result.ParseName(req.GetName())
@@ -163,16 +200,50 @@ func (s *Server) DeleteResult(ctx context.Context, req *pb.DeleteResultRequest)
 	// This does not need to be done in the same transaction as to delete,
 	// since the identifiers are immutable.
 	r := &db.Result{}
-	get := s.db.WithContext(ctx).
+	get := s.inCluster(ctx, s.db.WithContext(ctx)).
 		Where(&db.Result{Parent: parent, Name: name}).
 		First(r)
 	if err := errors.Wrap(get.Error); err != nil {
 		return &empty.Empty{}, err
 	}
+	if err := s.checkCluster(ctx, r.Cluster, parent, auth.ResourceResults, auth.PermissionDelete); err != nil {
+		return &empty.Empty{}, err
+	}
+	audit.SetEtagBefore(ctx, r.Etag)
 
-	// Delete the result.
-	del := s.db.WithContext(ctx).Delete(&db.Result{}, r)
-	return &empty.Empty{}, errors.Wrap(del.Error)
+	// Delete the result. Its records are deleted in cascade, and are reported
+	// as deleted too when notifications are enabled. Only their identifiers
+	// and types are read, in batches, as their data may be large.
+	var records []*pb.Record
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if s.notifier != nil {
+			var batch []*db.Record
+			q := tx.Model(&db.Record{}).
+				Select("parent", "result_name", "id", "name", "type").
+				Where(&db.Record{Parent: r.Parent, ResultID: r.ID}).
+				FindInBatches(&batch, deletedRecordsBatchSize, func(*gorm.DB, int) error {
+					for _, rec := range batch {
+						out := record.ToAPI(rec)
+						out.Data = &pb.Any{Type: rec.Type}
+						out.Cluster = r.Cluster
+						records = append(records, out)
+					}
+					return nil
+				})
+			if err := q.Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&db.Result{}, r).Error
+	})
+	if err := errors.Wrap(err); err != nil {
+		return &empty.Empty{}, err
+	}
+	for _, rec := range records {
+		s.notifier.RecordDeleted(rec)
+	}
+	s.notifier.ResultDeleted(result.ToAPI(r))
+	return &empty.Empty{}, nil
 }
 
 // ListResults returns list results from the database.
@@ -189,16 +260,30 @@ func (s *Server) ListResults(ctx context.Context, req *pb.ListResultsRequest) (*
 	if err != nil {
 		return nil, err
 	}
+	constraint, err := s.resultConstraint(ctx, req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	resultsLister.Constrain(constraint)
 
 	results, nextPageToken, err := resultsLister.List(ctx, s.db)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.ListResultsResponse{
+	resp := &pb.ListResultsResponse{
 		Results:       results,
 		NextPageToken: nextPageToken,
-	}, nil
+	}
+	if req.GetIncludeTotalSize() {
+		total, estimated, err := resultsLister.Count(ctx, s.db, s.config.LIST_TOTAL_SIZE_EXACT_LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		resp.TotalSize = totalSize(total)
+		resp.TotalSizeEstimated = estimated
+	}
+	return resp, nil
 }
 
 func getResultByParentName(gdb *gorm.DB, parent, name string) (*db.Result, error) {
@@ -211,3 +296,48 @@ func getResultByParentName(gdb *gorm.DB, parent, name string) (*db.Result, error
 	}
 	return r, nil
 }
+
+// resultConstraint returns the CEL expression that Results of the given parent
+// must match to be visible to the caller, or an empty string if the auth
+// checker doesn't restrict them.
+func (s *Server) resultConstraint(ctx context.Context, parent string) (string, error) {
+	f, ok := s.auth.(auth.Filterer)
+	if !ok {
+		return "", nil
+	}
+	return f.Filter(ctx, parent, auth.ResourceResults)
+}
+
+// checkResultConstraint evaluates the constraint returned by resultConstraint
+// against a single Result. Results hidden from the caller are reported as not
+// found so as not to leak their existence.
+func (s *Server) checkResultConstraint(ctx context.Context, r *db.Result) error {
+	constraint, err := s.resultConstraint(ctx, r.Parent)
+	if err != nil || constraint == "" {
+		return err
+	}
+	prg, err := celenv.ParseFilter(s.resultsEnv, constraint)
+	if err != nil {
+		return status.Error(codes.NotFound, "result not found")
+	}
+	out := result.ToAPI(r)
+	summary := out.GetSummary()
+	if summary == nil {
+		summary = &pb.RecordSummary{}
+	}
+	ok, err := celenv.Match(prg, map[string]any{
+		"parent":      r.Parent,
+		"uid":         r.ID,
+		"annotations": map[string]string(r.Annotations),
+		"summary":     summary,
+		"create_time": out.GetCreateTime(),
+		"update_time": out.GetUpdateTime(),
+		"cluster":     r.Cluster,
+	})
+	// Results missing the fields the constraint tests can't be evaluated, and
+	// are hidden as they are from lists.
+	if err != nil || !ok {
+		return status.Error(codes.NotFound, "result not found")
+	}
+	return nil
+}
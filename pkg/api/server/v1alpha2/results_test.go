@@ -30,6 +30,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/tektoncd/results/pkg/api/server/db/pagination"
 	"github.com/tektoncd/results/pkg/api/server/test"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	recordutil "github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"google.golang.org/grpc/codes"
@@ -386,7 +387,8 @@ func TestListResults(t *testing.T) {
 					Record:    fmt.Sprintf("%s/results/%s/records/%s", parent, resultID, uuid.New().String()),
 					Type:      "resource_type",
 					StartTime: timestamppb.New(fakeClock.Now()),
-					EndTime:   timestamppb.New(fakeClock.Now().Add(time.Minute)),
+					// Later results end later, but take less time.
+					EndTime: timestamppb.New(fakeClock.Now().Add(time.Minute - time.Duration(i)*500*time.Millisecond)),
 				},
 			},
 		})
@@ -615,8 +617,45 @@ func TestListResults(t *testing.T) {
 		}
 	}
 
+	t.Run("paginate results sorting by summary.duration asc", testPagination("", "summary.duration asc", reversedResultsByTimestamp))
+	t.Run("paginate results sorting by summary.duration desc", testPagination("", "summary.duration desc", sortedResultsByTimestamp))
+
+	sortedResultsByAnnotation := make([]*pb.Result, len(results))
+	copy(sortedResultsByAnnotation, results)
+	sort.Slice(sortedResultsByAnnotation, func(i, j int) bool {
+		return sortedResultsByAnnotation[i].Annotations["foo"] > sortedResultsByAnnotation[j].Annotations["foo"]
+	})
+	t.Run("paginate results sorting by an annotation", testPagination("", `annotations["foo"] desc`, sortedResultsByAnnotation))
+	// All the results have the same status, so they are sorted by the second field.
+	t.Run("paginate results sorting by fields in different directions",
+		testPagination("", "summary.status desc, create_time asc", sortedResultsByTimestamp))
+
 	filter := fmt.Sprintf(`annotations["foo"] != %q && annotations["foo"] != %q`, results[0].Annotations["foo"], results[1].Annotations["foo"])
 	t.Run("paginate results using filter", testPagination(filter, "", results[2:]))
+
+	t.Run("total size", func(t *testing.T) {
+		for _, tc := range []struct {
+			exactLimit    int
+			wantEstimated bool
+		}{
+			{exactLimit: 100},
+			{exactLimit: 10, wantEstimated: true},
+		} {
+			server.config.LIST_TOTAL_SIZE_EXACT_LIMIT = tc.exactLimit
+			got, err := server.ListResults(ctx, &pb.ListResultsRequest{
+				Parent:           parent,
+				Filter:           filter,
+				PageSize:         5,
+				IncludeTotalSize: true,
+			})
+			if err != nil {
+				t.Fatalf("ListResults(): %v", err)
+			}
+			if got.GetTotalSize() != 18 || got.GetTotalSizeEstimated() != tc.wantEstimated {
+				t.Errorf("exact limit %d: want total size 18 (estimated: %t), got %d (estimated: %t)", tc.exactLimit, tc.wantEstimated, got.GetTotalSize(), got.GetTotalSizeEstimated())
+			}
+		}
+	})
 }
 
 func pagetoken(t *testing.T, name, filter string) string {
@@ -631,3 +670,48 @@ func pagetoken(t *testing.T, name, filter string) string {
 func mockEtag(id uint32, t int64) string {
 	return fmt.Sprintf("%v-%v", id, t)
 }
+
+// resultsFilter is a Checker allowing all the actions, restricting the
+// Results visible to callers to those matching a CEL filter.
+type resultsFilter struct {
+	auth.AllowAll
+	filter string
+}
+
+func (f resultsFilter) Filter(_ context.Context, _, resource string) (string, error) {
+	if resource != auth.ResourceResults {
+		return "", nil
+	}
+	return f.filter, nil
+}
+
+func TestGetResult_constraint(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name   string
+		filter string
+		want   codes.Code
+	}{
+		{name: "match", filter: `annotations["team"] == "a"`, want: codes.OK},
+		{name: "no match", filter: `annotations["team"] == "b"`, want: codes.NotFound},
+		// Results without the annotation can't be evaluated.
+		{name: "missing field", filter: `annotations["repo"] == "a"`, want: codes.NotFound},
+		{name: "invalid", filter: `annotations[`, want: codes.NotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t), WithAuth(resultsFilter{filter: tc.filter}))
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+			if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+				Parent: "foo",
+				Result: &pb.Result{Name: "foo/results/bar", Annotations: map[string]string{"team": "a"}},
+			}); err != nil {
+				t.Fatalf("CreateResult: %v", err)
+			}
+			if _, err := srv.GetResult(ctx, &pb.GetResultRequest{Name: "foo/results/bar"}); status.Code(err) != tc.want {
+				t.Errorf("GetResult: want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
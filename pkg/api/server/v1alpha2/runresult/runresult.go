@@ -0,0 +1,143 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runresult indexes the results of the TaskRuns and PipelineRuns
+// stored in Records, so that runs can be searched by the values of their
+// results.
+package runresult
+
+import (
+	"encoding/json"
+	"sort"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxNameLength and MaxValueLength are the sizes of the indexed names
+	// and values. Longer results aren't indexed, as truncated values would
+	// match results they don't hold.
+	MaxNameLength  = 256
+	MaxValueLength = 512
+
+	batchSize = 100
+)
+
+// runTypes are the types of the Records holding runs with results.
+var runTypes = map[string]bool{
+	"tekton.dev/v1.TaskRun":          true,
+	"tekton.dev/v1.PipelineRun":      true,
+	"tekton.dev/v1beta1.TaskRun":     true,
+	"tekton.dev/v1beta1.PipelineRun": true,
+}
+
+// run holds the results of TaskRuns and PipelineRuns of both v1 and v1beta1,
+// which only differ by the name of the field holding them.
+type run struct {
+	Status struct {
+		Results         []result `json:"results"`
+		TaskResults     []result `json:"taskResults"`
+		PipelineResults []result `json:"pipelineResults"`
+	} `json:"status"`
+}
+
+type result struct {
+	Name  string                 `json:"name"`
+	Value pipelinev1.ResultValue `json:"value"`
+}
+
+// Index replaces the indexed results of the Record r by the results of the
+// run it holds, within the transaction tx. Records which don't hold runs
+// aren't indexed.
+func Index(tx *gorm.DB, r *db.Record) error {
+	if !runTypes[r.Type] {
+		return nil
+	}
+	if err := tx.Where(&db.RunResult{Parent: r.Parent, ResultID: r.ResultID, RecordID: r.ID}).
+		Delete(&db.RunResult{}).Error; err != nil {
+		return err
+	}
+	rows := FromRecord(r)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(rows, batchSize).Error
+}
+
+// FromRecord returns the indexed results of the run held by the Record r,
+// sorted by name and value. Records whose data can't be decoded have no
+// results, so that they are stored regardless.
+func FromRecord(r *db.Record) []*db.RunResult {
+	if !runTypes[r.Type] {
+		return nil
+	}
+	o := &run{}
+	if err := json.Unmarshal(r.Data, o); err != nil {
+		return nil
+	}
+
+	type key struct{ name, value string }
+	seen := map[key]bool{}
+	var rows []*db.RunResult
+	add := func(name, value string) {
+		k := key{name, value}
+		if seen[k] || len(name) > MaxNameLength || len(value) > MaxValueLength {
+			return
+		}
+		seen[k] = true
+		rows = append(rows, &db.RunResult{
+			Parent:   r.Parent,
+			ResultID: r.ResultID,
+			RecordID: r.ID,
+			Name:     name,
+			Value:    value,
+		})
+	}
+	for _, results := range [][]result{o.Status.Results, o.Status.TaskResults, o.Status.PipelineResults} {
+		for _, res := range results {
+			switch res.Value.Type {
+			case pipelinev1.ParamTypeArray:
+				for _, v := range res.Value.ArrayVal {
+					add(res.Name, v)
+				}
+			case pipelinev1.ParamTypeObject:
+				for k, v := range res.Value.ObjectVal {
+					add(res.Name+"."+k, v)
+				}
+			default:
+				add(res.Name, res.Value.StringVal)
+			}
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		return rows[i].Value < rows[j].Value
+	})
+	return rows
+}
+
+// Having constrains a query of Records to the Records of runs having a result
+// with the given name and value.
+func Having(q *gorm.DB, name, value string) *gorm.DB {
+	return q.Where(`EXISTS (SELECT 1 FROM run_results
+		WHERE run_results.parent = records.parent
+		AND run_results.result_id = records.result_id
+		AND run_results.record_id = records.id
+		AND run_results.name = ? AND run_results.value = ?)`, name, value)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runresult
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/db"
+)
+
+func TestFromRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		dataType string
+		data     string
+		want     [][2]string
+	}{
+		{
+			name:     "v1 TaskRun",
+			dataType: "tekton.dev/v1.TaskRun",
+			data: `{"status":{"results":[
+				{"name":"IMAGE_DIGEST","type":"string","value":"sha256:aaaa"},
+				{"name":"IMAGES","type":"array","value":["registry/b","registry/a","registry/a"]},
+				{"name":"ARTIFACT_OUTPUTS","type":"object","value":{"uri":"registry/a","digest":"sha256:aaaa"}}
+			]}}`,
+			want: [][2]string{
+				{"ARTIFACT_OUTPUTS.digest", "sha256:aaaa"},
+				{"ARTIFACT_OUTPUTS.uri", "registry/a"},
+				{"IMAGES", "registry/a"},
+				{"IMAGES", "registry/b"},
+				{"IMAGE_DIGEST", "sha256:aaaa"},
+			},
+		},
+		{
+			name:     "v1beta1 TaskRun",
+			dataType: "tekton.dev/v1beta1.TaskRun",
+			data:     `{"status":{"taskResults":[{"name":"IMAGE_DIGEST","value":"sha256:aaaa"}]}}`,
+			want:     [][2]string{{"IMAGE_DIGEST", "sha256:aaaa"}},
+		},
+		{
+			name:     "v1 PipelineRun",
+			dataType: "tekton.dev/v1.PipelineRun",
+			data:     `{"status":{"results":[{"name":"commit","value":"abc"}]}}`,
+			want:     [][2]string{{"commit", "abc"}},
+		},
+		{
+			name:     "v1beta1 PipelineRun",
+			dataType: "tekton.dev/v1beta1.PipelineRun",
+			data:     `{"status":{"pipelineResults":[{"name":"commit","value":"abc"}]}}`,
+			want:     [][2]string{{"commit", "abc"}},
+		},
+		{
+			name:     "long value",
+			dataType: "tekton.dev/v1.TaskRun",
+			data:     `{"status":{"results":[{"name":"report","value":"` + strings.Repeat("a", MaxValueLength+1) + `"},{"name":"commit","value":"abc"}]}}`,
+			want:     [][2]string{{"commit", "abc"}},
+		},
+		{
+			name:     "other type",
+			dataType: "tekton.dev/v1beta1.CustomRun",
+			data:     `{"status":{"results":[{"name":"commit","value":"abc"}]}}`,
+		},
+		{
+			name:     "invalid data",
+			dataType: "tekton.dev/v1.TaskRun",
+			data:     `{`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &db.Record{Parent: "ns", ResultID: "1", ID: "2", Type: tc.dataType, Data: []byte(tc.data)}
+			var got [][2]string
+			for _, row := range FromRecord(r) {
+				if row.Parent != r.Parent || row.ResultID != r.ResultID || row.RecordID != r.ID {
+					t.Errorf("result %s of Record (%s, %s, %s)", row.Name, row.Parent, row.ResultID, row.RecordID)
+				}
+				got = append(got, [2]string{row.Name, row.Value})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
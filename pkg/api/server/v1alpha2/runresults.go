@@ -0,0 +1,77 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/runresult"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchRunsByResult finds the Records of the runs which produced a result
+// with the given value, from the index of run results.
+func (s *Server) SearchRunsByResult(ctx context.Context, req *pb.SearchRunsByResultRequest) (*pb.SearchRunsByResultResponse, error) {
+	if req.GetParent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "parent missing")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "result name missing")
+	}
+	if len(req.GetName()) > runresult.MaxNameLength || len(req.GetValue()) > runresult.MaxValueLength {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("results are only indexed up to %d characters per name and %d per value",
+				runresult.MaxNameLength, runresult.MaxValueLength))
+	}
+
+	parent, resultName, err := result.ParseName(req.GetParent())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+
+	recordsLister, err := lister.OfRecords(s.recordsEnv, parent, resultName, &pb.ListRecordsRequest{
+		Parent:    req.GetParent(),
+		Filter:    req.GetFilter(),
+		OrderBy:   "create_time asc",
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	recordsLister.Constrain(constraint)
+
+	records, nextPageToken, err := recordsLister.List(ctx, runresult.Having(s.db, req.GetName(), req.GetValue()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SearchRunsByResultResponse{
+		Records:       records,
+		NextPageToken: nextPageToken,
+	}, nil
+}
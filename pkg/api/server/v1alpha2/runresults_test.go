@@ -0,0 +1,163 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSearchRunsByResult(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to setup db: %v", err)
+	}
+	ctx := context.Background()
+
+	const digest = "sha256:aaaa"
+	taskRun := func(results ...pipelinev1.TaskRunResult) *pb.Any {
+		t.Helper()
+		tr := &pipelinev1.TaskRun{}
+		tr.Status.Results = results
+		b, err := json.Marshal(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pb.Any{Type: "tekton.dev/v1.TaskRun", Value: b}
+	}
+	create := func(namespace, name string, data *pb.Any) *pb.Record {
+		t.Helper()
+		result := namespace + "/results/" + name
+		if _, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+			Parent: namespace,
+			Result: &pb.Result{Name: result},
+		}); err != nil {
+			t.Fatalf("CreateResult: %v", err)
+		}
+		r, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+			Parent: result,
+			Record: &pb.Record{Name: result + "/records/" + name, Data: data},
+		})
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		return r
+	}
+
+	create("ns", "build", taskRun(
+		pipelinev1.TaskRunResult{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues(digest)},
+	))
+	create("ns", "images", taskRun(
+		pipelinev1.TaskRunResult{Name: "IMAGES", Value: *pipelinev1.NewStructuredValues("registry/a", "registry/b")},
+		pipelinev1.TaskRunResult{Name: "ARTIFACT_OUTPUTS", Value: *pipelinev1.NewObject(map[string]string{"digest": digest})},
+	))
+	create("other", "build", taskRun(
+		pipelinev1.TaskRunResult{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues(digest)},
+	))
+	// The result of this run is updated to another value.
+	updated := create("ns", "updated", taskRun(
+		pipelinev1.TaskRunResult{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues(digest)},
+	))
+	updated.Data = taskRun(pipelinev1.TaskRunResult{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues("sha256:bbbb")})
+	if _, err := srv.UpdateRecord(ctx, &pb.UpdateRecordRequest{Record: updated}); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+	// The results of deleted runs are deleted along with them.
+	deleted := create("ns", "deleted", taskRun(
+		pipelinev1.TaskRunResult{Name: "IMAGE_DIGEST", Value: *pipelinev1.NewStructuredValues(digest)},
+	))
+	if _, err := srv.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: deleted.GetName()}); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	// Records of other types, such as CustomRuns, aren't indexed.
+	create("ns", "custom", &pb.Any{Type: "tekton.dev/v1beta1.CustomRun", Value: []byte(`{"status":{"results":[{"name":"IMAGE_DIGEST","value":"sha256:aaaa"}]}}`)})
+
+	for _, tc := range []struct {
+		name   string
+		req    *pb.SearchRunsByResultRequest
+		want   []string
+		status codes.Code
+	}{
+		{
+			name: "string result",
+			req:  &pb.SearchRunsByResultRequest{Parent: "-/results/-", Name: "IMAGE_DIGEST", Value: digest},
+			want: []string{"ns/results/build/records/build", "other/results/build/records/build"},
+		},
+		{
+			name: "namespace",
+			req:  &pb.SearchRunsByResultRequest{Parent: "ns/results/-", Name: "IMAGE_DIGEST", Value: digest},
+			want: []string{"ns/results/build/records/build"},
+		},
+		{
+			name: "updated result",
+			req:  &pb.SearchRunsByResultRequest{Parent: "ns/results/-", Name: "IMAGE_DIGEST", Value: "sha256:bbbb"},
+			want: []string{"ns/results/updated/records/updated"},
+		},
+		{
+			name: "array element",
+			req:  &pb.SearchRunsByResultRequest{Parent: "ns/results/-", Name: "IMAGES", Value: "registry/b"},
+			want: []string{"ns/results/images/records/images"},
+		},
+		{
+			name: "object key",
+			req:  &pb.SearchRunsByResultRequest{Parent: "ns/results/-", Name: "ARTIFACT_OUTPUTS.digest", Value: digest},
+			want: []string{"ns/results/images/records/images"},
+		},
+		{
+			name: "filter",
+			req:  &pb.SearchRunsByResultRequest{Parent: "-/results/-", Name: "IMAGE_DIGEST", Value: digest, Filter: `parent == "other"`},
+			want: []string{"other/results/build/records/build"},
+		},
+		{
+			name: "no match",
+			req:  &pb.SearchRunsByResultRequest{Parent: "-/results/-", Name: "IMAGE_DIGEST", Value: "sha256:cccc"},
+		},
+		{
+			name:   "missing name",
+			req:    &pb.SearchRunsByResultRequest{Parent: "-/results/-", Value: digest},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "value too long",
+			req:    &pb.SearchRunsByResultRequest{Parent: "-/results/-", Name: "IMAGE_DIGEST", Value: strings.Repeat("a", 513)},
+			status: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.SearchRunsByResult(ctx, tc.req)
+			if status.Code(err) != tc.status {
+				t.Fatalf("SearchRunsByResult: %v, want %v", err, tc.status)
+			}
+			var names []string
+			for _, r := range got.GetRecords() {
+				names = append(names, r.GetName())
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/lister"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/migration"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	typeTaskRunV1     = "tekton.dev/v1.TaskRun"
+	typePipelineRunV1 = "tekton.dev/v1.PipelineRun"
+
+	labelPipelineRun = "tekton.dev/pipelineRun"
+
+	childTaskRunsPageSize = 1000
+	// maxChildTaskRuns bounds the number of TaskRuns read for a PipelineRun.
+	maxChildTaskRuns = 5000
+)
+
+// storedRun is a TaskRun or PipelineRun read from its Record, converted to
+// tekton.dev/v1.
+type storedRun struct {
+	record      string
+	taskRun     *pipelinev1.TaskRun
+	pipelineRun *pipelinev1.PipelineRun
+}
+
+// getRun reads the TaskRun or PipelineRun stored in the Record named name.
+func (s *Server) getRun(ctx context.Context, name string) (*storedRun, error) {
+	r, err := s.GetRecord(ctx, &pb.GetRecordRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	dataType, data, err := v1Data(ctx, r)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error converting Record %s: %v", name, err)
+	}
+
+	run := &storedRun{record: name}
+	switch dataType {
+	case typeTaskRunV1:
+		run.taskRun = &pipelinev1.TaskRun{}
+		err = json.Unmarshal(data, run.taskRun)
+	case typePipelineRunV1:
+		run.pipelineRun = &pipelinev1.PipelineRun{}
+		err = json.Unmarshal(data, run.pipelineRun)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Record %s of type %s isn't a TaskRun or PipelineRun", name, r.GetData().GetType())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error decoding Record %s: %v", name, err)
+	}
+	return run, nil
+}
+
+// kind returns the kind of the run, TaskRun or PipelineRun.
+func (r *storedRun) kind() string {
+	if r.pipelineRun != nil {
+		return "PipelineRun"
+	}
+	return "TaskRun"
+}
+
+// v1Data returns the type and data of the Record r, migrated from older
+// versions of its type to the latest one by the registered migrations.
+func v1Data(ctx context.Context, r *pb.Record) (string, []byte, error) {
+	dataType, data := r.GetData().GetType(), r.GetData().GetValue()
+	for migrated := true; migrated; {
+		migrated = false
+		for _, m := range migration.Registered() {
+			if m.IsBackfill() || m.FromType() != dataType {
+				continue
+			}
+			var err error
+			if data, err = m.Migrate(ctx, data); err != nil {
+				return "", nil, fmt.Errorf("%s: %w", m.Name(), err)
+			}
+			dataType, migrated = m.ToType(), true
+			break
+		}
+	}
+	return dataType, data, nil
+}
+
+// childTaskRun is a TaskRun of a PipelineRun.
+type childTaskRun struct {
+	// task is the name of the pipeline task of the TaskRun, suffixed by the
+	// index of the TaskRun for tasks fanned out by a matrix, e.g. build[1].
+	task string
+	// pipelineTask is the name of the pipeline task of the TaskRun.
+	pipelineTask string
+	record       string
+	*pipelinev1.TaskRun
+}
+
+// childTaskRuns returns the TaskRuns of the PipelineRun pr stored in the
+// Record named name, by start time. TaskRuns are stored under the Result of
+// their PipelineRun, and identified by its child references, or by their
+// labels for PipelineRuns stored without them.
+func (s *Server) childTaskRuns(ctx context.Context, name string, pr *pipelinev1.PipelineRun) ([]childTaskRun, error) {
+	parent, resultName, _, err := record.ParseName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auth.Check(ctx, parent, auth.ResourceRecords, auth.PermissionList); err != nil {
+		return nil, err
+	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := map[string]string{}
+	// Position of the TaskRuns in the child references, which list the
+	// TaskRuns of a task fanned out by a matrix by index.
+	positions := map[string]int{}
+	for _, ref := range pr.Status.ChildReferences {
+		if ref.Kind == "TaskRun" {
+			tasks[ref.Name] = ref.PipelineTaskName
+			positions[ref.Name] = len(positions)
+		}
+	}
+
+	req := &pb.ListRecordsRequest{
+		Parent:   parent + "/results/" + resultName,
+		Filter:   `data_type == TASK_RUN || data_type == "tekton.dev/v1beta1.TaskRun"`,
+		OrderBy:  "create_time asc",
+		PageSize: childTaskRunsPageSize,
+	}
+	byTask := map[string][]childTaskRun{}
+	for read := 0; ; {
+		recordsLister, err := lister.OfRecords(s.recordsEnv, parent, resultName, req)
+		if err != nil {
+			return nil, err
+		}
+		recordsLister.Constrain(constraint)
+		records, next, err := recordsLister.List(ctx, s.db)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			_, data, err := v1Data(ctx, r)
+			if err != nil {
+				s.logger.Warnf("Skipping TaskRun Record %s: %v", r.GetName(), err)
+				continue
+			}
+			tr := &pipelinev1.TaskRun{}
+			if err := json.Unmarshal(data, tr); err != nil {
+				s.logger.Warnf("Skipping TaskRun Record %s: %v", r.GetName(), err)
+				continue
+			}
+			task, ok := tasks[tr.Name]
+			if len(tasks) > 0 && !ok || len(tasks) == 0 && tr.Labels[labelPipelineRun] != pr.Name {
+				continue
+			}
+			if task == "" {
+				task = tr.Labels[labelPipelineTask]
+			}
+			if task == "" {
+				task = tr.Name
+			}
+			byTask[task] = append(byTask[task], childTaskRun{task: task, pipelineTask: task, record: r.GetName(), TaskRun: tr})
+		}
+		if read += len(records); next == "" {
+			break
+		} else if read >= maxChildTaskRuns {
+			return nil, status.Errorf(codes.InvalidArgument, "more than %d TaskRuns stored for PipelineRun %s", maxChildTaskRuns, pr.Name)
+		}
+		req.PageToken = next
+	}
+
+	taskNames := make([]string, 0, len(byTask))
+	for task := range byTask {
+		taskNames = append(taskNames, task)
+	}
+	sort.Strings(taskNames)
+	var children []childTaskRun
+	for _, task := range taskNames {
+		runs := byTask[task]
+		sort.Slice(runs, func(i, j int) bool { return matrixLess(runs[i].Name, runs[j].Name, positions) })
+		for i := range runs {
+			if len(runs) > 1 {
+				runs[i].task = fmt.Sprintf("%s[%d]", task, i)
+			}
+			children = append(children, runs[i])
+		}
+	}
+	// TaskRuns starting together stay ordered by task and matrix index.
+	sort.SliceStable(children, func(i, j int) bool {
+		a, b := children[i].Status.StartTime, children[j].Status.StartTime
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(b)
+	})
+	return children, nil
+}
+
+// matrixLess reports whether the TaskRun named a of a task fanned out by a
+// matrix has a lower index than the TaskRun named b: it comes first in the
+// child references of the PipelineRun or, without them, has a lower numeric
+// suffix, which Tekton sets to the index in the names it generates.
+func matrixLess(a, b string, positions map[string]int) bool {
+	posA, okA := positions[a]
+	posB, okB := positions[b]
+	if okA && okB {
+		return posA < posB
+	}
+	indexA, errA := strconv.Atoi(a[strings.LastIndex(a, "-")+1:])
+	indexB, errB := strconv.Atoi(b[strings.LastIndex(b, "-")+1:])
+	if errA == nil && errB == nil && indexA != indexB {
+		return indexA < indexB
+	}
+	return a < b
+}
@@ -26,8 +26,14 @@ import (
 	cw "github.com/jonboulle/clockwork"
 	resultscel "github.com/tektoncd/results/pkg/api/server/cel"
 	model "github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/notify"
+	"github.com/tektoncd/results/pkg/api/server/redact"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/plugin"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"gorm.io/gorm"
 )
@@ -39,7 +45,7 @@ var (
 	clock cw.Clock = cw.NewRealClock()
 )
 
-type getResultID func(ctx context.Context, parent, result string) (string, error)
+type getResultID func(ctx context.Context, parent, result string) (*resultID, error)
 
 // Server with implementation of API server
 type Server struct {
@@ -52,6 +58,8 @@ type Server struct {
 	recordsEnv      *cel.Env
 	db              *gorm.DB
 	auth            auth.Checker
+	redactor        *redact.Redactor
+	notifier        *notify.Notifier
 	LogPluginServer *plugin.LogServer
 
 	// testing.
@@ -93,7 +101,17 @@ func New(config *config.Config, logger *zap.SugaredLogger, db *gorm.DB, opts ...
 	}
 
 	if config.DB_ENABLE_AUTO_MIGRATION {
-		if err := db.AutoMigrate(&model.Result{}, &model.Record{}); err != nil {
+		deleted, err := model.PrepareClusters(db)
+		if err != nil {
+			return nil, fmt.Errorf("error automigrating DB: %w", err)
+		}
+		if deleted > 0 {
+			logger.Warnf("Deleted %d Records of the same name as others in their Result", deleted)
+		}
+		if err := db.AutoMigrate(&model.Result{}, &model.Record{}, &model.RunResult{}, &model.ProvenanceDigest{}, &model.Migration{}); err != nil {
+			return nil, fmt.Errorf("error automigrating DB: %w", err)
+		}
+		if err := model.DropLegacyIndexes(db); err != nil {
 			return nil, fmt.Errorf("error automigrating DB: %w", err)
 		}
 	}
@@ -117,8 +135,58 @@ func WithAuth(c auth.Checker) Option {
 	}
 }
 
+// WithRedactor is an option to mask secrets matched by the rules of r in the
+// logs received by UpdateLog. Secrets hinted by the sender are masked even
+// without it.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(s *Server) {
+		s.redactor = r
+	}
+}
+
+// WithNotifier is an option to send events to the subscribers of n when
+// Results and Records are created, updated or deleted.
+func WithNotifier(n *notify.Notifier) Option {
+	return func(s *Server) {
+		s.notifier = n
+	}
+}
+
 func withGetResultID(f getResultID) Option {
 	return func(s *Server) {
 		s.getResultID = f
 	}
 }
+
+// Etag returns the current etag of the Result, Record or Log with the given
+// name. Unlike the API methods, no permission is checked, so it must only be
+// used to report on calls which succeeded.
+func (s *Server) Etag(ctx context.Context, name string) (string, error) {
+	txn := s.db.WithContext(ctx)
+	if parent, res, rec, err := record.ParseName(name); err == nil {
+		r, err := getRecord(s.inCluster(ctx, txn), parent, res, rec)
+		if err != nil {
+			return "", err
+		}
+		return r.Etag, nil
+	}
+	if parent, res, rec, err := log.ParseName(name); err == nil {
+		r, err := getRecord(s.inCluster(ctx, txn), parent, res, rec)
+		if err == nil && r.Type != v1alpha3.LogRecordType {
+			r, err = getLogRecord(s.inCluster(ctx, txn), parent, res, rec)
+		}
+		if err != nil {
+			return "", err
+		}
+		return r.Etag, nil
+	}
+	parent, res, err := result.ParseName(name)
+	if err != nil {
+		return "", err
+	}
+	r, err := getResultByParentName(s.inCluster(ctx, txn), parent, res)
+	if err != nil {
+		return "", err
+	}
+	return r.Etag, nil
+}
@@ -15,12 +15,20 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sync/atomic"
 	"testing"
 
 	cw "github.com/jonboulle/clockwork"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/logger"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	recordutil "github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -36,3 +44,41 @@ func TestMain(m *testing.M) {
 	clock = fakeClock
 	os.Exit(m.Run())
 }
+
+func TestEtag(t *testing.T) {
+	srv, err := New(&config.Config{DB_ENABLE_AUTO_MIGRATION: true}, logger.Get("info"), test.NewDB(t))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	result, err := srv.CreateResult(ctx, &pb.CreateResultRequest{
+		Parent: "foo",
+		Result: &pb.Result{Name: "foo/results/bar"},
+	})
+	if err != nil {
+		t.Fatalf("CreateResult: %v", err)
+	}
+	record, err := srv.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: result.GetName(),
+		Record: &pb.Record{Name: recordutil.FormatName(result.GetName(), "baz")},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: result.GetName(), want: result.GetEtag()},
+		{name: record.GetName(), want: record.GetEtag()},
+	} {
+		if got, err := srv.Etag(ctx, tc.name); err != nil || got != tc.want {
+			t.Errorf("Etag(%s): got (%s, %v), want %s", tc.name, got, err, tc.want)
+		}
+	}
+	if _, err := srv.Etag(ctx, recordutil.FormatName(result.GetName(), "doesnotexist")); status.Code(err) != codes.NotFound {
+		t.Errorf("Etag: want NotFound, got %v", err)
+	}
+}
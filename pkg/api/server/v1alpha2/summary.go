@@ -30,6 +30,11 @@ func (s *Server) GetRecordListSummary(ctx context.Context, req *pb.RecordListSum
 	if err != nil {
 		return nil, err
 	}
+	constraint, err := s.recordConstraint(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	recordAggregator.Constrain(constraint)
 
 	agg, err := recordAggregator.Aggregate(ctx, s.db)
 	if err != nil {
@@ -0,0 +1,259 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// GetRunTimeline reconstructs the execution of a stored PipelineRun.
+func (s *Server) GetRunTimeline(ctx context.Context, req *pb.GetRunTimelineRequest) (*pb.RunTimeline, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+	run, err := s.getRun(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if run.pipelineRun == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Record %s holds a %s, not a PipelineRun", req.GetName(), run.kind())
+	}
+	children, err := s.childTaskRuns(ctx, req.GetName(), run.pipelineRun)
+	if err != nil {
+		return nil, err
+	}
+	return newTimeline(req.GetName(), run.pipelineRun, children), nil
+}
+
+// timelineTask is a TaskRun of a PipelineRun with the times of its execution.
+type timelineTask struct {
+	childTaskRun
+	// deps are the TaskRuns of the pipeline tasks the task runs after.
+	deps []*timelineTask
+	// podStart is the time the first step of the TaskRun started.
+	create, start, podStart, completion *metav1.Time
+}
+
+// newTimeline returns the timeline of the PipelineRun pr stored in the Record
+// named name, from its TaskRuns sorted by start time.
+func newTimeline(name string, pr *pipelinev1.PipelineRun, children []childTaskRun) *pb.RunTimeline {
+	cond := pr.Status.GetCondition(apis.ConditionSucceeded)
+	tl := &pb.RunTimeline{
+		Record:         name,
+		Name:           pr.Name,
+		Uid:            string(pr.UID),
+		Status:         conditionReason(cond),
+		CreateTime:     timestamp(&pr.CreationTimestamp),
+		StartTime:      timestamp(pr.Status.StartTime),
+		CompletionTime: timestamp(pr.Status.CompletionTime),
+	}
+	pipelineStart := pr.Status.StartTime
+	if pipelineStart == nil || pipelineStart.IsZero() {
+		pipelineStart = &pr.CreationTimestamp
+	}
+
+	spec := pr.Status.PipelineSpec
+	if spec == nil {
+		spec = pr.Spec.PipelineSpec
+	}
+	if spec == nil {
+		spec = &pipelinev1.PipelineSpec{}
+	}
+	deps := pipelinev1.PipelineTaskList(spec.Tasks).Deps()
+	finally := sets.New[string]()
+	for _, t := range spec.Finally {
+		finally.Insert(t.Name)
+		// Finally tasks run after all the tasks, and may use the results
+		// of other finally tasks.
+		deps[t.Name] = append(t.Deps(), pipelinev1.PipelineTaskList(spec.Tasks).Names().UnsortedList()...)
+	}
+
+	tasks := make([]*timelineTask, len(children))
+	byPipelineTask := map[string][]*timelineTask{}
+	for i, c := range children {
+		t := &timelineTask{
+			childTaskRun: c,
+			create:       &c.CreationTimestamp,
+			start:        c.Status.StartTime,
+			podStart:     podStartTime(c.TaskRun),
+			completion:   c.Status.CompletionTime,
+		}
+		if t.create.IsZero() {
+			t.create = t.start
+		}
+		tasks[i] = t
+		byPipelineTask[c.pipelineTask] = append(byPipelineTask[c.pipelineTask], t)
+	}
+
+	path := criticalPath(tasks, deps, byPipelineTask)
+	critical := map[*timelineTask]bool{}
+	for _, t := range path {
+		critical[t] = true
+		tl.CriticalPath = append(tl.CriticalPath, t.task)
+	}
+	if len(path) > 0 {
+		tl.CriticalPathDuration = waitBetween(pipelineStart, path[len(path)-1].completion)
+	}
+	for _, t := range tasks {
+		ready := pipelineStart
+		for _, d := range t.deps {
+			if d.completion != nil && d.completion.After(ready.Time) {
+				ready = d.completion
+			}
+		}
+		task := &pb.TimelineTask{
+			Name:           t.task,
+			TaskRun:        t.Name,
+			Record:         t.record,
+			Status:         conditionReason(t.Status.GetCondition(apis.ConditionSucceeded)),
+			Finally:        finally.Has(t.pipelineTask),
+			Critical:       critical[t],
+			ReadyTime:      timestamp(ready),
+			CreateTime:     timestamp(t.create),
+			PodStartTime:   timestamp(t.podStart),
+			CompletionTime: timestamp(t.completion),
+			Queue:          waitBetween(ready, t.create),
+			Pending:        waitBetween(t.create, t.podStart),
+			Running:        waitBetween(t.podStart, t.completion),
+		}
+		runAfter := sets.New[string]()
+		for _, d := range t.deps {
+			runAfter.Insert(d.pipelineTask)
+		}
+		task.RunAfter = sets.List(runAfter)
+		tl.Tasks = append(tl.Tasks, task)
+	}
+	tl.MaxParallelism, tl.AverageParallelism = parallelism(tasks)
+	return tl
+}
+
+// criticalPath links the tasks to the TaskRuns of their dependencies, and
+// returns the tasks on the critical path in order of execution: the last task
+// to complete, preceded by its dependency which completed last, and so on.
+func criticalPath(tasks []*timelineTask, deps map[string][]string, byPipelineTask map[string][]*timelineTask) []*timelineTask {
+	var last *timelineTask
+	for _, t := range tasks {
+		for _, d := range deps[t.pipelineTask] {
+			t.deps = append(t.deps, byPipelineTask[d]...)
+		}
+		if t.completion != nil && (last == nil || t.completion.After(last.completion.Time)) {
+			last = t
+		}
+	}
+
+	var path []*timelineTask
+	for t, seen := last, sets.New[*timelineTask](); t != nil && !seen.Has(t); {
+		seen.Insert(t)
+		path = append([]*timelineTask{t}, path...)
+		var next *timelineTask
+		for _, d := range t.deps {
+			if d.completion != nil && (next == nil || d.completion.After(next.completion.Time)) {
+				next = d
+			}
+		}
+		t = next
+	}
+	return path
+}
+
+// parallelism returns the largest number of tasks running at the same time,
+// and the average number of tasks running from the start of the first task to
+// the completion of the last one.
+func parallelism(tasks []*timelineTask) (int32, float64) {
+	type event struct {
+		at    time.Time
+		delta int32
+	}
+	var events []event
+	var first, last time.Time
+	var busy time.Duration
+	for _, t := range tasks {
+		if t.start == nil || t.completion == nil || t.start.IsZero() || t.completion.IsZero() {
+			continue
+		}
+		events = append(events, event{t.start.Time, 1}, event{t.completion.Time, -1})
+		busy += t.completion.Sub(t.start.Time)
+		if first.IsZero() || t.start.Time.Before(first) {
+			first = t.start.Time
+		}
+		if t.completion.After(last) {
+			last = t.completion.Time
+		}
+	}
+	// Tasks completing when others start don't run at the same time.
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+	var running, maxRunning int32
+	for _, e := range events {
+		running += e.delta
+		maxRunning = max(maxRunning, running)
+	}
+	if elapsed := last.Sub(first); elapsed > 0 {
+		return maxRunning, busy.Seconds() / elapsed.Seconds()
+	}
+	return maxRunning, float64(maxRunning)
+}
+
+// podStartTime returns the time the first step of the TaskRun tr started, or
+// nil if none did.
+func podStartTime(tr *pipelinev1.TaskRun) *metav1.Time {
+	var first *metav1.Time
+	for _, step := range tr.Status.Steps {
+		var started metav1.Time
+		switch {
+		case step.Terminated != nil:
+			started = step.Terminated.StartedAt
+		case step.Running != nil:
+			started = step.Running.StartedAt
+		}
+		if !started.IsZero() && (first == nil || started.Before(first)) {
+			first = &started
+		}
+	}
+	return first
+}
+
+// waitBetween returns the time from start to end, or nil if either is unset.
+// Negative times, from the truncation of stored times to seconds, are zero.
+func waitBetween(start, end *metav1.Time) *durationpb.Duration {
+	d := durationBetween(start, end)
+	if d != nil && d.AsDuration() < 0 {
+		return durationpb.New(0)
+	}
+	return d
+}
+
+func timestamp(t *metav1.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
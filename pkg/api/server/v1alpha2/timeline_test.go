@@ -0,0 +1,237 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetRunTimeline(t *testing.T) {
+	f := newRunsFixture(t)
+	ctx := context.Background()
+
+	pr := &pipelinev1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ns", UID: types.UID("uid-release"), CreationTimestamp: *f.time(0)},
+	}
+	pr.Status.Status = succeeded(corev1.ConditionTrue, "Succeeded", "")
+	pr.Status.StartTime = f.time(0)
+	pr.Status.CompletionTime = f.time(5*time.Minute + 10*time.Second)
+	pr.Status.PipelineSpec = &pipelinev1.PipelineSpec{
+		Tasks: []pipelinev1.PipelineTask{
+			{Name: "fetch"},
+			{Name: "build", RunAfter: []string{"fetch"}},
+			{Name: "lint", Params: pipelinev1.Params{
+				{Name: "revision", Value: *pipelinev1.NewStructuredValues("$(tasks.fetch.results.commit)")},
+			}},
+			{Name: "test", RunAfter: []string{"build"}},
+			{Name: "deploy", RunAfter: []string{"test"}},
+		},
+		Finally: []pipelinev1.PipelineTask{{Name: "notify"}},
+	}
+	pr.Status.SkippedTasks = []pipelinev1.SkippedTask{{Name: "deploy"}}
+	for _, task := range []string{"fetch", "build", "lint", "test", "notify"} {
+		pr.Status.ChildReferences = append(pr.Status.ChildReferences, childTaskRunRef("release-"+task, task))
+	}
+	f.create("release", "release", typePipelineRunV1, pr)
+
+	// timed stores the TaskRun of a task created at created, whose pod
+	// started at podStart.
+	timed := func(task string, created, podStart, completion time.Duration) {
+		tr := f.taskRun("release", "release-"+task, task, created, completion, "Succeeded", "alpine", "sha256:aaaa")
+		tr.CreationTimestamp = *f.time(created)
+		tr.Status.Steps[0].Terminated = &corev1.ContainerStateTerminated{
+			Reason: "Completed", StartedAt: *f.time(podStart), FinishedAt: *f.time(completion),
+		}
+		f.create("release", tr.Name, typeTaskRunV1, tr)
+	}
+	timed("fetch", time.Second, 5*time.Second, time.Minute)
+	timed("build", 62*time.Second, 70*time.Second, 4*time.Minute)
+	timed("lint", 62*time.Second, 80*time.Second, 2*time.Minute)
+	timed("test", 241*time.Second, 245*time.Second, 5*time.Minute)
+	timed("notify", 301*time.Second, 303*time.Second, 310*time.Second)
+
+	got, err := f.srv.GetRunTimeline(ctx, &pb.GetRunTimelineRequest{Name: "ns/results/release/records/release"})
+	if err != nil {
+		t.Fatalf("GetRunTimeline: %v", err)
+	}
+
+	ts := func(offset time.Duration) *timestamppb.Timestamp { return timestamppb.New(f.start.Add(offset)) }
+	d := durationpb.New
+	task := func(name string, runAfter []string, critical bool, ready, created, podStart, completion time.Duration) *pb.TimelineTask {
+		return &pb.TimelineTask{
+			Name:           name,
+			TaskRun:        "release-" + name,
+			Record:         "ns/results/release/records/release-" + name,
+			Status:         "Succeeded",
+			Finally:        name == "notify",
+			RunAfter:       runAfter,
+			Critical:       critical,
+			ReadyTime:      ts(ready),
+			CreateTime:     ts(created),
+			PodStartTime:   ts(podStart),
+			CompletionTime: ts(completion),
+			Queue:          d(created - ready),
+			Pending:        d(podStart - created),
+			Running:        d(completion - podStart),
+		}
+	}
+	want := &pb.RunTimeline{
+		Record:         "ns/results/release/records/release",
+		Name:           "release",
+		Uid:            "uid-release",
+		Status:         "Succeeded",
+		CreateTime:     ts(0),
+		StartTime:      ts(0),
+		CompletionTime: ts(310 * time.Second),
+		Tasks: []*pb.TimelineTask{
+			task("fetch", nil, true, 0, time.Second, 5*time.Second, time.Minute),
+			task("build", []string{"fetch"}, true, time.Minute, 62*time.Second, 70*time.Second, 4*time.Minute),
+			task("lint", []string{"fetch"}, false, time.Minute, 62*time.Second, 80*time.Second, 2*time.Minute),
+			task("test", []string{"build"}, true, 4*time.Minute, 241*time.Second, 245*time.Second, 5*time.Minute),
+			// Finally tasks run after all the tasks which ran.
+			task("notify", []string{"build", "fetch", "lint", "test"}, true, 5*time.Minute, 301*time.Second, 303*time.Second, 310*time.Second),
+		},
+		CriticalPath:         []string{"fetch", "build", "test", "notify"},
+		CriticalPathDuration: d(310 * time.Second),
+		MaxParallelism:       2,
+		// The tasks run for 363s from 1s to 310s.
+		AverageParallelism: 363.0 / 309.0,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		req    *pb.GetRunTimelineRequest
+		status codes.Code
+	}{
+		{
+			name:   "missing name",
+			req:    &pb.GetRunTimelineRequest{},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "TaskRun",
+			req:    &pb.GetRunTimelineRequest{Name: "ns/results/release/records/release-build"},
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "not found",
+			req:    &pb.GetRunTimelineRequest{Name: "ns/results/release/records/missing"},
+			status: codes.NotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := f.srv.GetRunTimeline(ctx, tc.req); status.Code(err) != tc.status {
+				t.Errorf("GetRunTimeline: %v, want %v", err, tc.status)
+			}
+		})
+	}
+}
+
+func TestGetRunTimelineMatrix(t *testing.T) {
+	f := newRunsFixture(t)
+
+	// Without child references nor spec, TaskRuns are found by their labels
+	// and have no dependencies.
+	pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "matrix", Namespace: "ns"}}
+	pr.Status.StartTime = f.time(0)
+	f.create("matrix", "matrix", typePipelineRunV1, pr)
+	f.create("matrix", "matrix-build-0", typeTaskRunV1, f.taskRun("matrix", "matrix-build-0", "build", 0, 2*time.Minute, "Succeeded", "alpine", "sha256:aaaa"))
+	f.create("matrix", "matrix-build-1", typeTaskRunV1, f.taskRun("matrix", "matrix-build-1", "build", time.Minute, 4*time.Minute, "Failed", "alpine", "sha256:aaaa"))
+
+	got, err := f.srv.GetRunTimeline(context.Background(), &pb.GetRunTimelineRequest{Name: "ns/results/matrix/records/matrix"})
+	if err != nil {
+		t.Fatalf("GetRunTimeline: %v", err)
+	}
+	var names []string
+	for _, task := range got.GetTasks() {
+		names = append(names, task.GetName())
+	}
+	if diff := cmp.Diff([]string{"build[0]", "build[1]"}, names); diff != "" {
+		t.Errorf("tasks -want, +got: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"build[1]"}, got.GetCriticalPath()); diff != "" {
+		t.Errorf("critical path -want, +got: %s", diff)
+	}
+	if got.GetMaxParallelism() != 2 || got.GetAverageParallelism() != 1.25 {
+		t.Errorf("parallelism: max %d, average %v, want 2 and 1.25", got.GetMaxParallelism(), got.GetAverageParallelism())
+	}
+}
+
+func TestGetRunTimelineMatrix_index(t *testing.T) {
+	f := newRunsFixture(t)
+
+	// TaskRuns are indexed by the suffix of their names, build-10 coming
+	// after build-9, and start in order.
+	const n = 12
+	pr := &pipelinev1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "matrix", Namespace: "ns"}}
+	pr.Status.StartTime = f.time(0)
+	f.create("matrix", "matrix", typePipelineRunV1, pr)
+	var (
+		want []string
+		refs []pipelinev1.ChildStatusReference
+	)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("matrix-build-%d", i)
+		f.create("matrix", name, typeTaskRunV1, f.taskRun("matrix", name, "build", time.Duration(i)*time.Second, time.Minute, "Succeeded", "alpine", "sha256:aaaa"))
+		want = append(want, fmt.Sprintf("build[%d]", i))
+		refs = append(refs, childTaskRunRef(name, "build"))
+	}
+
+	for _, tc := range []struct {
+		name string
+		refs []pipelinev1.ChildStatusReference
+	}{
+		{name: "labels"},
+		{name: "child references", refs: refs},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := pr.DeepCopy()
+			pr.Status.ChildReferences = tc.refs
+			children, err := f.srv.childTaskRuns(context.Background(), "ns/results/matrix/records/matrix", pr)
+			if err != nil {
+				t.Fatalf("childTaskRuns: %v", err)
+			}
+			var got []string
+			for i, c := range children {
+				if c.Name != fmt.Sprintf("matrix-build-%d", i) {
+					t.Errorf("TaskRun %d is %s", i, c.Name)
+				}
+				got = append(got, c.task)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("tasks -want, +got: %s", diff)
+			}
+		})
+	}
+}
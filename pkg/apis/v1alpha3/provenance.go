@@ -0,0 +1,94 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProvenanceRecordType represents the API resource type for provenance
+// records.
+const ProvenanceRecordType = "results.tekton.dev/v1alpha3.Provenance"
+
+// Provenance represents the API resource for the outputs of Tekton Chains for
+// a run: its attestations, their signatures, and the artifacts it built.
+type Provenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProvenanceSpec `json:"spec"`
+}
+
+// ProvenanceSpec represents the specification for the Provenance resource.
+type ProvenanceSpec struct {
+	// Resource is the run the provenance is about.
+	Resource Resource `json:"resource"`
+	// Record is the name of the Record of the run.
+	Record string `json:"record,omitempty"`
+
+	// Signed is the signing state of the run reported by Chains, either
+	// "true" or "failed".
+	Signed string `json:"signed,omitempty"`
+	// Transparency is the URL of the entry of the run in the transparency
+	// log, if Chains uploaded one.
+	Transparency string `json:"transparency,omitempty"`
+
+	// Attestations are the payloads and signatures Chains stored on the run.
+	// They are empty when Chains stores them elsewhere, such as in OCI
+	// registries.
+	Attestations []Attestation `json:"attestations,omitempty"`
+
+	// Subjects are the artifacts built by the run, as declared by the
+	// in-toto statements of its attestations, or by its type-hinted results.
+	Subjects []Subject `json:"subjects,omitempty"`
+	// Digests are the digests of Subjects, formatted as algorithm:hex, e.g.
+	// sha256:abc..., so that runs can be searched by artifact digest.
+	Digests []string `json:"digests,omitempty"`
+}
+
+// Attestation is a payload signed by Chains, such as an in-toto statement
+// holding SLSA provenance, and its signature.
+type Attestation struct {
+	// Key identifies the attestation in the Chains annotations of the run,
+	// e.g. taskrun-<uid>.
+	Key string `json:"key"`
+	// PredicateType is the predicate type of the payload, if it is an
+	// in-toto statement.
+	PredicateType string `json:"predicateType,omitempty"`
+	Payload       []byte `json:"payload,omitempty"`
+	// Signature is the signature of the payload, which is a DSSE envelope
+	// for in-toto statements.
+	Signature []byte `json:"signature,omitempty"`
+	// Certificate and Chain are the PEM encoded certificate of the signing
+	// key and its chain, for keyless signing.
+	Certificate string `json:"certificate,omitempty"`
+	Chain       string `json:"chain,omitempty"`
+}
+
+// Subject is an artifact built by a run.
+type Subject struct {
+	// Name is the name of the artifact, such as an image reference.
+	Name string `json:"name,omitempty"`
+	// Digest maps digest algorithms to the hex encoded digest of the
+	// artifact, e.g. sha256 to abc....
+	Digest map[string]string `json:"digest"`
+}
+
+// Default sets up default values for Provenance TypeMeta, such as API version
+// and kind.
+func (p *Provenance) Default() {
+	p.Kind = "Provenance"
+	p.APIVersion = "results.tekton.dev/v1alpha3"
+}
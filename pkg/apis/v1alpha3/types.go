@@ -29,6 +29,18 @@ type Log struct {
 type LogSpec struct {
 	Resource Resource `json:"resource"`
 	Type     LogType  `json:"type"`
+	// Step is set if the log holds the output of a single step of the
+	// resource, rather than of the whole resource.
+	Step *LogStep `json:"step,omitempty"`
+}
+
+// LogStep identifies a step of a TaskRun and how it terminated.
+type LogStep struct {
+	Name       string       `json:"name"`
+	Container  string       `json:"container,omitempty"`
+	ExitCode   *int32       `json:"exitCode,omitempty"`
+	StartTime  *metav1.Time `json:"startTime,omitempty"`
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
 }
 
 // Resource represents information to identify a Kubernetes API resource.
@@ -71,6 +83,14 @@ type LogStatus struct {
 	IsStored        bool   `json:"isStored"`
 	ErrorOnStoreMsg string `json:"errorOnStoreMsg"`
 	IsRetryableErr  bool   `json:"isRetryableErr"`
+	// IsStreaming is set while the run is still executing and log data is
+	// being appended.
+	IsStreaming bool `json:"isStreaming,omitempty"`
+	// Redactions is the number of secrets masked in the stored log data.
+	Redactions int64 `json:"redactions,omitempty"`
+	// Segments are the offsets at which the log data appended to a stored
+	// log starts, for storage which can't append to stored objects.
+	Segments []int64 `json:"segments,omitempty"`
 }
 
 // Default sets up default values for Log TypeMeta, such as API version and kind.
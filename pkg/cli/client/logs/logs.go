@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/tektoncd/results/pkg/cli/client"
@@ -34,11 +35,15 @@ func (c *Client) GetLog(ctx context.Context, req *pb.GetLogRequest) (io.Reader,
 			_ = pw.Close()
 		}()
 
+		var params url.Values
+		if req.GetStep() != "" {
+			params = url.Values{"step": []string{req.GetStep()}}
+		}
 		// Build the URL for the log request, replacing "records" with "logs" in the path
-		url := c.BuildURL(fmt.Sprintf("parents/%s", strings.Replace(req.Name, "records", "logs", 1)), nil)
+		logURL := c.BuildURL(fmt.Sprintf("parents/%s", strings.Replace(req.Name, "records", "logs", 1)), params)
 
 		// Make the request using the RESTClient's DoRequest method
-		resp, err := c.DoRequest(ctx, http.MethodGet, url, nil)
+		resp, err := c.DoRequest(ctx, http.MethodGet, logURL, nil)
 		if err != nil {
 			pw.CloseWithError(fmt.Errorf("failed to get log: %v", err))
 			return
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/tektoncd/results/pkg/cli/client"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
@@ -15,6 +16,10 @@ import (
 type RecordClient interface {
 	GetRecord(ctx context.Context, namespace, uid string) (*pb.Record, error)
 	ListRecords(ctx context.Context, in *pb.ListRecordsRequest, fields string) (*pb.ListRecordsResponse, error)
+	GetDeliveryMetrics(ctx context.Context, in *pb.DeliveryMetricsRequest) (*pb.DeliveryMetricsResponse, error)
+	SearchRunsByResult(ctx context.Context, in *pb.SearchRunsByResultRequest) (*pb.SearchRunsByResultResponse, error)
+	DiffRuns(ctx context.Context, in *pb.DiffRunsRequest) (*pb.DiffRunsResponse, error)
+	GetRunTimeline(ctx context.Context, in *pb.GetRunTimelineRequest) (*pb.RunTimeline, error)
 }
 
 // recordClient implements the RecordClient interface
@@ -86,3 +91,96 @@ func (c *recordClient) ListRecords(ctx context.Context, in *pb.ListRecordsReques
 
 	return out, err
 }
+
+// GetDeliveryMetrics makes request to get the delivery metrics of deployment PipelineRuns
+func (c *recordClient) GetDeliveryMetrics(ctx context.Context, in *pb.DeliveryMetricsRequest) (*pb.DeliveryMetricsResponse, error) {
+	out := &pb.DeliveryMetricsResponse{}
+
+	params := url.Values{}
+	for name, value := range map[string]string{
+		"filter":                 in.Filter,
+		"commit_time_annotation": in.CommitTimeAnnotation,
+		"commit_time_param":      in.CommitTimeParam,
+		"group_by":               in.GroupBy,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+	if in.StartTime != nil {
+		params.Set("start_time", in.StartTime.AsTime().Format(time.RFC3339))
+	}
+	if in.EndTime != nil {
+		params.Set("end_time", in.EndTime.AsTime().Format(time.RFC3339))
+	}
+
+	buildURL := c.BuildURL(fmt.Sprintf("parents/%s/records/summary/dora", in.Parent), params)
+	resp, err := c.DoRequest(ctx, http.MethodGet, buildURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.ProtoUnmarshal(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchRunsByResult makes request to find the runs which produced a result with a given value
+func (c *recordClient) SearchRunsByResult(ctx context.Context, in *pb.SearchRunsByResultRequest) (*pb.SearchRunsByResultResponse, error) {
+	out := &pb.SearchRunsByResultResponse{}
+
+	params := url.Values{}
+	params.Set("name", in.Name)
+	params.Set("value", in.Value)
+	if in.Filter != "" {
+		params.Set("filter", in.Filter)
+	}
+	if in.PageSize > 0 {
+		params.Set("page_size", fmt.Sprintf("%d", in.PageSize))
+	}
+	if in.PageToken != "" {
+		params.Set("page_token", in.PageToken)
+	}
+
+	buildURL := c.BuildURL(fmt.Sprintf("parents/%s/records/results", in.Parent), params)
+	resp, err := c.DoRequest(ctx, http.MethodGet, buildURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.ProtoUnmarshal(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiffRuns makes request to compare two stored runs of the same kind
+func (c *recordClient) DiffRuns(ctx context.Context, in *pb.DiffRunsRequest) (*pb.DiffRunsResponse, error) {
+	out := &pb.DiffRunsResponse{}
+
+	params := url.Values{}
+	params.Set("other", in.Other)
+
+	buildURL := c.BuildURL(fmt.Sprintf("parents/%s/diff", in.Name), params)
+	resp, err := c.DoRequest(ctx, http.MethodGet, buildURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.ProtoUnmarshal(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRunTimeline makes request to reconstruct the execution of a stored PipelineRun
+func (c *recordClient) GetRunTimeline(ctx context.Context, in *pb.GetRunTimelineRequest) (*pb.RunTimeline, error) {
+	out := &pb.RunTimeline{}
+	buildURL := c.BuildURL(fmt.Sprintf("parents/%s/timeline", in.Name), nil)
+	resp, err := c.DoRequest(ctx, http.MethodGet, buildURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.ProtoUnmarshal(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/tektoncd/results/pkg/cli/client"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	k8stransport "k8s.io/client-go/transport"
 )
 
@@ -112,3 +113,187 @@ func TestListRecords(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeliveryMetrics(t *testing.T) {
+	var got *http.Request
+	transport := &mockTransport{
+		listRecordsFunc: func(req *http.Request) (*http.Response, error) {
+			got = req
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"metrics": [{"deployments": "3", "changeFailureRate": 0.25}]}`))),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	baseURL, _ := url.Parse("http://localhost:8080")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:     baseURL,
+		Timeout: 30 * time.Second,
+		Transport: &k8stransport.Config{
+			WrapTransport: func(_ http.RoundTripper) http.RoundTripper {
+				return transport
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+
+	resp, err := NewClient(restClient).GetDeliveryMetrics(context.Background(), &pb.DeliveryMetricsRequest{
+		Parent:          "prod/results/-",
+		Filter:          `data.metadata.labels["app"]=="web"`,
+		CommitTimeParam: "git.commit-time",
+		StartTime:       timestamppb.New(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("GetDeliveryMetrics() error = %v", err)
+	}
+	if got.URL.Path != "/parents/prod/results/-/records/summary/dora" {
+		t.Errorf("unexpected path: %s", got.URL.Path)
+	}
+	wantQuery := url.Values{
+		"filter":            {`data.metadata.labels["app"]=="web"`},
+		"commit_time_param": {"git.commit-time"},
+		"start_time":        {"2026-07-01T00:00:00Z"},
+	}
+	if got.URL.Query().Encode() != wantQuery.Encode() {
+		t.Errorf("unexpected query: got %s, want %s", got.URL.Query().Encode(), wantQuery.Encode())
+	}
+	if len(resp.GetMetrics()) != 1 || resp.GetMetrics()[0].GetDeployments() != 3 {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
+
+func TestSearchRunsByResult(t *testing.T) {
+	var got *http.Request
+	transport := &mockTransport{
+		listRecordsFunc: func(req *http.Request) (*http.Response, error) {
+			got = req
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"records": [{"name": "ns/results/a/records/a"}], "nextPageToken": "next"}`))),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	baseURL, _ := url.Parse("http://localhost:8080")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:     baseURL,
+		Timeout: 30 * time.Second,
+		Transport: &k8stransport.Config{
+			WrapTransport: func(_ http.RoundTripper) http.RoundTripper {
+				return transport
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+
+	resp, err := NewClient(restClient).SearchRunsByResult(context.Background(), &pb.SearchRunsByResultRequest{
+		Parent:   "ns/results/-",
+		Name:     "IMAGE_DIGEST",
+		Value:    "sha256:aaaa",
+		PageSize: 10,
+	})
+	if err != nil {
+		t.Fatalf("SearchRunsByResult() error = %v", err)
+	}
+	if got.URL.Path != "/parents/ns/results/-/records/results" {
+		t.Errorf("unexpected path: %s", got.URL.Path)
+	}
+	wantQuery := url.Values{
+		"name":      {"IMAGE_DIGEST"},
+		"value":     {"sha256:aaaa"},
+		"page_size": {"10"},
+	}
+	if got.URL.Query().Encode() != wantQuery.Encode() {
+		t.Errorf("unexpected query: got %s, want %s", got.URL.Query().Encode(), wantQuery.Encode())
+	}
+	if len(resp.GetRecords()) != 1 || resp.GetNextPageToken() != "next" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
+
+func TestDiffRuns(t *testing.T) {
+	var got *http.Request
+	transport := &mockTransport{
+		listRecordsFunc: func(req *http.Request) (*http.Response, error) {
+			got = req
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"base": {"name": "a"}, "other": {"name": "b"}, "differences": [{"section": "param", "path": "revision", "base": "main", "other": "dev"}]}`))),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	baseURL, _ := url.Parse("http://localhost:8080")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:     baseURL,
+		Timeout: 30 * time.Second,
+		Transport: &k8stransport.Config{
+			WrapTransport: func(_ http.RoundTripper) http.RoundTripper {
+				return transport
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+
+	resp, err := NewClient(restClient).DiffRuns(context.Background(), &pb.DiffRunsRequest{
+		Name:  "ns/results/a/records/a",
+		Other: "ns/results/b/records/b",
+	})
+	if err != nil {
+		t.Fatalf("DiffRuns() error = %v", err)
+	}
+	if got.URL.Path != "/parents/ns/results/a/records/a/diff" {
+		t.Errorf("unexpected path: %s", got.URL.Path)
+	}
+	if other := got.URL.Query().Get("other"); other != "ns/results/b/records/b" {
+		t.Errorf("unexpected other: %s", other)
+	}
+	if resp.GetBase().GetName() != "a" || resp.GetOther().GetName() != "b" || len(resp.GetDifferences()) != 1 {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
+
+func TestGetRunTimeline(t *testing.T) {
+	var got *http.Request
+	transport := &mockTransport{
+		listRecordsFunc: func(req *http.Request) (*http.Response, error) {
+			got = req
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"name": "release", "tasks": [{"name": "build"}], "criticalPath": ["build"]}`))),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	baseURL, _ := url.Parse("http://localhost:8080")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:     baseURL,
+		Timeout: 30 * time.Second,
+		Transport: &k8stransport.Config{
+			WrapTransport: func(_ http.RoundTripper) http.RoundTripper {
+				return transport
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+
+	resp, err := NewClient(restClient).GetRunTimeline(context.Background(), &pb.GetRunTimelineRequest{Name: "ns/results/a/records/a"})
+	if err != nil {
+		t.Fatalf("GetRunTimeline() error = %v", err)
+	}
+	if got.URL.Path != "/parents/ns/results/a/records/a/timeline" {
+		t.Errorf("unexpected path: %s", got.URL.Path)
+	}
+	if resp.GetName() != "release" || len(resp.GetTasks()) != 1 || len(resp.GetCriticalPath()) != 1 {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
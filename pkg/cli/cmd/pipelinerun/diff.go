@@ -0,0 +1,179 @@
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/options"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxDiffValueLength is the length beyond which values are truncated in the
+// text report. The JSON report holds the full values.
+const maxDiffValueLength = 60
+
+// diffCommand initializes a cobra command to compare two stored PipelineRuns
+func diffCommand(p common.Params) *cobra.Command {
+	opts := &options.DiffOptions{}
+
+	eg := `Compare the last green PipelineRun with a red one in namespace 'foo':
+    tkn-results pipelinerun diff 3c4e06b6-4b2f-4d1a-9d6c-0d1f2a3b4c5d 9f8e7d6c-5b4a-4f3e-8d2c-1b0a9f8e7d6c -n foo
+
+Compare two PipelineRuns as json:
+    tkn-results pipelinerun diff 3c4e06b6-4b2f-4d1a-9d6c-0d1f2a3b4c5d 9f8e7d6c-5b4a-4f3e-8d2c-1b0a9f8e7d6c -o json
+`
+	cmd := &cobra.Command{
+		Use:   "diff [base-uid] [other-uid]",
+		Short: "Compare two PipelineRuns stored in Tekton Results",
+		Long: `Compare two PipelineRuns stored in Tekton Results, identified by their UIDs.
+
+The report lists the differences of their status, params, resolved pipeline spec,
+results, and of the status, step images and image digests of their tasks, along
+with the duration of each task in both runs.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if opts.Output != "" && opts.Output != "json" {
+				return fmt.Errorf("unsupported output format %q, only json is supported", opts.Output)
+			}
+			opts.Client = p.RESTClient()
+			opts.BaseUID, opts.OtherUID = args[0], args[1]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return diff(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format, json or a text report if empty")
+
+	return cmd
+}
+
+func diff(ctx context.Context, out io.Writer, p common.Params, opts *options.DiffOptions) error {
+	recordClient := records.NewClient(opts.Client)
+	findRecord := func(uid string) (*pb.Record, error) {
+		return common.FindRunRecord(ctx, recordClient, p.Namespace(), "PipelineRun", &options.DescribeOptions{
+			UID:          uid,
+			ResourceType: common.ResourceTypePipelineRun,
+		})
+	}
+	base, err := findRecord(opts.BaseUID)
+	if err != nil {
+		return err
+	}
+	other, err := findRecord(opts.OtherUID)
+	if err != nil {
+		return err
+	}
+	resp, err := recordClient.DiffRuns(ctx, &pb.DiffRunsRequest{
+		Name:  base.GetName(),
+		Other: other.GetName(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compare PipelineRuns: %v", err)
+	}
+
+	if opts.Output == "json" {
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	}
+	return printDiff(out, resp)
+}
+
+func printDiff(out io.Writer, resp *pb.DiffRunsResponse) error {
+	// The header of the summary starts with an empty cell, which is padded
+	// with spaces rather than indented.
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', 0)
+	base, other := resp.GetBase(), resp.GetOther()
+	fmt.Fprintln(w, "\tBASE\tOTHER")
+	fmt.Fprintf(w, "Name\t%s\t%s\n", base.GetName(), other.GetName())
+	fmt.Fprintf(w, "UID\t%s\t%s\n", base.GetUid(), other.GetUid())
+	fmt.Fprintf(w, "Status\t%s\t%s\n", orNone(base.GetStatus()), orNone(other.GetStatus()))
+	fmt.Fprintf(w, "Started\t%s\t%s\n", formatTimestamp(base.GetStartTime()), formatTimestamp(other.GetStartTime()))
+	fmt.Fprintf(w, "Duration\t%s\t%s\n", formatDuration(base.GetDuration()), formatDuration(other.GetDuration()))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(resp.GetDifferences()) == 0 {
+		fmt.Fprintln(out, "No differences")
+	} else {
+		fmt.Fprintln(out, "Differences")
+		fmt.Fprintln(w, "SECTION\tPATH\tBASE\tOTHER")
+		for _, d := range resp.GetDifferences() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.GetSection(), d.GetPath(), truncate(orNone(d.GetBase())), truncate(orNone(d.GetOther())))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(resp.GetTaskDurations()) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Task durations")
+	fmt.Fprintln(w, "TASK\tBASE\tOTHER\tDELTA")
+	for _, t := range resp.GetTaskDurations() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.GetTask(), formatDuration(t.GetBase()), formatDuration(t.GetOther()), formatDelta(t.GetBase(), t.GetOther()))
+	}
+	return w.Flush()
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "---"
+	}
+	return ts.AsTime().UTC().Format("2006-01-02 15:04:05")
+}
+
+func formatDuration(d *durationpb.Duration) string {
+	if d == nil {
+		return "---"
+	}
+	return d.AsDuration().Round(time.Second).String()
+}
+
+// formatDelta formats the change of duration from base to other, e.g. +1m30s.
+func formatDelta(base, other *durationpb.Duration) string {
+	if base == nil || other == nil {
+		return "---"
+	}
+	delta := (other.AsDuration() - base.AsDuration()).Round(time.Second)
+	if delta > 0 {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "---"
+	}
+	return s
+}
+
+func truncate(s string) string {
+	if r := []rune(s); len(r) > maxDiffValueLength {
+		return string(r[:maxDiffValueLength-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package pipelinerun
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/client-go/transport"
+)
+
+func TestDiffCommand(t *testing.T) {
+	const response = `{
+		"base": {"name": "build-green", "uid": "a", "status": "Succeeded", "startTime": "2026-10-01T10:00:00Z", "duration": "180s"},
+		"other": {"name": "build-red", "uid": "b", "status": "Failed", "startTime": "2026-10-01T11:00:00Z", "duration": "150s"},
+		"differences": [
+			{"section": "param", "path": "revision", "base": "main", "other": "fix-the-build-by-pinning-every-dependency-to-the-previous-release"},
+			{"section": "task", "path": "test", "base": "Succeeded", "other": "Failed"},
+			{"section": "task", "path": "deploy", "base": "Succeeded", "change": "REMOVED"}
+		],
+		"taskDurations": [
+			{"task": "build", "base": "60s", "other": "90s"},
+			{"task": "test", "base": "90s", "other": "60s"},
+			{"task": "deploy", "base": "30s"}
+		]
+	}`
+
+	tests := []struct {
+		name           string
+		args           []string
+		response       string
+		expectedOutput string
+		jsonOutput     bool
+		errorMessage   string
+	}{
+		{
+			name:     "text",
+			args:     []string{"diff", "a", "b", "-n", "foo"},
+			response: response,
+			expectedOutput: `           BASE                  OTHER
+Name       build-green           build-red
+UID        a                     b
+Status     Succeeded             Failed
+Started    2026-10-01 10:00:00   2026-10-01 11:00:00
+Duration   3m0s                  2m30s
+
+Differences
+SECTION   PATH       BASE        OTHER
+param     revision   main        fix-the-build-by-pinning-every-dependency-to-the-previous...
+task      test       Succeeded   Failed
+task      deploy     Succeeded   ---
+
+Task durations
+TASK     BASE    OTHER   DELTA
+build    1m0s    1m30s   +30s
+test     1m30s   1m0s    -30s
+deploy   30s     ---     ---
+`,
+		},
+		{
+			name:     "no_differences",
+			args:     []string{"diff", "a", "a", "-n", "foo"},
+			response: `{"base": {"name": "build", "uid": "a"}, "other": {"name": "build", "uid": "a"}}`,
+			expectedOutput: `           BASE    OTHER
+Name       build   build
+UID        a       a
+Status     ---     ---
+Started    ---     ---
+Duration   ---     ---
+
+No differences
+`,
+		},
+		{
+			name:       "json",
+			args:       []string{"diff", "a", "b", "-n", "foo", "-o", "json"},
+			response:   `{"differences": [{"section": "result", "path": "digest", "base": "sha256:aa", "other": "sha256:bb"}]}`,
+			jsonOutput: true,
+		},
+		{
+			name:         "missing_uid",
+			args:         []string{"diff", "a"},
+			errorMessage: "accepts 2 arg(s), received 1",
+		},
+		{
+			name:         "invalid_output",
+			args:         []string{"diff", "a", "b", "-o", "yaml"},
+			errorMessage: "unsupported output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/diff") {
+					got = r
+					_, _ = w.Write([]byte(tt.response))
+					return
+				}
+				// Records are named after the UIDs of their runs.
+				name := strings.TrimPrefix(r.URL.Path, "/apis/results.tekton.dev/v1alpha2/parents/")
+				_, _ = fmt.Fprintf(w, `{"name": %q}`, name)
+			}))
+			defer server.Close()
+
+			serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+			restClient, err := client.NewRESTClient(&client.Config{
+				URL:       serverURL,
+				Timeout:   30 * time.Second,
+				Transport: &transport.Config{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create REST client: %v", err)
+			}
+			params := testutils.NewParams()
+			params.SetRESTClient(restClient)
+
+			output, err := testutils.ExecuteCommand(Command(params), tt.args...)
+			if tt.errorMessage != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMessage) {
+					t.Errorf("Expected error message to contain %q, got %v", tt.errorMessage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			wantPath := "/apis/results.tekton.dev/v1alpha2/parents/foo/results/" + tt.args[1] + "/records/" + tt.args[1] + "/diff"
+			if got.URL.Path != wantPath {
+				t.Errorf("unexpected path: got %s, want %s", got.URL.Path, wantPath)
+			}
+			wantOther := "foo/results/" + tt.args[2] + "/records/" + tt.args[2]
+			if other := got.URL.Query().Get("other"); other != wantOther {
+				t.Errorf("unexpected other: got %s, want %s", other, wantOther)
+			}
+			if tt.jsonOutput {
+				resp := &pb.DiffRunsResponse{}
+				if err := protojson.Unmarshal([]byte(output), resp); err != nil {
+					t.Fatalf("Failed to unmarshal output: %v", err)
+				}
+				if len(resp.GetDifferences()) != 1 || resp.GetDifferences()[0].GetOther() != "sha256:bb" {
+					t.Errorf("unexpected output: %s", output)
+				}
+				return
+			}
+			test.AssertOutput(t, tt.expectedOutput, output)
+		})
+	}
+}
+
+func TestDiffCommand_resultNames(t *testing.T) {
+	// The Results of the runs aren't named after their UIDs, e.g. because
+	// they are grouped under the Result of their PipelineRun, so their
+	// Records are found by the UIDs of the runs.
+	records := map[string]string{
+		"a": "foo/results/release/records/rec-a",
+		"b": "foo/results/release/records/rec-b",
+	}
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/diff"):
+			got = r
+			_, _ = w.Write([]byte(`{"base": {"name": "build-green", "uid": "a"}, "other": {"name": "build-red", "uid": "b"}}`))
+		case r.URL.Path == "/apis/results.tekton.dev/v1alpha2/parents/foo/results/-/records":
+			filter := r.URL.Query().Get("filter")
+			for uid, name := range records {
+				if strings.Contains(filter, fmt.Sprintf(`data.metadata.uid=="%s"`, uid)) {
+					_, _ = fmt.Fprintf(w, `{"records": [{"name": %q}]}`, name)
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": 5, "message": "record not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:       serverURL,
+		Timeout:   30 * time.Second,
+		Transport: &transport.Config{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+	params := testutils.NewParams()
+	params.SetRESTClient(restClient)
+
+	if _, err := testutils.ExecuteCommand(Command(params), "diff", "a", "b", "-n", "foo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wantPath := "/apis/results.tekton.dev/v1alpha2/parents/" + records["a"] + "/diff"; got.URL.Path != wantPath {
+		t.Errorf("unexpected path: got %s, want %s", got.URL.Path, wantPath)
+	}
+	if other := got.URL.Query().Get("other"); other != records["b"] {
+		t.Errorf("unexpected other: got %s, want %s", other, records["b"])
+	}
+
+	if _, err := testutils.ExecuteCommand(Command(params), "diff", "a", "c", "-n", "foo"); err == nil || !strings.Contains(err.Error(), "no PipelineRun found with UID c") {
+		t.Errorf("Expected an error for a missing PipelineRun, got %v", err)
+	}
+}
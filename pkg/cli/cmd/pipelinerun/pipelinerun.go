@@ -55,6 +55,10 @@ Examples:
 	cmd.AddCommand(listCommand(p))
 	cmd.AddCommand(logsCommand(p))
 	cmd.AddCommand(describeCommand(p))
+	cmd.AddCommand(rerunCommand(p))
+	cmd.AddCommand(restoreCommand(p))
+	cmd.AddCommand(diffCommand(p))
+	cmd.AddCommand(timelineCommand(p))
 
 	return cmd
 }
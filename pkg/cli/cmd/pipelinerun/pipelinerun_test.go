@@ -40,7 +40,7 @@ func TestCommand(t *testing.T) {
 
 	t.Run("subcommands", func(t *testing.T) {
 		// Check if all expected subcommands are registered
-		expectedSubcommands := []string{"list", "describe", "logs"}
+		expectedSubcommands := []string{"list", "describe", "logs", "rerun", "restore"}
 
 		for _, subcmdName := range expectedSubcommands {
 			subcmd, _, err := cmd.Find([]string{subcmdName})
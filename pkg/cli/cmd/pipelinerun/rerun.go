@@ -0,0 +1,139 @@
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/common/prerun"
+	"github.com/tektoncd/results/pkg/cli/options"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// rerunCommand initializes a cobra command to re-run a stored PipelineRun
+func rerunCommand(p common.Params) *cobra.Command {
+	opts := &options.RerunOptions{
+		DescribeOptions: options.DescribeOptions{ResourceType: common.ResourceTypePipelineRun},
+	}
+
+	eg := `Re-run a PipelineRun in namespace 'foo':
+    tkn-results pipelinerun rerun my-pipelinerun -n foo
+
+Re-run a PipelineRun with a different value for the 'revision' param:
+    tkn-results pipelinerun rerun my-pipelinerun -p revision=v1.2.3
+
+Re-run a PipelineRun with another ServiceAccount:
+    tkn-results pipelinerun rerun my-pipelinerun --service-account deployer
+
+Print the PipelineRun that would be created, without creating it:
+    tkn-results pipelinerun rerun my-pipelinerun --dry-run -o yaml
+`
+	cmd := &cobra.Command{
+		Use:   "rerun [pipelinerun-name]",
+		Short: "Re-run a PipelineRun stored in Tekton Results",
+		Long: `Create a new PipelineRun in the cluster from a PipelineRun stored in Tekton Results,
+even if the original PipelineRun was deleted from the cluster.
+
+The status and runtime metadata of the stored PipelineRun are dropped, and the new
+PipelineRun is named after it with a generated suffix. The name of the Record it was
+created from is set in the results.tekton.dev/rerunOf annotation.
+
+If multiple PipelineRuns match the given name, the most recent one is re-run.
+Use --uid to target a specific PipelineRun when needed.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		Args: func(_ *cobra.Command, args []string) error {
+			if opts.UID != "" {
+				return nil
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument when --uid is not provided")
+			}
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if opts.Output != "" && opts.Output != "json" && opts.Output != "yaml" {
+				return fmt.Errorf("unsupported output format %q, only json and yaml are supported", opts.Output)
+			}
+			opts.Client = p.RESTClient()
+			if len(args) > 0 {
+				opts.ResourceName = args[0]
+			}
+			if p.TektonClient() == nil && !opts.DryRun {
+				tektonClient, err := prerun.InitTektonClient(p)
+				if err != nil {
+					return err
+				}
+				p.SetTektonClient(tektonClient)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return rerun(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.UID, "uid", "", "UID of the PipelineRun to re-run")
+	cmd.Flags().StringArrayVarP(&opts.Params, "param", "p", nil, "Override a param, as name=value, name=a,b,c for arrays or name.key=value for objects")
+	cmd.Flags().StringVarP(&opts.ServiceAccount, "service-account", "s", "", "ServiceAccount to run the PipelineRun with, replacing those of its tasks")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the PipelineRun instead of creating it")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format of --dry-run. One of: json|yaml (default yaml)")
+
+	return cmd
+}
+
+func rerun(ctx context.Context, out io.Writer, p common.Params, opts *options.RerunOptions) error {
+	record, err := common.FindRunRecord(ctx, records.NewClient(opts.Client), p.Namespace(), "PipelineRun", opts)
+	if err != nil {
+		return err
+	}
+	pr, err := common.DecodePipelineRun(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	params, err := common.OverrideParams(pr.Spec.Params, opts.Params)
+	if err != nil {
+		return err
+	}
+	pr.Spec.Params = params
+	if opts.ServiceAccount != "" {
+		pr.Spec.TaskRunTemplate.ServiceAccountName = opts.ServiceAccount
+		// The ServiceAccounts of given tasks would take precedence.
+		for i := range pr.Spec.TaskRunSpecs {
+			pr.Spec.TaskRunSpecs[i].ServiceAccountName = ""
+		}
+	}
+	// A cancelled PipelineRun would be cancelled again.
+	pr.Spec.Status = ""
+	rerun := &v1.PipelineRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "PipelineRun"},
+		ObjectMeta: common.RerunObjectMeta(pr.ObjectMeta, record.GetName(), false),
+		Spec:       pr.Spec,
+	}
+	if rerun.Namespace == "" {
+		rerun.Namespace = p.Namespace()
+	}
+
+	if opts.DryRun {
+		if opts.Output == "json" {
+			return (&printers.JSONPrinter{}).PrintObj(rerun, out)
+		}
+		return (&printers.YAMLPrinter{}).PrintObj(rerun, out)
+	}
+
+	created, err := p.TektonClient().TektonV1().PipelineRuns(rerun.Namespace).Create(ctx, rerun, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create PipelineRun: %v", err)
+	}
+	_, err = fmt.Fprintf(out, "PipelineRun started: %s\n\nIn order to track the PipelineRun progress run:\ntkn pipelinerun logs %s -f -n %s\n",
+		created.Name, created.Name, created.Namespace)
+	return err
+}
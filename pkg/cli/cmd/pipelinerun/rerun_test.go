@@ -0,0 +1,188 @@
+package pipelinerun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stest "k8s.io/client-go/testing"
+	"k8s.io/client-go/transport"
+)
+
+const storedPipelineRun = `{
+	"apiVersion": "tekton.dev/v1",
+	"kind": "PipelineRun",
+	"metadata": {
+		"name": "deploy-abcde",
+		"namespace": "default",
+		"uid": "1234",
+		"resourceVersion": "42",
+		"labels": {"tekton.dev/pipeline": "deploy"},
+		"annotations": {"results.tekton.dev/record": "default/results/1234/records/1234"}
+	},
+	"spec": {
+		"pipelineRef": {"name": "deploy"},
+		"params": [{"name": "revision", "value": "main"}],
+		"taskRunTemplate": {"serviceAccountName": "default"},
+		"taskRunSpecs": [{"pipelineTaskName": "push", "serviceAccountName": "pusher"}],
+		"status": "Cancelled"
+	},
+	"status": {"conditions": [{"type": "Succeeded", "status": "False", "reason": "Cancelled"}]}
+}`
+
+// recordsServer returns a REST client listing the given records.
+func recordsServer(t *testing.T, records ...*pb.Record) *client.RESTClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/records") {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := protojson.Marshal(&pb.ListRecordsResponse{Records: records})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(server.Close)
+	serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:       serverURL,
+		Timeout:   30 * time.Second,
+		Transport: &transport.Config{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+	return restClient
+}
+
+func TestRerunPipelineRun(t *testing.T) {
+	record := &pb.Record{
+		Name: "default/results/1234/records/1234",
+		Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: []byte(storedPipelineRun)},
+	}
+	tektonClient := fake.NewSimpleClientset()
+	tektonClient.PrependReactor("create", "pipelineruns", func(action k8stest.Action) (bool, runtime.Object, error) {
+		pr := action.(k8stest.CreateAction).GetObject().(*v1.PipelineRun)
+		pr.Name = pr.GenerateName + "xyz"
+		return false, nil, nil
+	})
+	params := testutils.NewParams()
+	params.SetRESTClient(recordsServer(t, record))
+	params.SetTektonClient(tektonClient)
+
+	output, err := testutils.ExecuteCommand(Command(params), "rerun", "deploy-abcde", "-p", "revision=v1.2.3", "-s", "deployer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, `PipelineRun started: deploy-abcde-xyz
+
+In order to track the PipelineRun progress run:
+tkn pipelinerun logs deploy-abcde-xyz -f -n default
+`, output)
+
+	got, err := tektonClient.TektonV1().PipelineRuns("default").Get(context.Background(), "deploy-abcde-xyz", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the created PipelineRun: %v", err)
+	}
+	want := v1.PipelineRunSpec{
+		PipelineRef:     &v1.PipelineRef{Name: "deploy"},
+		Params:          v1.Params{{Name: "revision", Value: *v1.NewStructuredValues("v1.2.3")}},
+		TaskRunTemplate: v1.PipelineTaskRunTemplate{ServiceAccountName: "deployer"},
+		// The ServiceAccount replaces those of the tasks.
+		TaskRunSpecs: []v1.PipelineTaskRunSpec{{PipelineTaskName: "push"}},
+	}
+	test.AssertOutput(t, want, got.Spec)
+	test.AssertOutput(t, map[string]string{common.RerunOfAnnotation: record.GetName()}, got.Annotations)
+	test.AssertOutput(t, map[string]string{"tekton.dev/pipeline": "deploy"}, got.Labels)
+	if got.UID != "" || got.ResourceVersion != "" || len(got.Status.Conditions) != 0 {
+		t.Errorf("runtime metadata or status carried over: %+v", got)
+	}
+}
+
+func TestRerunPipelineRun_dryRun(t *testing.T) {
+	record := &pb.Record{
+		Name: "default/results/1234/records/1234",
+		Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: []byte(storedPipelineRun)},
+	}
+	params := testutils.NewParams()
+	params.SetRESTClient(recordsServer(t, record))
+
+	output, err := testutils.ExecuteCommand(Command(params), "rerun", "deploy-abcde", "--dry-run")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  annotations:
+    results.tekton.dev/rerunOf: default/results/1234/records/1234
+  generateName: deploy-abcde-
+  labels:
+    tekton.dev/pipeline: deploy
+  namespace: default
+spec:
+  params:
+  - name: revision
+    value: main
+  pipelineRef:
+    name: deploy
+  taskRunSpecs:
+  - pipelineTaskName: push
+    serviceAccountName: pusher
+  taskRunTemplate:
+    serviceAccountName: default
+status: {}
+`, output)
+}
+
+func TestRerunPipelineRun_errors(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		args    []string
+		records []*pb.Record
+		wantErr string
+	}{
+		{
+			name:    "not found",
+			args:    []string{"rerun", "missing", "--dry-run"},
+			wantErr: "no PipelineRun found with name missing",
+		},
+		{
+			name: "invalid param",
+			args: []string{"rerun", "deploy-abcde", "--dry-run", "-p", "revision"},
+			records: []*pb.Record{{
+				Name: "default/results/1234/records/1234",
+				Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: []byte(storedPipelineRun)},
+			}},
+			wantErr: `invalid param "revision"`,
+		},
+		{
+			name:    "invalid output",
+			args:    []string{"rerun", "deploy-abcde", "--dry-run", "-o", "table"},
+			wantErr: "unsupported output format",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testutils.NewParams()
+			params.SetRESTClient(recordsServer(t, tt.records...))
+			_, err := testutils.ExecuteCommand(Command(params), tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
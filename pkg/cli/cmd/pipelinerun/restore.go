@@ -0,0 +1,227 @@
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	typedv1 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/common/prerun"
+	"github.com/tektoncd/results/pkg/cli/options"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+)
+
+// restoreCommand initializes a cobra command to restore a stored PipelineRun
+// and its TaskRuns into the cluster
+func restoreCommand(p common.Params) *cobra.Command {
+	opts := &options.RestoreOptions{
+		DescribeOptions: options.DescribeOptions{ResourceType: common.ResourceTypePipelineRun},
+	}
+
+	eg := `Restore a PipelineRun pruned from namespace 'foo':
+    tkn-results pipelinerun restore my-pipelinerun -n foo
+
+Restore a PipelineRun into another namespace:
+    tkn-results pipelinerun restore my-pipelinerun -n foo --target-namespace archive
+`
+	cmd := &cobra.Command{
+		Use:   "restore [pipelinerun-name]",
+		Short: "Restore a PipelineRun stored in Tekton Results into the cluster",
+		Long: `Recreate a completed PipelineRun and its TaskRuns in the cluster, with their status,
+from the Records stored in Tekton Results. This lets tools reading runs from the
+cluster, such as the Tekton Dashboard or tkn, show runs that were pruned.
+
+Restored runs are read-only: they are labelled with results.tekton.dev/restored=true,
+which the watcher ignores so they are not stored again, and their spec.managedBy is set
+to results.tekton.dev/restore, so they are never run again. For Tekton releases without
+spec.managedBy, the restored PipelineRun is pending and its TaskRuns are cancelled.
+
+The runs keep their names, so a run can't be restored into a namespace where it
+still exists.
+
+If multiple PipelineRuns match the given name, the most recent one is restored.
+Use --uid to target a specific PipelineRun when needed.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		Args: func(_ *cobra.Command, args []string) error {
+			if opts.UID != "" {
+				return nil
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument when --uid is not provided")
+			}
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			opts.Client = p.RESTClient()
+			if len(args) > 0 {
+				opts.ResourceName = args[0]
+			}
+			if p.TektonClient() == nil {
+				tektonClient, err := prerun.InitTektonClient(p)
+				if err != nil {
+					return err
+				}
+				p.SetTektonClient(tektonClient)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return restore(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.UID, "uid", "", "UID of the PipelineRun to restore")
+	cmd.Flags().StringVar(&opts.TargetNamespace, "target-namespace", "", "Namespace to restore the PipelineRun into (default the namespace of the PipelineRun)")
+
+	return cmd
+}
+
+func restore(ctx context.Context, out io.Writer, p common.Params, opts *options.RestoreOptions) error {
+	recordClient := records.NewClient(opts.Client)
+	record, err := common.FindRunRecord(ctx, recordClient, p.Namespace(), "PipelineRun", opts)
+	if err != nil {
+		return err
+	}
+	pr, err := common.DecodePipelineRun(ctx, record)
+	if err != nil {
+		return err
+	}
+	if !pr.IsDone() {
+		return fmt.Errorf("PipelineRun %s has not completed, only completed PipelineRuns can be restored", pr.Name)
+	}
+	children, err := childTaskRunRecords(ctx, recordClient, record, pr.Name)
+	if err != nil {
+		return err
+	}
+
+	namespace := opts.TargetNamespace
+	if namespace == "" {
+		namespace = pr.Namespace
+	}
+	if namespace == "" {
+		namespace = p.Namespace()
+	}
+	tekton := p.TektonClient().TektonV1()
+
+	restored := &v1.PipelineRun{
+		ObjectMeta: common.RestoreObjectMeta(pr.ObjectMeta, namespace),
+		Spec:       pr.Spec,
+	}
+	restored.Spec.ManagedBy = ptr.To(common.RestoreManagedBy)
+	restored.Spec.Status = v1.PipelineRunSpecStatusPending
+	created, err := tekton.PipelineRuns(namespace).Create(ctx, restored, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create PipelineRun: %v", err)
+	}
+	if err := restorePipelineRunStatus(ctx, tekton.PipelineRuns(namespace), created.Name, pr.Status); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "PipelineRun restored: %s\n", created.Name); err != nil {
+		return err
+	}
+
+	owner := metav1.OwnerReference{
+		APIVersion:         "tekton.dev/v1",
+		Kind:               "PipelineRun",
+		Name:               created.Name,
+		UID:                created.UID,
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(true),
+	}
+	for _, child := range children {
+		tr, err := common.DecodeTaskRun(ctx, child)
+		if err != nil {
+			return err
+		}
+		restored := &v1.TaskRun{
+			ObjectMeta: common.RestoreObjectMeta(tr.ObjectMeta, namespace),
+			Spec:       tr.Spec,
+		}
+		restored.OwnerReferences = []metav1.OwnerReference{owner}
+		if _, ok := restored.Labels["tekton.dev/pipelineRunUID"]; ok {
+			restored.Labels["tekton.dev/pipelineRunUID"] = string(created.UID)
+		}
+		restored.Spec.ManagedBy = ptr.To(common.RestoreManagedBy)
+		restored.Spec.Status = v1.TaskRunSpecStatusCancelled
+		createdTaskRun, err := tekton.TaskRuns(namespace).Create(ctx, restored, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create TaskRun: %v", err)
+		}
+		if err := restoreTaskRunStatus(ctx, tekton.TaskRuns(namespace), createdTaskRun.Name, tr.Status); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "TaskRun restored: %s\n", createdTaskRun.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// childTaskRunRecords returns the Records of the TaskRuns of the PipelineRun
+// with the given name and Record.
+func childTaskRunRecords(ctx context.Context, c records.RecordClient, record *pb.Record, pipelineRun string) ([]*pb.Record, error) {
+	parent, _, _ := strings.Cut(record.GetName(), "/records/")
+	req := &pb.ListRecordsRequest{
+		Parent: parent,
+		Filter: common.BuildFilterString(&options.ListOptions{
+			ResourceType: common.ResourceTypeTaskRun,
+			PipelineRun:  pipelineRun,
+		}),
+		PageSize: 100,
+	}
+	var out []*pb.Record
+	for {
+		resp, err := c.ListRecords(ctx, req, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list TaskRuns: %v", err)
+		}
+		out = append(out, resp.Records...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func restorePipelineRunStatus(ctx context.Context, c typedv1.PipelineRunInterface, name string, status v1.PipelineRunStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pr, err := c.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pr.Status = status
+		_, err = c.UpdateStatus(ctx, pr, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore the status of PipelineRun %s: %v", name, err)
+	}
+	return nil
+}
+
+func restoreTaskRunStatus(ctx context.Context, c typedv1.TaskRunInterface, name string, status v1.TaskRunStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tr, err := c.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		tr.Status = status
+		_, err = c.UpdateStatus(ctx, tr, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore the status of TaskRun %s: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package pipelinerun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stest "k8s.io/client-go/testing"
+	"k8s.io/client-go/transport"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	prunedPipelineRun = `{
+	"apiVersion": "tekton.dev/v1",
+	"kind": "PipelineRun",
+	"metadata": {
+		"name": "ci-xyz",
+		"namespace": "default",
+		"uid": "1234",
+		"labels": {"tekton.dev/pipeline": "ci"},
+		"annotations": {"results.tekton.dev/record": "default/results/1234/records/1234", "results.tekton.dev/stored": "true"},
+		"finalizers": ["results.tekton.dev/pipelinerun"]
+	},
+	"spec": {"pipelineRef": {"name": "ci"}},
+	"status": {"conditions": [{"type": "Succeeded", "status": "True", "reason": "Succeeded"}]}
+}`
+	prunedTaskRun = `{
+	"apiVersion": "tekton.dev/v1",
+	"kind": "TaskRun",
+	"metadata": {
+		"name": "ci-xyz-build",
+		"namespace": "default",
+		"uid": "5678",
+		"labels": {"tekton.dev/pipelineRun": "ci-xyz", "tekton.dev/pipelineRunUID": "1234"},
+		"ownerReferences": [{"apiVersion": "tekton.dev/v1", "kind": "PipelineRun", "name": "ci-xyz", "uid": "1234"}]
+	},
+	"spec": {"taskRef": {"name": "build"}},
+	"status": {"podName": "ci-xyz-build-pod", "conditions": [{"type": "Succeeded", "status": "True", "reason": "Succeeded"}]}
+}`
+)
+
+// restoreServer returns a REST client serving the PipelineRun Record for
+// lookups across Results, and the TaskRun Records for lookups in its Result.
+func restoreServer(t *testing.T, pipelineRun *pb.Record, taskRuns ...*pb.Record) *client.RESTClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := &pb.ListRecordsResponse{Records: taskRuns}
+		if strings.Contains(r.URL.Path, "/results/-/records") {
+			resp = &pb.ListRecordsResponse{Records: []*pb.Record{pipelineRun}}
+		}
+		b, _ := protojson.Marshal(resp)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(server.Close)
+	serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:       serverURL,
+		Timeout:   30 * time.Second,
+		Transport: &transport.Config{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+	return restClient
+}
+
+func TestRestorePipelineRun(t *testing.T) {
+	tektonClient := fake.NewSimpleClientset()
+	tektonClient.PrependReactor("create", "pipelineruns", func(action k8stest.Action) (bool, runtime.Object, error) {
+		action.(k8stest.CreateAction).GetObject().(*v1.PipelineRun).UID = "abcd"
+		return false, nil, nil
+	})
+	params := testutils.NewParams()
+	params.SetRESTClient(restoreServer(t,
+		&pb.Record{
+			Name: "default/results/1234/records/1234",
+			Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: []byte(prunedPipelineRun)},
+		},
+		&pb.Record{
+			Name: "default/results/1234/records/5678",
+			Data: &pb.Any{Type: "tekton.dev/v1.TaskRun", Value: []byte(prunedTaskRun)},
+		}))
+	params.SetTektonClient(tektonClient)
+
+	output, err := testutils.ExecuteCommand(Command(params), "restore", "ci-xyz", "--target-namespace", "archive")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, "PipelineRun restored: ci-xyz\nTaskRun restored: ci-xyz-build\n", output)
+
+	succeeded := duckv1.Status{Conditions: duckv1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+		Reason: "Succeeded",
+	}}}
+	pr, err := tektonClient.TektonV1().PipelineRuns("archive").Get(context.Background(), "ci-xyz", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the restored PipelineRun: %v", err)
+	}
+	test.AssertOutput(t, map[string]string{"tekton.dev/pipeline": "ci", "results.tekton.dev/restored": "true"}, pr.Labels)
+	test.AssertOutput(t, map[string]string{"results.tekton.dev/record": "default/results/1234/records/1234"}, pr.Annotations)
+	test.AssertOutput(t, []string(nil), pr.Finalizers)
+	test.AssertOutput(t, ptr.To(common.RestoreManagedBy), pr.Spec.ManagedBy)
+	test.AssertOutput(t, succeeded, pr.Status.Status)
+
+	tr, err := tektonClient.TektonV1().TaskRuns("archive").Get(context.Background(), "ci-xyz-build", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the restored TaskRun: %v", err)
+	}
+	test.AssertOutput(t, map[string]string{
+		"tekton.dev/pipelineRun":      "ci-xyz",
+		"tekton.dev/pipelineRunUID":   "abcd",
+		"results.tekton.dev/restored": "true",
+	}, tr.Labels)
+	test.AssertOutput(t, []metav1.OwnerReference{{
+		APIVersion:         "tekton.dev/v1",
+		Kind:               "PipelineRun",
+		Name:               "ci-xyz",
+		UID:                "abcd",
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(true),
+	}}, tr.OwnerReferences)
+	test.AssertOutput(t, ptr.To(common.RestoreManagedBy), tr.Spec.ManagedBy)
+	test.AssertOutput(t, "ci-xyz-build-pod", tr.Status.PodName)
+	test.AssertOutput(t, succeeded, tr.Status.Status)
+}
+
+func TestRestorePipelineRun_running(t *testing.T) {
+	running := strings.Replace(prunedPipelineRun, `"status": "True"`, `"status": "Unknown"`, 1)
+	params := testutils.NewParams()
+	params.SetRESTClient(restoreServer(t, &pb.Record{
+		Name: "default/results/1234/records/1234",
+		Data: &pb.Any{Type: "tekton.dev/v1.PipelineRun", Value: []byte(running)},
+	}))
+	params.SetTektonClient(fake.NewSimpleClientset())
+
+	_, err := testutils.ExecuteCommand(Command(params), "restore", "ci-xyz")
+	if err == nil || !strings.Contains(err.Error(), "has not completed") {
+		t.Errorf("expected error for a running PipelineRun, got %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/options"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// chartWidth is the number of columns of the bars of the text chart.
+const chartWidth = 40
+
+// timelineCommand initializes a cobra command to show the timeline of a
+// stored PipelineRun
+func timelineCommand(p common.Params) *cobra.Command {
+	opts := &options.TimelineOptions{
+		DescribeOptions: options.DescribeOptions{ResourceType: common.ResourceTypePipelineRun},
+	}
+
+	eg := `Show the timeline of a PipelineRun in namespace 'foo':
+    tkn-results pipelinerun timeline my-pipelinerun -n foo
+
+Write the timeline of a PipelineRun as a trace, to open in chrome://tracing or Perfetto:
+    tkn-results pipelinerun timeline --uid 3c4e06b6-4b2f-4d1a-9d6c-0d1f2a3b4c5d -o trace > release.json
+`
+	cmd := &cobra.Command{
+		Use:   "timeline [pipelinerun-name]",
+		Short: "Show where the time of a PipelineRun stored in Tekton Results went",
+		Long: `Show the execution of a PipelineRun stored in Tekton Results, reconstructed from
+its TaskRuns: when each task became ready to run, how long it queued before its
+TaskRun was created, how long the TaskRun was pending before its pod started, and
+how long it ran.
+
+The critical path is the chain of tasks which bounded the duration of the
+PipelineRun: the last task to complete, preceded by the dependency it waited for
+last, and so on. Shortening tasks off the critical path doesn't shorten the
+PipelineRun.
+
+The timeline is shown as a text chart, or written as JSON with -o json, or as a
+Chrome trace with -o trace.
+
+If multiple PipelineRuns match the given name, the most recent one is shown.
+Use --uid to target a specific PipelineRun when needed.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		Args: func(_ *cobra.Command, args []string) error {
+			if opts.UID != "" {
+				return nil
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument when --uid is not provided")
+			}
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			switch opts.Output {
+			case "", "json", "trace":
+			default:
+				return fmt.Errorf("unsupported output format %q, only json and trace are supported", opts.Output)
+			}
+			opts.Client = p.RESTClient()
+			if len(args) > 0 {
+				opts.ResourceName = args[0]
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return timeline(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.UID, "uid", "", "UID of the PipelineRun")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format, json, trace or a text chart if empty")
+
+	return cmd
+}
+
+func timeline(ctx context.Context, out io.Writer, p common.Params, opts *options.TimelineOptions) error {
+	recordClient := records.NewClient(opts.Client)
+	record, err := common.FindRunRecord(ctx, recordClient, p.Namespace(), "PipelineRun", opts)
+	if err != nil {
+		return err
+	}
+	tl, err := recordClient.GetRunTimeline(ctx, &pb.GetRunTimelineRequest{Name: record.GetName()})
+	if err != nil {
+		return fmt.Errorf("failed to get the timeline of PipelineRun: %v", err)
+	}
+
+	switch opts.Output {
+	case "json":
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(tl)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	case "trace":
+		return printTrace(out, tl)
+	}
+	return printTimeline(out, tl)
+}
+
+func printTimeline(out io.Writer, tl *pb.RunTimeline) error {
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "PipelineRun:\t%s (%s)\n", tl.GetName(), orNone(tl.GetStatus()))
+	fmt.Fprintf(w, "Started:\t%s\n", formatTimestamp(tl.GetStartTime()))
+	fmt.Fprintf(w, "Duration:\t%s\n", formatDuration(durationBetween(tl.GetStartTime(), tl.GetCompletionTime())))
+	if len(tl.GetCriticalPath()) > 0 {
+		fmt.Fprintf(w, "Critical path:\t%s (%s)\n", strings.Join(tl.GetCriticalPath(), " -> "), formatDuration(tl.GetCriticalPathDuration()))
+	}
+	fmt.Fprintf(w, "Parallelism:\tmax %d, average %.2f\n", tl.GetMaxParallelism(), tl.GetAverageParallelism())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(tl.GetTasks()) == 0 {
+		fmt.Fprintln(out, "No TaskRuns found")
+		return nil
+	}
+	origin, end := timelineSpan(tl)
+	fmt.Fprintln(w, "TASK\tSTATUS\tQUEUE\tPENDING\tRUNNING\tTIMELINE")
+	for _, t := range tl.GetTasks() {
+		name := "  " + t.GetName()
+		if t.GetCritical() {
+			name = "* " + t.GetName()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t|%s|\n", name, orNone(t.GetStatus()),
+			formatDuration(t.GetQueue()), formatDuration(t.GetPending()), formatDuration(t.GetRunning()), bar(t, origin, end))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n* critical path   . pending   = running   (%s per column)\n", (end.Sub(origin) / chartWidth).Round(time.Millisecond))
+	return nil
+}
+
+// timelineSpan returns the times from which and until which the timeline is
+// charted: from the start of the PipelineRun to the completion of the last of
+// the PipelineRun and its tasks.
+func timelineSpan(tl *pb.RunTimeline) (time.Time, time.Time) {
+	origin := tl.GetStartTime()
+	if origin == nil {
+		origin = tl.GetCreateTime()
+	}
+	var end time.Time
+	if tl.GetCompletionTime() != nil {
+		end = tl.GetCompletionTime().AsTime()
+	}
+	for _, t := range tl.GetTasks() {
+		for _, ts := range []*timestamppb.Timestamp{t.GetReadyTime(), t.GetCreateTime(), t.GetPodStartTime(), t.GetCompletionTime()} {
+			if ts == nil {
+				continue
+			}
+			if origin == nil || ts.AsTime().Before(origin.AsTime()) {
+				origin = ts
+			}
+			if ts.AsTime().After(end) {
+				end = ts.AsTime()
+			}
+		}
+	}
+	return origin.AsTime(), end
+}
+
+// bar draws the task t on the chart of the timeline from origin to end: its
+// TaskRun is pending from its creation to the start of its pod, then runs
+// until its completion.
+func bar(t *pb.TimelineTask, origin, end time.Time) string {
+	column := func(ts *timestamppb.Timestamp) int {
+		span := end.Sub(origin)
+		if ts == nil || span <= 0 {
+			return chartWidth
+		}
+		return min(chartWidth, max(0, int(float64(chartWidth)*float64(ts.AsTime().Sub(origin))/float64(span))))
+	}
+	created, podStart, completed := column(t.GetCreateTime()), column(t.GetPodStartTime()), column(t.GetCompletionTime())
+	if t.GetPodStartTime() == nil {
+		podStart = created
+	}
+	// Running tasks are shown even if they ran for less than a column.
+	if t.GetRunning() != nil && completed == podStart && podStart < chartWidth {
+		completed++
+	}
+	b := []rune(strings.Repeat(" ", chartWidth))
+	for i := created; i < podStart && i < chartWidth; i++ {
+		b[i] = '.'
+	}
+	for i := podStart; i < completed; i++ {
+		b[i] = '='
+	}
+	return string(b)
+}
+
+// traceEvent is an event of the Chrome trace event format, see
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+type traceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Time     int64          `json:"ts"`
+	Duration int64          `json:"dur,omitempty"`
+	Process  int            `json:"pid"`
+	Thread   int            `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// printTrace writes the timeline as a trace, with a thread per task showing
+// the queue, pending and running times of its TaskRun. Times are microseconds
+// since the start of the PipelineRun.
+func printTrace(out io.Writer, tl *pb.RunTimeline) error {
+	origin, _ := timelineSpan(tl)
+	micros := func(ts *timestamppb.Timestamp) int64 {
+		return ts.AsTime().Sub(origin).Microseconds()
+	}
+	events := []traceEvent{{
+		Name: "process_name", Phase: "M", Process: 1,
+		Args: map[string]any{"name": "PipelineRun " + tl.GetName()},
+	}}
+	for i, t := range tl.GetTasks() {
+		thread := i + 1
+		events = append(events, traceEvent{
+			Name: "thread_name", Phase: "M", Process: 1, Thread: thread,
+			Args: map[string]any{"name": t.GetName()},
+		})
+		phases := []struct {
+			category    string
+			from, until *timestamppb.Timestamp
+		}{
+			{"queue", t.GetReadyTime(), t.GetCreateTime()},
+			{"pending", t.GetCreateTime(), t.GetPodStartTime()},
+			{"running", t.GetPodStartTime(), t.GetCompletionTime()},
+		}
+		for _, ph := range phases {
+			if ph.from == nil || ph.until == nil || !ph.until.AsTime().After(ph.from.AsTime()) {
+				continue
+			}
+			e := traceEvent{
+				Name: t.GetName(), Category: ph.category, Phase: "X", Process: 1, Thread: thread,
+				Time: micros(ph.from), Duration: micros(ph.until) - micros(ph.from),
+			}
+			if ph.category == "running" {
+				e.Args = map[string]any{"taskRun": t.GetTaskRun(), "status": t.GetStatus(), "critical": t.GetCritical()}
+			}
+			events = append(events, e)
+		}
+	}
+	b, err := json.MarshalIndent(map[string]any{"traceEvents": events, "displayTimeUnit": "ms"}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(b))
+	return err
+}
+
+// durationBetween returns the time from start to end, or nil if either is unset.
+func durationBetween(start, end *timestamppb.Timestamp) *durationpb.Duration {
+	if start == nil || end == nil {
+		return nil
+	}
+	return durationpb.New(end.AsTime().Sub(start.AsTime()))
+}
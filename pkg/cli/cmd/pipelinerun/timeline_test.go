@@ -0,0 +1,167 @@
+package pipelinerun
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/client-go/transport"
+)
+
+func TestTimelineCommand(t *testing.T) {
+	const (
+		record   = `{"name": "foo/results/uid-release/records/uid-release"}`
+		timeline = `{
+			"name": "release",
+			"status": "Succeeded",
+			"startTime": "2026-10-01T10:00:00Z",
+			"completionTime": "2026-10-01T10:05:20Z",
+			"tasks": [
+				{"name": "fetch", "taskRun": "release-fetch", "status": "Succeeded", "critical": true,
+					"readyTime": "2026-10-01T10:00:00Z", "createTime": "2026-10-01T10:00:00Z", "podStartTime": "2026-10-01T10:00:08Z", "completionTime": "2026-10-01T10:01:00Z",
+					"queue": "0s", "pending": "8s", "running": "52s"},
+				{"name": "lint", "taskRun": "release-lint", "status": "Failed",
+					"readyTime": "2026-10-01T10:01:00Z", "createTime": "2026-10-01T10:01:08Z", "podStartTime": "2026-10-01T10:01:40Z", "completionTime": "2026-10-01T10:02:00Z",
+					"queue": "8s", "pending": "32s", "running": "20s"},
+				{"name": "build", "taskRun": "release-build", "status": "Succeeded", "critical": true,
+					"readyTime": "2026-10-01T10:01:00Z", "createTime": "2026-10-01T10:01:00Z", "podStartTime": "2026-10-01T10:01:00Z", "completionTime": "2026-10-01T10:05:20Z",
+					"queue": "0s", "pending": "0s", "running": "260s"}
+			],
+			"criticalPath": ["fetch", "build"],
+			"criticalPathDuration": "320s",
+			"maxParallelism": 2,
+			"averageParallelism": 1.0625
+		}`
+	)
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedOutput string
+		errorMessage   string
+	}{
+		{
+			name: "chart",
+			args: []string{"timeline", "--uid", "uid-release", "-n", "foo"},
+			expectedOutput: `PipelineRun:     release (Succeeded)
+Started:         2026-10-01 10:00:00
+Duration:        5m20s
+Critical path:   fetch -> build (5m20s)
+Parallelism:     max 2, average 1.06
+
+TASK      STATUS      QUEUE   PENDING   RUNNING   TIMELINE
+* fetch   Succeeded   0s      8s        52s       |.======                                 |
+  lint    Failed      8s      32s       20s       |        ....===                         |
+* build   Succeeded   0s      0s        4m20s     |       =================================|
+
+* critical path   . pending   = running   (8s per column)
+`,
+		},
+		{
+			name:         "missing_name",
+			args:         []string{"timeline"},
+			errorMessage: "requires exactly one argument when --uid is not provided",
+		},
+		{
+			name:         "invalid_output",
+			args:         []string{"timeline", "release", "-o", "yaml"},
+			errorMessage: "unsupported output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/timeline") {
+					got = r
+					_, _ = w.Write([]byte(timeline))
+					return
+				}
+				_, _ = w.Write([]byte(record))
+			}))
+			defer server.Close()
+
+			serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+			restClient, err := client.NewRESTClient(&client.Config{
+				URL:       serverURL,
+				Timeout:   30 * time.Second,
+				Transport: &transport.Config{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create REST client: %v", err)
+			}
+			params := testutils.NewParams()
+			params.SetRESTClient(restClient)
+
+			output, err := testutils.ExecuteCommand(Command(params), tt.args...)
+			if tt.errorMessage != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMessage) {
+					t.Errorf("Expected error message to contain %q, got %v", tt.errorMessage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			wantPath := "/apis/results.tekton.dev/v1alpha2/parents/foo/results/uid-release/records/uid-release/timeline"
+			if got.URL.Path != wantPath {
+				t.Errorf("unexpected path: got %s, want %s", got.URL.Path, wantPath)
+			}
+			test.AssertOutput(t, tt.expectedOutput, output)
+		})
+	}
+}
+
+func TestPrintTrace(t *testing.T) {
+	var b strings.Builder
+	if err := printTrace(&b, mustTimeline(t, `{
+		"name": "release",
+		"startTime": "2026-10-01T10:00:00Z",
+		"tasks": [{"name": "build", "taskRun": "release-build", "status": "Succeeded", "critical": true,
+			"readyTime": "2026-10-01T10:00:00Z", "createTime": "2026-10-01T10:00:01Z", "podStartTime": "2026-10-01T10:00:01Z", "completionTime": "2026-10-01T10:00:03.5Z"}]
+	}`)); err != nil {
+		t.Fatalf("printTrace: %v", err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &trace); err != nil {
+		t.Fatalf("invalid trace: %v", err)
+	}
+	var got []string
+	for _, e := range trace.TraceEvents {
+		got = append(got, strings.Join([]string{e.Phase, e.Name, e.Category, (time.Duration(e.Time) * time.Microsecond).String(), (time.Duration(e.Duration) * time.Microsecond).String()}, " "))
+	}
+	// The pod started when the TaskRun was created, so it wasn't pending.
+	want := []string{
+		"M process_name  0s 0s",
+		"M thread_name  0s 0s",
+		"X build queue 0s 1s",
+		"X build running 1s 2.5s",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func mustTimeline(t *testing.T, s string) *pb.RunTimeline {
+	t.Helper()
+	tl := &pb.RunTimeline{}
+	if err := protojson.Unmarshal([]byte(s), tl); err != nil {
+		t.Fatal(err)
+	}
+	return tl
+}
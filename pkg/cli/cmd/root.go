@@ -11,6 +11,8 @@ import (
 	"github.com/tektoncd/results/pkg/cli/cmd/taskrun"
 
 	"github.com/tektoncd/results/pkg/cli/cmd/pipelinerun"
+	"github.com/tektoncd/results/pkg/cli/cmd/search"
+	"github.com/tektoncd/results/pkg/cli/cmd/stats"
 
 	"github.com/tektoncd/results/pkg/cli/cmd/config"
 	"github.com/tektoncd/results/pkg/cli/common"
@@ -83,6 +85,8 @@ func Root(p common.Params) *cobra.Command {
 		config.Command(p),
 		pipelinerun.Command(p),
 		taskrun.Command(p),
+		stats.Command(p),
+		search.Command(p),
 	)
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -0,0 +1,185 @@
+// Package search provides the command finding the runs stored in Tekton
+// Results by the values of their results.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/common/prerun"
+	"github.com/tektoncd/results/pkg/cli/flags"
+	"github.com/tektoncd/results/pkg/cli/options"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Command returns a cobra command for `tkn-results search`
+func Command(p common.Params) *cobra.Command {
+	opts := &options.SearchOptions{}
+	var result string
+
+	eg := `Find the runs which built an image, from their IMAGE_DIGEST result:
+    tkn-results search --result IMAGE_DIGEST=sha256:4f1c... -n build
+
+Find the runs of all namespaces which built an image listed in their IMAGES array result:
+    tkn-results search --result IMAGES=registry.example.com/app:v1 -A
+
+Find the runs which produced an artifact, from the digest key of their ARTIFACT_OUTPUTS object result:
+    tkn-results search --result ARTIFACT_OUTPUTS.digest=sha256:4f1c... -A
+
+Restrict the search to the runs of an application:
+    tkn-results search --result commit=4b825dc -L app=web
+`
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Find TaskRuns and PipelineRuns by the value of their results",
+		Long: `Find the TaskRuns and PipelineRuns which produced a result with a given value,
+such as the digest of an image they built.
+
+Array results match any of their elements. The keys of object results are searched
+as RESULT.KEY, e.g. ARTIFACT_OUTPUTS.digest. Results are indexed when runs are
+stored, so runs stored by older versions of Tekton Results aren't found.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// Initialize params from flags first
+			if err := flags.InitParams(p, cmd); err != nil {
+				return err
+			}
+			if p.RESTClient() == nil {
+				restClient, err := prerun.InitClient(p, cmd)
+				if err != nil {
+					return err
+				}
+				p.SetRESTClient(restClient)
+			}
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			allNs, _ := cmd.Flags().GetBool("all-namespaces")
+			nsSet := cmd.Flags().Changed("namespace")
+			if allNs && nsSet {
+				return errors.New("cannot use --all-namespaces/-A and --namespace/-n together")
+			}
+			name, value, ok := strings.Cut(result, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid --result %q, expected format: name=value", result)
+			}
+			opts.ResultName, opts.ResultValue = strings.TrimSpace(name), value
+			if opts.Limit < 5 || opts.Limit > 1000 {
+				return errors.New("limit should be between 5 and 1000")
+			}
+			if opts.Output != "" && opts.Output != "json" {
+				return fmt.Errorf("unsupported output format %q, only json is supported", opts.Output)
+			}
+			opts.Client = p.RESTClient()
+			if opts.Label != "" {
+				return common.ValidateLabels(opts.Label)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return search(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	flags.AddResultsOptions(cmd)
+
+	cmd.Flags().StringVar(&result, "result", "", "Result to search, as name=value")
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "Search the runs of all namespaces")
+	cmd.Flags().StringVarP(&opts.Label, "label", "L", "", "Filter by label (format: key=value[,key=value...])")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "CEL filter further restricting the runs")
+	cmd.Flags().Int32VarP(&opts.Limit, "limit", "", 50, "Maximum number of runs to return (must be between 5 and 1000, default is 50)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format, json or a table if empty")
+	_ = cmd.MarkFlagRequired("result")
+
+	return cmd
+}
+
+func search(ctx context.Context, out io.Writer, p common.Params, opts *options.SearchOptions) error {
+	parent := fmt.Sprintf("%s/results/-", p.Namespace())
+	if opts.AllNamespaces {
+		parent = common.AllNamespacesResultsParent
+	}
+
+	filters := []string{}
+	if f := common.BuildFilterString(opts); f != "" {
+		filters = append(filters, f)
+	}
+	if f := strings.TrimSpace(opts.Filter); f != "" {
+		filters = append(filters, "("+f+")")
+	}
+	resp, err := records.NewClient(opts.Client).SearchRunsByResult(ctx, &pb.SearchRunsByResultRequest{
+		Parent:   parent,
+		Name:     opts.ResultName,
+		Value:    opts.ResultValue,
+		Filter:   strings.Join(filters, " && "),
+		PageSize: opts.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == "json" {
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	}
+	return printRuns(out, resp.GetRecords())
+}
+
+// run holds the fields of TaskRuns and PipelineRuns of both v1 and v1beta1
+// printed in the table of matching runs.
+type run struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		duckv1.Status  `json:",inline"`
+		StartTime      *metav1.Time `json:"startTime,omitempty"`
+		CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	} `json:"status"`
+}
+
+func printRuns(out io.Writer, recs []*pb.Record) error {
+	if len(recs) == 0 {
+		_, err := fmt.Fprintln(out, "No runs found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	if _, err := fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tUID\tSTARTED\tDURATION\tSTATUS"); err != nil {
+		return err
+	}
+	for _, rec := range recs {
+		r := &run{}
+		if err := json.Unmarshal(rec.GetData().GetValue(), r); err != nil {
+			return fmt.Errorf("failed to unmarshal record %s: %v", rec.GetName(), err)
+		}
+		started := "---"
+		if r.Status.StartTime != nil {
+			started = r.Status.StartTime.UTC().Format("2006-01-02 15:04:05")
+		}
+		dataType := rec.GetData().GetType()
+		kind := dataType[strings.LastIndex(dataType, ".")+1:]
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Namespace, kind, r.Name, r.UID, started,
+			formatted.Duration(r.Status.StartTime, r.Status.CompletionTime), formatted.Condition(r.Status.Conditions)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
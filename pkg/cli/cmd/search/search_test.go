@@ -0,0 +1,136 @@
+package search
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	"k8s.io/client-go/transport"
+)
+
+func TestSearchCommand(t *testing.T) {
+	// The data of Records is base64 encoded in JSON responses.
+	const (
+		taskRun = `{"metadata":{"name":"build-abc","namespace":"build","uid":"uid-1"},` +
+			`"status":{"startTime":"2026-10-01T10:00:00Z","completionTime":"2026-10-01T10:02:30Z",` +
+			`"conditions":[{"type":"Succeeded","status":"True","reason":"Succeeded"}]}}`
+		pipelineRun = `{"metadata":{"name":"release","namespace":"prod","uid":"uid-2"},"status":{}}`
+	)
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	response := `{"records": [
+		{"name": "build/results/uid-1/records/uid-1", "data": {"type": "tekton.dev/v1.TaskRun", "value": "` + encode(taskRun) + `"}},
+		{"name": "prod/results/uid-2/records/uid-2", "data": {"type": "tekton.dev/v1beta1.PipelineRun", "value": "` + encode(pipelineRun) + `"}}
+	]}`
+
+	tests := []struct {
+		name           string
+		args           []string
+		response       string
+		expectedPath   string
+		expectedQuery  url.Values
+		expectedOutput string
+		errorMessage   string
+	}{
+		{
+			name:         "namespace",
+			args:         []string{"--result", "IMAGE_DIGEST=sha256:aaaa"},
+			response:     response,
+			expectedPath: "/apis/results.tekton.dev/v1alpha2/parents/default/results/-/records/results",
+			expectedQuery: url.Values{
+				"name":      {"IMAGE_DIGEST"},
+				"value":     {"sha256:aaaa"},
+				"page_size": {"50"},
+			},
+			expectedOutput: `NAMESPACE   KIND          NAME        UID     STARTED               DURATION   STATUS
+build       TaskRun       build-abc   uid-1   2026-10-01 10:00:00   2m30s      Succeeded
+prod        PipelineRun   release     uid-2   ---                   ---        ---
+`,
+		},
+		{
+			name:         "all_namespaces_and_filters",
+			args:         []string{"-A", "--result", "ARTIFACT_OUTPUTS.digest=sha256:aaaa", "-L", "app=web", "--filter", `data_type == PIPELINE_RUN`, "--limit", "10"},
+			response:     `{}`,
+			expectedPath: "/apis/results.tekton.dev/v1alpha2/parents/-/results/-/records/results",
+			expectedQuery: url.Values{
+				"name":      {"ARTIFACT_OUTPUTS.digest"},
+				"value":     {"sha256:aaaa"},
+				"filter":    {`data.metadata.labels["app"]=="web" && (data_type == PIPELINE_RUN)`},
+				"page_size": {"10"},
+			},
+			expectedOutput: `No runs found
+`,
+		},
+		{
+			name:         "missing_result",
+			args:         []string{"-A"},
+			errorMessage: "expected format: name=value",
+		},
+		{
+			name:         "invalid_result",
+			args:         []string{"--result", "sha256:aaaa"},
+			errorMessage: "expected format: name=value",
+		},
+		{
+			name:         "invalid_limit",
+			args:         []string{"--result", "a=b", "--limit", "1"},
+			errorMessage: "limit should be between 5 and 1000",
+		},
+		{
+			name:         "invalid_output",
+			args:         []string{"--result", "a=b", "-o", "yaml"},
+			errorMessage: "unsupported output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+			restClient, err := client.NewRESTClient(&client.Config{
+				URL:       serverURL,
+				Timeout:   30 * time.Second,
+				Transport: &transport.Config{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create REST client: %v", err)
+			}
+			params := testutils.NewParams()
+			params.SetRESTClient(restClient)
+
+			output, err := testutils.ExecuteCommand(Command(params), tt.args...)
+			if tt.errorMessage != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMessage) {
+					t.Errorf("Expected error message to contain %q, got %v", tt.errorMessage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got.URL.Path != tt.expectedPath {
+				t.Errorf("unexpected path: got %s, want %s", got.URL.Path, tt.expectedPath)
+			}
+			if got.URL.Query().Encode() != tt.expectedQuery.Encode() {
+				t.Errorf("unexpected query: got %s, want %s", got.URL.Query().Encode(), tt.expectedQuery.Encode())
+			}
+			test.AssertOutput(t, tt.expectedOutput, output)
+		})
+	}
+}
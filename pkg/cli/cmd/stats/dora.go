@@ -0,0 +1,200 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/options"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// doraCommand initializes a cobra command to compute DORA metrics
+func doraCommand(p common.Params) *cobra.Command {
+	opts := &options.DORAOptions{}
+	var from, to string
+
+	eg := `Compute the DORA metrics of the PipelineRuns labelled as deployments in the last 30 days:
+    tkn-results stats dora -L app.kubernetes.io/component=deploy -n production
+
+Identify deployments with a CEL filter:
+    tkn-results stats dora --filter 'data.metadata.name.startsWith("deploy-")' -n production
+
+Compute lead times from the commit time set in an annotation:
+    tkn-results stats dora -L app.kubernetes.io/component=deploy --commit-time-annotation example.com/commit-time
+
+Compute lead times from the commit time passed in the 'time' key of the 'git' object param:
+    tkn-results stats dora -L app.kubernetes.io/component=deploy --commit-time-param git.time
+
+Compute the metrics of a quarter month by month, across all namespaces:
+    tkn-results stats dora -L app.kubernetes.io/component=deploy -A --from 2026-07-01 --to 2026-10-01 --group-by month
+`
+	cmd := &cobra.Command{
+		Use:   "dora",
+		Short: "Compute DORA delivery metrics of deployment PipelineRuns",
+		Long: `Compute the deployment frequency, lead time for changes, change failure rate and
+mean time to restore of the deployment PipelineRuns, identified by label or CEL filter.
+
+Lead times are computed from the time of the deployed commit, which must be set in
+an annotation or param of the deployment PipelineRuns as an RFC 3339 timestamp.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			allNs, _ := cmd.Flags().GetBool("all-namespaces")
+			nsSet := cmd.Flags().Changed("namespace")
+			if allNs && nsSet {
+				return errors.New("cannot use --all-namespaces/-A and --namespace/-n together")
+			}
+			if opts.CommitTimeAnnotation != "" && opts.CommitTimeParam != "" {
+				return errors.New("cannot use --commit-time-annotation and --commit-time-param together")
+			}
+			if opts.Output != "" && opts.Output != "json" {
+				return fmt.Errorf("unsupported output format %q, only json is supported", opts.Output)
+			}
+			var err error
+			if opts.From, err = parseTime(from); err != nil {
+				return fmt.Errorf("invalid --from: %v", err)
+			}
+			if opts.To, err = parseTime(to); err != nil {
+				return fmt.Errorf("invalid --to: %v", err)
+			}
+			opts.Client = p.RESTClient()
+			if opts.Label != "" {
+				return common.ValidateLabels(opts.Label)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return dora(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.AllNamespaces, "all-namespaces", "A", false, "Compute the metrics of the deployments of all namespaces")
+	cmd.Flags().StringVarP(&opts.Label, "label", "L", "", "Label identifying deployment PipelineRuns (format: key=value[,key=value...])")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "CEL filter identifying deployment PipelineRuns")
+	cmd.Flags().StringVar(&opts.CommitTimeAnnotation, "commit-time-annotation", "", "Annotation holding the time of the deployed commit")
+	cmd.Flags().StringVar(&opts.CommitTimeParam, "commit-time-param", "", "Param holding the time of the deployed commit, as name or name.key for object params")
+	cmd.Flags().StringVar(&opts.GroupBy, "group-by", "", "Group the metrics, e.g. by week, month, namespace, pipeline or 'label <key>'")
+	cmd.Flags().StringVar(&from, "from", "", "Start of the period, as a date or RFC 3339 time (default: 30 days before --to)")
+	cmd.Flags().StringVar(&to, "to", "", "End of the period, as a date or RFC 3339 time (default: now)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format, json or a table if empty")
+
+	return cmd
+}
+
+// parseTime parses a date or an RFC 3339 time, returning the zero time if s is
+// empty.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func dora(ctx context.Context, out io.Writer, p common.Params, opts *options.DORAOptions) error {
+	parent := fmt.Sprintf("%s/results/-", p.Namespace())
+	if opts.AllNamespaces {
+		parent = common.AllNamespacesResultsParent
+	}
+
+	filters := []string{}
+	if f := common.BuildFilterString(opts); f != "" {
+		filters = append(filters, f)
+	}
+	if f := strings.TrimSpace(opts.Filter); f != "" {
+		filters = append(filters, "("+f+")")
+	}
+	req := &pb.DeliveryMetricsRequest{
+		Parent:               parent,
+		Filter:               strings.Join(filters, " && "),
+		CommitTimeAnnotation: opts.CommitTimeAnnotation,
+		CommitTimeParam:      opts.CommitTimeParam,
+		GroupBy:              opts.GroupBy,
+	}
+	if !opts.From.IsZero() {
+		req.StartTime = timestamppb.New(opts.From)
+	}
+	if !opts.To.IsZero() {
+		req.EndTime = timestamppb.New(opts.To)
+	}
+
+	resp, err := records.NewClient(opts.Client).GetDeliveryMetrics(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == "json" {
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	}
+	return printDeliveryMetrics(out, resp.GetMetrics())
+}
+
+func printDeliveryMetrics(out io.Writer, metrics []*pb.DeliveryMetrics) error {
+	if len(metrics) == 0 {
+		_, err := fmt.Fprintln(out, "No deployments found")
+		return err
+	}
+
+	// Metrics grouped by time are told apart by their period.
+	grouped := false
+	for _, m := range metrics {
+		grouped = grouped || m.GetGroup() != ""
+	}
+
+	w := tabwriter.NewWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	header := "PERIOD\tDEPLOYMENTS\tFAILED\tFREQUENCY\tLEAD TIME\tCHANGE FAILURE RATE\tTIME TO RESTORE"
+	if grouped {
+		header = "GROUP\t" + header
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+	for _, m := range metrics {
+		row := fmt.Sprintf("%s - %s\t%d\t%d\t%.2f/day\t%s\t%.1f%%\t%s",
+			formatTime(m.GetPeriodStart()), formatTime(m.GetPeriodEnd()),
+			m.GetDeployments(), m.GetFailedDeployments(), m.GetDeploymentFrequency(),
+			formatDuration(m.GetLeadTime()), 100*m.GetChangeFailureRate(), formatDuration(m.GetMeanTimeToRestore()))
+		if grouped {
+			group := m.GetGroup()
+			if group == "" {
+				group = "---"
+			}
+			row = group + "\t" + row
+		}
+		if _, err := fmt.Fprintln(w, row); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func formatTime(t *timestamppb.Timestamp) string {
+	return t.AsTime().Format("2006-01-02 15:04")
+}
+
+func formatDuration(d *durationpb.Duration) string {
+	if d == nil {
+		return "---"
+	}
+	return d.AsDuration().Round(time.Minute).String()
+}
@@ -0,0 +1,143 @@
+package stats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	"k8s.io/client-go/transport"
+)
+
+func TestDoraCommand(t *testing.T) {
+	const (
+		ungrouped = `{"metrics": [{
+			"periodStart": "2026-07-01T00:00:00Z",
+			"periodEnd": "2026-10-01T00:00:00Z",
+			"deployments": "46",
+			"failedDeployments": "4",
+			"deploymentFrequency": 0.5,
+			"leadTime": "10800s",
+			"changeFailureRate": 0.08,
+			"meanTimeToRestore": "5400s"
+		}]}`
+		grouped = `{"metrics": [
+			{"group": "prod", "periodStart": "2026-07-01T00:00:00Z", "periodEnd": "2026-10-01T00:00:00Z", "deployments": "46", "deploymentFrequency": 0.5},
+			{"group": "staging", "periodStart": "2026-07-01T00:00:00Z", "periodEnd": "2026-10-01T00:00:00Z", "failedDeployments": "1", "changeFailureRate": 1}
+		]}`
+	)
+
+	tests := []struct {
+		name           string
+		args           []string
+		response       string
+		expectedPath   string
+		expectedQuery  url.Values
+		expectedOutput string
+		errorMessage   string
+	}{
+		{
+			name:         "label_and_period",
+			args:         []string{"dora", "-L", "app=web", "--from", "2026-07-01", "--to", "2026-10-01T00:00:00Z", "--commit-time-param", "git.time"},
+			response:     ungrouped,
+			expectedPath: "/apis/results.tekton.dev/v1alpha2/parents/default/results/-/records/summary/dora",
+			expectedQuery: url.Values{
+				"filter":            {`data.metadata.labels["app"]=="web"`},
+				"commit_time_param": {"git.time"},
+				"start_time":        {"2026-07-01T00:00:00Z"},
+				"end_time":          {"2026-10-01T00:00:00Z"},
+			},
+			expectedOutput: `PERIOD                                DEPLOYMENTS   FAILED   FREQUENCY   LEAD TIME   CHANGE FAILURE RATE   TIME TO RESTORE
+2026-07-01 00:00 - 2026-10-01 00:00   46            4        0.50/day    3h0m0s      8.0%                  1h30m0s
+`,
+		},
+		{
+			name:         "filter_and_group",
+			args:         []string{"dora", "-A", "-L", "app=web", "--filter", `data.metadata.name.startsWith("deploy")`, "--group-by", "namespace"},
+			response:     grouped,
+			expectedPath: "/apis/results.tekton.dev/v1alpha2/parents/-/results/-/records/summary/dora",
+			expectedQuery: url.Values{
+				"filter":   {`data.metadata.labels["app"]=="web" && (data.metadata.name.startsWith("deploy"))`},
+				"group_by": {"namespace"},
+			},
+			expectedOutput: `GROUP     PERIOD                                DEPLOYMENTS   FAILED   FREQUENCY   LEAD TIME   CHANGE FAILURE RATE   TIME TO RESTORE
+prod      2026-07-01 00:00 - 2026-10-01 00:00   46            0        0.50/day    ---         0.0%                  ---
+staging   2026-07-01 00:00 - 2026-10-01 00:00   0             1        0.00/day    ---         100.0%                ---
+`,
+		},
+		{
+			name:         "no_deployments",
+			args:         []string{"dora", "--group-by", "month"},
+			response:     `{}`,
+			expectedPath: "/apis/results.tekton.dev/v1alpha2/parents/default/results/-/records/summary/dora",
+			expectedQuery: url.Values{
+				"group_by": {"month"},
+			},
+			expectedOutput: `No deployments found
+`,
+		},
+		{
+			name:         "conflicting_commit_times",
+			args:         []string{"dora", "--commit-time-annotation", "a", "--commit-time-param", "p"},
+			errorMessage: "cannot use --commit-time-annotation and --commit-time-param together",
+		},
+		{
+			name:         "invalid_from",
+			args:         []string{"dora", "--from", "July"},
+			errorMessage: "invalid --from",
+		},
+		{
+			name:         "invalid_output",
+			args:         []string{"dora", "-o", "yaml"},
+			errorMessage: "unsupported output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+			restClient, err := client.NewRESTClient(&client.Config{
+				URL:       serverURL,
+				Timeout:   30 * time.Second,
+				Transport: &transport.Config{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create REST client: %v", err)
+			}
+			params := testutils.NewParams()
+			params.SetRESTClient(restClient)
+
+			output, err := testutils.ExecuteCommand(Command(params), tt.args...)
+			if tt.errorMessage != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMessage) {
+					t.Errorf("Expected error message to contain %q, got %v", tt.errorMessage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got.URL.Path != tt.expectedPath {
+				t.Errorf("unexpected path: got %s, want %s", got.URL.Path, tt.expectedPath)
+			}
+			if got.URL.Query().Encode() != tt.expectedQuery.Encode() {
+				t.Errorf("unexpected query: got %s, want %s", got.URL.Query().Encode(), tt.expectedQuery.Encode())
+			}
+			test.AssertOutput(t, tt.expectedOutput, output)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Package stats provides the commands computing statistics over the runs
+// stored in Tekton Results.
+package stats
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/common/prerun"
+	"github.com/tektoncd/results/pkg/cli/flags"
+)
+
+// Command returns a cobra command for `tkn-results stats` sub commands
+func Command(p common.Params) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Compute statistics over stored runs",
+		Long: `Compute statistics over the runs stored in Tekton Results.
+
+Examples:
+  # Compute the DORA metrics of the PipelineRuns labelled as deployments
+  tkn-results stats dora -L app.kubernetes.io/component=deploy -n production
+
+  # Compute the DORA metrics of a quarter, month by month
+  tkn-results stats dora -L app.kubernetes.io/component=deploy -A --from 2026-07-01 --to 2026-10-01 --group-by month`,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// Initialize params from flags first
+			if err := flags.InitParams(p, cmd); err != nil {
+				return err
+			}
+			if p.RESTClient() == nil {
+				restClient, err := prerun.InitClient(p, cmd)
+				if err != nil {
+					return err
+				}
+				p.SetRESTClient(restClient)
+			}
+			return nil
+		},
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+	}
+
+	flags.AddResultsOptions(cmd)
+
+	cmd.AddCommand(doraCommand(p))
+
+	return cmd
+}
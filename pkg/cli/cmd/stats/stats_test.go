@@ -0,0 +1,29 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/tektoncd/results/pkg/cli/testutils"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command(testutils.NewParams())
+
+	if cmd.Use != "stats" {
+		t.Errorf("unexpected command name: got %v, want %v", cmd.Use, "stats")
+	}
+	if cmd.PersistentPreRunE == nil {
+		t.Error("command should have PersistentPreRunE")
+	}
+	if cmdType, ok := cmd.Annotations["commandType"]; !ok || cmdType != "main" {
+		t.Errorf("unexpected command type annotation: got %v, want 'main'", cmdType)
+	}
+
+	subcmd, _, err := cmd.Find([]string{"dora"})
+	if err != nil {
+		t.Fatalf("dora subcommand not found: %v", err)
+	}
+	if subcmd.Name() != "dora" {
+		t.Errorf("unexpected subcommand name: got %v, want 'dora'", subcmd.Name())
+	}
+}
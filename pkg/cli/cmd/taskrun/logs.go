@@ -33,6 +33,9 @@ Get logs for a TaskRun in a specific namespace:
 
 Get logs for a TaskRun by UID if there are multiple TaskRun with the same name:
   tkn-results taskrun logs --uid 12345678-1234-1234-1234-1234567890ab
+
+Get logs of the 'build' step of a TaskRun named 'foo':
+  tkn-results taskrun logs foo --step build
 `
 
 	cmd := &cobra.Command{
@@ -138,6 +141,7 @@ Logs are only available for completed TaskRuns. Running TaskRuns do not have log
 			// Create a request to get the logs
 			req := &pb.GetLogRequest{
 				Name: record.Name,
+				Step: opts.Step,
 			}
 
 			// Get the logs
@@ -162,6 +166,7 @@ Logs are only available for completed TaskRuns. Running TaskRuns do not have log
 		},
 	}
 	cmd.Flags().StringVar(&opts.UID, "uid", "", "UID of the TaskRun to get logs for")
+	cmd.Flags().StringVar(&opts.Step, "step", "", "Name of the step to get logs for. Requires the watcher to store logs per step")
 
 	return cmd
 }
@@ -0,0 +1,137 @@
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/common/prerun"
+	"github.com/tektoncd/results/pkg/cli/options"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// rerunCommand initializes a cobra command to re-run a stored TaskRun
+func rerunCommand(p common.Params) *cobra.Command {
+	opts := &options.RerunOptions{
+		DescribeOptions: options.DescribeOptions{ResourceType: common.ResourceTypeTaskRun},
+	}
+
+	eg := `Re-run a TaskRun in namespace 'foo':
+    tkn-results taskrun rerun my-taskrun -n foo
+
+Re-run a TaskRun with a different value for the 'revision' param:
+    tkn-results taskrun rerun my-taskrun -p revision=v1.2.3
+
+Re-run a TaskRun with another ServiceAccount:
+    tkn-results taskrun rerun my-taskrun --service-account builder
+
+Print the TaskRun that would be created, without creating it:
+    tkn-results taskrun rerun my-taskrun --dry-run -o yaml
+`
+	cmd := &cobra.Command{
+		Use:   "rerun [taskrun-name]",
+		Short: "Re-run a TaskRun stored in Tekton Results",
+		Long: `Create a new TaskRun in the cluster from a TaskRun stored in Tekton Results,
+even if the original TaskRun was deleted from the cluster.
+
+The status and runtime metadata of the stored TaskRun are dropped, and the new
+TaskRun is named after it with a generated suffix. TaskRuns of a PipelineRun are
+re-run on their own, outside of the PipelineRun. The name of the Record the TaskRun
+was created from is set in the results.tekton.dev/rerunOf annotation.
+
+If multiple TaskRuns match the given name, the most recent one is re-run.
+Use --uid to target a specific TaskRun when needed.`,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		Example: eg,
+		Args: func(_ *cobra.Command, args []string) error {
+			if opts.UID != "" {
+				return nil
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument when --uid is not provided")
+			}
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if opts.Output != "" && opts.Output != "json" && opts.Output != "yaml" {
+				return fmt.Errorf("unsupported output format %q, only json and yaml are supported", opts.Output)
+			}
+			opts.Client = p.RESTClient()
+			if len(args) > 0 {
+				opts.ResourceName = args[0]
+			}
+			if p.TektonClient() == nil && !opts.DryRun {
+				tektonClient, err := prerun.InitTektonClient(p)
+				if err != nil {
+					return err
+				}
+				p.SetTektonClient(tektonClient)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return rerun(cmd.Context(), cmd.OutOrStdout(), p, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.UID, "uid", "", "UID of the TaskRun to re-run")
+	cmd.Flags().StringArrayVarP(&opts.Params, "param", "p", nil, "Override a param, as name=value, name=a,b,c for arrays or name.key=value for objects")
+	cmd.Flags().StringVarP(&opts.ServiceAccount, "service-account", "s", "", "ServiceAccount to run the TaskRun with")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the TaskRun instead of creating it")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output format of --dry-run. One of: json|yaml (default yaml)")
+
+	return cmd
+}
+
+func rerun(ctx context.Context, out io.Writer, p common.Params, opts *options.RerunOptions) error {
+	record, err := common.FindRunRecord(ctx, records.NewClient(opts.Client), p.Namespace(), "TaskRun", opts)
+	if err != nil {
+		return err
+	}
+	tr, err := common.DecodeTaskRun(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	params, err := common.OverrideParams(tr.Spec.Params, opts.Params)
+	if err != nil {
+		return err
+	}
+	tr.Spec.Params = params
+	if opts.ServiceAccount != "" {
+		tr.Spec.ServiceAccountName = opts.ServiceAccount
+	}
+	// A cancelled TaskRun would be cancelled again.
+	tr.Spec.Status = ""
+	tr.Spec.StatusMessage = ""
+	rerun := &v1.TaskRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+		ObjectMeta: common.RerunObjectMeta(tr.ObjectMeta, record.GetName(), true),
+		Spec:       tr.Spec,
+	}
+	if rerun.Namespace == "" {
+		rerun.Namespace = p.Namespace()
+	}
+
+	if opts.DryRun {
+		if opts.Output == "json" {
+			return (&printers.JSONPrinter{}).PrintObj(rerun, out)
+		}
+		return (&printers.YAMLPrinter{}).PrintObj(rerun, out)
+	}
+
+	created, err := p.TektonClient().TektonV1().TaskRuns(rerun.Namespace).Create(ctx, rerun, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create TaskRun: %v", err)
+	}
+	_, err = fmt.Fprintf(out, "TaskRun started: %s\n\nIn order to track the TaskRun progress run:\ntkn taskrun logs %s -f -n %s\n",
+		created.Name, created.Name, created.Namespace)
+	return err
+}
@@ -0,0 +1,107 @@
+package taskrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/common"
+	"github.com/tektoncd/results/pkg/cli/testutils"
+	"github.com/tektoncd/results/pkg/test"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stest "k8s.io/client-go/testing"
+	"k8s.io/client-go/transport"
+)
+
+// storedTaskRun is a v1beta1 TaskRun that ran as part of a PipelineRun.
+const storedTaskRun = `{
+	"apiVersion": "tekton.dev/v1beta1",
+	"kind": "TaskRun",
+	"metadata": {
+		"name": "ci-xyz-build",
+		"namespace": "default",
+		"uid": "5678",
+		"labels": {
+			"tekton.dev/task": "build",
+			"tekton.dev/pipelineRun": "ci-xyz",
+			"tekton.dev/pipelineTask": "build"
+		},
+		"ownerReferences": [{"apiVersion": "tekton.dev/v1", "kind": "PipelineRun", "name": "ci-xyz", "uid": "1234"}]
+	},
+	"spec": {
+		"taskRef": {"name": "build"},
+		"params": [{"name": "revision", "value": "main"}],
+		"serviceAccountName": "default"
+	},
+	"status": {"conditions": [{"type": "Succeeded", "status": "False", "reason": "Failed"}]}
+}`
+
+func TestRerunTaskRun(t *testing.T) {
+	record := &pb.Record{
+		Name: "default/results/1234/records/5678",
+		Data: &pb.Any{Type: "tekton.dev/v1beta1.TaskRun", Value: []byte(storedTaskRun)},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/records") {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := protojson.Marshal(&pb.ListRecordsResponse{Records: []*pb.Record{record}})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL + "/apis/results.tekton.dev/v1alpha2")
+	restClient, err := client.NewRESTClient(&client.Config{
+		URL:       serverURL,
+		Timeout:   30 * time.Second,
+		Transport: &transport.Config{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+
+	tektonClient := fake.NewSimpleClientset()
+	tektonClient.PrependReactor("create", "taskruns", func(action k8stest.Action) (bool, runtime.Object, error) {
+		tr := action.(k8stest.CreateAction).GetObject().(*v1.TaskRun)
+		tr.Name = tr.GenerateName + "abc"
+		return false, nil, nil
+	})
+	params := testutils.NewParams()
+	params.SetRESTClient(restClient)
+	params.SetTektonClient(tektonClient)
+
+	output, err := testutils.ExecuteCommand(Command(params), "rerun", "ci-xyz-build", "-p", "revision=v1.2.3", "-s", "builder")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	test.AssertOutput(t, `TaskRun started: ci-xyz-build-abc
+
+In order to track the TaskRun progress run:
+tkn taskrun logs ci-xyz-build-abc -f -n default
+`, output)
+
+	got, err := tektonClient.TektonV1().TaskRuns("default").Get(context.Background(), "ci-xyz-build-abc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the created TaskRun: %v", err)
+	}
+	test.AssertOutput(t, &v1.TaskRef{Name: "build"}, got.Spec.TaskRef)
+	test.AssertOutput(t, v1.Params{{Name: "revision", Value: *v1.NewStructuredValues("v1.2.3")}}, got.Spec.Params)
+	test.AssertOutput(t, "builder", got.Spec.ServiceAccountName)
+	// The re-run TaskRun is no longer part of the PipelineRun.
+	test.AssertOutput(t, map[string]string{"tekton.dev/task": "build"}, got.Labels)
+	test.AssertOutput(t, map[string]string{common.RerunOfAnnotation: record.GetName()}, got.Annotations)
+	if len(got.OwnerReferences) != 0 {
+		t.Errorf("owner references carried over: %v", got.OwnerReferences)
+	}
+}
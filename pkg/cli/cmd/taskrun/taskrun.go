@@ -55,6 +55,7 @@ Examples:
 	cmd.AddCommand(listCommand(p))
 	cmd.AddCommand(logsCommand(p))
 	cmd.AddCommand(describeCommand(p))
+	cmd.AddCommand(rerunCommand(p))
 
 	return cmd
 }
@@ -3,6 +3,7 @@ package common
 import (
 	"io"
 
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/results/pkg/cli/client"
 )
 
@@ -54,4 +55,9 @@ type Params interface {
 	// Returns REST client - from which log/record clients are created
 	SetRESTClient(client *client.RESTClient)
 	RESTClient() *client.RESTClient
+
+	// Tekton client access method for dependency injection
+	// Returns the clientset used to create runs in the cluster
+	SetTektonClient(client versioned.Interface)
+	TektonClient() versioned.Interface
 }
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/results/pkg/cli/client"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -16,7 +17,8 @@ type ResultsParams struct {
 	skipTLSVerify  bool
 
 	// Simple client storage
-	restClient *client.RESTClient
+	restClient   *client.RESTClient
+	tektonClient versioned.Interface
 }
 
 var _ Params = (*ResultsParams)(nil)
@@ -137,3 +139,13 @@ func (p *ResultsParams) SetRESTClient(client *client.RESTClient) {
 func (p *ResultsParams) RESTClient() *client.RESTClient {
 	return p.restClient
 }
+
+// SetTektonClient injects a Tekton clientset
+func (p *ResultsParams) SetTektonClient(client versioned.Interface) {
+	p.tektonClient = client
+}
+
+// TektonClient returns the injected Tekton clientset
+func (p *ResultsParams) TektonClient() versioned.Interface {
+	return p.tektonClient
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/results/pkg/cli/client"
 	"github.com/tektoncd/results/pkg/cli/common"
 	"github.com/tektoncd/results/pkg/cli/config"
 	"github.com/tektoncd/results/pkg/cli/flags"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // PersistentPreRunE returns a function that can be used as a persistent pre-run
@@ -57,3 +59,25 @@ func InitClient(p common.Params, cmd *cobra.Command) (*client.RESTClient, error)
 	}
 	return client.NewRESTClient(c.Get())
 }
+
+// InitTektonClient initializes the Tekton clientset used to create resources in
+// the cluster, from the kubeconfig and context set in the parameters.
+//
+// Parameters:
+//   - p: common.Params containing configuration parameters.
+//
+// Returns:
+//   - versioned.Interface: The initialized Tekton clientset.
+//   - error: An error if the kubeconfig can't be loaded.
+func InitTektonClient(p common.Params) (versioned.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if p.KubeConfigPath() != "" {
+		loadingRules.ExplicitPath = p.KubeConfigPath()
+	}
+	configOverrides := &clientcmd.ConfigOverrides{CurrentContext: p.KubeContext()}
+	rc, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return versioned.NewForConfig(rc)
+}
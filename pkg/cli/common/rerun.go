@@ -0,0 +1,211 @@
+package common //nolint:revive // Package provides shared CLI utilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/results/pkg/cli/client/records"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RerunOfAnnotation is set on re-runs to the name of the Record they were
+// created from.
+const RerunOfAnnotation = "results.tekton.dev/rerunOf"
+
+// runAnnotationPrefixes are the prefixes of the annotations the watcher and
+// Tekton Chains set on the runs they processed, which must not be carried over
+// to re-runs. Otherwise the watcher would take the steps of a re-run for sent
+// already and Chains would not sign it.
+var runAnnotationPrefixes = []string{
+	"results.tekton.dev/",
+	"chains.tekton.dev/",
+}
+
+// runAnnotations are the other annotations which must not be carried over to
+// re-runs.
+var runAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// declaredAnnotations are the annotations of the watcher which integrators set
+// on runs to tell what to store, and are carried over to re-runs.
+var declaredAnnotations = []string{
+	annotation.ResultAnnotations,
+	annotation.RecordSummaryAnnotations,
+}
+
+// childLabels are the labels tying a TaskRun to the PipelineRun it was
+// created for.
+var childLabels = []string{
+	"tekton.dev/pipeline",
+	"tekton.dev/pipelineRun",
+	"tekton.dev/pipelineRunUID",
+	"tekton.dev/pipelineTask",
+	"tekton.dev/memberOf",
+}
+
+// FindRunRecord returns the most recent Record of the run selected by the
+// name or UID in opts, in the given namespace. kind is the kind of the run,
+// used in error messages.
+func FindRunRecord(ctx context.Context, c records.RecordClient, namespace, kind string, opts FilterOptions) (*pb.Record, error) {
+	if uid := opts.GetUID(); uid != "" {
+		if r, err := c.GetRecord(ctx, namespace, uid); err == nil {
+			return r, nil
+		}
+	}
+	resp, err := c.ListRecords(ctx, &pb.ListRecordsRequest{
+		Parent:   fmt.Sprintf("%s/results/-", namespace),
+		Filter:   BuildFilterString(opts),
+		OrderBy:  "create_time desc",
+		PageSize: 5,
+	}, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %v", kind, err)
+	}
+	if len(resp.Records) == 0 {
+		if opts.GetUID() != "" {
+			return nil, fmt.Errorf("no %s found with UID %s", kind, opts.GetUID())
+		}
+		return nil, fmt.Errorf("no %s found with name %s", kind, opts.GetResourceName())
+	}
+	return resp.Records[0], nil
+}
+
+// DecodePipelineRun returns the PipelineRun stored in record, converted to v1.
+func DecodePipelineRun(ctx context.Context, record *pb.Record) (*v1.PipelineRun, error) {
+	pr := &v1.PipelineRun{}
+	if record.GetData().GetType() == "tekton.dev/v1beta1.PipelineRun" {
+		var old v1beta1.PipelineRun
+		if err := json.Unmarshal(record.GetData().GetValue(), &old); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PipelineRun data: %v", err)
+		}
+		if err := old.ConvertTo(ctx, pr); err != nil {
+			return nil, fmt.Errorf("failed to convert PipelineRun to v1: %v", err)
+		}
+		return pr, nil
+	}
+	if err := json.Unmarshal(record.GetData().GetValue(), pr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PipelineRun data: %v", err)
+	}
+	return pr, nil
+}
+
+// DecodeTaskRun returns the TaskRun stored in record, converted to v1.
+func DecodeTaskRun(ctx context.Context, record *pb.Record) (*v1.TaskRun, error) {
+	tr := &v1.TaskRun{}
+	if record.GetData().GetType() == "tekton.dev/v1beta1.TaskRun" {
+		var old v1beta1.TaskRun
+		if err := json.Unmarshal(record.GetData().GetValue(), &old); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TaskRun data: %v", err)
+		}
+		if err := old.ConvertTo(ctx, tr); err != nil {
+			return nil, fmt.Errorf("failed to convert TaskRun to v1: %v", err)
+		}
+		return tr, nil
+	}
+	if err := json.Unmarshal(record.GetData().GetValue(), tr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TaskRun data: %v", err)
+	}
+	return tr, nil
+}
+
+// RerunObjectMeta returns the metadata of a new run created from the stored
+// run with metadata m and Record record. The runtime metadata is dropped, and
+// the new run is named after the stored one.
+func RerunObjectMeta(m metav1.ObjectMeta, record string, child bool) metav1.ObjectMeta {
+	generateName := m.GenerateName
+	if generateName == "" {
+		generateName = m.Name + "-"
+	}
+	out := metav1.ObjectMeta{
+		GenerateName: generateName,
+		Namespace:    m.Namespace,
+		Labels:       map[string]string{},
+		Annotations:  map[string]string{},
+	}
+	for k, v := range m.Labels {
+		out.Labels[k] = v
+	}
+	if child {
+		for _, k := range childLabels {
+			delete(out.Labels, k)
+		}
+	}
+	for k, v := range m.Annotations {
+		if runAnnotation(k) {
+			continue
+		}
+		out.Annotations[k] = v
+	}
+	out.Annotations[RerunOfAnnotation] = record
+	return out
+}
+
+// runAnnotation reports whether the annotation k must not be carried over to
+// re-runs.
+func runAnnotation(k string) bool {
+	if slices.Contains(declaredAnnotations, k) {
+		return false
+	}
+	if slices.Contains(runAnnotations, k) {
+		return true
+	}
+	return slices.ContainsFunc(runAnnotationPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(k, prefix)
+	})
+}
+
+// OverrideParams sets the params given as name=value in overrides. Values of
+// array params are split on commas, and keys of object params are set with
+// name.key=value.
+func OverrideParams(params v1.Params, overrides []string) (v1.Params, error) {
+	out := make(v1.Params, len(params))
+	for i, p := range params {
+		out[i] = *p.DeepCopy()
+	}
+	for _, o := range overrides {
+		name, value, ok := strings.Cut(o, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid param %q, expected name=value", o)
+		}
+		i := paramIndex(out, name)
+		if i >= 0 {
+			switch out[i].Value.Type {
+			case v1.ParamTypeArray:
+				out[i].Value = v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: strings.Split(value, ",")}
+			case v1.ParamTypeObject:
+				return nil, fmt.Errorf("param %q is an object, set its keys with %s.key=value", name, name)
+			default:
+				out[i].Value = *v1.NewStructuredValues(value)
+			}
+			continue
+		}
+		if object, key, ok := strings.Cut(name, "."); ok {
+			if i := paramIndex(out, object); i >= 0 && out[i].Value.Type == v1.ParamTypeObject {
+				if out[i].Value.ObjectVal == nil {
+					out[i].Value.ObjectVal = map[string]string{}
+				}
+				out[i].Value.ObjectVal[key] = value
+				continue
+			}
+		}
+		out = append(out, v1.Param{Name: name, Value: *v1.NewStructuredValues(value)})
+	}
+	return out, nil
+}
+
+func paramIndex(params v1.Params, name string) int {
+	for i, p := range params {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,126 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/cli/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOverrideParams(t *testing.T) {
+	params := v1.Params{
+		{Name: "revision", Value: *v1.NewStructuredValues("main")},
+		{Name: "flags", Value: *v1.NewStructuredValues("-v", "-race")},
+		{Name: "git", Value: *v1.NewObject(map[string]string{"url": "https://example.com/repo", "revision": "main"})},
+	}
+
+	tests := []struct {
+		name      string
+		overrides []string
+		want      v1.Params
+		wantErr   bool
+	}{
+		{
+			name: "no overrides",
+			want: params,
+		},
+		{
+			name:      "string, array and object key",
+			overrides: []string{"revision=v1.2.3", "flags=-count=1", "git.revision=v1.2.3"},
+			want: v1.Params{
+				{Name: "revision", Value: *v1.NewStructuredValues("v1.2.3")},
+				{Name: "flags", Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"-count=1"}}},
+				{Name: "git", Value: *v1.NewObject(map[string]string{"url": "https://example.com/repo", "revision": "v1.2.3"})},
+			},
+		},
+		{
+			name:      "new param",
+			overrides: []string{"debug=true"},
+			want:      append(params, v1.Param{Name: "debug", Value: *v1.NewStructuredValues("true")}),
+		},
+		{
+			name:      "invalid",
+			overrides: []string{"revision"},
+			wantErr:   true,
+		},
+		{
+			name:      "whole object",
+			overrides: []string{"git=main"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := common.OverrideParams(params, tt.overrides)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+	// The stored params must be left untouched.
+	if params[2].Value.ObjectVal["revision"] != "main" {
+		t.Errorf("OverrideParams modified its input: %v", params)
+	}
+}
+
+func TestRerunObjectMeta(t *testing.T) {
+	m := metav1.ObjectMeta{
+		Name:            "build-abcde",
+		Namespace:       "default",
+		UID:             "1234",
+		ResourceVersion: "42",
+		Generation:      2,
+		Labels: map[string]string{
+			"app":                    "web",
+			"tekton.dev/pipelineRun": "ci-xyz",
+			"tekton.dev/task":        "build",
+		},
+		Annotations: map[string]string{
+			"results.tekton.dev/record":            "default/results/1/records/2",
+			"results.tekton.dev/result":            "default/results/1",
+			"results.tekton.dev/resultAnnotations": `{"repo":"web"}`,
+			"results.tekton.dev/stepLogs":          "clone,build",
+			"results.tekton.dev/provenance":        "abc123",
+			"results.tekton.dev/rerunOf":           "default/results/0/records/1",
+			"chains.tekton.dev/signed":             "true",
+			"chains.tekton.dev/transparency":       "https://rekor.example.com/123",
+			"owner":                                "team-a",
+		},
+		OwnerReferences: []metav1.OwnerReference{{Name: "ci-xyz"}},
+		Finalizers:      []string{"results.tekton.dev/taskrun"},
+	}
+
+	got := common.RerunObjectMeta(m, "default/results/1/records/2", true)
+	want := metav1.ObjectMeta{
+		GenerateName: "build-abcde-",
+		Namespace:    "default",
+		Labels: map[string]string{
+			"app":             "web",
+			"tekton.dev/task": "build",
+		},
+		Annotations: map[string]string{
+			"results.tekton.dev/resultAnnotations": `{"repo":"web"}`,
+			"owner":                                "team-a",
+			common.RerunOfAnnotation:               "default/results/1/records/2",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	m.GenerateName = "build-"
+	if got := common.RerunObjectMeta(m, "", false); got.GenerateName != "build-" || got.Labels["tekton.dev/pipelineRun"] != "ci-xyz" {
+		t.Errorf("unexpected metadata %v", got)
+	}
+}
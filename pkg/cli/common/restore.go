@@ -0,0 +1,40 @@
+package common //nolint:revive // Package provides shared CLI utilities
+
+import (
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreManagedBy is the spec.managedBy value of restored runs, which keeps
+// the Tekton controller from running them again.
+const RestoreManagedBy = "results.tekton.dev/restore"
+
+// restoreAnnotations are the annotations dropped from restored runs.
+var restoreAnnotations = []string{
+	annotation.Stored,
+	annotation.ChildReadyForDeletion,
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// RestoreObjectMeta returns the metadata of a run restored into namespace
+// from the stored run with metadata m. The run keeps its name, labels and
+// annotations, and is labelled as restored so that the watcher ignores it.
+func RestoreObjectMeta(m metav1.ObjectMeta, namespace string) metav1.ObjectMeta {
+	out := metav1.ObjectMeta{
+		Name:        m.Name,
+		Namespace:   namespace,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+	for k, v := range m.Labels {
+		out.Labels[k] = v
+	}
+	out.Labels[annotation.Restored] = "true"
+	for k, v := range m.Annotations {
+		out.Annotations[k] = v
+	}
+	for _, k := range restoreAnnotations {
+		delete(out.Annotations, k)
+	}
+	return out
+}
@@ -0,0 +1,13 @@
+package options
+
+import (
+	"github.com/tektoncd/results/pkg/cli/client"
+)
+
+// DiffOptions holds the options for comparing two stored runs
+type DiffOptions struct {
+	Client   *client.RESTClient
+	BaseUID  string
+	OtherUID string
+	Output   string
+}
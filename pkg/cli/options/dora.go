@@ -0,0 +1,54 @@
+package options
+
+import (
+	"time"
+
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/common"
+)
+
+var _ common.FilterOptions = (*DORAOptions)(nil)
+
+// DORAOptions holds the options for computing delivery metrics
+type DORAOptions struct {
+	Client               *client.RESTClient
+	AllNamespaces        bool
+	Label                string
+	Filter               string
+	CommitTimeAnnotation string
+	CommitTimeParam      string
+	GroupBy              string
+	From                 time.Time
+	To                   time.Time
+	Output               string
+}
+
+// GetLabel implements FilterOptions interface
+func (o *DORAOptions) GetLabel() string {
+	return o.Label
+}
+
+// GetResourceName implements FilterOptions interface
+func (o *DORAOptions) GetResourceName() string {
+	return "" // Deployments are identified by label or filter
+}
+
+// GetPipelineRun implements FilterOptions interface
+func (o *DORAOptions) GetPipelineRun() string {
+	return ""
+}
+
+// GetResourceType implements FilterOptions interface
+func (o *DORAOptions) GetResourceType() string {
+	return "" // The server only analyzes PipelineRuns
+}
+
+// GetUID implements FilterOptions interface
+func (o *DORAOptions) GetUID() string {
+	return ""
+}
+
+// SelectsExactMatch implements FilterOptions interface
+func (o *DORAOptions) SelectsExactMatch() bool {
+	return true
+}
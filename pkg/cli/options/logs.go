@@ -13,6 +13,8 @@ type LogsOptions struct {
 	UID          string
 	ResourceType string
 	ResourceName string
+	// Step selects the log of a single step of a TaskRun.
+	Step string
 }
 
 // GetLabel implements FilterOptions interface
@@ -0,0 +1,18 @@
+package options
+
+import (
+	"github.com/tektoncd/results/pkg/cli/common"
+)
+
+var _ common.FilterOptions = (*RerunOptions)(nil)
+
+// RerunOptions contains options for re-running a stored run.
+type RerunOptions struct {
+	DescribeOptions
+	// Params override the params of the run, as name=value.
+	Params         []string
+	ServiceAccount string
+	// DryRun prints the run instead of creating it.
+	DryRun bool
+	Output string
+}
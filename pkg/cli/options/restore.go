@@ -0,0 +1,15 @@
+package options
+
+import (
+	"github.com/tektoncd/results/pkg/cli/common"
+)
+
+var _ common.FilterOptions = (*RestoreOptions)(nil)
+
+// RestoreOptions contains options for restoring a stored run into the cluster.
+type RestoreOptions struct {
+	DescribeOptions
+	// TargetNamespace is the namespace to restore the run into, defaulting
+	// to the namespace of the stored run.
+	TargetNamespace string
+}
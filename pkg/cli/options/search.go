@@ -0,0 +1,50 @@
+package options
+
+import (
+	"github.com/tektoncd/results/pkg/cli/client"
+	"github.com/tektoncd/results/pkg/cli/common"
+)
+
+var _ common.FilterOptions = (*SearchOptions)(nil)
+
+// SearchOptions holds the options for searching runs by result
+type SearchOptions struct {
+	Client        *client.RESTClient
+	AllNamespaces bool
+	Label         string
+	Filter        string
+	ResultName    string
+	ResultValue   string
+	Limit         int32
+	Output        string
+}
+
+// GetLabel implements FilterOptions interface
+func (o *SearchOptions) GetLabel() string {
+	return o.Label
+}
+
+// GetResourceName implements FilterOptions interface
+func (o *SearchOptions) GetResourceName() string {
+	return ""
+}
+
+// GetPipelineRun implements FilterOptions interface
+func (o *SearchOptions) GetPipelineRun() string {
+	return ""
+}
+
+// GetResourceType implements FilterOptions interface
+func (o *SearchOptions) GetResourceType() string {
+	return "" // The server only indexes the results of TaskRuns and PipelineRuns
+}
+
+// GetUID implements FilterOptions interface
+func (o *SearchOptions) GetUID() string {
+	return ""
+}
+
+// SelectsExactMatch implements FilterOptions interface
+func (o *SearchOptions) SelectsExactMatch() bool {
+	return true
+}
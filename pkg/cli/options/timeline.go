@@ -0,0 +1,14 @@
+package options
+
+import (
+	"github.com/tektoncd/results/pkg/cli/common"
+)
+
+var _ common.FilterOptions = (*TimelineOptions)(nil)
+
+// TimelineOptions contains options for showing the timeline of a stored PipelineRun.
+type TimelineOptions struct {
+	DescribeOptions
+	// Output is the format of the timeline: a text chart if empty, json or trace.
+	Output string
+}
@@ -1,6 +1,7 @@
 package testutils
 
 import (
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/results/pkg/cli/client"
 	"github.com/tektoncd/results/pkg/cli/common"
 )
@@ -17,7 +18,8 @@ type Params struct {
 	skipTLSVerify  bool
 
 	// Simple client storage for testing
-	restClient *client.RESTClient
+	restClient   *client.RESTClient
+	tektonClient versioned.Interface
 }
 
 // Ensure Params implements the interface
@@ -89,3 +91,13 @@ func (p *Params) SetRESTClient(client *client.RESTClient) {
 func (p *Params) RESTClient() *client.RESTClient {
 	return p.restClient
 }
+
+// SetTektonClient injects a Tekton clientset for testing purposes
+func (p *Params) SetTektonClient(client versioned.Interface) {
+	p.tektonClient = client
+}
+
+// TektonClient returns the injected Tekton clientset for testing
+func (p *Params) TektonClient() versioned.Interface {
+	return p.tektonClient
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/results/pkg/api/server/test"
 	server "github.com/tektoncd/results/pkg/api/server/v1alpha2"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -46,7 +47,7 @@ func NewResultsClient(t *testing.T, config *config.Config, opts ...server.Option
 	if err != nil {
 		t.Fatalf("Failed to create fake server: %v", err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	pb.RegisterResultsServer(s, srv)
 	pb.RegisterLogsServer(s, srv)
 	lis, err := net.Listen("tcp", port)
@@ -58,7 +59,8 @@ func NewResultsClient(t *testing.T, config *config.Config, opts ...server.Option
 			log.Printf("error starting result server: %v\n", err)
 		}
 	}()
-	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 	if err != nil {
 		t.Fatalf("did not connect: %v", err)
 	}
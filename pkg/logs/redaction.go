@@ -0,0 +1,37 @@
+package logs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// MinRedactionLength is the length under which values are not redacted, as
+// they would mask too many unrelated words.
+const MinRedactionLength = 6
+
+// RedactionHint returns the hint sent to the API server for a secret value
+// to be masked in logs, so that the value itself is never sent.
+func RedactionHint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// RedactionHints returns the hints of a secret value: the hint of the whole
+// value, and of each of its words if it has several, since values are
+// matched one word at a time.
+func RedactionHints(value string) []string {
+	var hints []string
+	value = strings.TrimSpace(value)
+	if len(value) >= MinRedactionLength {
+		hints = append(hints, RedactionHint(value))
+	}
+	if fields := strings.Fields(value); len(fields) > 1 {
+		for _, f := range fields {
+			if len(f) >= MinRedactionLength {
+				hints = append(hints, RedactionHint(f))
+			}
+		}
+	}
+	return hints
+}
@@ -0,0 +1,22 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRedactionHints(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  []string
+	}{
+		{value: "short"},
+		{value: " s3cr3t-value\n", want: []string{RedactionHint("s3cr3t-value")}},
+		{value: "user password1", want: []string{RedactionHint("user password1"), RedactionHint("password1")}},
+	} {
+		if diff := cmp.Diff(tc.want, RedactionHints(tc.value)); diff != "" {
+			t.Errorf("RedactionHints(%q): -want, +got: %s", tc.value, diff)
+		}
+	}
+}
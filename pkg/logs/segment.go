@@ -0,0 +1,204 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+// maxLineSize is the size past which a line without a newline is sent
+// anyway, cut after its last space.
+const maxLineSize = 1024 * 1024
+
+// UpdateLogClient is the client side of an UpdateLog call.
+type UpdateLogClient interface {
+	LogSender
+	CloseAndRecv() (*pb.LogSummary, error)
+}
+
+// SegmentWriter is an io.Writer sending log data to the API server while it is
+// produced. Every interval, the UpdateLog call in progress is closed, so that
+// the data it carried becomes readable, and the next data is appended to the
+// log by a new call. Data is only cut at newlines, so that the API server gets
+// whole lines to redact, and up to one chunk, or one line, is held in memory.
+type SegmentWriter struct {
+	open     func() (UpdateLogClient, error)
+	name     string
+	size     int
+	interval time.Duration
+	stop     chan struct{}
+
+	mu     sync.Mutex
+	skip   int64
+	hints  func() []string
+	client UpdateLogClient
+	buffer bytes.Buffer
+	err    error
+}
+
+// NewSegmentWriter returns a SegmentWriter sending log chunks of about size
+// bytes to the named log, through UpdateLog calls created by open. If interval
+// is zero, a single call is used, closed by Close.
+func NewSegmentWriter(open func() (UpdateLogClient, error), name string, size int, interval time.Duration) *SegmentWriter {
+	if size < 1 {
+		size = DefaultBufferSize
+	}
+	w := &SegmentWriter{
+		open:     open,
+		name:     name,
+		size:     size,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	if interval > 0 {
+		go w.run()
+	}
+	return w
+}
+
+// Skip discards the first n bytes written, which were already sent.
+func (w *SegmentWriter) Skip(n int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.skip = n
+}
+
+// SetRedactionHints sets the hints of secret values to be masked by the API
+// server, as returned by RedactionHints. They are sent with the first message
+// of each UpdateLog call.
+func (w *SegmentWriter) SetRedactionHints(hints []string) {
+	w.SetRedactionHintsFunc(func() []string { return hints })
+}
+
+// SetRedactionHintsFunc sets the function returning the hints of secret
+// values to be masked by the API server. It is called as each UpdateLog call
+// is opened, so that the hints cover the secrets of Pods created while the log
+// is being written.
+func (w *SegmentWriter) SetRedactionHintsFunc(hints func() []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.hints = hints
+}
+
+// Write sends the whole lines of p in chunks, buffering the remainder until a
+// chunk is full or the segment ends.
+func (w *SegmentWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	n := len(p)
+	if w.skip >= int64(n) {
+		w.skip -= int64(n)
+		return n, nil
+	}
+	p = p[w.skip:]
+	w.skip = 0
+
+	w.buffer.Write(p)
+	for w.buffer.Len() >= w.size {
+		end := w.chunkEnd()
+		if end == 0 {
+			break
+		}
+		if err := w.send(w.buffer.Next(end), true); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// chunkEnd returns the size of the next chunk of the buffer: its whole lines
+// up to size bytes, or its first line if longer. It returns 0 if the first
+// line is incomplete and shorter than maxLineSize.
+func (w *SegmentWriter) chunkEnd() int {
+	b := w.buffer.Bytes()
+	if end := bytes.LastIndexByte(b[:min(len(b), w.size)], '\n') + 1; end > 0 {
+		return end
+	}
+	if end := bytes.IndexByte(b, '\n') + 1; end > 0 {
+		return end
+	}
+	if len(b) < maxLineSize {
+		return 0
+	}
+	if end := bytes.LastIndexByte(b, ' ') + 1; end > 0 {
+		return end
+	}
+	return len(b)
+}
+
+// Close sends the remaining data and ends the log.
+func (w *SegmentWriter) Close() error {
+	if w.interval > 0 {
+		close(w.stop)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	return w.endSegment(false)
+}
+
+func (w *SegmentWriter) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+		w.mu.Lock()
+		if w.err == nil && (w.client != nil || w.buffer.Len() > 0) {
+			w.endSegment(true) //nolint:errcheck
+		}
+		w.mu.Unlock()
+	}
+}
+
+// endSegment sends the buffered data and closes the UpdateLog call. The last
+// message tells the API server whether more data is to be appended. If it is,
+// a trailing incomplete line is kept for the next segment, so that the API
+// server gets whole lines to redact.
+func (w *SegmentWriter) endSegment(partial bool) error {
+	n := w.buffer.Len()
+	if partial {
+		n = bytes.LastIndexByte(w.buffer.Bytes(), '\n') + 1
+	}
+	if err := w.send(w.buffer.Next(n), partial); err != nil {
+		return err
+	}
+	_, err := w.client.CloseAndRecv()
+	w.client = nil
+	// The API server ends successful calls with an io.EOF error.
+	if err != nil && !strings.Contains(err.Error(), "EOF") {
+		w.err = err
+	}
+	return w.err
+}
+
+func (w *SegmentWriter) send(p []byte, partial bool) error {
+	var hints []string
+	if w.client == nil {
+		if w.client, w.err = w.open(); w.err != nil {
+			return w.err
+		}
+		if w.hints != nil {
+			hints = w.hints()
+		}
+	}
+	w.err = w.client.Send(&pb.Log{
+		Name:           w.name,
+		Data:           p,
+		Partial:        partial,
+		RedactionHints: hints,
+	})
+	return w.err
+}
@@ -0,0 +1,258 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+)
+
+// segment records the messages of a single UpdateLog call.
+type segment struct {
+	data    []string
+	hints   [][]string
+	partial bool
+	closed  bool
+}
+
+type mockUpdateLogClient struct {
+	mu       *sync.Mutex
+	segments *[]*segment
+}
+
+func (m *mockUpdateLogClient) Send(log *pb.Log) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := (*m.segments)[len(*m.segments)-1]
+	s.data = append(s.data, string(log.GetData()))
+	s.hints = append(s.hints, log.GetRedactionHints())
+	s.partial = log.GetPartial()
+	return nil
+}
+
+func (m *mockUpdateLogClient) CloseAndRecv() (*pb.LogSummary, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	(*m.segments)[len(*m.segments)-1].closed = true
+	return nil, io.EOF
+}
+
+func newMockOpener() (func() (UpdateLogClient, error), func() []segment) {
+	mu := &sync.Mutex{}
+	segments := &[]*segment{}
+	open := func() (UpdateLogClient, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*segments = append(*segments, &segment{})
+		return &mockUpdateLogClient{mu: mu, segments: segments}, nil
+	}
+	get := func() []segment {
+		mu.Lock()
+		defer mu.Unlock()
+		var out []segment
+		for _, s := range *segments {
+			out = append(out, *s)
+		}
+		return out
+	}
+	return open, get
+}
+
+func TestSegmentWriter(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", 4, 0)
+	for _, s := range []string{"ab\n", "cdefg\nh", "ij\nk"} {
+		if n, err := w.Write([]byte(s)); err != nil || n != len(s) {
+			t.Fatalf("Write(%q): (%d, %v)", s, n, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Chunks are cut at newlines, and lines longer than a chunk are sent
+	// whole.
+	want := []segment{{data: []string{"ab\n", "cdefg\n", "hij\n", "k"}, hints: make([][]string, 4), closed: true}}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSegmentWriter_skip(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", 1024, 0)
+	w.Skip(5)
+	for _, s := range []string{"abc", "defgh", "ij"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write(%q): %v", s, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := []segment{{data: []string{"fghij"}, hints: make([][]string, 1), closed: true}}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSegmentWriter_interval(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", 1024, 10*time.Millisecond)
+	if _, err := w.Write([]byte("step one\n")); err != nil {
+		t.Fatal(err)
+	}
+	// Wait for the segment to be sent.
+	for start := time.Now(); len(segments()) == 0 || !segments()[0].closed; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("timed out waiting for the first segment")
+		}
+	}
+	if _, err := w.Write([]byte("step two\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := segments()
+	if len(got) < 2 {
+		t.Fatalf("expected at least 2 segments, got %+v", got)
+	}
+	var data strings.Builder
+	for i, s := range got {
+		if !s.closed {
+			t.Errorf("segment %d not closed", i)
+		}
+		if last := i == len(got)-1; s.partial == last {
+			t.Errorf("segment %d: partial is %t", i, s.partial)
+		}
+		data.WriteString(strings.Join(s.data, ""))
+	}
+	if want := "step one\nstep two\n"; data.String() != want {
+		t.Errorf("want %q, got %q", want, data.String())
+	}
+}
+
+func TestSegmentWriter_partialLine(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", 1024, 0)
+	w.SetRedactionHints([]string{"hint"})
+	if _, err := w.Write([]byte("line one\nline t")); err != nil {
+		t.Fatal(err)
+	}
+	w.mu.Lock()
+	err := w.endSegment(true)
+	w.mu.Unlock()
+	if err != nil {
+		t.Fatalf("endSegment: %v", err)
+	}
+	if _, err := w.Write([]byte("wo\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// The incomplete line is sent with the next segment, and hints with the
+	// first message of each segment.
+	want := []segment{
+		{data: []string{"line one\n"}, hints: [][]string{{"hint"}}, partial: true, closed: true},
+		{data: []string{"line two\n"}, hints: [][]string{{"hint"}}, closed: true},
+	}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSegmentWriter_hintsFunc(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", 1024, 0)
+	calls := 0
+	w.SetRedactionHintsFunc(func() []string {
+		calls++
+		return []string{fmt.Sprintf("hint-%d", calls)}
+	})
+	for _, s := range []string{"step one\n", "step two\n"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+		w.mu.Lock()
+		err := w.endSegment(true)
+		w.mu.Unlock()
+		if err != nil {
+			t.Fatalf("endSegment: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// The hints are refreshed as each segment is opened.
+	want := []segment{
+		{data: []string{"step one\n"}, hints: [][]string{{"hint-1"}}, partial: true, closed: true},
+		{data: []string{"step two\n"}, hints: [][]string{{"hint-2"}}, partial: true, closed: true},
+		{data: []string{""}, hints: [][]string{{"hint-3"}}, closed: true},
+	}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSegmentWriter_longLine(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", DefaultBufferSize, 0)
+	// The secret straddles the end of the first chunk, in a line longer than
+	// a chunk.
+	secret := "s3cr3t-value"
+	line := strings.Repeat("x", DefaultBufferSize-len(secret)/2) + " " + secret + "\n"
+	for _, s := range []string{line[:DefaultBufferSize], line[DefaultBufferSize:] + "next"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.mu.Lock()
+	err := w.endSegment(true)
+	w.mu.Unlock()
+	if err != nil {
+		t.Fatalf("endSegment: %v", err)
+	}
+	if _, err := w.Write([]byte(" line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := []segment{
+		{data: []string{line, ""}, hints: make([][]string, 2), partial: true, closed: true},
+		{data: []string{"next line\n"}, hints: make([][]string, 1), closed: true},
+	}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSegmentWriter_maxLineSize(t *testing.T) {
+	open, segments := newMockOpener()
+	w := NewSegmentWriter(open, "foo/results/bar/logs/baz", DefaultBufferSize, 0)
+	// Lines without a newline are cut after their last space once too long.
+	words := strings.Repeat("x", maxLineSize-4) + " abc def"
+	if _, err := w.Write([]byte(words)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := []segment{{data: []string{words[:maxLineSize+1], "def"}, hints: make([][]string, 2), closed: true}}
+	if diff := cmp.Diff(want, segments(), cmp.AllowUnexported(segment{})); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
@@ -103,7 +103,12 @@ func (w *BufferedLog) Write(p []byte) (n int, err error) {
 // Flush sends all remaining bytes in the buffer to consumer.
 func (w *BufferedLog) Flush() (int, error) {
 	if len(w.buffer.Bytes()) > 0 {
-		return w.sendBytes(w.buffer.Bytes())
+		n, err := w.sendBytes(w.buffer.Bytes())
+		if err != nil {
+			return n, err
+		}
+		w.buffer.Reset()
+		return n, nil
 	}
 	return 0, nil
 }
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/provenance"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/runresult"
+)
+
+func init() {
+	// The results of runs and the digests of provenance are indexed when
+	// their Records are written, so only the Records stored before the
+	// indexes existed are backfilled.
+	for _, kind := range []string{"PipelineRun", "TaskRun"} {
+		for _, version := range []string{"v1beta1", "v1"} {
+			Register(Migration{
+				Group:    "tekton.dev",
+				Kind:     kind,
+				From:     version,
+				Backfill: "run-results",
+				Index:    runresult.Index,
+			})
+		}
+	}
+	Register(Migration{
+		Group:    "results.tekton.dev",
+		Kind:     "Provenance",
+		From:     "v1alpha3",
+		Backfill: "provenance-digests",
+		Index:    provenance.Index,
+	})
+}
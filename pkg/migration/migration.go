@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration migrates the data of Records stored with an older version
+// of their type, such as tekton.dev/v1beta1 TaskRuns, to a newer version, and
+// backfills the indexes of the data of Records stored before they existed.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"gorm.io/gorm"
+)
+
+// States of migrations, as checkpointed in the database.
+const (
+	StateRunning   = "Running"
+	StateCompleted = "Completed"
+	StateFailed    = "Failed"
+)
+
+// Func returns the data of a Record in the version a migration migrates to,
+// given its data in the version the migration migrates from.
+type Func func(ctx context.Context, data []byte) ([]byte, error)
+
+// IndexFunc indexes the data of a Record in other tables, within the
+// transaction tx.
+type IndexFunc func(tx *gorm.DB, r *db.Record) error
+
+// Migration migrates the data of the Records of a kind from one version to
+// another. A migration only applies to Records of its FromType, and sets their
+// type to its ToType, so each Record is migrated at most once however often
+// the migration runs.
+//
+// A backfill migration instead indexes the Records of its FromType, leaving
+// their data and type unchanged. Records stored afterwards are indexed as they
+// are written, so a backfill isn't run again once it completed.
+type Migration struct {
+	// Group and Kind of the migrated Records, e.g. tekton.dev and TaskRun.
+	Group string
+	Kind  string
+	// From and To are the versions the migration migrates from and to,
+	// e.g. v1beta1 and v1.
+	From string
+	To   string
+	// Migrate migrates the data of a Record.
+	Migrate Func
+
+	// Backfill names the index a backfill migration fills, e.g.
+	// run-results. Backfill migrations have no To version nor Migrate
+	// function.
+	Backfill string
+	// Index, if set, indexes each migrated Record within the transaction
+	// which saves it.
+	Index IndexFunc
+}
+
+// Name returns the name identifying the migration, e.g.
+// taskrun.tekton.dev/v1beta1-v1, or run-results.taskrun.tekton.dev/v1 for
+// backfills.
+func (m Migration) Name() string {
+	if m.IsBackfill() {
+		return fmt.Sprintf("%s.%s.%s/%s", m.Backfill, strings.ToLower(m.Kind), m.Group, m.From)
+	}
+	return fmt.Sprintf("%s.%s/%s-%s", strings.ToLower(m.Kind), m.Group, m.From, m.To)
+}
+
+// IsBackfill tells whether the migration backfills an index rather than
+// migrating the data of Records.
+func (m Migration) IsBackfill() bool {
+	return m.Backfill != ""
+}
+
+// FromType returns the type of the Records the migration applies to.
+func (m Migration) FromType() string {
+	return fmt.Sprintf("%s/%s.%s", m.Group, m.From, m.Kind)
+}
+
+// ToType returns the type of the migrated Records, which is their FromType for
+// backfills.
+func (m Migration) ToType() string {
+	if m.IsBackfill() {
+		return m.FromType()
+	}
+	return fmt.Sprintf("%s/%s.%s", m.Group, m.To, m.Kind)
+}
+
+var registry = map[string]Migration{}
+
+// Register registers a migration, so that it is run by the API server. It is
+// meant to be called from init functions, and panics if a migration with the
+// same name is already registered.
+func Register(m Migration) {
+	name := m.Name()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("migration %s registered twice", name))
+	}
+	registry[name] = m
+}
+
+// Registered returns the registered migrations, ordered by name.
+func Registered() []Migration {
+	out := make([]Migration, 0, len(registry))
+	for _, m := range registry {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name() < out[j].Name()
+	})
+	return out
+}
+
+// Select returns the registered migrations with the given names, ordered by
+// name, or all of them if no name is given.
+func Select(names []string) ([]Migration, error) {
+	selected := map[string]bool{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown migration %q", name)
+		}
+		selected[name] = true
+	}
+	all := Registered()
+	if len(selected) == 0 {
+		return all, nil
+	}
+	out := make([]Migration, 0, len(selected))
+	for _, m := range all {
+		if selected[m.Name()] {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMigrationTypes(t *testing.T) {
+	m := Migration{Group: "tekton.dev", Kind: "TaskRun", From: "v1beta1", To: "v1"}
+	got := []string{m.Name(), m.FromType(), m.ToType()}
+	want := []string{"taskrun.tekton.dev/v1beta1-v1", "tekton.dev/v1beta1.TaskRun", "tekton.dev/v1.TaskRun"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+
+	m = Migration{Group: "tekton.dev", Kind: "TaskRun", From: "v1", Backfill: "run-results"}
+	got = []string{m.Name(), m.FromType(), m.ToType()}
+	want = []string{"run-results.taskrun.tekton.dev/v1", "tekton.dev/v1.TaskRun", "tekton.dev/v1.TaskRun"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func names(migrations []Migration) []string {
+	out := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		out = append(out, m.Name())
+	}
+	return out
+}
+
+func TestSelect(t *testing.T) {
+	for _, tc := range []struct {
+		names []string
+		want  []string
+	}{
+		{
+			names: nil,
+			want: []string{
+				"pipelinerun.tekton.dev/v1beta1-v1",
+				"provenance-digests.provenance.results.tekton.dev/v1alpha3",
+				"run-results.pipelinerun.tekton.dev/v1",
+				"run-results.pipelinerun.tekton.dev/v1beta1",
+				"run-results.taskrun.tekton.dev/v1",
+				"run-results.taskrun.tekton.dev/v1beta1",
+				"taskrun.tekton.dev/v1beta1-v1",
+			},
+		},
+		{
+			names: []string{""},
+			want: []string{
+				"pipelinerun.tekton.dev/v1beta1-v1",
+				"provenance-digests.provenance.results.tekton.dev/v1alpha3",
+				"run-results.pipelinerun.tekton.dev/v1",
+				"run-results.pipelinerun.tekton.dev/v1beta1",
+				"run-results.taskrun.tekton.dev/v1",
+				"run-results.taskrun.tekton.dev/v1beta1",
+				"taskrun.tekton.dev/v1beta1-v1",
+			},
+		},
+		{
+			names: []string{" taskrun.tekton.dev/v1beta1-v1"},
+			want:  []string{"taskrun.tekton.dev/v1beta1-v1"},
+		},
+	} {
+		got, err := Select(tc.names)
+		if err != nil {
+			t.Fatalf("Select(%q): %v", tc.names, err)
+		}
+		if diff := cmp.Diff(tc.want, names(got)); diff != "" {
+			t.Errorf("Select(%q): -want, +got: %s", tc.names, diff)
+		}
+	}
+
+	if _, err := Select([]string{"customrun.tekton.dev/v1alpha1-v1beta1"}); err == nil {
+		t.Error("Select: want error for an unknown migration")
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultBatchSize  = 50
+	defaultRetries    = 5
+	defaultRetryDelay = time.Second
+)
+
+// Runner runs migrations, one after the other, in batches of Records. The
+// progress of each migration is checkpointed in the database after each
+// batch, so that a migration interrupted by a restart of the API server
+// resumes where it stopped.
+//
+// Records a migration fails to migrate are counted and skipped, and retried
+// the next time the migration runs. Database errors are retried with an
+// exponential backoff, and fail the migration once the retries are
+// exhausted.
+type Runner struct {
+	logger     *zap.SugaredLogger
+	db         *gorm.DB
+	migrations []Migration
+	batchSize  int
+	limit      rate.Limit
+	dryRun     bool
+	retries    int
+	retryDelay time.Duration
+}
+
+// Option customizes a Runner.
+type Option func(*Runner)
+
+// WithBatchSize sets the number of Records migrated in each transaction.
+func WithBatchSize(n int) Option {
+	return func(r *Runner) {
+		if n > 0 {
+			r.batchSize = n
+		}
+	}
+}
+
+// WithRateLimit limits the number of Records migrated per second, to bound
+// the load migrations put on the database. A zero rate means no limit.
+func WithRateLimit(recordsPerSecond float64) Option {
+	return func(r *Runner) {
+		if recordsPerSecond > 0 {
+			r.limit = rate.Limit(recordsPerSecond)
+		}
+	}
+}
+
+// WithDryRun makes the Runner go through the Records to migrate and count
+// those it would migrate or fail to migrate, without updating them. Dry runs
+// are checkpointed separately from actual runs.
+func WithDryRun(dryRun bool) Option {
+	return func(r *Runner) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithRetries sets how many times database errors are retried before failing
+// a migration, and the delay before the first retry, which doubles with each
+// retry.
+func WithRetries(n int, delay time.Duration) Option {
+	return func(r *Runner) {
+		r.retries = n
+		r.retryDelay = delay
+	}
+}
+
+// NewRunner returns a Runner for the given migrations.
+func NewRunner(logger *zap.SugaredLogger, gdb *gorm.DB, migrations []Migration, opts ...Option) *Runner {
+	r := &Runner{
+		logger:     logger,
+		db:         gdb,
+		migrations: migrations,
+		batchSize:  defaultBatchSize,
+		limit:      rate.Inf,
+		retries:    defaultRetries,
+		retryDelay: defaultRetryDelay,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Run runs the migrations until they complete or fail, or the context is
+// done. A failed migration doesn't keep the next ones from running. It
+// returns the errors of the failed migrations.
+func (r *Runner) Run(ctx context.Context) error {
+	// Batches are taken from the bucket at once, so it must hold a whole
+	// batch.
+	limiter := rate.NewLimiter(r.limit, r.batchSize)
+	var errs []error
+	for _, m := range r.migrations {
+		if err := r.run(ctx, m, limiter); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.logger.Errorw("Migration failed", "migration", m.Name(), "dryRun", r.dryRun, "error", err)
+			errs = append(errs, fmt.Errorf("migration %s: %w", m.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Runner) run(ctx context.Context, m Migration, limiter *rate.Limiter) error {
+	logger := r.logger.With("migration", m.Name(), "dryRun", r.dryRun)
+
+	checkpoint, err := r.start(ctx, m)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		logger.Infow("Backfill already completed")
+		return nil
+	}
+	logger.Infow("Migration started", "lastRecordID", checkpoint.LastRecordID)
+
+	for {
+		var records []db.Record
+		err := r.retry(ctx, func() error {
+			records = nil
+			return r.db.WithContext(ctx).
+				Where("type = ? AND id > ?", m.FromType(), checkpoint.LastRecordID).
+				Order("id").
+				Limit(r.batchSize).
+				Find(&records).Error
+		})
+		if err != nil {
+			return r.fail(ctx, checkpoint, err)
+		}
+
+		if len(records) == 0 {
+			now := time.Now()
+			checkpoint.State = StateCompleted
+			checkpoint.UpdatedTime = now
+			checkpoint.CompletedTime = &now
+			if err := r.retry(ctx, func() error { return save(r.db.WithContext(ctx), checkpoint) }); err != nil {
+				return err
+			}
+			logger.Infow("Migration completed", "migrated", checkpoint.MigratedCount, "failed", checkpoint.FailedCount)
+			return nil
+		}
+
+		if err := limiter.WaitN(ctx, len(records)); err != nil {
+			return err
+		}
+
+		migrated := make([]*db.Record, 0, len(records))
+		for i := range records {
+			if m.Migrate != nil {
+				data, err := m.Migrate(ctx, records[i].Data)
+				if err != nil {
+					checkpoint.FailedCount++
+					logger.Warnw("Failed to migrate Record", "parent", records[i].Parent, "result", records[i].ResultName, "record", records[i].Name, "id", records[i].ID, "error", err)
+					continue
+				}
+				records[i].Data = data
+			}
+			migrated = append(migrated, &records[i])
+		}
+		checkpoint.LastRecordID = records[len(records)-1].ID
+		checkpoint.MigratedCount += int64(len(migrated))
+		checkpoint.UpdatedTime = time.Now()
+
+		// Records and the checkpoint are saved in the same transaction,
+		// so that a batch is never migrated twice nor skipped.
+		err = r.retry(ctx, func() error {
+			return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if !r.dryRun {
+					for _, record := range migrated {
+						if !m.IsBackfill() {
+							// Another replica of the API server may
+							// have migrated the Record in the meantime.
+							q := tx.Model(record).
+								Where("type = ?", m.FromType()).
+								Updates(db.Record{Data: record.Data, Type: m.ToType()})
+							if q.Error != nil {
+								return q.Error
+							}
+							record.Type = m.ToType()
+						}
+						if m.Index != nil {
+							if err := m.Index(tx, record); err != nil {
+								return err
+							}
+						}
+					}
+				}
+				return save(tx, checkpoint)
+			})
+		})
+		if err != nil {
+			return r.fail(ctx, checkpoint, err)
+		}
+	}
+}
+
+// start returns the checkpoint to run the migration from. Interrupted runs
+// are resumed. Completed and failed migrations start over, to migrate the
+// Records stored since and retry those which failed, except completed
+// backfills, for which it returns no checkpoint. Dry runs always start over.
+func (r *Runner) start(ctx context.Context, m Migration) (*db.Migration, error) {
+	checkpoint := &db.Migration{}
+	err := r.retry(ctx, func() error {
+		return r.db.WithContext(ctx).
+			Where("name = ? AND dry_run = ?", m.Name(), r.dryRun).
+			Limit(1).
+			Find(checkpoint).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m.IsBackfill() && checkpoint.State == StateCompleted && !r.dryRun {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if checkpoint.State != StateRunning || r.dryRun {
+		checkpoint = &db.Migration{
+			Name:        m.Name(),
+			DryRun:      r.dryRun,
+			StartedTime: now,
+		}
+	}
+	checkpoint.State = StateRunning
+	checkpoint.Error = ""
+	checkpoint.UpdatedTime = now
+	if err := r.retry(ctx, func() error { return save(r.db.WithContext(ctx), checkpoint) }); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// fail records the error which stopped a migration in its checkpoint. Runs
+// stopped because the context is done are left running, so that they resume
+// on the next start.
+func (r *Runner) fail(ctx context.Context, checkpoint *db.Migration, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	checkpoint.State = StateFailed
+	checkpoint.Error = err.Error()
+	checkpoint.UpdatedTime = time.Now()
+	if saveErr := save(r.db.WithContext(ctx), checkpoint); saveErr != nil {
+		r.logger.Errorw("Failed to checkpoint failed migration", "migration", checkpoint.Name, "error", saveErr)
+	}
+	return err
+}
+
+// save upserts a checkpoint. gorm's Save can't be used, as it inserts models
+// whose primary key has a zero value, which DryRun is for actual runs.
+func save(tx *gorm.DB, checkpoint *db.Migration) error {
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(checkpoint).Error
+}
+
+// retry calls f until it succeeds, the retries are exhausted or the context
+// is done.
+func (r *Runner) retry(ctx context.Context, f func() error) error {
+	delay := r.retryDelay
+	for attempt := 0; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= r.retries || ctx.Err() != nil {
+			return err
+		}
+		r.logger.Warnw("Migration database operation failed, retrying", "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
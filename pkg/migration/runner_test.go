@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/results/pkg/api/server/db"
+	"github.com/tektoncd/results/pkg/api/server/test"
+	"go.uber.org/zap/zaptest"
+	"gorm.io/gorm"
+)
+
+// widgets migrates example.dev Widgets from v1 to v2 by upper-casing their
+// data, and fails to migrate those whose data is "bad".
+var widgets = Migration{
+	Group: "example.dev",
+	Kind:  "Widget",
+	From:  "v1",
+	To:    "v2",
+	Migrate: func(_ context.Context, data []byte) ([]byte, error) {
+		if string(data) == `"bad"` {
+			return nil, errors.New("bad widget")
+		}
+		return bytes.ToUpper(data), nil
+	},
+}
+
+func setup(t *testing.T, records map[string]string) *gorm.DB {
+	t.Helper()
+	gdb := test.NewDB(t)
+	if err := gdb.AutoMigrate(&db.Result{}, &db.Record{}, &db.RunResult{}, &db.Migration{}); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+	if err := gdb.Create(&db.Result{Parent: "foo", ID: "r", Name: "r"}).Error; err != nil {
+		t.Fatalf("failed to create Result: %v", err)
+	}
+	for id, data := range records {
+		typ := widgets.FromType()
+		if data == "other" {
+			typ = "example.dev/v1.Gadget"
+		}
+		record := &db.Record{Parent: "foo", ResultID: "r", ResultName: "r", ID: id, Name: id, Type: typ, Data: []byte(`"` + data + `"`)}
+		if err := gdb.Create(record).Error; err != nil {
+			t.Fatalf("failed to create Record: %v", err)
+		}
+	}
+	return gdb
+}
+
+func recordsByID(t *testing.T, gdb *gorm.DB) map[string]string {
+	t.Helper()
+	var records []db.Record
+	if err := gdb.Find(&records).Error; err != nil {
+		t.Fatalf("failed to list Records: %v", err)
+	}
+	out := map[string]string{}
+	for _, r := range records {
+		out[r.ID] = r.Type + " " + string(r.Data)
+	}
+	return out
+}
+
+// widgetValues backfills the data of example.dev/v1 Widgets as run results.
+var widgetValues = Migration{
+	Group:    "example.dev",
+	Kind:     "Widget",
+	From:     "v1",
+	Backfill: "widget-values",
+	Index: func(tx *gorm.DB, r *db.Record) error {
+		return tx.Create(&db.RunResult{Parent: r.Parent, ResultID: r.ResultID, RecordID: r.ID, Name: "widget", Value: string(r.Data)}).Error
+	},
+}
+
+func indexed(t *testing.T, gdb *gorm.DB) map[string]string {
+	t.Helper()
+	var rows []db.RunResult
+	if err := gdb.Find(&rows).Error; err != nil {
+		t.Fatalf("failed to list run results: %v", err)
+	}
+	out := map[string]string{}
+	for _, r := range rows {
+		out[r.RecordID] = r.Value
+	}
+	return out
+}
+
+func checkpoint(t *testing.T, gdb *gorm.DB, dryRun bool) db.Migration {
+	t.Helper()
+	return checkpointOf(t, gdb, widgets, dryRun)
+}
+
+func checkpointOf(t *testing.T, gdb *gorm.DB, m Migration, dryRun bool) db.Migration {
+	t.Helper()
+	var out db.Migration
+	if err := gdb.Where("name = ? AND dry_run = ?", m.Name(), dryRun).First(&out).Error; err != nil {
+		t.Fatalf("failed to get checkpoint: %v", err)
+	}
+	return out
+}
+
+func TestRun(t *testing.T) {
+	gdb := setup(t, map[string]string{"a": "one", "b": "bad", "c": "two", "d": "other", "e": "three"})
+	runner := NewRunner(zaptest.NewLogger(t).Sugar(), gdb, []Migration{widgets}, WithBatchSize(2))
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := map[string]string{
+		"a": `example.dev/v2.Widget "ONE"`,
+		"b": `example.dev/v1.Widget "bad"`,
+		"c": `example.dev/v2.Widget "TWO"`,
+		"d": `example.dev/v1.Gadget "other"`,
+		"e": `example.dev/v2.Widget "THREE"`,
+	}
+	if diff := cmp.Diff(want, recordsByID(t, gdb)); diff != "" {
+		t.Errorf("Records -want, +got: %s", diff)
+	}
+	got := checkpoint(t, gdb, false)
+	if got.State != StateCompleted || got.MigratedCount != 3 || got.FailedCount != 1 || got.LastRecordID != "e" || got.CompletedTime == nil {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+
+	// The next run only retries the Record which failed.
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if diff := cmp.Diff(want, recordsByID(t, gdb)); diff != "" {
+		t.Errorf("Records -want, +got: %s", diff)
+	}
+	got = checkpoint(t, gdb, false)
+	if got.State != StateCompleted || got.MigratedCount != 0 || got.FailedCount != 1 {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+}
+
+func TestRun_dryRun(t *testing.T) {
+	gdb := setup(t, map[string]string{"a": "one", "b": "bad"})
+	runner := NewRunner(zaptest.NewLogger(t).Sugar(), gdb, []Migration{widgets}, WithDryRun(true))
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := map[string]string{
+		"a": `example.dev/v1.Widget "one"`,
+		"b": `example.dev/v1.Widget "bad"`,
+	}
+	if diff := cmp.Diff(want, recordsByID(t, gdb)); diff != "" {
+		t.Errorf("Records -want, +got: %s", diff)
+	}
+	got := checkpoint(t, gdb, true)
+	if got.State != StateCompleted || got.MigratedCount != 1 || got.FailedCount != 1 {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+	var count int64
+	gdb.Model(&db.Migration{}).Where("dry_run = ?", false).Count(&count)
+	if count != 0 {
+		t.Errorf("dry run checkpointed an actual run")
+	}
+}
+
+func TestRun_resume(t *testing.T) {
+	gdb := setup(t, map[string]string{"a": "one", "b": "two", "c": "three"})
+	if err := gdb.Create(&db.Migration{Name: widgets.Name(), State: StateRunning, LastRecordID: "b", MigratedCount: 2}).Error; err != nil {
+		t.Fatalf("failed to create checkpoint: %v", err)
+	}
+	runner := NewRunner(zaptest.NewLogger(t).Sugar(), gdb, []Migration{widgets})
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := map[string]string{
+		"a": `example.dev/v1.Widget "one"`,
+		"b": `example.dev/v1.Widget "two"`,
+		"c": `example.dev/v2.Widget "THREE"`,
+	}
+	if diff := cmp.Diff(want, recordsByID(t, gdb)); diff != "" {
+		t.Errorf("Records -want, +got: %s", diff)
+	}
+	if got := checkpoint(t, gdb, false); got.State != StateCompleted || got.MigratedCount != 3 {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+}
+
+func TestRun_databaseError(t *testing.T) {
+	gdb := setup(t, map[string]string{"a": "one"})
+	if err := gdb.Migrator().DropTable(&db.Record{}); err != nil {
+		t.Fatalf("DropTable: %v", err)
+	}
+	runner := NewRunner(zaptest.NewLogger(t).Sugar(), gdb, []Migration{widgets}, WithRetries(2, time.Millisecond))
+	if err := runner.Run(context.Background()); err == nil {
+		t.Fatal("Run: want error")
+	}
+	if got := checkpoint(t, gdb, false); got.State != StateFailed || got.Error == "" {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+}
+
+func TestRun_backfill(t *testing.T) {
+	gdb := setup(t, map[string]string{"a": "one", "b": "two", "d": "other"})
+	logger := zaptest.NewLogger(t).Sugar()
+
+	if err := NewRunner(logger, gdb, []Migration{widgetValues}, WithDryRun(true)).Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := indexed(t, gdb); len(got) != 0 {
+		t.Errorf("dry run indexed Records: %v", got)
+	}
+
+	runner := NewRunner(logger, gdb, []Migration{widgetValues}, WithBatchSize(1))
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := map[string]string{"a": `"one"`, "b": `"two"`}
+	if diff := cmp.Diff(want, indexed(t, gdb)); diff != "" {
+		t.Errorf("indexed -want, +got: %s", diff)
+	}
+	// The data and type of the Records are left unchanged.
+	if diff := cmp.Diff(map[string]string{
+		"a": `example.dev/v1.Widget "one"`,
+		"b": `example.dev/v1.Widget "two"`,
+		"d": `example.dev/v1.Gadget "other"`,
+	}, recordsByID(t, gdb)); diff != "" {
+		t.Errorf("Records -want, +got: %s", diff)
+	}
+	got := checkpointOf(t, gdb, widgetValues, false)
+	if got.State != StateCompleted || got.MigratedCount != 2 || got.FailedCount != 0 {
+		t.Errorf("unexpected checkpoint: %+v", got)
+	}
+
+	// Completed backfills don't run again, which would fail on the rows
+	// indexed already.
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if again := checkpointOf(t, gdb, widgetValues, false); !again.UpdatedTime.Equal(got.UpdatedTime) {
+		t.Errorf("completed backfill ran again: %+v", again)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(Migration{
+		Group:   "tekton.dev",
+		Kind:    "TaskRun",
+		From:    "v1beta1",
+		To:      "v1",
+		Migrate: taskRunV1Beta1ToV1,
+	})
+	Register(Migration{
+		Group:   "tekton.dev",
+		Kind:    "PipelineRun",
+		From:    "v1beta1",
+		To:      "v1",
+		Migrate: pipelineRunV1Beta1ToV1,
+	})
+}
+
+func taskRunV1Beta1ToV1(ctx context.Context, data []byte) ([]byte, error) {
+	var tr v1beta1.TaskRun //nolint:staticcheck
+	if err := json.Unmarshal(data, &tr); err != nil {
+		return nil, err
+	}
+
+	trV1 := &v1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "TaskRun"},
+	}
+	if err := tr.ConvertTo(ctx, trV1); err != nil {
+		return nil, err
+	}
+	return json.Marshal(trV1)
+}
+
+func pipelineRunV1Beta1ToV1(ctx context.Context, data []byte) ([]byte, error) {
+	var pr v1beta1.PipelineRun //nolint:staticcheck
+	if err := json.Unmarshal(data, &pr); err != nil {
+		return nil, err
+	}
+
+	prV1 := &v1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "PipelineRun"},
+	}
+	if err := pr.ConvertTo(ctx, prV1); err != nil {
+		return nil, err
+	}
+	return json.Marshal(prV1)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestTaskRunV1Beta1ToV1(t *testing.T) {
+	data := []byte(`{
+		"apiVersion": "tekton.dev/v1beta1",
+		"kind": "TaskRun",
+		"metadata": {"name": "build", "namespace": "default"},
+		"spec": {"taskRef": {"name": "build"}, "serviceAccountName": "builder"}
+	}`)
+	out, err := taskRunV1Beta1ToV1(context.Background(), data)
+	if err != nil {
+		t.Fatalf("taskRunV1Beta1ToV1: %v", err)
+	}
+	var tr v1.TaskRun
+	if err := json.Unmarshal(out, &tr); err != nil {
+		t.Fatalf("failed to decode migrated TaskRun: %v", err)
+	}
+	if tr.APIVersion != "tekton.dev/v1" || tr.Name != "build" || tr.Spec.TaskRef.Name != "build" || tr.Spec.ServiceAccountName != "builder" {
+		t.Errorf("unexpected migrated TaskRun: %s", out)
+	}
+
+	if _, err := taskRunV1Beta1ToV1(context.Background(), []byte("{")); err == nil {
+		t.Error("taskRunV1Beta1ToV1: want error for invalid data")
+	}
+}
+
+func TestPipelineRunV1Beta1ToV1(t *testing.T) {
+	data := []byte(`{
+		"apiVersion": "tekton.dev/v1beta1",
+		"kind": "PipelineRun",
+		"metadata": {"name": "ci", "namespace": "default"},
+		"spec": {"pipelineRef": {"name": "ci"}, "serviceAccountName": "builder"}
+	}`)
+	out, err := pipelineRunV1Beta1ToV1(context.Background(), data)
+	if err != nil {
+		t.Fatalf("pipelineRunV1Beta1ToV1: %v", err)
+	}
+	var pr v1.PipelineRun
+	if err := json.Unmarshal(out, &pr); err != nil {
+		t.Fatalf("failed to decode migrated PipelineRun: %v", err)
+	}
+	if pr.APIVersion != "tekton.dev/v1" || pr.Name != "ci" || pr.Spec.PipelineRef.Name != "ci" || pr.Spec.TaskRunTemplate.ServiceAccountName != "builder" {
+		t.Errorf("unexpected migrated PipelineRun: %s", out)
+	}
+}
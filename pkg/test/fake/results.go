@@ -109,3 +109,38 @@ func (c *ResultsClient) DeleteRecord(_ context.Context, _ *pb.DeleteRecordReques
 func (c *ResultsClient) GetRecordListSummary(_ context.Context, _ *pb.RecordListSummaryRequest, _ ...grpc.CallOption) (*pb.RecordListSummary, error) {
 	return nil, fmt.Errorf("unimplemented")
 }
+
+// ListFlakyTasks is unimplemented
+func (c *ResultsClient) ListFlakyTasks(_ context.Context, _ *pb.ListFlakyTasksRequest, _ ...grpc.CallOption) (*pb.ListFlakyTasksResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// GetDeliveryMetrics is unimplemented
+func (c *ResultsClient) GetDeliveryMetrics(_ context.Context, _ *pb.DeliveryMetricsRequest, _ ...grpc.CallOption) (*pb.DeliveryMetricsResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// ListMigrations is unimplemented
+func (c *ResultsClient) ListMigrations(_ context.Context, _ *pb.ListMigrationsRequest, _ ...grpc.CallOption) (*pb.ListMigrationsResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// SearchRunsByDigest is unimplemented
+func (c *ResultsClient) SearchRunsByDigest(_ context.Context, _ *pb.SearchRunsByDigestRequest, _ ...grpc.CallOption) (*pb.SearchRunsByDigestResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// SearchRunsByResult is unimplemented
+func (c *ResultsClient) SearchRunsByResult(_ context.Context, _ *pb.SearchRunsByResultRequest, _ ...grpc.CallOption) (*pb.SearchRunsByResultResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// DiffRuns is unimplemented
+func (c *ResultsClient) DiffRuns(_ context.Context, _ *pb.DiffRunsRequest, _ ...grpc.CallOption) (*pb.DiffRunsResponse, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
+
+// GetRunTimeline is unimplemented
+func (c *ResultsClient) GetRunTimeline(_ context.Context, _ *pb.GetRunTimelineRequest, _ ...grpc.CallOption) (*pb.RunTimeline, error) {
+	return nil, fmt.Errorf("unimplemented")
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing traces the operations of the API server and the watcher
+// with OpenTelemetry. Spans are sent to the global TracerProvider, which is a
+// no-op unless an exporter is configured.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const instrumentationName = "github.com/tektoncd/results"
+
+// Start starts a span named name as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, in span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a context which isn't canceled with ctx, but in which spans
+// are still started as children of the span in ctx. It is meant for work
+// carrying on in the background after ctx ends.
+func Detach(ctx context.Context) context.Context {
+	return DetachTo(context.Background(), ctx)
+}
+
+// DetachTo is like Detach, but the returned context is canceled with parent.
+func DetachTo(parent, ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(parent, trace.SpanContextFromContext(ctx))
+}
+
+// Object is a Kubernetes object.
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// ObjectAttributes returns the attributes identifying a Kubernetes object.
+func ObjectAttributes(o Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("k8s.object.kind", o.GetObjectKind().GroupVersionKind().Kind),
+		attribute.String("k8s.namespace.name", o.GetNamespace()),
+		attribute.String("k8s.object.name", o.GetName()),
+		attribute.String("k8s.object.uid", string(o.GetUID())),
+	}
+}
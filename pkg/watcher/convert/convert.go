@@ -56,6 +56,16 @@ func ToProto(in runtime.Object) (*rpb.Any, error) {
 
 // ToLogProto converts k8s object to log proto object.
 func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
+	return toLogProto(in, kind, name, nil)
+}
+
+// ToStepLogProto converts k8s object to the log proto object of one of its
+// steps.
+func ToStepLogProto(in metav1.Object, kind, name string, step *v1alpha3.LogStep) (*rpb.Any, error) {
+	return toLogProto(in, kind, name, step)
+}
+
+func toLogProto(in metav1.Object, kind, name string, step *v1alpha3.LogStep) (*rpb.Any, error) {
 	if in == nil {
 		return nil, nil
 	}
@@ -63,10 +73,14 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 	if err != nil {
 		return nil, err
 	}
+	logName := fmt.Sprintf("%s-log", in.GetName())
+	if step != nil {
+		logName = fmt.Sprintf("%s-%s-log", in.GetName(), step.Name)
+	}
 	log := &v1alpha3.Log{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: in.GetNamespace(),
-			Name:      fmt.Sprintf("%s-log", in.GetName()),
+			Name:      logName,
 			UID:       types.UID(uid),
 		},
 		Spec: v1alpha3.LogSpec{
@@ -76,6 +90,7 @@ func ToLogProto(in metav1.Object, kind, name string) (*rpb.Any, error) {
 				Name:      in.GetName(),
 				UID:       in.GetUID(),
 			},
+			Step: step,
 		},
 	}
 	log.Default()
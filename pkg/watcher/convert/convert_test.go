@@ -527,3 +527,37 @@ func newConditionAccessor(conds ...*apis.Condition) conditionAccessor {
 func (ca conditionAccessor) GetCondition(t apis.ConditionType) *apis.Condition {
 	return ca.m[t]
 }
+
+func TestToStepLogProto(t *testing.T) {
+	exitCode := int32(1)
+	step := &v1alpha3.LogStep{Name: "build", Container: "step-build", ExitCode: &exitCode}
+	log := &v1alpha3.Log{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: taskrun.GetNamespace(),
+			Name:      fmt.Sprintf("%s-build-log", taskrun.GetName()),
+			UID:       types.UID("baz"),
+		},
+		Spec: v1alpha3.LogSpec{
+			Resource: v1alpha3.Resource{
+				Kind:      "TaskRun",
+				Namespace: taskrun.GetNamespace(),
+				Name:      taskrun.GetName(),
+				UID:       taskrun.GetUID(),
+			},
+			Step: step,
+		},
+	}
+	log.Default()
+	want := &rpb.Any{
+		Type:  "results.tekton.dev/v1alpha3.Log",
+		Value: toJSON(log),
+	}
+
+	got, err := ToStepLogProto(taskrun, "TaskRun", "foo/results/bar/records/baz", step)
+	if err != nil {
+		t.Fatalf("ToStepLogProto: %v", err)
+	}
+	if d := cmp.Diff(want, got, protocmp.Transform()); d != "" {
+		t.Errorf("Diff(-want,+got): %s", d)
+	}
+}
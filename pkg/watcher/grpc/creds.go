@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/auth"
 	"google.golang.org/api/idtoken"
 	"google.golang.org/grpc/credentials"
 )
@@ -65,3 +66,23 @@ func (c *googleCreds) GetRequestMetadata(ctx context.Context, uri ...string) (ma
 func (googleCreds) RequireTransportSecurity() bool {
 	return true
 }
+
+// Cluster tells the API server the cluster of the Results and Records the
+// requests act on, for it to look them up and authorize the requests in that
+// cluster.
+func Cluster(name string) credentials.PerRPCCredentials {
+	return clusterCreds(name)
+}
+
+type clusterCreds string
+
+// GetRequestMetadata returns the cluster metadata of each request.
+func (c clusterCreds) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{auth.ClusterHeader: string(c)}, nil
+}
+
+// RequireTransportSecurity indicates whether the credentials requires
+// transport security. The cluster name isn't a secret.
+func (clusterCreds) RequireTransportSecurity() bool {
+	return false
+}
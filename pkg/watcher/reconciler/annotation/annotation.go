@@ -63,6 +63,32 @@ const (
 	// API server and therefore, ready to be garbage collected.
 	ChildReadyForDeletion = annotationPrefix + "childReadyForDeletion"
 
+	// LogSources is an annotation that custom task controllers can add to
+	// CustomRuns to declare, as a JSON list, the Pods or containers whose
+	// logs make up the log of the CustomRun, e.g.
+	// [{"selector":"app=deploy","container":"main"}].
+	LogSources = annotationPrefix + "logSources"
+
+	// ChildObjects is an annotation that custom task controllers can add to
+	// CustomRuns to declare, as a JSON list, the objects to store as Records
+	// under the Result of the CustomRun, e.g.
+	// [{"apiVersion":"example.dev/v1","kind":"Approval","name":"approval-1"}].
+	ChildObjects = annotationPrefix + "childObjects"
+
+	// StepLogs is an annotation listing, separated by commas, the steps of a
+	// TaskRun whose logs are stored separately already.
+	StepLogs = annotationPrefix + "stepLogs"
+
+	// Provenance is an annotation holding a hash of the Tekton Chains
+	// annotations of a run whose provenance is stored already, so that it is
+	// only stored again once they change.
+	Provenance = annotationPrefix + "provenance"
+
+	// Restored is a label set to "true" on runs restored into the cluster from
+	// their Records. The watcher ignores these runs, so that they are not
+	// stored again.
+	Restored = annotationPrefix + "restored"
+
 	// FieldManager identifier to be used with Server-Side Apply patches
 	fieldManager = "tekton-results-watcher"
 )
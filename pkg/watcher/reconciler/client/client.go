@@ -22,6 +22,7 @@ import (
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 )
 
 // ObjectClient is a shim around generated k8s clients to handle objects in
@@ -64,3 +65,20 @@ func (c *CustomRunClient) Patch(ctx context.Context, name string, pt types.Patch
 	_, err := c.CustomRunInterface.Patch(ctx, name, pt, data, opts, subresources...)
 	return err
 }
+
+// DynamicClient implements the dynamic ObjectClient for objects of arbitrary
+// kinds.
+type DynamicClient struct {
+	dynamic.ResourceInterface
+}
+
+// Delete deletes the Kubernetes resource.
+func (c *DynamicClient) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.ResourceInterface.Delete(ctx, name, opts)
+}
+
+// Patch patches the Kubernetes resource.
+func (c *DynamicClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) error {
+	_, err := c.ResourceInterface.Patch(ctx, name, pt, data, opts, subresources...)
+	return err
+}
@@ -69,6 +69,24 @@ type Config struct {
 	// Collect logs with timestamps
 	LogsTimestamps bool
 
+	// LogsFollow enables streaming the logs of Runs while they are still
+	// executing, rather than once they are complete.
+	LogsFollow bool
+
+	// LogsFlushInterval is how often the logs of executing Runs are made
+	// readable in the API server when LogsFollow is enabled.
+	LogsFlushInterval time.Duration
+
+	// LogsPerStep enables storing the log of each step of TaskRuns
+	// separately once the step terminates, in addition to the log of the
+	// whole TaskRun.
+	LogsPerStep bool
+
+	// LogsRedactSecrets enables masking the values of the Secrets used by
+	// the Pods of Runs in their logs. Only hashes of the values are sent to
+	// the API server.
+	LogsRedactSecrets bool
+
 	// SummaryLabels are labels which should be part of the summary of the result
 	SummaryLabels string
 
@@ -83,6 +101,16 @@ type Config struct {
 	// included. Runs with nil or empty managedBy are also always accepted.
 	// This set must not be mutated after initialization to avoid data races.
 	AllowedManagedByValues sets.Set[string]
+
+	// ChildObjectKinds is the set of kinds, formatted as Kind.group, of the
+	// objects CustomRuns can declare as child objects to store. Objects of
+	// other kinds are skipped.
+	ChildObjectKinds sets.Set[string]
+
+	// ClusterName is the name of the cluster the watcher runs in, recorded in
+	// the Results it creates so that the Results of several clusters can be
+	// stored by the same API server.
+	ClusterName string
 }
 
 // GetDisableAnnotationUpdate returns whether annotation updates should be
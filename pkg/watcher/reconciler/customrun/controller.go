@@ -24,11 +24,16 @@ import (
 	"github.com/tektoncd/results/pkg/apis/config"
 	"github.com/tektoncd/results/pkg/customrunmetrics"
 	"github.com/tektoncd/results/pkg/metrics"
+	"github.com/tektoncd/results/pkg/watcher/logs"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 )
 
@@ -52,9 +57,12 @@ func NewControllerWithConfig(ctx context.Context, resultsClient pb.ResultsClient
 		logger.Errorf("Failed to create customrun metrics recorder: %v. Metrics will not be recorded.", err)
 	}
 
+	kubeClientSet := kubeclient.Get(ctx)
 	c := &Reconciler{
-		kubeClientSet:    kubeclient.Get(ctx),
+		kubeClientSet:    kubeClientSet,
 		resultsClient:    resultsClient,
+		logsClient:       logs.Get(ctx),
+		restMapper:       restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClientSet.Discovery())),
 		customRunLister:  lister,
 		pipelineClient:   pipelineclient.Get(ctx),
 		cfg:              cfg,
@@ -63,6 +71,15 @@ func NewControllerWithConfig(ctx context.Context, resultsClient pb.ResultsClient
 		customRunMetrics: customRunMetrics,
 	}
 
+	if restConfig := injection.GetConfig(ctx); restConfig != nil {
+		// The dynamic client reads the child objects CustomRuns declare,
+		// whatever their kind.
+		c.dynamicClient, err = dynamic.NewForConfig(restConfig)
+		if err != nil {
+			logger.Errorf("Failed to create dynamic client: %v. Child objects of CustomRuns will not be stored.", err)
+		}
+	}
+
 	impl := customrunreconciler.NewImpl(ctx, c, func(_ *controller.Impl) controller.Options {
 		return controller.Options{
 			// This results customrun reconciler shouldn't mutate the customrun's status.
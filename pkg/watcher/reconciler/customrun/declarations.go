@@ -0,0 +1,164 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resultsannotation "github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/dynamic"
+	"github.com/tektoncd/results/pkg/watcher/results"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// objectReference references an object in the namespace of a CustomRun.
+type objectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// declarations are the log sources and child objects a CustomRun declares
+// for the watcher to store along with it. Custom task controllers declare
+// them either in annotations, or under the same keys in the extraFields of
+// the status of the CustomRun. Annotations take precedence.
+type declarations struct {
+	LogSources   []dynamic.LogSource `json:"results.tekton.dev/logSources,omitempty"`
+	ChildObjects []objectReference   `json:"results.tekton.dev/childObjects,omitempty"`
+}
+
+// declared returns the declarations of cr.
+func declared(cr *pipelinev1beta1.CustomRun) (*declarations, error) {
+	d := &declarations{}
+	// The extraFields belong to custom task controllers, which may store
+	// anything in them, so only the declarations are decoded.
+	var fields map[string]json.RawMessage
+	if err := cr.Status.DecodeExtraFields(&fields); err == nil {
+		if err := decodeDeclaration(fields[resultsannotation.LogSources], &d.LogSources); err != nil {
+			return nil, err
+		}
+		if err := decodeDeclaration(fields[resultsannotation.ChildObjects], &d.ChildObjects); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := cr.Annotations[resultsannotation.LogSources]; ok {
+		d.LogSources = nil
+		if err := decodeDeclaration([]byte(v), &d.LogSources); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := cr.Annotations[resultsannotation.ChildObjects]; ok {
+		d.ChildObjects = nil
+		if err := decodeDeclaration([]byte(v), &d.ChildObjects); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func decodeDeclaration(data []byte, into any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return controller.NewPermanentError(fmt.Errorf("error parsing declaration %s: %w", data, err))
+	}
+	return nil
+}
+
+// declaresLogs tells whether cr declares log sources. CustomRuns whose
+// declarations can't be parsed are considered to declare some, so that the
+// error is reported when their logs are stored.
+func declaresLogs(cr *pipelinev1beta1.CustomRun) bool {
+	d, err := declared(cr)
+	return err != nil || len(d.LogSources) > 0
+}
+
+// logSources implements dynamic.LogSources for CustomRuns.
+func (r *Reconciler) logSources(_ context.Context, o results.Object) ([]dynamic.LogSource, error) {
+	cr, ok := o.(*pipelinev1beta1.CustomRun)
+	if !ok {
+		return nil, fmt.Errorf("expected CustomRun, got %T", o)
+	}
+	d, err := declared(cr)
+	if err != nil {
+		return nil, err
+	}
+	return d.LogSources, nil
+}
+
+// childObjects implements dynamic.ChildObjects for CustomRuns. Declared
+// objects are read from the namespace of the CustomRun. Those which don't
+// exist anymore are skipped, as are those of kinds operators didn't allow and
+// those which are not owned by the CustomRun, so that CustomRuns can't get
+// arbitrary objects stored.
+func (r *Reconciler) childObjects(ctx context.Context, o results.Object) ([]results.ChildObject, error) {
+	cr, ok := o.(*pipelinev1beta1.CustomRun)
+	if !ok {
+		return nil, fmt.Errorf("expected CustomRun, got %T", o)
+	}
+	d, err := declared(cr)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.ChildObjects) == 0 {
+		return nil, nil
+	}
+	if r.dynamicClient == nil || r.restMapper == nil {
+		return nil, fmt.Errorf("unable to read child objects of customrun %s/%s: no dynamic client", cr.Namespace, cr.Name)
+	}
+
+	logger := logging.FromContext(ctx)
+	children := make([]results.ChildObject, 0, len(d.ChildObjects))
+	for _, ref := range d.ChildObjects {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, controller.NewPermanentError(fmt.Errorf("error parsing apiVersion of child object %s: %w", ref.Name, err))
+		}
+		gk := gv.WithKind(ref.Kind).GroupKind()
+		if r.cfg == nil || !r.cfg.ChildObjectKinds.Has(gk.String()) {
+			logger.Warnw("Skipping child object of a kind which is not allowed", zap.String("kind", gk.String()), zap.String("name", ref.Name))
+			continue
+		}
+		mapping, err := r.restMapper.RESTMapping(gk, gv.Version)
+		if err != nil {
+			return nil, fmt.Errorf("error mapping kind %s of child object %s: %w", ref.Kind, ref.Name, err)
+		}
+		child, err := r.dynamicClient.Resource(mapping.Resource).Namespace(cr.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			logger.Warnw("Child object not found", zap.String("kind", ref.Kind), zap.String("name", ref.Name))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting child object %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		if !dynamic.OwnedBy(child, cr.UID) {
+			logger.Warnw("Skipping child object which is not owned by the CustomRun", zap.String("kind", ref.Kind), zap.String("name", ref.Name))
+			continue
+		}
+		// Managed fields are only relevant to the API server.
+		child.SetManagedFields(nil)
+		children = append(children, child)
+	}
+	return children, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	resultsannotation "github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/dynamic"
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+func TestDeclared(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		extraFields string
+		want        *declarations
+		wantErr     bool
+	}{
+		{
+			name: "none",
+			want: &declarations{},
+		},
+		{
+			name: "annotations",
+			annotations: map[string]string{
+				resultsannotation.LogSources:   `[{"selector":"app=deploy","container":"main"}]`,
+				resultsannotation.ChildObjects: `[{"apiVersion":"example.dev/v1","kind":"Approval","name":"approval"}]`,
+			},
+			want: &declarations{
+				LogSources:   []dynamic.LogSource{{Selector: "app=deploy", Container: "main"}},
+				ChildObjects: []objectReference{{APIVersion: "example.dev/v1", Kind: "Approval", Name: "approval"}},
+			},
+		},
+		{
+			name:        "status",
+			extraFields: `{"approver":"alice","results.tekton.dev/logSources":[{"pod":"approval"}],"results.tekton.dev/childObjects":[{"apiVersion":"v1","kind":"ConfigMap","name":"decision"}]}`,
+			want: &declarations{
+				LogSources:   []dynamic.LogSource{{Pod: "approval"}},
+				ChildObjects: []objectReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "decision"}},
+			},
+		},
+		{
+			name: "annotations take precedence",
+			annotations: map[string]string{
+				resultsannotation.LogSources: `[{"pod":"from-annotation"}]`,
+			},
+			extraFields: `{"results.tekton.dev/logSources":[{"pod":"from-status"}],"results.tekton.dev/childObjects":[{"apiVersion":"v1","kind":"ConfigMap","name":"decision"}]}`,
+			want: &declarations{
+				LogSources:   []dynamic.LogSource{{Pod: "from-annotation"}},
+				ChildObjects: []objectReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "decision"}},
+			},
+		},
+		{
+			name:        "extraFields other than an object are ignored",
+			extraFields: `["not", "an", "object"]`,
+			want:        &declarations{},
+		},
+		{
+			name: "invalid annotation",
+			annotations: map[string]string{
+				resultsannotation.ChildObjects: `{"kind":"ConfigMap"}`,
+			},
+			wantErr: true,
+		},
+		{
+			name:        "invalid status",
+			extraFields: `{"results.tekton.dev/logSources":"approval"}`,
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &pipelinev1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "ns", Annotations: tc.annotations},
+			}
+			cr.Status.ExtraFields = runtime.RawExtension{Raw: []byte(tc.extraFields)}
+
+			got, err := declared(cr)
+			if tc.wantErr {
+				if !controller.IsPermanentError(err) {
+					t.Fatalf("expected permanent error, got %v", err)
+				}
+				if !declaresLogs(cr) {
+					t.Error("expected CustomRun with invalid declarations to declare logs")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+			if declaresLogs(cr) != (len(tc.want.LogSources) > 0) {
+				t.Errorf("declaresLogs() = %t", declaresLogs(cr))
+			}
+		})
+	}
+}
+
+func TestChildObjects(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	approval := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.dev/v1",
+		"kind":       "Approval",
+		"metadata": map[string]any{
+			"name":            "approval",
+			"namespace":       "ns",
+			"managedFields":   []any{map[string]any{"manager": "approver"}},
+			"ownerReferences": []any{map[string]any{"apiVersion": "tekton.dev/v1beta1", "kind": "CustomRun", "name": "cr", "uid": "cr-uid"}},
+		},
+		"status": map[string]any{"state": "approved"},
+	}}
+	// Objects in other namespaces can't be referenced.
+	other := approval.DeepCopy()
+	other.SetNamespace("other")
+	other.SetName("other")
+	// Objects which are not owned by the CustomRun can't be referenced.
+	unowned := approval.DeepCopy()
+	unowned.SetName("unowned")
+	unowned.SetOwnerReferences(nil)
+	// Objects of kinds which are not allowed can't be referenced.
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"name":            "credentials",
+			"namespace":       "ns",
+			"ownerReferences": approval.Object["metadata"].(map[string]any)["ownerReferences"],
+		},
+	}}
+
+	gvk := schema.GroupVersionKind{Group: "example.dev", Version: "v1", Kind: "Approval"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+	r := &Reconciler{
+		dynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), approval, other, unowned, secret),
+		restMapper:    mapper,
+		cfg:           &reconciler.Config{ChildObjectKinds: reconciler.ParseKinds("Approval.example.dev, Unknown.example.dev")},
+	}
+
+	cr := &pipelinev1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "ns", UID: "cr-uid", Annotations: map[string]string{
+			resultsannotation.ChildObjects: `[
+				{"apiVersion":"example.dev/v1","kind":"Approval","name":"approval"},
+				{"apiVersion":"example.dev/v1","kind":"Approval","name":"other"},
+				{"apiVersion":"example.dev/v1","kind":"Approval","name":"unowned"},
+				{"apiVersion":"v1","kind":"Secret","name":"credentials"}
+			]`,
+		}},
+	}
+	got, err := r.childObjects(ctx, cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].GetName() != "approval" || got[0].GetObjectKind().GroupVersionKind() != gvk {
+		t.Fatalf("unexpected child objects: %v", got)
+	}
+	if got[0].GetManagedFields() != nil {
+		t.Errorf("expected managed fields to be dropped, got %v", got[0].GetManagedFields())
+	}
+
+	cr.Annotations[resultsannotation.ChildObjects] = `[{"apiVersion":"example.dev/v1","kind":"Unknown","name":"approval"}]`
+	if _, err := r.childObjects(ctx, cr); err == nil {
+		t.Error("expected error mapping unknown kind")
+	}
+}
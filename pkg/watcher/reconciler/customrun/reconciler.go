@@ -39,8 +39,10 @@ import (
 	"github.com/tektoncd/results/pkg/watcher/results"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sdynamic "k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -53,6 +55,9 @@ type Reconciler struct {
 	kubeClientSet kubernetes.Interface
 
 	resultsClient    pb.ResultsClient
+	logsClient       pb.LogsClient
+	dynamicClient    k8sdynamic.Interface
+	restMapper       meta.RESTMapper
 	customRunLister  v1beta1.CustomRunLister
 	pipelineClient   versioned.Interface
 	cfg              *reconciler.Config
@@ -87,9 +92,15 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, cr *pipelinev1beta1.Cust
 		CustomRunInterface: r.pipelineClient.TektonV1beta1().CustomRuns(cr.Namespace),
 	}
 
-	// CustomRuns don't have logs (executed by custom controllers, not pods)
-	// so logsClient is nil
-	dyn := dynamic.NewDynamicReconciler(r.kubeClientSet, r.resultsClient, nil, customRunClient, r.cfg)
+	// CustomRuns are executed by custom controllers rather than pods, so
+	// they only have logs if their controller declares where to read them.
+	var logsClient pb.LogsClient
+	if declaresLogs(cr) {
+		logsClient = r.logsClient
+	}
+	dyn := dynamic.NewDynamicReconciler(r.kubeClientSet, r.resultsClient, logsClient, customRunClient, r.cfg)
+	dyn.LogSourcesFunc = r.logSources
+	dyn.ChildObjectsFunc = r.childObjects
 	dyn.AfterDeletion = func(ctx context.Context, object results.Object) error {
 		cr, ok := object.(*pipelinev1beta1.CustomRun)
 		if !ok {
@@ -148,6 +159,13 @@ func (r *Reconciler) finalize(ctx context.Context, cr *pipelinev1beta1.CustomRun
 		}
 	}()
 
+	// If logsClient isn't nil and the CustomRun declares logs, it means we
+	// have logging storage enabled and we can't use finalizers to coordinate
+	// deletion.
+	if r.logsClient != nil && declaresLogs(cr) {
+		return nil
+	}
+
 	// If annotation update is disabled, we can't use finalizers to coordinate deletion.
 	if r.cfg.DisableAnnotationUpdate {
 		return nil
@@ -25,6 +25,7 @@ import (
 	fakeversioned "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	resultsannotation "github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"go.uber.org/zap/zaptest"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -132,8 +133,62 @@ func TestFinalize(t *testing.T) {
 		cfg            *reconciler.Config
 		reconcileError knativereconciler.Event
 		patchErr       error
+		logsEnabled    bool
 		want           knativereconciler.Event
 	}{
+		{
+			name: "logs enabled and declared - no wait for stored annotation",
+			cr: &pipelinev1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cr",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						resultsannotation.LogSources: `[{"pod":"test-pod"}]`,
+					},
+				},
+				Status: pipelinev1beta1.CustomRunStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{
+							apis.Condition{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					CustomRunStatusFields: pipelinev1beta1.CustomRunStatusFields{
+						CompletionTime: &metav1.Time{Time: time.Now()},
+					},
+				},
+			},
+			cfg:         cfg,
+			logsEnabled: true,
+			want:        nil,
+		},
+		{
+			name: "logs enabled but not declared - wait for stored annotation",
+			cr: &pipelinev1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cr",
+					Namespace: "test-ns",
+				},
+				Status: pipelinev1beta1.CustomRunStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{
+							apis.Condition{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					CustomRunStatusFields: pipelinev1beta1.CustomRunStatusFields{
+						CompletionTime: &metav1.Time{Time: time.Now()},
+					},
+				},
+			},
+			cfg:         cfg,
+			logsEnabled: true,
+			want:        controller.NewRequeueAfter(finalizerRequeueInterval),
+		},
 		{
 			name: "migration: merge-patch finalizer removed successfully",
 			cr: &pipelinev1beta1.CustomRun{
@@ -233,6 +288,9 @@ func TestFinalize(t *testing.T) {
 			r := &Reconciler{
 				cfg: tc.cfg,
 			}
+			if tc.logsEnabled {
+				r.logsClient = pb.NewLogsClient(nil)
+			}
 
 			if tc.cr.ManagedFields != nil {
 				fakeClient := fakeversioned.NewSimpleClientset(tc.cr)
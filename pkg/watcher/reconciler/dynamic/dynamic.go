@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -34,13 +36,16 @@ import (
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	"github.com/tektoncd/results/pkg/logs"
+	"github.com/tektoncd/results/pkg/tracing"
 	"github.com/tektoncd/results/pkg/watcher/convert"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/client"
 	"github.com/tektoncd/results/pkg/watcher/results"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -68,6 +73,16 @@ type Reconciler struct {
 	IsReadyForDeletionFunc IsReadyForDeletion
 	AfterDeletion          AfterDeletion
 	AfterStorage           AfterStorage
+	// LogSourcesFunc and ChildObjectsFunc are set by the controllers of
+	// kinds whose logs and children are declared by the objects
+	// themselves, such as CustomRuns.
+	LogSourcesFunc   LogSources
+	ChildObjectsFunc ChildObjects
+
+	// Followers tracks the executing Runs whose logs are followed in the
+	// background. Controllers share it across reconciliations and tie it to
+	// their context.
+	Followers *Followers
 }
 
 func init() {
@@ -97,6 +112,7 @@ func NewDynamicReconciler(kubeClientSet kubernetes.Interface, rc pb.ResultsClien
 		KubeClientSet: kubeClientSet,
 		objectClient:  oc,
 		cfg:           cfg,
+		Followers:     NewFollowers(context.Background()),
 		// Always true predicate.
 		IsReadyForDeletionFunc: func(_ context.Context, _ results.Object) (bool, error) {
 			return true, nil
@@ -107,6 +123,15 @@ func NewDynamicReconciler(kubeClientSet kubernetes.Interface, rc pb.ResultsClien
 // Reconcile handles result/record uploading for the given Run object.
 // If enabled, the object may be deleted upon successful result upload.
 func (r *Reconciler) Reconcile(ctx context.Context, o results.Object) error {
+	ctx, span := tracing.Start(ctx, "Reconciler.Reconcile")
+	err := r.reconcile(ctx, o)
+	// The kind of o is only known once reconcile has inferred it.
+	span.SetAttributes(tracing.ObjectAttributes(o)...)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, o results.Object) error {
 	var ctxCancel context.CancelFunc
 	// context with timeout does not work with the partial end to end flow that exists with unit tests;
 	// this field will always be set for real
@@ -179,7 +204,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, o results.Object) error {
 			// for timeout capability
 			go func() {
 				// TODO need to leverage the log status API noting log storage completion to coordinate with pruning
-				backgroundCtx, cancel := context.WithCancel(context.Background())
+				backgroundCtx, cancel := context.WithCancel(tracing.Detach(ctx))
 				// need this to get grpc to clean up its threads
 				defer cancel()
 				timeout := 30 * time.Second
@@ -227,6 +252,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, o results.Object) error {
 		}
 		logger.Debug("Successfully store eventlist")
 	}
+
+	if r.ChildObjectsFunc != nil {
+		if err := r.storeChildObjects(ctx, o); err != nil {
+			logger.Warnw("Failed to store child objects", zap.Error(err))
+			if ctxCancel != nil {
+				ctxCancel()
+			}
+			return err
+		}
+	}
+
+	if err := r.storeProvenance(ctx, o, rec.GetName()); err != nil {
+		logger.Warnw("Failed to store provenance", zap.Error(err))
+		if ctxCancel != nil {
+			ctxCancel()
+		}
+		return err
+	}
 	logger = logger.With(zap.String("results.tekton.dev/result", res.Name),
 		zap.String("results.tekton.dev/record", rec.Name))
 	logger.Debugw("Record has been successfully upserted into API server", timeTakenField)
@@ -407,80 +450,320 @@ func getCompletionTime(object results.Object) (*time.Time, error) {
 	return completionTime, nil
 }
 
-// sendLog streams logs to the API server
+// sendLog streams logs to the API server. Logs of complete Runs are sent at
+// once. If LogsFollow is enabled, logs of executing Runs are streamed in the
+// background as they are produced.
 func (r *Reconciler) sendLog(ctx context.Context, o results.Object) error {
 	logger := logging.FromContext(ctx)
 	condition := o.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
 	GVK := o.GetObjectKind().GroupVersionKind()
-	if !GVK.Empty() &&
-		(GVK.Kind == "TaskRun" || GVK.Kind == "PipelineRun") &&
-		condition != nil &&
-		condition.Type == "Succeeded" &&
-		!condition.IsUnknown() {
-
-		rec, err := r.resultsClient.GetLogRecord(ctx, o)
-		if err != nil {
+	if GVK.Empty() ||
+		condition == nil ||
+		condition.Type != "Succeeded" {
+		return nil
+	}
+	var sources []LogSource
+	if GVK.Kind != "TaskRun" && GVK.Kind != "PipelineRun" {
+		// The logs of other kinds are read from the Pods they declare,
+		// once they are done.
+		if r.LogSourcesFunc == nil || condition.IsUnknown() {
+			return nil
+		}
+		var err error
+		sources, err = r.LogSourcesFunc(ctx, o)
+		if err != nil || len(sources) == 0 {
 			return err
 		}
-		if rec != nil {
-			// we had already started logs streaming
-			parent, resName, recName, err := record.ParseName(rec.GetName())
-			if err != nil {
-				return err
-			}
-			logName := log.FormatName(result.FormatName(parent, resName), recName)
-			// Update log annotation if it doesn't exist
-			return r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName})
+	}
+	if r.cfg != nil && r.cfg.LogsPerStep {
+		if tr, ok := o.(*pipelinev1.TaskRun); ok {
+			r.sendStepLogs(ctx, tr)
 		}
+	}
+	follow := condition.IsUnknown()
+	if follow && (r.cfg == nil || !r.cfg.LogsFollow) {
+		return nil
+	}
 
-		// Create a log record if the object has/supports logs.
-		rec, err = r.resultsClient.PutLog(ctx, o)
+	rec, err := r.resultsClient.GetLogRecord(ctx, o)
+	if err != nil {
+		return err
+	}
+	var offset int64
+	if rec != nil {
+		// we had already started logs streaming
+		parent, resName, recName, err := record.ParseName(rec.GetName())
 		if err != nil {
 			return err
 		}
-
-		parent, resName, recName, err := record.ParseName(rec.GetName())
+		logName := log.FormatName(result.FormatName(parent, resName), recName)
+		logStatus, err := getLogStatus(rec)
 		if err != nil {
 			return err
 		}
-		logName := log.FormatName(result.FormatName(parent, resName), recName)
-
-		var logType string
-		switch o.GetObjectKind().GroupVersionKind().Kind {
-		case "TaskRun":
-			logType = tknlog.LogTypeTask
-		case "PipelineRun":
-			logType = tknlog.LogTypePipeline
+		if !logStatus.IsStreaming || r.Followers.Following(o.GetUID()) {
+			// Update log annotation if it doesn't exist
+			return r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName})
 		}
+		// The log was left incomplete, e.g. by a restart of the watcher.
+		offset = logStatus.Size
+	}
 
-		if err := r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName}); err != nil {
-			return err
-		}
+	// Create a log record if the object has/supports logs.
+	rec, err = r.resultsClient.PutLog(ctx, o)
+	if err != nil {
+		return err
+	}
 
-		logger.Debug("Streaming log started")
+	parent, resName, recName, err := record.ParseName(rec.GetName())
+	if err != nil {
+		return err
+	}
+	logName := log.FormatName(result.FormatName(parent, resName), recName)
 
-		err = r.streamLogs(ctx, o, logType, logName)
-		if err != nil {
-			logger.Errorw("Error streaming log", zap.Error(err))
-			// TODO once we have the log status available, report the error there for retry if needed
-		}
-		logger.Info("Streaming log completed")
+	var logType string
+	switch o.GetObjectKind().GroupVersionKind().Kind {
+	case "TaskRun":
+		logType = tknlog.LogTypeTask
+	case "PipelineRun":
+		logType = tknlog.LogTypePipeline
+	}
 
+	if err := r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Log, Value: logName}); err != nil {
+		return err
 	}
 
+	if follow {
+		// The Run may execute for much longer than reconciliations are
+		// allowed to, so its logs are followed independently.
+		r.Followers.Follow(ctx, o.GetUID(), func(ctx context.Context) {
+			ctx = logging.WithLogger(ctx, logger)
+			logger.Debug("Following log started")
+			if err := r.streamLogs(ctx, o, logType, logName, offset, true); err != nil {
+				logger.Errorw("Error following log", zap.Error(err))
+			}
+			logger.Info("Following log completed")
+		})
+		return nil
+	}
+
+	logger.Debug("Streaming log started")
+
+	if sources != nil {
+		err = r.streamPodLogs(ctx, o, sources, logName, offset)
+	} else {
+		err = r.streamLogs(ctx, o, logType, logName, offset, false)
+	}
+	if err != nil {
+		logger.Errorw("Error streaming log", zap.Error(err))
+		// TODO once we have the log status available, report the error there for retry if needed
+	}
+	logger.Info("Streaming log completed")
+
 	return nil
 }
 
-func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType, logName string) error {
+// sendStepLogs stores the log of each step of tr separately once the step
+// terminates, reading it from the step container. The steps whose log is
+// stored are recorded in the StepLogs annotation and skipped afterwards, and
+// failures are logged so that the remaining steps are still stored.
+func (r *Reconciler) sendStepLogs(ctx context.Context, tr *pipelinev1.TaskRun) {
 	logger := logging.FromContext(ctx)
-	logsClient, err := r.resultsClient.UpdateLog(ctx)
+	if tr.Status.PodName == "" {
+		return
+	}
+	var stored []string
+	if v := tr.GetAnnotations()[annotation.StepLogs]; v != "" {
+		stored = strings.Split(v, ",")
+	}
+	var hints []string
+	hinted, sent := false, false
+	for _, s := range tr.Status.Steps {
+		t := s.Terminated
+		if t == nil || slices.Contains(stored, s.Name) {
+			continue
+		}
+		if !hinted {
+			hints, hinted = r.redactionHints(ctx, tr), true
+		}
+		exitCode, startedAt, finishedAt := t.ExitCode, t.StartedAt, t.FinishedAt
+		step := &v1alpha3.LogStep{
+			Name:       s.Name,
+			Container:  s.Container,
+			ExitCode:   &exitCode,
+			StartTime:  &startedAt,
+			FinishTime: &finishedAt,
+		}
+		if err := r.sendStepLog(ctx, tr, step, hints); err != nil {
+			logger.Warnw("Error sending step log", zap.String("step", s.Name), zap.Error(err))
+			continue
+		}
+		stored = append(stored, s.Name)
+		sent = true
+	}
+	if !sent {
+		return
+	}
+	if err := r.addResultsAnnotations(ctx, tr, annotation.Annotation{Name: annotation.StepLogs, Value: strings.Join(stored, ",")}); err != nil {
+		logger.Warnw("Error recording sent step logs", zap.Error(err))
+	}
+}
+
+func (r *Reconciler) sendStepLog(ctx context.Context, tr *pipelinev1.TaskRun, step *v1alpha3.LogStep, hints []string) error {
+	rec, err := r.resultsClient.PutStepLog(ctx, tr, step)
+	if err != nil {
+		return err
+	}
+	logStatus, err := getLogStatus(rec)
+	if err != nil {
+		return err
+	}
+	if logStatus.IsStored && !logStatus.IsStreaming {
+		return nil
+	}
+	parent, resName, recName, err := record.ParseName(rec.GetName())
 	if err != nil {
-		return fmt.Errorf("failed to create UpdateLog client: %w", err)
+		return err
 	}
+	logName := log.FormatName(result.FormatName(parent, resName), recName)
 
-	writer := logs.NewBufferedWriter(logsClient, logName, logs.DefaultBufferSize)
+	stream, err := r.KubeClientSet.CoreV1().Pods(tr.GetNamespace()).GetLogs(tr.Status.PodName, &v1.PodLogOptions{
+		Container:  step.Container,
+		Timestamps: r.cfg.LogsTimestamps,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading logs of container %s: %w", step.Container, err)
+	}
+	defer stream.Close()
+
+	writer := logs.NewSegmentWriter(func() (logs.UpdateLogClient, error) {
+		return r.resultsClient.UpdateLog(ctx)
+	}, logName, logs.DefaultBufferSize, 0)
+	writer.Skip(logStatus.Size)
+	writer.SetRedactionHints(hints)
+	_, copyErr := io.Copy(writer, stream)
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return copyErr
+}
+
+// redactionHints returns the hints of the values of the Secrets used by the
+// Pods of o, for the API server to mask them in its logs. Secrets which can't
+// be read are skipped.
+func (r *Reconciler) redactionHints(ctx context.Context, o results.Object) []string {
+	if r.cfg == nil || !r.cfg.LogsRedactSecrets {
+		return nil
+	}
+	var selector string
+	switch o.GetObjectKind().GroupVersionKind().Kind {
+	case "TaskRun":
+		selector = "tekton.dev/taskRun=" + o.GetName()
+	case "PipelineRun":
+		selector = "tekton.dev/pipelineRun=" + o.GetName()
+	default:
+		return nil
+	}
+	pods, err := r.KubeClientSet.CoreV1().Pods(o.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logging.FromContext(ctx).Warnw("Error listing pods for log redaction", zap.Error(err))
+		return nil
+	}
+	return r.podRedactionHints(ctx, o.GetNamespace(), pods.Items)
+}
+
+// podRedactionHints returns the hints of the values of the Secrets used by
+// pods. Secrets which can't be read are skipped.
+func (r *Reconciler) podRedactionHints(ctx context.Context, namespace string, pods []v1.Pod) []string {
+	logger := logging.FromContext(ctx)
+	names := map[string]struct{}{}
+	for _, pod := range pods {
+		for _, name := range podSecrets(&pod) {
+			names[name] = struct{}{}
+		}
+	}
+	var hints []string
+	for name := range names {
+		secret, err := r.KubeClientSet.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			logger.Warnw("Error getting secret for log redaction", zap.String("secret", name), zap.Error(err))
+			continue
+		}
+		for _, v := range secret.Data {
+			hints = append(hints, logs.RedactionHints(string(v))...)
+		}
+	}
+	return hints
+}
+
+// podSecrets returns the names of the Secrets mounted into pod or exposed to
+// its containers through environment variables.
+func podSecrets(pod *v1.Pod) []string {
+	var names []string
+	for _, v := range pod.Spec.Volumes {
+		if v.Secret != nil {
+			names = append(names, v.Secret.SecretName)
+		}
+		if v.Projected != nil {
+			for _, src := range v.Projected.Sources {
+				if src.Secret != nil {
+					names = append(names, src.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				names = append(names, e.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil {
+				names = append(names, e.SecretRef.Name)
+			}
+		}
+	}
+	return names
+}
+
+// getLogStatus returns the status of the log stored in rec, telling how much
+// was stored already and whether more data is to be appended.
+func getLogStatus(rec *pb.Record) (*v1alpha3.LogStatus, error) {
+	l := &v1alpha3.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), l); err != nil {
+		return nil, fmt.Errorf("error decoding log record %s: %w", rec.GetName(), err)
+	}
+	return &l.Status, nil
+}
+
+// streamLogs sends the logs of o to the API server as they are read, skipping
+// the first offset bytes which were stored already. If follow is set, the logs
+// are read until the Run completes, and made readable every LogsFlushInterval.
+func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType, logName string, offset int64, follow bool) (err error) {
+	ctx, span := tracing.Start(ctx, "Reconciler.streamLogs", append(tracing.ObjectAttributes(o),
+		attribute.String("results.tekton.dev/log", logName),
+		attribute.Int64("results.tekton.dev/log-offset", offset),
+		attribute.Bool("results.tekton.dev/log-follow", follow),
+	)...)
+	defer func() { tracing.End(span, err) }()
+	logger := logging.FromContext(ctx)
+	var interval time.Duration
+	if follow {
+		interval = r.cfg.LogsFlushInterval
+	}
+	writer := logs.NewSegmentWriter(func() (logs.UpdateLogClient, error) {
+		logsClient, err := r.resultsClient.UpdateLog(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create UpdateLog client: %w", err)
+		}
+		return logsClient, nil
+	}, logName, logs.DefaultBufferSize, interval)
+	writer.Skip(offset)
+	// The Pods of PipelineRuns are created as their tasks start, so the hints
+	// are refreshed with each segment.
+	writer.SetRedactionHintsFunc(func() []string { return r.redactionHints(ctx, o) })
 
-	inMemWriteBufferStdout := bytes.NewBuffer(make([]byte, 0))
 	inMemWriteBufferStderr := bytes.NewBuffer(make([]byte, 0))
 	tknParams := &cli.TektonParams{}
 	tknParams.SetNamespace(o.GetNamespace())
@@ -492,47 +775,34 @@ func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType,
 
 	reader, err := tknlog.NewReader(logType, &tknopts.LogOptions{
 		AllSteps:        true,
+		Follow:          follow,
 		Params:          tknParams,
 		PipelineRunName: o.GetName(),
 		TaskrunName:     o.GetName(),
 		Timestamps:      r.cfg.LogsTimestamps,
+		Streamer:        podLogStreamer(ctx),
 		Stream: &cli.Stream{
-			Out: inMemWriteBufferStdout,
+			Out: writer,
 			Err: inMemWriteBufferStderr,
 		},
 	})
 	if err != nil {
+		writer.Close() //nolint:errcheck
 		return fmt.Errorf("failed to create tkn reader: %w", err)
 	}
 	logChan, errChan, err := reader.Read()
 	if err != nil {
+		writer.Close() //nolint:errcheck
 		return fmt.Errorf("error reading from tkn reader: %w", err)
 	}
 
+	// Logs are written to the API server as they are read, so that only up
+	// to one chunk is held in memory.
 	tknlog.NewWriter(logType, true).Write(&cli.Stream{
-		Out: inMemWriteBufferStdout,
+		Out: writer,
 		Err: inMemWriteBufferStderr,
 	}, logChan, errChan)
 
-	// pull the first error that occurred and return on that; reminder - per https://golang.org/ref/spec#Channel_types
-	// channels act as FIFO queues
-	chanErr, ok := <-errChan
-	if ok && chanErr != nil {
-		return fmt.Errorf("error occurred while calling tkn client write: %w", chanErr)
-	}
-
-	bufStdout := inMemWriteBufferStdout.Bytes()
-	cntStdout, writeStdOutErr := writer.Write(bufStdout)
-	if writeStdOutErr != nil {
-		logger.Warnw("streamLogs in mem bufStdout write err", zap.String("error", writeStdOutErr.Error()))
-	}
-	if cntStdout != len(bufStdout) {
-		logger.Warnw("streamLogs bufStdout write len inconsistent",
-			zap.Int("in", len(bufStdout)),
-			zap.Int("out", cntStdout),
-		)
-
-	}
 	bufStderr := inMemWriteBufferStderr.Bytes()
 	// we do not write these errors to the results api server
 
@@ -545,31 +815,22 @@ func (r *Reconciler) streamLogs(ctx context.Context, o results.Object, logType,
 			zap.String("errStr", errStr))
 	}
 
-	_, flushErr := writer.Flush()
-	if flushErr != nil {
-		logger.Warnw("flush ret err", zap.String("error", flushErr.Error()))
-		logger.Error(flushErr)
-		return flushErr
-	}
-	// so we use CloseAndRecv vs. just CloseSent to achieve a few things:
-	// 1) CloseAndRecv calls CloseSend under the covers, followed by a Recv call to obtain a LogSummary
-	// 2) LogSummary appears to have some stats on the state of operations
-	// 3) It also appears to be the best form of "confirmation" that the asynchronous operation of UpdateLog on the api
-	// server side has reached a terminal state
-	// 4) Hence, creating a child context which we cancel hopefully does not interrupt the UpdateLog call when this method exits,
-	// 5) However, we need the context cancel to close out the last goroutine launched in newClientStreamWithParams that does
-	// the final clean, otherwise we end up with our now familiar goroutine leak, which in the end is a memory leak
-
-	// comparing closeErr with io.EOF does not work; and I could not find code / desc etc. constants in the grpc code that handled
-	// the wrapped EOF error we expect to get from grpc when things are "OK"
-	if logSummary, closeErr := logsClient.CloseAndRecv(); closeErr != nil && !strings.Contains(closeErr.Error(), "EOF") {
-		logger.Warnw("CloseAndRecv ret err",
+	// The log is ended even if reading failed, so that what was read is kept
+	// and readers stop following it.
+	// Closing the writer uses CloseAndRecv vs. just CloseSend, as it is the best form of "confirmation" that the
+	// asynchronous operation of UpdateLog on the api server side has reached a terminal state.
+	closeErr := writer.Close()
+
+	// pull the first error that occurred and return on that; reminder - per https://golang.org/ref/spec#Channel_types
+	// channels act as FIFO queues
+	chanErr, ok := <-errChan
+	if ok && chanErr != nil {
+		return fmt.Errorf("error occurred while calling tkn client write: %w", chanErr)
+	}
+	if closeErr != nil {
+		logger.Warnw("closing log writer ret err",
 			zap.String("name", o.GetName()),
 			zap.String("error", closeErr.Error()))
-		if logSummary != nil {
-			logger.Errorw("CloseAndRecv", zap.String("logSummary", logSummary.String()))
-		}
-		logger.Error(closeErr)
 		return closeErr
 	}
 
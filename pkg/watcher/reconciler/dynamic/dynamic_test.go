@@ -17,6 +17,8 @@ package dynamic
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,17 +26,22 @@ import (
 
 	watcherresults "github.com/tektoncd/results/pkg/watcher/results"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 
 	"github.com/jonboulle/clockwork"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	rtesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/results/pkg/api/server/config"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	"github.com/tektoncd/results/pkg/internal/test"
+	"github.com/tektoncd/results/pkg/logs"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/client"
@@ -42,6 +49,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sTest "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -127,7 +135,7 @@ var (
 func TestReconcile_TaskRun(t *testing.T) {
 	// Configures fake tekton clients + informers.
 	ctx, _ := rtesting.SetupFakeContext(t)
-	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
 
 	fakeclock := clockwork.NewFakeClockAt(time.Now())
 	clock = fakeclock
@@ -436,7 +444,7 @@ func TestReconcile_TaskRun(t *testing.T) {
 func TestReconcile_PipelineRun(t *testing.T) {
 	// Configures fake tekton clients + informers.
 	ctx, _ := rtesting.SetupFakeContext(t)
-	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{})
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
 
 	fakeclock := clockwork.NewFakeClockAt(time.Now())
 	clock = fakeclock
@@ -511,3 +519,298 @@ func TestReconcile_PipelineRun(t *testing.T) {
 	// since everything is handled as a generic object testing TaskRuns should
 	// be sufficient coverage.
 }
+
+func TestGetLogStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+		want v1alpha3.LogStatus
+	}{
+		{name: "complete", data: `{"status":{"size":42,"isStored":true}}`, want: v1alpha3.LogStatus{Size: 42, IsStored: true}},
+		{name: "streaming", data: `{"status":{"size":7,"isStored":true,"isStreaming":true}}`, want: v1alpha3.LogStatus{Size: 7, IsStored: true, IsStreaming: true}},
+		{name: "empty", data: `{}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getLogStatus(&pb.Record{Data: &pb.Any{Value: []byte(tc.data)}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, *got); diff != "" {
+				t.Errorf("getLogStatus() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := getLogStatus(&pb.Record{Data: &pb.Any{Value: []byte("{")}}); err == nil {
+		t.Error("expected error decoding invalid log record")
+	}
+}
+
+func TestSendStepLogs(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+	cfg := &reconciler.Config{}
+
+	tr := taskrun.DeepCopy()
+	tr.Status.PodName = "taskrun-pod"
+	tr.Status.Steps = []pipelinev1.StepState{{
+		Name:      "build",
+		Container: "step-build",
+		ContainerState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+		},
+	}, {
+		Name:      "test",
+		Container: "step-test",
+		ContainerState: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+	}}
+	trclient := &client.TaskRunClient{TaskRunInterface: pipelineclient.Get(ctx).TektonV1().TaskRuns(tr.GetNamespace())}
+	if _, err := trclient.Create(ctx, tr, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewDynamicReconciler(k8sTest.NewSimpleClientset(), resultsClient, logsClient, trclient, cfg)
+
+	rec, err := r.resultsClient.PutLog(ctx, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, resultName, recordName, err := record.ParseName(rec.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logName := log.FormatName(result.FormatName(parent, resultName), recordName)
+	stepLogs := func() []*pb.StepLog {
+		t.Helper()
+		resp, err := logsClient.ListStepLogs(ctx, &pb.ListStepLogsRequest{Name: logName})
+		if err != nil {
+			t.Fatalf("ListStepLogs: %v", err)
+		}
+		return resp.GetStepLogs()
+	}
+
+	// Only the logs of terminated steps are sent.
+	r.sendStepLogs(ctx, tr)
+	if got := tr.GetAnnotations()[annotation.StepLogs]; got != "build" {
+		t.Errorf("%s annotation = %q, want %q", annotation.StepLogs, got, "build")
+	}
+	got := stepLogs()
+	// The fake clientset returns "fake logs" for every container.
+	if len(got) != 1 {
+		t.Fatalf("expected 1 step log, got %v", got)
+	}
+	if got[0].GetStep() != "build" || got[0].GetContainer() != "step-build" || got[0].GetExitCode() != 1 || got[0].GetSize() != int64(len("fake logs")) {
+		t.Errorf("unexpected step log: %v", got[0])
+	}
+
+	// Logs already sent are skipped once the next step terminates.
+	tr.Status.Steps[1].ContainerState = corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{},
+	}
+	r.sendStepLogs(ctx, tr)
+	if got := tr.GetAnnotations()[annotation.StepLogs]; got != "build,test" {
+		t.Errorf("%s annotation = %q, want %q", annotation.StepLogs, got, "build,test")
+	}
+	got = stepLogs()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 step logs, got %v", got)
+	}
+	for _, l := range got {
+		if l.GetSize() != int64(len("fake logs")) {
+			t.Errorf("step log %s stored more than once: %v", l.GetStep(), l)
+		}
+	}
+}
+
+func TestRedactionHints(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	kube := k8sTest.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "taskrun-pod", Namespace: "ns", Labels: map[string]string{"tekton.dev/taskRun": "taskrun"}},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "git-creds"}}}},
+				Containers: []corev1.Container{{
+					Name: "step-build",
+					Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-token"}, Key: "token"},
+					}}},
+				}},
+			},
+		},
+		// Not used by the pod of the TaskRun.
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-pod", Namespace: "ns", Labels: map[string]string{"tekton.dev/taskRun": "other"}},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "other"}}}},
+			},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "ns"}, Data: map[string][]byte{"password": []byte("git-password")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api-token", Namespace: "ns"}, Data: map[string][]byte{"token": []byte("api-token-value")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}, Data: map[string][]byte{"password": []byte("other-password")}},
+	)
+	r := &Reconciler{KubeClientSet: kube, cfg: &reconciler.Config{}}
+	if got := r.redactionHints(ctx, taskrun); got != nil {
+		t.Errorf("expected no hints when disabled, got %v", got)
+	}
+
+	r.cfg.LogsRedactSecrets = true
+	got := r.redactionHints(ctx, taskrun)
+	want := []string{logs.RedactionHint("git-password"), logs.RedactionHint("api-token-value")}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestSendLog_CustomRun(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+	owner := []metav1.OwnerReference{{APIVersion: "tekton.dev/v1beta1", Kind: "CustomRun", Name: "customrun", UID: "67890"}}
+	pod := func(name string, labels map[string]string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels, OwnerReferences: owner}}
+		for _, c := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+		}
+		return p
+	}
+	kube := k8sTest.NewSimpleClientset(
+		pod("deploy-b", map[string]string{"app": "deploy"}, "main", "sidecar"),
+		pod("deploy-a", map[string]string{"app": "deploy"}, "main"),
+		pod("approval", nil, "wait", "notify"),
+		pod("other", map[string]string{"app": "other"}, "main"),
+	)
+	// Pods which are not owned by the CustomRun are skipped.
+	unowned := pod("deploy-c", map[string]string{"app": "deploy"}, "main")
+	unowned.OwnerReferences = nil
+	if _, err := kube.CoreV1().Pods("ns").Create(ctx, unowned, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewDynamicReconciler(kube, resultsClient, logsClient, nil, &reconciler.Config{DisableAnnotationUpdate: true})
+
+	cr := &pipelinev1beta1.CustomRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "CustomRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "customrun", Namespace: "ns", UID: "67890"},
+		Status: pipelinev1beta1.CustomRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+
+	// Without log sources, CustomRuns have no log.
+	if err := r.sendLog(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if rec, err := r.resultsClient.GetLogRecord(ctx, cr); err != nil || rec != nil {
+		t.Fatalf("expected no log record, got %v, %v", rec, err)
+	}
+
+	r.LogSourcesFunc = func(context.Context, watcherresults.Object) ([]LogSource, error) {
+		return []LogSource{
+			{Selector: "app=deploy", Container: "main"},
+			{Pod: "approval"},
+			// Pods already read and missing Pods are skipped.
+			{Pod: "deploy-a"},
+			{Pod: "missing"},
+			{Pod: "deploy-c"},
+		}, nil
+	}
+	if err := r.sendLog(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := r.resultsClient.GetLogRecord(ctx, cr)
+	if err != nil || rec == nil {
+		t.Fatalf("expected log record, got %v, %v", rec, err)
+	}
+	parent, resultName, recordName, err := record.ParseName(rec.GetName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := logsClient.GetLog(ctx, &pb.GetLogRequest{Name: log.FormatName(result.FormatName(parent, resultName), recordName)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got += string(chunk.GetData())
+	}
+	// The fake clientset returns "fake logs" for every container.
+	want := "[deploy-a : main] fake logs\n" +
+		"[deploy-b : main] fake logs\n" +
+		"[approval : wait] fake logs\n" +
+		"[approval : notify] fake logs\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got: %s", diff)
+	}
+}
+
+func TestStoreChildObjects(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+	r := NewDynamicReconciler(k8sTest.NewSimpleClientset(), resultsClient, logsClient, nil, &reconciler.Config{})
+
+	cr := &pipelinev1beta1.CustomRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "CustomRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "customrun", Namespace: "ns", UID: "67890"},
+	}
+	child := &unstructured.Unstructured{}
+	child.SetAPIVersion("example.dev/v1")
+	child.SetKind("Approval")
+	child.SetNamespace("ns")
+	child.SetName("approval")
+	child.SetUID("24680")
+	r.ChildObjectsFunc = func(context.Context, watcherresults.Object) ([]watcherresults.ChildObject, error) {
+		return []watcherresults.ChildObject{child}, nil
+	}
+
+	resultName := result.FormatName(cr.GetNamespace(), string(cr.GetUID()))
+	get := func() *pb.Record {
+		t.Helper()
+		res, err := resultsClient.GetResult(ctx, &pb.GetResultRequest{Name: resultName})
+		if err != nil {
+			t.Fatalf("Error getting result: %v", err)
+		}
+		uid, err := uuid.Parse(res.GetUid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := resultsClient.GetRecord(ctx, &pb.GetRecordRequest{Name: watcherresults.FormatChildName(resultName, uid, child)})
+		if err != nil {
+			t.Fatalf("Error getting child record: %v", err)
+		}
+		return rec
+	}
+
+	if err := r.storeChildObjects(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	rec := get()
+	if rec.GetData().GetType() != "example.dev/v1.Approval" {
+		t.Errorf("unexpected record type %q", rec.GetData().GetType())
+	}
+
+	// Children are updated when they change.
+	if err := unstructured.SetNestedField(child.Object, "approved", "status", "state"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.storeChildObjects(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if updated := get(); updated.GetEtag() == rec.GetEtag() || !strings.Contains(string(updated.GetData().GetValue()), "approved") {
+		t.Errorf("expected child record to be updated, got %s", updated.GetData().GetValue())
+	}
+
+	r.ChildObjectsFunc = func(context.Context, watcherresults.Object) ([]watcherresults.ChildObject, error) {
+		return nil, errors.New("boom")
+	}
+	if err := r.storeChildObjects(ctx, cr); err == nil {
+		t.Error("expected error from ChildObjectsFunc")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/tektoncd/cli/pkg/pods/stream"
+	"github.com/tektoncd/results/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Followers tracks the executing Runs whose logs are followed in the
+// background. It is shared by the reconcilers of a kind, so that each log is
+// followed once, and the logs stop being followed once the context it was
+// created with is done, e.g. when the watcher shuts down.
+type Followers struct {
+	ctx       context.Context
+	following sync.Map
+	wg        sync.WaitGroup
+}
+
+// NewFollowers returns Followers whose logs are followed until ctx is done.
+func NewFollowers(ctx context.Context) *Followers {
+	return &Followers{ctx: ctx}
+}
+
+// Following tells whether the log of the Run with the given UID is followed.
+func (f *Followers) Following(uid types.UID) bool {
+	_, ok := f.following.Load(uid)
+	return ok
+}
+
+// Follow calls follow in the background, unless the log of the Run with the
+// given UID is followed already. The context passed to follow is done once the
+// context of f is, and its spans are children of the span in ctx.
+func (f *Followers) Follow(ctx context.Context, uid types.UID, follow func(ctx context.Context)) bool {
+	if _, loaded := f.following.LoadOrStore(uid, struct{}{}); loaded {
+		return false
+	}
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer f.following.Delete(uid)
+		followCtx, cancel := context.WithCancel(tracing.DetachTo(f.ctx, ctx))
+		// need this to get grpc to clean up its threads
+		defer cancel()
+		follow(followCtx)
+	}()
+	return true
+}
+
+// Wait waits for the logs being followed to be done.
+func (f *Followers) Wait() {
+	f.wg.Wait()
+}
+
+// podLogStreamer streams the logs of Pod containers until ctx is done, for
+// the tkn reader to stop following them once the watcher shuts down.
+func podLogStreamer(ctx context.Context) stream.NewStreamerFunc {
+	return func(pods typedv1.PodInterface, name string, opts *corev1.PodLogOptions) stream.Streamer {
+		return streamerFunc(func() (io.ReadCloser, error) {
+			return pods.GetLogs(name, opts).Stream(ctx)
+		})
+	}
+}
+
+type streamerFunc func() (io.ReadCloser, error)
+
+func (s streamerFunc) Stream() (io.ReadCloser, error) {
+	return s()
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFollowers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := NewFollowers(ctx)
+
+	started := make(chan struct{})
+	// The context of the reconciliation ends before the log is followed.
+	reconcileCtx, reconcileCancel := context.WithCancel(context.Background())
+	if !f.Follow(reconcileCtx, "uid", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	}) {
+		t.Fatal("Follow() = false, want the log to be followed")
+	}
+	reconcileCancel()
+	<-started
+
+	if !f.Following("uid") {
+		t.Error("Following() = false while the log is followed")
+	}
+	if f.Follow(context.Background(), "uid", func(context.Context) {
+		t.Error("the log was followed twice")
+	}) {
+		t.Error("Follow() = true, want the log followed already to be skipped")
+	}
+
+	// Stopping the controller stops following logs.
+	cancel()
+	f.Wait()
+	if f.Following("uid") {
+		t.Error("Following() = true once the log stopped being followed")
+	}
+}
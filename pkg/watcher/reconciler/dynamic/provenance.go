@@ -0,0 +1,290 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"github.com/tektoncd/results/pkg/watcher/results"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+// Annotations set by Tekton Chains on the runs it processed.
+const (
+	chainsAnnotationPrefix = "chains.tekton.dev/"
+	chainsSigned           = chainsAnnotationPrefix + "signed"
+	chainsTransparency     = chainsAnnotationPrefix + "transparency"
+
+	// The annotations of the attestations stored on runs are named after
+	// these prefixes, followed by the key of the attestation.
+	chainsPayloadPrefix     = chainsAnnotationPrefix + "payload-"
+	chainsSignaturePrefix   = chainsAnnotationPrefix + "signature-"
+	chainsCertificatePrefix = chainsAnnotationPrefix + "cert-"
+	chainsChainPrefix       = chainsAnnotationPrefix + "chain-"
+
+	inTotoStatementType = "https://in-toto.io/Statement/"
+)
+
+// storeProvenance stores the outputs of Tekton Chains for o under its
+// Result, once Chains processed it. The provenance is stored again only when
+// the Chains annotations of o change.
+func (r *Reconciler) storeProvenance(ctx context.Context, o results.Object, record string) error {
+	logger := logging.FromContext(ctx)
+	p := provenance(o, record)
+	if p == nil {
+		return nil
+	}
+	hash := chainsHash(o.GetAnnotations())
+	if o.GetAnnotations()[annotation.Provenance] == hash {
+		logger.Debug("Skipping provenance: Chains annotations are unchanged")
+		return nil
+	}
+	rec, err := r.resultsClient.PutProvenance(ctx, o, p)
+	if err != nil {
+		return fmt.Errorf("error storing provenance: %w", err)
+	}
+	logger.Debugw("Provenance stored", zap.String("results.tekton.dev/record", rec.GetName()),
+		zap.Strings("digests", p.Spec.Digests))
+	return r.addResultsAnnotations(ctx, o, annotation.Annotation{Name: annotation.Provenance, Value: hash})
+}
+
+// chainsHash returns a hash of the Tekton Chains annotations among the given
+// annotations.
+func chainsHash(annotations map[string]string) string {
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		if strings.HasPrefix(k, chainsAnnotationPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\n", k, annotations[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// provenance returns the outputs of Tekton Chains for o, stored in the
+// Record named record, or nil if Chains didn't process o.
+func provenance(o results.Object, record string) *v1alpha3.Provenance {
+	annotations := o.GetAnnotations()
+	signed, ok := annotations[chainsSigned]
+	if !ok {
+		return nil
+	}
+	p := &v1alpha3.Provenance{
+		Spec: v1alpha3.ProvenanceSpec{
+			Resource: v1alpha3.Resource{
+				Kind:      o.GetObjectKind().GroupVersionKind().Kind,
+				Namespace: o.GetNamespace(),
+				Name:      o.GetName(),
+				UID:       o.GetUID(),
+			},
+			Record:       record,
+			Signed:       signed,
+			Transparency: annotations[chainsTransparency],
+			Attestations: attestations(annotations),
+		},
+	}
+
+	subjects := map[string]v1alpha3.Subject{}
+	addSubject := func(s v1alpha3.Subject) {
+		if len(s.Digest) == 0 {
+			return
+		}
+		subjects[s.Name+"@"+formatDigest(s.Digest)] = s
+	}
+	for i := range p.Spec.Attestations {
+		a := &p.Spec.Attestations[i]
+		st := statement(a)
+		if st == nil {
+			continue
+		}
+		a.PredicateType = st.PredicateType
+		for _, s := range st.Subject {
+			addSubject(s)
+		}
+	}
+	for _, s := range resultSubjects(o) {
+		addSubject(s)
+	}
+
+	keys := make([]string, 0, len(subjects))
+	for k := range subjects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := subjects[k]
+		p.Spec.Subjects = append(p.Spec.Subjects, s)
+		for alg, hex := range s.Digest {
+			p.Spec.Digests = append(p.Spec.Digests, alg+":"+strings.ToLower(hex))
+		}
+	}
+	slices.Sort(p.Spec.Digests)
+	p.Spec.Digests = slices.Compact(p.Spec.Digests)
+	return p
+}
+
+// attestations returns the attestations stored in the annotations of a run,
+// by key.
+func attestations(annotations map[string]string) []v1alpha3.Attestation {
+	byKey := map[string]*v1alpha3.Attestation{}
+	get := func(key string) *v1alpha3.Attestation {
+		a, ok := byKey[key]
+		if !ok {
+			a = &v1alpha3.Attestation{Key: key}
+			byKey[key] = a
+		}
+		return a
+	}
+	for k, v := range annotations {
+		switch {
+		case strings.HasPrefix(k, chainsPayloadPrefix):
+			get(strings.TrimPrefix(k, chainsPayloadPrefix)).Payload = decodeAnnotation(v)
+		case strings.HasPrefix(k, chainsSignaturePrefix):
+			get(strings.TrimPrefix(k, chainsSignaturePrefix)).Signature = decodeAnnotation(v)
+		case strings.HasPrefix(k, chainsCertificatePrefix):
+			get(strings.TrimPrefix(k, chainsCertificatePrefix)).Certificate = string(decodeAnnotation(v))
+		case strings.HasPrefix(k, chainsChainPrefix):
+			get(strings.TrimPrefix(k, chainsChainPrefix)).Chain = string(decodeAnnotation(v))
+		}
+	}
+
+	var out []v1alpha3.Attestation
+	for _, a := range byKey {
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// decodeAnnotation returns the value of an annotation of Chains, which are
+// base64 encoded, or the value itself if it isn't.
+func decodeAnnotation(v string) []byte {
+	if b, err := base64.StdEncoding.DecodeString(v); err == nil {
+		return b
+	}
+	return []byte(v)
+}
+
+// inTotoStatement holds the fields of in-toto statements used to index
+// attestations.
+type inTotoStatement struct {
+	Type          string             `json:"_type"`
+	PredicateType string             `json:"predicateType"`
+	Subject       []v1alpha3.Subject `json:"subject"`
+}
+
+// dsseEnvelope holds the fields of the DSSE envelopes signing in-toto
+// statements.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     []byte `json:"payload"`
+}
+
+// statement returns the in-toto statement signed by a, read from its payload
+// or from the envelope of its signature, or nil if a signs none.
+func statement(a *v1alpha3.Attestation) *inTotoStatement {
+	payload := a.Payload
+	if len(payload) == 0 {
+		env := &dsseEnvelope{}
+		if err := json.Unmarshal(a.Signature, env); err != nil {
+			return nil
+		}
+		payload = env.Payload
+	}
+	st := &inTotoStatement{}
+	if err := json.Unmarshal(payload, st); err != nil || !strings.HasPrefix(st.Type, inTotoStatementType) {
+		return nil
+	}
+	return st
+}
+
+// resultSubjects returns the artifacts declared by the type-hinted results of
+// a TaskRun or PipelineRun, as understood by Chains, so that artifacts can be
+// found even when Chains stores attestations outside of runs.
+func resultSubjects(o results.Object) []v1alpha3.Subject {
+	values := map[string]pipelinev1.ResultValue{}
+	switch obj := o.(type) {
+	case *pipelinev1.TaskRun:
+		for _, r := range obj.Status.Results {
+			values[r.Name] = r.Value
+		}
+	case *pipelinev1.PipelineRun:
+		for _, r := range obj.Status.Results {
+			values[r.Name] = r.Value
+		}
+	default:
+		return nil
+	}
+
+	var subjects []v1alpha3.Subject
+	for name, v := range values {
+		switch {
+		case strings.HasSuffix(name, "IMAGE_URL"):
+			digest := values[strings.TrimSuffix(name, "URL")+"DIGEST"]
+			subjects = append(subjects, subject(v.StringVal, digest.StringVal))
+		case strings.HasSuffix(name, "ARTIFACT_URI"):
+			digest := values[strings.TrimSuffix(name, "URI")+"DIGEST"]
+			subjects = append(subjects, subject(v.StringVal, digest.StringVal))
+		case strings.HasSuffix(name, "ARTIFACT_OUTPUTS"):
+			subjects = append(subjects, subject(v.ObjectVal["uri"], v.ObjectVal["digest"]))
+		case name == "IMAGES":
+			for _, ref := range strings.FieldsFunc(v.StringVal, func(r rune) bool {
+				return r == ',' || r == '\n'
+			}) {
+				name, digest, _ := strings.Cut(strings.TrimSpace(ref), "@")
+				subjects = append(subjects, subject(name, digest))
+			}
+		}
+	}
+	return subjects
+}
+
+// subject returns the subject of the artifact with the given name and digest,
+// formatted as algorithm:hex. The subject has no digest if digest is invalid.
+func subject(name, digest string) v1alpha3.Subject {
+	s := v1alpha3.Subject{Name: strings.TrimSpace(name)}
+	alg, hex, ok := strings.Cut(strings.TrimSpace(digest), ":")
+	if ok && alg != "" && hex != "" {
+		s.Digest = map[string]string{alg: hex}
+	}
+	return s
+}
+
+// formatDigest formats the digests of a subject as algorithm:hex, sorted by
+// algorithm.
+func formatDigest(digest map[string]string) string {
+	parts := make([]string, 0, len(digest))
+	for alg, hex := range digest {
+		parts = append(parts, alg+":"+hex)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
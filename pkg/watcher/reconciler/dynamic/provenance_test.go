@@ -0,0 +1,239 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	rtesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	"github.com/tektoncd/results/pkg/internal/test"
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/client"
+	watcherresults "github.com/tektoncd/results/pkg/watcher/results"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTest "k8s.io/client-go/kubernetes/fake"
+)
+
+const slsaStatement = `{
+	"_type": "https://in-toto.io/Statement/v0.1",
+	"predicateType": "https://slsa.dev/provenance/v0.2",
+	"subject": [{"name": "registry/app", "digest": {"sha256": "AAAA"}}],
+	"predicate": {"materials": [{"uri": "git+https://example.dev/app", "digest": {"sha1": "ffff"}}]}
+}`
+
+func TestProvenance(t *testing.T) {
+	b64 := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	envelope, err := json.Marshal(&dsseEnvelope{PayloadType: "application/vnd.in-toto+json", Payload: []byte(slsaStatement)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTaskRun := func(annotations map[string]string, results ...pipelinev1.TaskRunResult) *pipelinev1.TaskRun {
+		tr := &pipelinev1.TaskRun{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ns", UID: "12345", Annotations: annotations},
+		}
+		tr.Status.Results = results
+		return tr
+	}
+	stringResult := func(name, value string) pipelinev1.TaskRunResult {
+		return pipelinev1.TaskRunResult{Name: name, Value: *pipelinev1.NewStructuredValues(value)}
+	}
+	resource := v1alpha3.Resource{Kind: "TaskRun", Namespace: "ns", Name: "build", UID: "12345"}
+	appSubject := v1alpha3.Subject{Name: "registry/app", Digest: map[string]string{"sha256": "AAAA"}}
+
+	for _, tc := range []struct {
+		name string
+		tr   *pipelinev1.TaskRun
+		want *v1alpha3.Provenance
+	}{
+		{
+			name: "not processed by chains",
+			tr:   newTaskRun(nil, stringResult("IMAGE_URL", "registry/app"), stringResult("IMAGE_DIGEST", "sha256:aaaa")),
+		},
+		{
+			name: "attestation stored on the run",
+			tr: newTaskRun(map[string]string{
+				"chains.tekton.dev/signed":                  "true",
+				"chains.tekton.dev/transparency":            "https://rekor.example.dev/api/v1/log/entries?logIndex=1",
+				"chains.tekton.dev/payload-taskrun-12345":   b64(slsaStatement),
+				"chains.tekton.dev/signature-taskrun-12345": b64(string(envelope)),
+				"chains.tekton.dev/cert-taskrun-12345":      b64("CERT"),
+				"chains.tekton.dev/chain-taskrun-12345":     b64("CHAIN"),
+			}),
+			want: &v1alpha3.Provenance{Spec: v1alpha3.ProvenanceSpec{
+				Resource:     resource,
+				Record:       "ns/results/build/records/build",
+				Signed:       "true",
+				Transparency: "https://rekor.example.dev/api/v1/log/entries?logIndex=1",
+				Attestations: []v1alpha3.Attestation{{
+					Key:           "taskrun-12345",
+					PredicateType: "https://slsa.dev/provenance/v0.2",
+					Payload:       []byte(slsaStatement),
+					Signature:     envelope,
+					Certificate:   "CERT",
+					Chain:         "CHAIN",
+				}},
+				Subjects: []v1alpha3.Subject{appSubject},
+				Digests:  []string{"sha256:aaaa"},
+			}},
+		},
+		{
+			name: "statement read from the envelope",
+			tr: newTaskRun(map[string]string{
+				"chains.tekton.dev/signed":                  "true",
+				"chains.tekton.dev/signature-taskrun-12345": b64(string(envelope)),
+			}),
+			want: &v1alpha3.Provenance{Spec: v1alpha3.ProvenanceSpec{
+				Resource: resource,
+				Record:   "ns/results/build/records/build",
+				Signed:   "true",
+				Attestations: []v1alpha3.Attestation{{
+					Key:           "taskrun-12345",
+					PredicateType: "https://slsa.dev/provenance/v0.2",
+					Signature:     envelope,
+				}},
+				Subjects: []v1alpha3.Subject{appSubject},
+				Digests:  []string{"sha256:aaaa"},
+			}},
+		},
+		{
+			name: "attestation stored elsewhere",
+			tr: newTaskRun(map[string]string{"chains.tekton.dev/signed": "true"},
+				stringResult("IMAGE_URL", "registry/app"),
+				stringResult("IMAGE_DIGEST", "sha256:AAAA"),
+				stringResult("lib-ARTIFACT_URI", "pkg:maven/lib"),
+				stringResult("lib-ARTIFACT_DIGEST", "sha256:bbbb"),
+				pipelinev1.TaskRunResult{Name: "sbom-ARTIFACT_OUTPUTS", Value: *pipelinev1.NewObject(map[string]string{
+					"uri":    "registry/sbom",
+					"digest": "sha256:cccc",
+				})},
+				stringResult("IMAGES", "registry/a@sha256:dddd,\nregistry/b@sha256:eeee"),
+				// Results without digests are ignored.
+				stringResult("other-IMAGE_URL", "registry/other"),
+			),
+			want: &v1alpha3.Provenance{Spec: v1alpha3.ProvenanceSpec{
+				Resource: resource,
+				Record:   "ns/results/build/records/build",
+				Signed:   "true",
+				Subjects: []v1alpha3.Subject{
+					{Name: "pkg:maven/lib", Digest: map[string]string{"sha256": "bbbb"}},
+					{Name: "registry/a", Digest: map[string]string{"sha256": "dddd"}},
+					appSubject,
+					{Name: "registry/b", Digest: map[string]string{"sha256": "eeee"}},
+					{Name: "registry/sbom", Digest: map[string]string{"sha256": "cccc"}},
+				},
+				Digests: []string{"sha256:aaaa", "sha256:bbbb", "sha256:cccc", "sha256:dddd", "sha256:eeee"},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := provenance(tc.tr, "ns/results/build/records/build")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStoreProvenance(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	resultsClient, logsClient := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+
+	tr := &pipelinev1.TaskRun{
+		TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ns", UID: "12345", Annotations: map[string]string{
+			"chains.tekton.dev/signed":                "true",
+			"chains.tekton.dev/payload-taskrun-12345": base64.StdEncoding.EncodeToString([]byte(slsaStatement)),
+		}},
+	}
+	trclient := &client.TaskRunClient{TaskRunInterface: pipelineclient.Get(ctx).TektonV1().TaskRuns(tr.GetNamespace())}
+	if _, err := trclient.Create(ctx, tr, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewDynamicReconciler(k8sTest.NewSimpleClientset(), resultsClient, logsClient, trclient, &reconciler.Config{})
+	if err := r.storeProvenance(ctx, tr, "ns/results/12345/records/12345"); err != nil {
+		t.Fatal(err)
+	}
+
+	resultName := result.FormatName(tr.GetNamespace(), string(tr.GetUID()))
+	res, err := resultsClient.GetResult(ctx, &pb.GetResultRequest{Name: resultName})
+	if err != nil {
+		t.Fatalf("Error getting result: %v", err)
+	}
+	uid, err := uuid.Parse(res.GetUid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := resultsClient.GetRecord(ctx, &pb.GetRecordRequest{Name: watcherresults.FormatProvenanceName(resultName, uid, tr)})
+	if err != nil {
+		t.Fatalf("Error getting provenance record: %v", err)
+	}
+	if rec.GetData().GetType() != v1alpha3.ProvenanceRecordType {
+		t.Errorf("unexpected record type %q", rec.GetData().GetType())
+	}
+	got := &v1alpha3.Provenance{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Kind != "Provenance" || got.Spec.Record != "ns/results/12345/records/12345" || !cmp.Equal(got.Spec.Digests, []string{"sha256:aaaa"}) {
+		t.Errorf("unexpected provenance: %+v", got)
+	}
+
+	// The provenance isn't stored again until the Chains annotations change.
+	if tr.Annotations[annotation.Provenance] == "" {
+		t.Fatalf("missing %s annotation: %v", annotation.Provenance, tr.Annotations)
+	}
+	if _, err := resultsClient.DeleteRecord(ctx, &pb.DeleteRecordRequest{Name: rec.GetName()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.storeProvenance(ctx, tr, "ns/results/12345/records/12345"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resultsClient.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetRecord: want NotFound for unchanged annotations, got %v", err)
+	}
+	tr.Annotations["chains.tekton.dev/transparency"] = "https://rekor.example.dev/api/v1/log/entries?logIndex=1"
+	if err := r.storeProvenance(ctx, tr, "ns/results/12345/records/12345"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resultsClient.GetRecord(ctx, &pb.GetRecordRequest{Name: rec.GetName()}); err != nil {
+		t.Errorf("GetRecord: want provenance stored again for changed annotations, got %v", err)
+	}
+
+	// Runs not processed by Chains have no provenance.
+	tr.Annotations = nil
+	tr.UID = "67890"
+	if err := r.storeProvenance(ctx, tr, "ns/results/67890/records/67890"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resultsClient.GetResult(ctx, &pb.GetResultRequest{Name: result.FormatName("ns", "67890")}); err == nil {
+		t.Error("expected no result to be created for runs without provenance")
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/logs"
+	"github.com/tektoncd/results/pkg/tracing"
+	"github.com/tektoncd/results/pkg/watcher/results"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// LogSource is a Pod, or the Pods matching a label selector, whose container
+// logs make up the log of an object.
+type LogSource struct {
+	// Pod is the name of the Pod.
+	Pod string `json:"pod,omitempty"`
+	// Selector is a label selector of the Pods, used if Pod is empty.
+	Selector string `json:"selector,omitempty"`
+	// Container is the name of the container to read the logs of. The
+	// logs of all the containers of the Pods are read if it is empty.
+	Container string `json:"container,omitempty"`
+}
+
+// LogSources returns the sources of the logs of objects whose logs can't be
+// read by tkn, such as CustomRuns. Their logs are stored once they are done.
+type LogSources func(ctx context.Context, object results.Object) ([]LogSource, error)
+
+// ChildObjects returns the objects to store as Records under the Result of
+// the object being reconciled, such as objects created by the controller of a
+// CustomRun.
+type ChildObjects func(ctx context.Context, object results.Object) ([]results.ChildObject, error)
+
+// storeChildObjects stores the children of o under its Result, updating
+// their Records when they changed.
+func (r *Reconciler) storeChildObjects(ctx context.Context, o results.Object) error {
+	children, err := r.ChildObjectsFunc(ctx, o)
+	if err != nil {
+		return err
+	}
+	logger := logging.FromContext(ctx)
+	for _, child := range children {
+		rec, err := r.resultsClient.PutChild(ctx, o, child)
+		if err != nil {
+			return fmt.Errorf("error storing child object %s: %w", child.GetName(), err)
+		}
+		logger.Debugw("Child object stored", zap.String("name", child.GetName()), zap.String("results.tekton.dev/record", rec.GetName()))
+	}
+	return nil
+}
+
+// containerLog identifies the log of a container of a Pod.
+type containerLog struct {
+	pod       string
+	container string
+}
+
+// streamPodLogs sends the logs of the containers declared by sources to the
+// API server, one container after the other, skipping the first offset bytes
+// which were stored already. Each line is prefixed with the names of its Pod
+// and container.
+func (r *Reconciler) streamPodLogs(ctx context.Context, o results.Object, sources []LogSource, logName string, offset int64) (err error) {
+	ctx, span := tracing.Start(ctx, "Reconciler.streamPodLogs", append(tracing.ObjectAttributes(o),
+		attribute.String("results.tekton.dev/log", logName),
+		attribute.Int64("results.tekton.dev/log-offset", offset),
+	)...)
+	defer func() { tracing.End(span, err) }()
+
+	containers, pods, err := r.logSourceContainers(ctx, o, sources)
+	if err != nil {
+		return err
+	}
+	writer := logs.NewSegmentWriter(func() (logs.UpdateLogClient, error) {
+		logsClient, err := r.resultsClient.UpdateLog(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create UpdateLog client: %w", err)
+		}
+		return logsClient, nil
+	}, logName, logs.DefaultBufferSize, 0)
+	writer.Skip(offset)
+	if r.cfg != nil && r.cfg.LogsRedactSecrets {
+		writer.SetRedactionHints(r.podRedactionHints(ctx, o.GetNamespace(), pods))
+	}
+
+	var errs []error
+	for _, c := range containers {
+		if err := r.copyContainerLog(ctx, writer, o.GetNamespace(), c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// The log is ended even if reading failed, so that what was read is
+	// kept and readers stop following it.
+	return errors.Join(append(errs, writer.Close())...)
+}
+
+// logSourceContainers returns the containers whose logs are declared by
+// sources, in the order they are declared, along with their Pods. Pods
+// matching a selector are ordered by name, so that the log is always read in
+// the same order. Pods which don't exist anymore, and Pods which are not owned
+// by o, are skipped, so that objects can't declare the logs of Pods they
+// didn't create.
+func (r *Reconciler) logSourceContainers(ctx context.Context, o results.Object, sources []LogSource) ([]containerLog, []v1.Pod, error) {
+	logger := logging.FromContext(ctx)
+	podsClient := r.KubeClientSet.CoreV1().Pods(o.GetNamespace())
+	var (
+		containers []containerLog
+		pods       []v1.Pod
+	)
+	seenPods := map[string]bool{}
+	seenContainers := map[containerLog]bool{}
+	for _, source := range sources {
+		var matches []v1.Pod
+		switch {
+		case source.Pod != "":
+			pod, err := podsClient.Get(ctx, source.Pod, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				logger.Warnw("Pod of log source not found", zap.String("pod", source.Pod))
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("error getting pod %s: %w", source.Pod, err)
+			}
+			matches = []v1.Pod{*pod}
+		case source.Selector != "":
+			list, err := podsClient.List(ctx, metav1.ListOptions{LabelSelector: source.Selector})
+			if err != nil {
+				return nil, nil, fmt.Errorf("error listing pods matching %q: %w", source.Selector, err)
+			}
+			matches = list.Items
+			slices.SortFunc(matches, func(a, b v1.Pod) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+		default:
+			logger.Warn("Skipping log source without pod nor selector")
+			continue
+		}
+
+		for _, pod := range matches {
+			if !OwnedBy(&pod, o.GetUID()) {
+				logger.Warnw("Skipping pod of log source which is not owned by the object", zap.String("pod", pod.Name))
+				continue
+			}
+			if !seenPods[pod.Name] {
+				seenPods[pod.Name] = true
+				pods = append(pods, pod)
+			}
+			for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+				if source.Container != "" && c.Name != source.Container {
+					continue
+				}
+				cl := containerLog{pod: pod.Name, container: c.Name}
+				if !seenContainers[cl] {
+					seenContainers[cl] = true
+					containers = append(containers, cl)
+				}
+			}
+		}
+	}
+	return containers, pods, nil
+}
+
+// copyContainerLog writes the log of a container to w, prefixing each line
+// like tkn does for the steps of TaskRuns.
+func (r *Reconciler) copyContainerLog(ctx context.Context, w io.Writer, namespace string, c containerLog) error {
+	stream, err := r.KubeClientSet.CoreV1().Pods(namespace).GetLogs(c.pod, &v1.PodLogOptions{
+		Container:  c.container,
+		Timestamps: r.cfg != nil && r.cfg.LogsTimestamps,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading logs of container %s of pod %s: %w", c.container, c.pod, err)
+	}
+	defer stream.Close()
+
+	prefix := fmt.Sprintf("[%s : %s] ", c.pod, c.container)
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if line[len(line)-1] != '\n' {
+				line += "\n"
+			}
+			if _, err := io.WriteString(w, prefix+line); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading logs of container %s of pod %s: %w", c.container, c.pod, err)
+		}
+	}
+}
+
+// OwnedBy tells whether o has an owner reference to the object with the given
+// UID.
+func OwnedBy(o metav1.Object, uid types.UID) bool {
+	return slices.ContainsFunc(o.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == uid
+	})
+}
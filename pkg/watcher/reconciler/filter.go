@@ -20,6 +20,8 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 )
@@ -60,6 +62,24 @@ func ParseManagedByValues(raw string) sets.Set[string] {
 	return s
 }
 
+// ParseKinds parses a comma-separated list of kinds, formatted as Kind.group,
+// or Kind for the core group, into a set.
+func ParseKinds(raw string) sets.Set[string] {
+	s := sets.New[string]()
+	for _, v := range strings.Split(raw, ",") {
+		if trimmed := strings.TrimSpace(v); trimmed != "" {
+			s.Insert(schema.ParseGroupKind(trimmed).String())
+		}
+	}
+	return s
+}
+
+// IsRestored reports whether the labels are those of a run restored from its
+// Records, which must not be stored again.
+func IsRestored(labels map[string]string) bool {
+	return labels[annotation.Restored] == "true"
+}
+
 // unwrapTombstone extracts the wrapped object from a
 // cache.DeletedFinalStateUnknown tombstone. If obj is not a tombstone
 // it is returned as-is.
@@ -72,6 +92,7 @@ func unwrapTombstone(obj interface{}) interface{} {
 
 // PipelineRunFilterFunc returns a filter function for PipelineRuns
 // that checks whether the run's spec.managedBy value is allowed.
+// Runs restored from their Records are filtered out.
 // Tombstone (DeletedFinalStateUnknown) objects are unwrapped before
 // the type assertion so that delete events are never silently dropped.
 // Objects being deleted (DeletionTimestamp set) that carry a Results
@@ -86,12 +107,16 @@ func PipelineRunFilterFunc(allowedManagedBy sets.Set[string]) func(obj interface
 		if pr.DeletionTimestamp != nil && slices.Contains(pr.Finalizers, PipelineRunFinalizer) {
 			return true
 		}
+		if IsRestored(pr.Labels) {
+			return false
+		}
 		return IsManagedByAllowed(pr.Spec.ManagedBy, allowedManagedBy)
 	}
 }
 
 // TaskRunFilterFunc returns a filter function for TaskRuns
 // that checks whether the run's spec.managedBy value is allowed.
+// Runs restored from their Records are filtered out.
 // Tombstone (DeletedFinalStateUnknown) objects are unwrapped before
 // the type assertion so that delete events are never silently dropped.
 // Objects being deleted (DeletionTimestamp set) that carry a Results
@@ -106,6 +131,9 @@ func TaskRunFilterFunc(allowedManagedBy sets.Set[string]) func(obj interface{})
 		if tr.DeletionTimestamp != nil && slices.Contains(tr.Finalizers, TaskRunFinalizer) {
 			return true
 		}
+		if IsRestored(tr.Labels) {
+			return false
+		}
 		return IsManagedByAllowed(tr.Spec.ManagedBy, allowedManagedBy)
 	}
 }
@@ -155,6 +155,34 @@ func TestParseManagedByValues(t *testing.T) {
 	}
 }
 
+func TestParseKinds(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected sets.Set[string]
+	}{
+		{
+			name:     "empty string",
+			raw:      "",
+			expected: sets.New[string](),
+		},
+		{
+			name:     "kinds of core and other groups with whitespace",
+			raw:      "Approval.example.dev, ConfigMap,",
+			expected: sets.New[string]("Approval.example.dev", "ConfigMap"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseKinds(tt.raw)
+			if !result.Equal(tt.expected) {
+				t.Errorf("ParseKinds(%q) = %v, wanted %v", tt.raw, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestPipelineRunFilterFunc(t *testing.T) {
 	defaultAllowed := sets.New[string]("tekton.dev/pipeline")
 
@@ -286,6 +314,30 @@ func TestPipelineRunFilterFunc(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:    "restored PipelineRun, should not match",
+			allowed: defaultAllowed,
+			obj: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{"results.tekton.dev/restored": "true"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:    "restored PipelineRun being deleted with Results finalizer, should match",
+			allowed: defaultAllowed,
+			obj: &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "default",
+					Labels:            map[string]string{"results.tekton.dev/restored": "true"},
+					Finalizers:        []string{PipelineRunFinalizer},
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -430,6 +482,30 @@ func TestTaskRunFilterFunc(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:    "restored TaskRun, should not match",
+			allowed: defaultAllowed,
+			obj: &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{"results.tekton.dev/restored": "true"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:    "restored TaskRun being deleted with Results finalizer, should match",
+			allowed: defaultAllowed,
+			obj: &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "default",
+					Labels:            map[string]string{"results.tekton.dev/restored": "true"},
+					Finalizers:        []string{TaskRunFinalizer},
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,117 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	k8sdynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+	knativereconciler "knative.dev/pkg/reconciler"
+)
+
+// NewControllerWithConfig creates a Controller for watching the objects of the
+// kind configured by resource, through a dynamic informer.
+func NewControllerWithConfig(ctx context.Context, resultsClient pb.ResultsClient, cfg *reconciler.Config, resource *Resource) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	kubeClientSet := kubeclient.Get(ctx)
+	dynamicClient := k8sdynamic.NewForConfigOrDie(injection.GetConfig(ctx))
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClientSet.Discovery()))
+	gvr, err := groupVersionResource(mapper, resource)
+	if err != nil {
+		logger.Panicf("Couldn't find resource of watched kind %s: %v", resource.GroupVersionKind(), err)
+	}
+	informer := dynamicinformer.NewFilteredDynamicInformer(dynamicClient, gvr, injection.GetNamespaceScope(ctx),
+		controller.GetResyncPeriod(ctx), cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+
+	// Objects of watched kinds are owned by other controllers and may live
+	// much longer than Runs, so they are never deleted.
+	resourceCfg := *cfg
+	resourceCfg.CompletedResourceGracePeriod = 0
+
+	c := &Reconciler{
+		kubeClientSet: kubeClientSet,
+		resultsClient: resultsClient,
+		dynamicClient: dynamicClient.Resource(gvr),
+		lister:        informer.Lister(),
+		resource:      resource,
+		cfg:           &resourceCfg,
+	}
+	c.LeaderAwareFuncs = knativereconciler.LeaderAwareFuncs{
+		PromoteFunc: func(bkt knativereconciler.Bucket, enq func(knativereconciler.Bucket, types.NamespacedName)) error {
+			all, err := c.lister.List(labels.Everything())
+			if err != nil {
+				return err
+			}
+			for _, elt := range all {
+				m, err := meta.Accessor(elt)
+				if err != nil {
+					return err
+				}
+				enq(bkt, types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()})
+			}
+			return nil
+		},
+	}
+
+	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
+		WorkQueueName: fmt.Sprintf("results-%s.%s", strings.ToLower(resource.Kind), gvr.Group),
+		Logger:        logger,
+	})
+
+	_, err = informer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	if err != nil {
+		logger.Panicf("Couldn't register %s informer event handler: %v", resource.Kind, err)
+	}
+	// Dynamic informers aren't injected, so they aren't started along with
+	// the informers of the other controllers.
+	go informer.Informer().Run(ctx.Done())
+
+	return impl
+}
+
+// groupVersionResource returns the resource of the objects configured by
+// resource, discovering it from the API server if it isn't configured. Only
+// namespaced kinds can be watched, as Results are stored by namespace.
+func groupVersionResource(mapper meta.RESTMapper, resource *Resource) (schema.GroupVersionResource, error) {
+	gvk := resource.GroupVersionKind()
+	if resource.Resource != "" {
+		return gvk.GroupVersion().WithResource(resource.Resource), nil
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return schema.GroupVersionResource{}, fmt.Errorf("%s is not namespaced", gvk)
+	}
+	return mapping.Resource, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"strings"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/watcher/results"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/apis"
+)
+
+// Object is an object of a watched kind. Its status, start and end times and
+// Result are derived as configured by the Resource of its kind.
+type Object struct {
+	*unstructured.Unstructured
+	resource *Resource
+}
+
+var (
+	_ results.Object      = (*Object)(nil)
+	_ results.TimesGetter = (*Object)(nil)
+	_ results.ResultNamer = (*Object)(nil)
+)
+
+// NewObject returns the Object of u, of the kind configured by resource.
+func NewObject(u *unstructured.Unstructured, resource *Resource) *Object {
+	return &Object{Unstructured: u, resource: resource}
+}
+
+// GetStatusCondition implements results.StatusConditionGetter.
+func (o *Object) GetStatusCondition() apis.ConditionAccessor {
+	return o
+}
+
+// GetCondition implements apis.ConditionAccessor. Only the Succeeded
+// condition is derived, with the reasons of TaskRuns, so that the status of
+// the object is summarized like the status of Runs.
+func (o *Object) GetCondition(t apis.ConditionType) *apis.Condition {
+	if t != apis.ConditionSucceeded {
+		return nil
+	}
+	c := &apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: pipelinev1.TaskRunReasonRunning.String(),
+	}
+	var done, succeeded bool
+	if o.resource.Condition != "" {
+		conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+		for _, raw := range conditions {
+			m, ok := raw.(map[string]any)
+			if !ok || m["type"] != o.resource.Condition {
+				continue
+			}
+			c.Message, _ = m["message"].(string)
+			status, _ := m["status"].(string)
+			done = status == string(corev1.ConditionTrue) || status == string(corev1.ConditionFalse)
+			succeeded = status == string(corev1.ConditionTrue)
+			break
+		}
+	} else if phase, err := read(o.resource.Phase, o.Object); err == nil {
+		done, succeeded = o.resource.phaseStatus(phase)
+	}
+
+	switch {
+	case done && succeeded:
+		c.Status = corev1.ConditionTrue
+		c.Reason = pipelinev1.TaskRunReasonSuccessful.String()
+	case done:
+		c.Status = corev1.ConditionFalse
+		c.Reason = pipelinev1.TaskRunReasonFailed.String()
+	}
+	return c
+}
+
+// IsDone tells whether the object completed.
+func (o *Object) IsDone() bool {
+	return !o.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// GetStartTime implements results.TimesGetter.
+func (o *Object) GetStartTime() *metav1.Time {
+	return o.time(o.resource.StartTime)
+}
+
+// GetCompletionTime implements results.TimesGetter.
+func (o *Object) GetCompletionTime() *metav1.Time {
+	return o.time(o.resource.EndTime)
+}
+
+func (o *Object) time(p string) *metav1.Time {
+	v, err := read(p, o.Object)
+	if err != nil || v == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
+// ResultName implements results.ResultNamer.
+func (o *Object) ResultName() string {
+	v, err := read(o.resource.Result, o.Object)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(v)
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/apis"
+)
+
+func TestObject_GetCondition(t *testing.T) {
+	buildRun := &Resource{APIVersion: "shipwright.io/v1beta1", Kind: "BuildRun", Condition: "Succeeded"}
+	rollout := &Resource{
+		APIVersion:    "argoproj.io/v1alpha1",
+		Kind:          "Rollout",
+		Phase:         "{.status.phase}",
+		SuccessPhases: []string{"Healthy"},
+		FailurePhases: []string{"Degraded"},
+	}
+	for _, tc := range []struct {
+		name       string
+		resource   *Resource
+		status     map[string]any
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no condition",
+			resource:   buildRun,
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Running",
+		},
+		{
+			name:     "condition succeeded",
+			resource: buildRun,
+			status: map[string]any{"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False"},
+				map[string]any{"type": "Succeeded", "status": "True"},
+			}},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "Succeeded",
+		},
+		{
+			name:     "condition failed",
+			resource: buildRun,
+			status: map[string]any{"conditions": []any{
+				map[string]any{"type": "Succeeded", "status": "False", "message": "build failed"},
+			}},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "Failed",
+		},
+		{
+			name:       "phase running",
+			resource:   rollout,
+			status:     map[string]any{"phase": "Progressing"},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Running",
+		},
+		{
+			name:       "phase succeeded",
+			resource:   rollout,
+			status:     map[string]any{"phase": "Healthy"},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: "Succeeded",
+		},
+		{
+			name:       "phase failed",
+			resource:   rollout,
+			status:     map[string]any{"phase": "Degraded"},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "Failed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]any{}}
+			if tc.status != nil {
+				u.Object["status"] = tc.status
+			}
+			o := NewObject(u, tc.resource)
+			c := o.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
+			if c.Status != tc.wantStatus || c.Reason != tc.wantReason {
+				t.Errorf("want %s/%s, got %s/%s", tc.wantStatus, tc.wantReason, c.Status, c.Reason)
+			}
+			if o.IsDone() != (tc.wantStatus != corev1.ConditionUnknown) {
+				t.Errorf("IsDone() = %t", o.IsDone())
+			}
+			if c := o.GetStatusCondition().GetCondition(apis.ConditionReady); c != nil {
+				t.Errorf("expected no Ready condition, got %v", c)
+			}
+		})
+	}
+}
+
+func TestObject_TimesAndResultName(t *testing.T) {
+	resource := &Resource{APIVersion: "shipwright.io/v1beta1", Kind: "BuildRun"}
+	if err := resource.validate(); err != nil {
+		t.Fatal(err)
+	}
+	resource.Result = "{.metadata.labels.app}"
+
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{"app": "frontend"}},
+		"status": map[string]any{
+			"startTime":      "2026-01-02T03:04:05Z",
+			"completionTime": "not a time",
+		},
+	}}
+	o := NewObject(u, resource)
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := o.GetStartTime(); got == nil || !got.Time.Equal(want) {
+		t.Errorf("GetStartTime() = %v, want %v", got, want)
+	}
+	if got := o.GetCompletionTime(); got != nil {
+		t.Errorf("GetCompletionTime() = %v, want nil", got)
+	}
+	if got := o.ResultName(); got != "frontend" {
+		t.Errorf("ResultName() = %q, want frontend", got)
+	}
+
+	unstructured.RemoveNestedField(u.Object, "metadata", "labels")
+	if got := o.ResultName(); got != "" {
+		t.Errorf("ResultName() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/client"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/dynamic"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sdynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	knativereconciler "knative.dev/pkg/reconciler"
+)
+
+// Reconciler stores the objects of a watched kind.
+type Reconciler struct {
+	// LeaderAwareFuncs is inlined to help us implement
+	// knativereconciler.LeaderAware.
+	knativereconciler.LeaderAwareFuncs
+
+	// kubeClientSet allows us to talk to the k8s for core APIs
+	kubeClientSet kubernetes.Interface
+
+	resultsClient pb.ResultsClient
+	dynamicClient k8sdynamic.NamespaceableResourceInterface
+	lister        cache.GenericLister
+	resource      *Resource
+	cfg           *reconciler.Config
+}
+
+// Check that our Reconciler implements controller.Reconciler and
+// knativereconciler.LeaderAware.
+var _ controller.Reconciler = (*Reconciler)(nil)
+var _ knativereconciler.LeaderAware = (*Reconciler)(nil)
+
+// Reconcile makes new watcher reconcile cycle to handle the object with the
+// given key.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx).With(zap.String("results.tekton.dev/kind", r.resource.Kind))
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+	if !r.IsLeaderFor(types.NamespacedName{Namespace: namespace, Name: name}) {
+		return controller.NewSkipKey(key)
+	}
+
+	obj, err := r.lister.ByNamespace(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		logger.Debugf("%s %s no longer exists", r.resource.Kind, key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return controller.NewPermanentError(fmt.Errorf("expected unstructured object, got %T", obj))
+	}
+	// Don't modify the informers copy.
+	o := NewObject(u.DeepCopy(), r.resource)
+
+	if r.cfg.DisableStoringIncompleteRuns && !o.IsDone() {
+		logger.Debugf("%s %s is not done and incomplete runs are disabled, skipping storing", r.resource.Kind, key)
+		return nil
+	}
+
+	objectClient := &client.DynamicClient{
+		ResourceInterface: r.dynamicClient.Namespace(namespace),
+	}
+	// Objects of watched kinds are executed by other controllers, so they
+	// have no logs.
+	dyn := dynamic.NewDynamicReconciler(r.kubeClientSet, r.resultsClient, nil, objectClient, r.cfg)
+	return dyn.Reconcile(logging.WithLogger(ctx, logger), o)
+}
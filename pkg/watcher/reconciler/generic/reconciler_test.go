@@ -0,0 +1,117 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/results/pkg/api/server/config"
+	"github.com/tektoncd/results/pkg/internal/test"
+	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sTest "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+	knativereconciler "knative.dev/pkg/reconciler"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	resultsClient, _ := test.NewResultsClient(t, &config.Config{LOGS_PATH: t.TempDir()})
+
+	resource := &Resource{
+		APIVersion: "shipwright.io/v1beta1",
+		Kind:       "BuildRun",
+		Result:     "{.metadata.labels.app}",
+	}
+	if err := resource.validate(); err != nil {
+		t.Fatal(err)
+	}
+	gvr := schema.GroupVersionResource{Group: "shipwright.io", Version: "v1beta1", Resource: "buildruns"}
+
+	newBuildRun := func(name, status string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "shipwright.io/v1beta1",
+			"kind":       "BuildRun",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": "ns",
+				"uid":       name + "-uid",
+				"labels":    map[string]any{"app": "frontend"},
+			},
+			"status": map[string]any{
+				"startTime":  "2026-01-02T03:04:05Z",
+				"conditions": []any{map[string]any{"type": "Succeeded", "status": status}},
+			},
+		}}
+	}
+	done := newBuildRun("done", "True")
+	running := newBuildRun("running", "Unknown")
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, o := range []*unstructured.Unstructured{done, running} {
+		if err := indexer.Add(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Reconciler{
+		kubeClientSet: k8sTest.NewSimpleClientset(),
+		resultsClient: resultsClient,
+		dynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{gvr: "BuildRunList"}, done, running).Resource(gvr),
+		lister:   cache.NewGenericLister(indexer, gvr.GroupResource()),
+		resource: resource,
+		cfg: &reconciler.Config{
+			DisableAnnotationUpdate:      true,
+			DisableStoringIncompleteRuns: true,
+		},
+	}
+	if err := r.Promote(knativereconciler.UniversalBucket(), func(knativereconciler.Bucket, types.NamespacedName) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"ns/done", "ns/running", "ns/missing"} {
+		if err := r.Reconcile(ctx, key); err != nil {
+			t.Fatalf("Reconcile(%s): %v", key, err)
+		}
+	}
+
+	res, err := resultsClient.GetResult(ctx, &pb.GetResultRequest{Name: "ns/results/frontend"})
+	if err != nil {
+		t.Fatalf("expected Result named after the app label: %v", err)
+	}
+	if res.GetSummary().GetStatus() != pb.RecordSummary_SUCCESS {
+		t.Errorf("expected successful Result summary, got %v", res.GetSummary())
+	}
+	records, err := resultsClient.ListRecords(ctx, &pb.ListRecordsRequest{Parent: res.GetName()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Incomplete objects are skipped.
+	if len(records.GetRecords()) != 1 {
+		t.Fatalf("expected 1 Record, got %v", records.GetRecords())
+	}
+	if got := records.GetRecords()[0].GetData().GetType(); got != "shipwright.io/v1beta1.BuildRun" {
+		t.Errorf("expected Record of type shipwright.io/v1beta1.BuildRun, got %s", got)
+	}
+}
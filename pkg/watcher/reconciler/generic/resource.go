@@ -0,0 +1,167 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generic provides a reconciler storing objects of arbitrary kinds,
+// such as Shipwright BuildRuns or Argo Rollouts, as configured by operators.
+package generic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultCondition = "Succeeded"
+	defaultStartTime = "{.status.startTime}"
+	defaultEndTime   = "{.status.completionTime}"
+)
+
+// Resource configures how the objects of a kind are stored. Fields reading
+// values from the objects are JSONPath expressions, as supported by kubectl.
+type Resource struct {
+	// APIVersion and Kind of the objects, e.g. shipwright.io/v1beta1 and
+	// BuildRun.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Resource is the plural name of the kind in the Kubernetes API, e.g.
+	// buildruns. It is discovered from the API server if empty.
+	Resource string `json:"resource,omitempty"`
+
+	// Condition is the type of the status condition telling whether the
+	// objects completed and succeeded. It defaults to Succeeded, unless
+	// Phase is set.
+	Condition string `json:"condition,omitempty"`
+	// Phase reads the phase of the objects, e.g. {.status.phase}, which
+	// tells they completed once it is one of SuccessPhases or
+	// FailurePhases.
+	Phase         string   `json:"phase,omitempty"`
+	SuccessPhases []string `json:"successPhases,omitempty"`
+	FailurePhases []string `json:"failurePhases,omitempty"`
+
+	// StartTime and EndTime read the times the objects started and
+	// completed, in RFC 3339 format. They default to {.status.startTime}
+	// and {.status.completionTime}.
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+
+	// Result reads the name of the Result the objects are stored under,
+	// e.g. {.metadata.labels.app}, so that related objects are stored
+	// together. Objects are stored under the Result of the PipelineRun
+	// owning them, or their own, if it is empty or reads no value.
+	Result string `json:"result,omitempty"`
+}
+
+type resources struct {
+	Resources []*Resource `json:"resources"`
+}
+
+// LoadResources reads the Resources stored in the YAML file at path. The
+// file is typically mounted from a ConfigMap.
+func LoadResources(path string) ([]*Resource, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := new(resources)
+	if err := yaml.UnmarshalStrict(b, r); err != nil {
+		return nil, fmt.Errorf("error parsing watched resources from %s: %w", path, err)
+	}
+	var errs []error
+	for i, res := range r.Resources {
+		if err := res.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("resource %d: %w", i, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid watched resources in %s: %w", path, err)
+	}
+	return r.Resources, nil
+}
+
+// GroupVersionKind returns the GroupVersionKind of the objects.
+func (r *Resource) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+}
+
+// validate checks r and sets its defaults.
+func (r *Resource) validate() error {
+	if r.APIVersion == "" || r.Kind == "" {
+		return errors.New("apiVersion and kind are required")
+	}
+	if _, err := schema.ParseGroupVersion(r.APIVersion); err != nil {
+		return fmt.Errorf("invalid apiVersion: %w", err)
+	}
+	switch {
+	case r.Condition != "" && r.Phase != "":
+		return errors.New("condition and phase are mutually exclusive")
+	case r.Phase != "" && len(r.SuccessPhases)+len(r.FailurePhases) == 0:
+		return errors.New("phase requires successPhases or failurePhases")
+	case r.Phase == "" && len(r.SuccessPhases)+len(r.FailurePhases) > 0:
+		return errors.New("successPhases and failurePhases require phase")
+	case r.Phase == "" && r.Condition == "":
+		r.Condition = defaultCondition
+	}
+	if r.StartTime == "" {
+		r.StartTime = defaultStartTime
+	}
+	if r.EndTime == "" {
+		r.EndTime = defaultEndTime
+	}
+	for _, p := range []string{r.Phase, r.StartTime, r.EndTime, r.Result} {
+		if p == "" {
+			continue
+		}
+		if err := jsonpath.New("").Parse(p); err != nil {
+			return fmt.Errorf("invalid JSONPath %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// read returns the value the JSONPath expression p reads from obj, or an
+// empty string if it reads none. Expressions are parsed for each read, as
+// parsed expressions can't be evaluated concurrently.
+func read(p string, obj map[string]any) (string, error) {
+	if p == "" {
+		return "", nil
+	}
+	j := jsonpath.New("").AllowMissingKeys(true)
+	if err := j.Parse(p); err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := j.Execute(&b, obj); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// phaseStatus tells whether phase is one of the phases of completed objects,
+// and whether it is one of success.
+func (r *Resource) phaseStatus(phase string) (done, succeeded bool) {
+	switch {
+	case slices.Contains(r.SuccessPhases, phase):
+		return true, true
+	case slices.Contains(r.FailurePhases, phase):
+		return true, false
+	}
+	return false, false
+}
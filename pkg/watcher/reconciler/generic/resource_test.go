@@ -0,0 +1,154 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadResources(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		yaml    string
+		want    []*Resource
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			yaml: `
+resources:
+- apiVersion: shipwright.io/v1beta1
+  kind: BuildRun
+`,
+			want: []*Resource{{
+				APIVersion: "shipwright.io/v1beta1",
+				Kind:       "BuildRun",
+				Condition:  "Succeeded",
+				StartTime:  "{.status.startTime}",
+				EndTime:    "{.status.completionTime}",
+			}},
+		},
+		{
+			name: "phase",
+			yaml: `
+resources:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Rollout
+  resource: rollouts
+  phase: "{.status.phase}"
+  successPhases: [Healthy]
+  failurePhases: [Degraded]
+  startTime: "{.metadata.creationTimestamp}"
+  endTime: "{.status.conditions[?(@.type==\"Completed\")].lastTransitionTime}"
+  result: "{.metadata.labels.app}"
+`,
+			want: []*Resource{{
+				APIVersion:    "argoproj.io/v1alpha1",
+				Kind:          "Rollout",
+				Resource:      "rollouts",
+				Phase:         "{.status.phase}",
+				SuccessPhases: []string{"Healthy"},
+				FailurePhases: []string{"Degraded"},
+				StartTime:     "{.metadata.creationTimestamp}",
+				EndTime:       `{.status.conditions[?(@.type=="Completed")].lastTransitionTime}`,
+				Result:        "{.metadata.labels.app}",
+			}},
+		},
+		{
+			name: "unknown field",
+			yaml: `
+resources:
+- apiVersion: shipwright.io/v1beta1
+  kind: BuildRun
+  conditions: Succeeded
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing kind",
+			yaml: `
+resources:
+- apiVersion: shipwright.io/v1beta1
+`,
+			wantErr: true,
+		},
+		{
+			name: "condition and phase",
+			yaml: `
+resources:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Rollout
+  condition: Available
+  phase: "{.status.phase}"
+  successPhases: [Healthy]
+`,
+			wantErr: true,
+		},
+		{
+			name: "phase without phases",
+			yaml: `
+resources:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Rollout
+  phase: "{.status.phase}"
+`,
+			wantErr: true,
+		},
+		{
+			name: "phases without phase",
+			yaml: `
+resources:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Rollout
+  successPhases: [Healthy]
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid JSONPath",
+			yaml: `
+resources:
+- apiVersion: shipwright.io/v1beta1
+  kind: BuildRun
+  result: "{.metadata.labels"
+`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "resources.yaml")
+			if err := os.WriteFile(path, []byte(tc.yaml), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadResources(path)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/results/pkg/pipelinerunmetrics"
 	"github.com/tektoncd/results/pkg/watcher/logs"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/dynamic"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -67,6 +68,7 @@ func NewControllerWithConfig(ctx context.Context, resultsClient pb.ResultsClient
 		pipelineClient:     pipelineclient.Get(ctx),
 		cfg:                cfg,
 		configStore:        configStore,
+		followers:          dynamic.NewFollowers(ctx),
 		metrics:            metrics.NewRecorder(),
 		pipelineRunMetrics: pipelineRunMetrics,
 	}
@@ -63,6 +63,9 @@ type Reconciler struct {
 	metrics            *metrics.Recorder
 	pipelineRunMetrics *pipelinerunmetrics.Recorder
 	configStore        *config.Store
+	// followers is shared by reconciliations, so that the logs of each
+	// executing Run are followed once, until the controller stops.
+	followers *dynamic.Followers
 }
 
 // Check that our Reconciler implements pipelinerunreconciler.Interface and pipelinerunreconciler.Finalizer
@@ -101,6 +104,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, pr *pipelinev1.PipelineR
 	}
 
 	dyn := dynamic.NewDynamicReconciler(r.kubeClientSet, r.resultsClient, r.logsClient, pipelineRunClient, r.cfg)
+	if r.followers != nil {
+		dyn.Followers = r.followers
+	}
 	// Tell the reconciler to wait until all underlying TaskRuns and CustomRuns
 	// are ready for deletion before deleting the PipelineRun. This guarantees
 	// that the TaskRuns will not be deleted before their final state being
@@ -45,6 +45,9 @@ type Reconciler struct {
 	metrics        *metrics.Recorder
 	taskRunMetrics *taskrunmetrics.Recorder
 	configStore    *config.Store
+	// followers is shared by reconciliations, so that the logs of each
+	// executing Run are followed once, until the controller stops.
+	followers *dynamic.Followers
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface and taskrunreconciler.Finalizer
@@ -81,6 +84,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, tr *pipelinev1.TaskRun)
 	}
 
 	dyn := dynamic.NewDynamicReconciler(r.kubeClientSet, r.resultsClient, r.logsClient, taskRunClient, r.cfg)
+	if r.followers != nil {
+		dyn.Followers = r.followers
+	}
 	dyn.AfterDeletion = func(ctx context.Context, object results.Object) error {
 		tr, ok := object.(*pipelinev1.TaskRun)
 		if !ok {
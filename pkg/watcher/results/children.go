@@ -0,0 +1,61 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/watcher/convert"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ChildObject is an object stored as a Record under the Result of another
+// Object, such as an object created by the controller of a CustomRun. Unlike
+// Object, it may be of any kind.
+type ChildObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// PutChild adds the given child of the Object to the Results API, under the
+// Result of the Object. If the Result is missing, it is created
+// automatically. The Record of the child is updated if its data changed.
+func (c *Client) PutChild(ctx context.Context, o Object, child ChildObject, opts ...grpc.CallOption) (*pb.Record, error) {
+	res, err := c.ensureResult(ctx, o, opts...)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(res.GetUid())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing UID of result %s: %w", res.GetName(), err)
+	}
+	data, err := convert.ToProto(child)
+	if err != nil {
+		return nil, err
+	}
+	return c.upsertRecordData(ctx, res.GetName(), FormatChildName(res.GetName(), uid, child), data, opts...)
+}
+
+// FormatChildName generates the record name of a child object given the
+// name and UUID of the Result it is stored under.
+func FormatChildName(resultName string, resultUID uuid.UUID, child metav1.Object) string {
+	return record.FormatName(resultName, uuid.NewMD5(resultUID, []byte(child.GetUID()+"child")).String())
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/log"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	"github.com/tektoncd/results/pkg/watcher/convert"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
@@ -52,6 +54,46 @@ func (c *Client) createLogRecord(ctx context.Context, result *pb.Result, o Objec
 	})
 }
 
+// PutStepLog adds a log record for the given step of the Object to the
+// Results API, or returns the existing one.
+func (c *Client) PutStepLog(ctx context.Context, o Object, step *v1alpha3.LogStep, opts ...grpc.CallOption) (*pb.Record, error) {
+	res, err := c.ensureResult(ctx, o, opts...)
+	if err != nil {
+		return nil, err
+	}
+	logName, err := getLogRecordName(res, o)
+	if err != nil {
+		return nil, err
+	}
+	parent, resName, logUID, err := record.ParseName(logName)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(logUID)
+	if err != nil {
+		return nil, err
+	}
+	name := record.FormatName(result.FormatName(parent, resName), uuid.NewMD5(uid, []byte(step.Name)).String())
+	rec, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: name}, opts...)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	if rec != nil {
+		return rec, nil
+	}
+	data, err := convert.ToStepLogProto(o, o.GetObjectKind().GroupVersionKind().Kind, name, step)
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateRecord(ctx, &pb.CreateRecordRequest{
+		Parent: res.GetName(),
+		Record: &pb.Record{
+			Name: name,
+			Data: data,
+		},
+	})
+}
+
 // getLogRecordName gets the log name to use for the given object.
 // The name is derived from a known Tekton annotation if available, else
 // the object's UID is used to create MD5 UUID.
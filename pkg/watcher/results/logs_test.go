@@ -2,10 +2,12 @@ package results
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -67,3 +69,46 @@ func TestClient_PutLog(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_PutStepLog(t *testing.T) {
+	ctx := context.Background()
+	c := client(t)
+
+	o := &pipelinev1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun",
+			Namespace: "test",
+			UID:       "taskrun-id",
+		},
+	}
+	var names []string
+	for _, step := range []string{"build", "build", "test"} {
+		rec, err := c.PutStepLog(ctx, o, &v1alpha3.LogStep{Name: step})
+		if err != nil {
+			t.Fatalf("PutStepLog(%s): %v", step, err)
+		}
+		names = append(names, rec.GetName())
+	}
+	if names[0] != names[1] {
+		t.Errorf("PutStepLog is not idempotent: %s != %s", names[0], names[1])
+	}
+	if names[0] == names[2] {
+		t.Errorf("steps share the log record %s", names[0])
+	}
+
+	rec, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: names[2]})
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	log := &v1alpha3.Log{}
+	if err := json.Unmarshal(rec.GetData().GetValue(), log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Spec.Step == nil || log.Spec.Step.Name != "test" || log.Spec.Resource.UID != o.GetUID() {
+		t.Errorf("unexpected log spec: %+v", log.Spec)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Tekton Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
+	"github.com/tektoncd/results/pkg/apis/v1alpha3"
+	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+)
+
+// PutProvenance adds the provenance of the given Object to the Results API,
+// under the Result of the Object. If the Result is missing, it is created
+// automatically. The Record of the provenance is updated if it changed.
+func (c *Client) PutProvenance(ctx context.Context, o Object, p *v1alpha3.Provenance, opts ...grpc.CallOption) (*pb.Record, error) {
+	res, err := c.ensureResult(ctx, o, opts...)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(res.GetUid())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing UID of result %s: %w", res.GetName(), err)
+	}
+	p.Default()
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	data := &pb.Any{
+		Type:  v1alpha3.ProvenanceRecordType,
+		Value: b,
+	}
+	return c.upsertRecordData(ctx, res.GetName(), FormatProvenanceName(res.GetName(), uid, o), data, opts...)
+}
+
+// FormatProvenanceName generates the record name of the provenance of an
+// object given the name and UUID of the Result it is stored under.
+func FormatProvenanceName(resultName string, resultUID uuid.UUID, o Object) string {
+	return record.FormatName(resultName, uuid.NewMD5(resultUID, []byte(o.GetUID()+"provenance")).String())
+}
@@ -27,6 +27,7 @@ import (
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/record"
 	"github.com/tektoncd/results/pkg/api/server/v1alpha2/result"
+	"github.com/tektoncd/results/pkg/tracing"
 	"github.com/tektoncd/results/pkg/watcher/convert"
 	"github.com/tektoncd/results/pkg/watcher/reconciler"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
@@ -81,12 +82,30 @@ type StatusConditionGetter interface {
 	GetStatusCondition() apis.ConditionAccessor
 }
 
+// TimesGetter is implemented by Objects of kinds the Client doesn't know,
+// such as kinds the watcher is configured to store, to tell when they started
+// and completed.
+type TimesGetter interface {
+	GetStartTime() *metav1.Time
+	GetCompletionTime() *metav1.Time
+}
+
+// ResultNamer is implemented by Objects which derive the Result they are
+// stored under themselves. ResultName returns the name of the Result in the
+// namespace of the Object, or an empty string to use the default name.
+type ResultNamer interface {
+	ResultName() string
+}
+
 // Put adds the given Object to the Results API.
 // If the parent result is missing or the object is not yet associated with a
 // result, one is created automatically.
 // If the Object is already associated with a Record, the existing Record is
 // updated - otherwise a new Record is created.
-func (c *Client) Put(ctx context.Context, o Object, opts ...grpc.CallOption) (*pb.Result, *pb.Record, error) {
+func (c *Client) Put(ctx context.Context, o Object, opts ...grpc.CallOption) (_ *pb.Result, _ *pb.Record, err error) {
+	ctx, span := tracing.Start(ctx, "results.Client.Put", tracing.ObjectAttributes(o)...)
+	defer func() { tracing.End(span, err) }()
+
 	// Make sure parent Result exists (or create one)
 	res, err := c.ensureResult(ctx, o, opts...)
 	if err != nil {
@@ -188,6 +207,8 @@ func (c *Client) ensureResult(ctx context.Context, o Object, opts ...grpc.CallOp
 	// if the Result doesn't exist yet just create it and return.
 	if status.Code(err) == codes.NotFound {
 		logger.Debug("Result doesn't exist yet - creating")
+		// Records get the cluster of their Result from the API server.
+		res.Cluster = c.ClusterName
 		req := &pb.CreateResultRequest{
 			Parent: parentName(o),
 			Result: res,
@@ -249,7 +270,7 @@ func copyKeys(in, out map[string]string) {
 }
 
 // getStartTime returns the Status.StartTime of a PipelineRun, TaskRun or
-// CustomRun, or nil for any other type.
+// CustomRun, the start time of a TimesGetter, or nil for any other type.
 func getStartTime(o Object) *timestamppb.Timestamp {
 	var startTime *timestamppb.Timestamp
 
@@ -270,6 +291,11 @@ func getStartTime(o Object) *timestamppb.Timestamp {
 			startTime = timestamppb.New(obj.Status.StartTime.Time)
 		}
 
+	case TimesGetter:
+		if t := obj.GetStartTime(); t != nil {
+			startTime = timestamppb.New(t.Time)
+		}
+
 	default:
 		return nil
 	}
@@ -277,7 +303,7 @@ func getStartTime(o Object) *timestamppb.Timestamp {
 }
 
 // getEndTime returns the Status.CompletionTime of a PipelineRun, TaskRun or
-// CustomRun, or nil for any other type.
+// CustomRun, the completion time of a TimesGetter, or nil for any other type.
 func getEndTime(o Object) *timestamppb.Timestamp {
 	var endTime *timestamppb.Timestamp
 
@@ -298,6 +324,11 @@ func getEndTime(o Object) *timestamppb.Timestamp {
 			endTime = timestamppb.New(obj.Status.CompletionTime.Time)
 		}
 
+	case TimesGetter:
+		if t := obj.GetCompletionTime(); t != nil {
+			endTime = timestamppb.New(t.Time)
+		}
+
 	default:
 		return nil
 	}
@@ -315,7 +346,9 @@ func resultName(o metav1.Object) string {
 	}
 
 	var part string
-	if v, ok := o.GetLabels()["triggers.tekton.dev/triggers-eventid"]; ok {
+	if n, ok := o.(ResultNamer); ok && n.ResultName() != "" {
+		part = n.ResultName()
+	} else if v, ok := o.GetLabels()["triggers.tekton.dev/triggers-eventid"]; ok {
 		// Don't prefix trigger events. These are 1) not CRD types, 2) are
 		// intended to be unique identifiers already, and 3) should be applied
 		// to all objects created via trigger templates, so there's no need to
@@ -366,13 +399,18 @@ func parentName(o metav1.Object) string {
 // upsertRecord updates or creates a record for the object. If there has been
 // no change in the Record data, the existing Record is returned.
 func (c *Client) upsertRecord(ctx context.Context, parent string, o Object, opts ...grpc.CallOption) (*pb.Record, error) {
-	recName := recordName(parent, o)
-	logger := logging.FromContext(ctx).With(zap.String(annotation.Record, recName))
 	data, err := convert.ToProto(o)
 	if err != nil {
 		return nil, err
 	}
+	return c.upsertRecordData(ctx, parent, recordName(parent, o), data, opts...)
+}
 
+// upsertRecordData updates or creates the named record with the given data.
+// If there has been no change in the Record data, the existing Record is
+// returned.
+func (c *Client) upsertRecordData(ctx context.Context, parent, recName string, data *pb.Any, opts ...grpc.CallOption) (*pb.Record, error) {
+	logger := logging.FromContext(ctx).With(zap.String(annotation.Record, recName))
 	curr, err := c.GetRecord(ctx, &pb.GetRecordRequest{Name: recName}, opts...)
 	if err != nil && status.Code(err) != codes.NotFound {
 		return nil, err
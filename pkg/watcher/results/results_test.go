@@ -17,6 +17,7 @@ package results
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -30,8 +31,17 @@ import (
 	"github.com/tektoncd/results/pkg/internal/protoutil"
 	"github.com/tektoncd/results/pkg/internal/test"
 	"github.com/tektoncd/results/pkg/watcher/convert"
+	creds "github.com/tektoncd/results/pkg/watcher/grpc"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	pb "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -615,6 +625,128 @@ func TestPut(t *testing.T) {
 	}
 }
 
+func TestPut_cluster(t *testing.T) {
+	ctx := context.Background()
+	eu := client(t)
+	eu.ClusterName = "build-eu-1"
+	// Both watchers store their Results in the same API server, which they
+	// tell their cluster.
+	us := &Client{ResultsClient: eu.ResultsClient, LogsClient: eu.LogsClient}
+	us.ClusterName = "build-us-1"
+	inEU := grpc.PerRPCCredentials(creds.Cluster("build-eu-1"))
+	inUS := grpc.PerRPCCredentials(creds.Cluster("build-us-1"))
+
+	pr := &pipelinev1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "PipelineRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "release",
+			Namespace:   "ci",
+			UID:         "pipelinerun-id",
+			Annotations: map[string]string{annotation.Result: "ci/results/release"},
+		},
+	}
+	res, rec, err := eu.Put(ctx, pr, inEU)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if res.GetCluster() != "build-eu-1" || rec.GetCluster() != "build-eu-1" {
+		t.Errorf("Put: Result of cluster %q and Record of cluster %q, want build-eu-1", res.GetCluster(), rec.GetCluster())
+	}
+
+	// A run of a namespace of the same name in another cluster ends up in a
+	// Result of the same name in its own cluster.
+	other := pr.DeepCopy()
+	other.UID = "other-pipelinerun-id"
+	otherRes, otherRec, err := us.Put(ctx, other, inUS)
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if otherRes.GetName() != res.GetName() || otherRes.GetUid() == res.GetUid() {
+		t.Errorf("Put: got Result %s (%s), want a Result named %s other than %s", otherRes.GetName(), otherRes.GetUid(), res.GetName(), res.GetUid())
+	}
+	if otherRes.GetCluster() != "build-us-1" || otherRec.GetCluster() != "build-us-1" {
+		t.Errorf("Put: Result of cluster %q and Record of cluster %q, want build-us-1", otherRes.GetCluster(), otherRec.GetCluster())
+	}
+
+	// Updates find the Result of their cluster.
+	if _, _, err := eu.Put(ctx, pr, inEU); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	for cluster, opt := range map[string]grpc.CallOption{"build-eu-1": inEU, "build-us-1": inUS} {
+		got, err := eu.GetResult(ctx, &pb.GetResultRequest{Name: res.GetName()}, opt)
+		if err != nil {
+			t.Fatalf("GetResult: %v", err)
+		}
+		if got.GetCluster() != cluster {
+			t.Errorf("GetResult in cluster %s: got Result of cluster %q", cluster, got.GetCluster())
+		}
+	}
+}
+
+func TestPut_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	o := &pipelinev1.TaskRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "TaskRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun",
+			Namespace: "test",
+			UID:       "taskrun-id",
+		},
+	}
+	if _, _, err := client(t).Put(context.Background(), o); err != nil {
+		t.Fatal(err)
+	}
+
+	// Server spans may end after the client received the response.
+	const serverSpan = "server tekton.results.v1alpha2.Results/CreateRecord"
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for start := time.Now(); spans[serverSpan] == nil && time.Since(start) < 5*time.Second; time.Sleep(time.Millisecond) {
+		for _, s := range recorder.Ended() {
+			key := s.Name()
+			if s.SpanKind() == oteltrace.SpanKindServer {
+				key = "server " + key
+			}
+			spans[key] = s
+		}
+	}
+	put, ok := spans["results.Client.Put"]
+	if !ok {
+		t.Fatalf("no span for Put in %v", spans)
+	}
+	if want := attribute.String("k8s.object.uid", "taskrun-id"); !slices.Contains(put.Attributes(), want) {
+		t.Errorf("attribute %v missing in %v", want, put.Attributes())
+	}
+	// The trace context is propagated to the API server, so that its spans
+	// are part of the same trace.
+	client, ok := spans["tekton.results.v1alpha2.Results/CreateRecord"]
+	if !ok {
+		t.Fatalf("no client span for CreateRecord in %v", spans)
+	}
+	server, ok := spans[serverSpan]
+	if !ok {
+		t.Fatalf("no server span for CreateRecord in %v", spans)
+	}
+	if client.Parent().SpanID() != put.SpanContext().SpanID() {
+		t.Error("client span is not a child of the Put span")
+	}
+	if server.Parent().SpanID() != client.SpanContext().SpanID() || !server.Parent().IsRemote() {
+		t.Error("server span is not a child of the client span")
+	}
+}
+
 func crdToRecord(t *testing.T, name string, o Object) *pb.Record {
 	t.Helper()
 
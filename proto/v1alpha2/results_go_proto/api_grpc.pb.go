@@ -46,6 +46,13 @@ const (
 	Results_ListRecords_FullMethodName          = "/tekton.results.v1alpha2.Results/ListRecords"
 	Results_DeleteRecord_FullMethodName         = "/tekton.results.v1alpha2.Results/DeleteRecord"
 	Results_GetRecordListSummary_FullMethodName = "/tekton.results.v1alpha2.Results/GetRecordListSummary"
+	Results_ListFlakyTasks_FullMethodName       = "/tekton.results.v1alpha2.Results/ListFlakyTasks"
+	Results_GetDeliveryMetrics_FullMethodName   = "/tekton.results.v1alpha2.Results/GetDeliveryMetrics"
+	Results_SearchRunsByDigest_FullMethodName   = "/tekton.results.v1alpha2.Results/SearchRunsByDigest"
+	Results_SearchRunsByResult_FullMethodName   = "/tekton.results.v1alpha2.Results/SearchRunsByResult"
+	Results_DiffRuns_FullMethodName             = "/tekton.results.v1alpha2.Results/DiffRuns"
+	Results_GetRunTimeline_FullMethodName       = "/tekton.results.v1alpha2.Results/GetRunTimeline"
+	Results_ListMigrations_FullMethodName       = "/tekton.results.v1alpha2.Results/ListMigrations"
 )
 
 // ResultsClient is the client API for Results service.
@@ -63,6 +70,30 @@ type ResultsClient interface {
 	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
 	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetRecordListSummary(ctx context.Context, in *RecordListSummaryRequest, opts ...grpc.CallOption) (*RecordListSummary, error)
+	// ListFlakyTasks ranks the tasks whose TaskRuns failed and later succeeded
+	// on the same commit or with the same params.
+	ListFlakyTasks(ctx context.Context, in *ListFlakyTasksRequest, opts ...grpc.CallOption) (*ListFlakyTasksResponse, error)
+	// GetDeliveryMetrics computes the DORA delivery metrics of the deployment
+	// PipelineRuns matching a filter.
+	GetDeliveryMetrics(ctx context.Context, in *DeliveryMetricsRequest, opts ...grpc.CallOption) (*DeliveryMetricsResponse, error)
+	// SearchRunsByDigest finds the runs which built an artifact, from the
+	// provenance Records stored for them.
+	SearchRunsByDigest(ctx context.Context, in *SearchRunsByDigestRequest, opts ...grpc.CallOption) (*SearchRunsByDigestResponse, error)
+	// SearchRunsByResult finds the TaskRun and PipelineRun Records of the runs
+	// which produced a result with the given value. Results are indexed when
+	// the Records are written.
+	SearchRunsByResult(ctx context.Context, in *SearchRunsByResultRequest, opts ...grpc.CallOption) (*SearchRunsByResultResponse, error)
+	// DiffRuns compares two stored runs of the same kind: their params,
+	// resolved spec, results and status, and the status, step images and
+	// durations of their tasks.
+	DiffRuns(ctx context.Context, in *DiffRunsRequest, opts ...grpc.CallOption) (*DiffRunsResponse, error)
+	// GetRunTimeline reconstructs the execution of a stored PipelineRun from
+	// its TaskRuns: their dependencies, waiting and running times, critical
+	// path and parallelism.
+	GetRunTimeline(ctx context.Context, in *GetRunTimelineRequest, opts ...grpc.CallOption) (*RunTimeline, error)
+	// ListMigrations returns the progress of the migrations of Record data
+	// between versions of their type.
+	ListMigrations(ctx context.Context, in *ListMigrationsRequest, opts ...grpc.CallOption) (*ListMigrationsResponse, error)
 }
 
 type resultsClient struct {
@@ -183,6 +214,76 @@ func (c *resultsClient) GetRecordListSummary(ctx context.Context, in *RecordList
 	return out, nil
 }
 
+func (c *resultsClient) ListFlakyTasks(ctx context.Context, in *ListFlakyTasksRequest, opts ...grpc.CallOption) (*ListFlakyTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFlakyTasksResponse)
+	err := c.cc.Invoke(ctx, Results_ListFlakyTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) GetDeliveryMetrics(ctx context.Context, in *DeliveryMetricsRequest, opts ...grpc.CallOption) (*DeliveryMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeliveryMetricsResponse)
+	err := c.cc.Invoke(ctx, Results_GetDeliveryMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) SearchRunsByDigest(ctx context.Context, in *SearchRunsByDigestRequest, opts ...grpc.CallOption) (*SearchRunsByDigestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchRunsByDigestResponse)
+	err := c.cc.Invoke(ctx, Results_SearchRunsByDigest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) SearchRunsByResult(ctx context.Context, in *SearchRunsByResultRequest, opts ...grpc.CallOption) (*SearchRunsByResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchRunsByResultResponse)
+	err := c.cc.Invoke(ctx, Results_SearchRunsByResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) DiffRuns(ctx context.Context, in *DiffRunsRequest, opts ...grpc.CallOption) (*DiffRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffRunsResponse)
+	err := c.cc.Invoke(ctx, Results_DiffRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) GetRunTimeline(ctx context.Context, in *GetRunTimelineRequest, opts ...grpc.CallOption) (*RunTimeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunTimeline)
+	err := c.cc.Invoke(ctx, Results_GetRunTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resultsClient) ListMigrations(ctx context.Context, in *ListMigrationsRequest, opts ...grpc.CallOption) (*ListMigrationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMigrationsResponse)
+	err := c.cc.Invoke(ctx, Results_ListMigrations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResultsServer is the server API for Results service.
 // All implementations must embed UnimplementedResultsServer
 // for forward compatibility.
@@ -198,6 +299,30 @@ type ResultsServer interface {
 	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
 	DeleteRecord(context.Context, *DeleteRecordRequest) (*emptypb.Empty, error)
 	GetRecordListSummary(context.Context, *RecordListSummaryRequest) (*RecordListSummary, error)
+	// ListFlakyTasks ranks the tasks whose TaskRuns failed and later succeeded
+	// on the same commit or with the same params.
+	ListFlakyTasks(context.Context, *ListFlakyTasksRequest) (*ListFlakyTasksResponse, error)
+	// GetDeliveryMetrics computes the DORA delivery metrics of the deployment
+	// PipelineRuns matching a filter.
+	GetDeliveryMetrics(context.Context, *DeliveryMetricsRequest) (*DeliveryMetricsResponse, error)
+	// SearchRunsByDigest finds the runs which built an artifact, from the
+	// provenance Records stored for them.
+	SearchRunsByDigest(context.Context, *SearchRunsByDigestRequest) (*SearchRunsByDigestResponse, error)
+	// SearchRunsByResult finds the TaskRun and PipelineRun Records of the runs
+	// which produced a result with the given value. Results are indexed when
+	// the Records are written.
+	SearchRunsByResult(context.Context, *SearchRunsByResultRequest) (*SearchRunsByResultResponse, error)
+	// DiffRuns compares two stored runs of the same kind: their params,
+	// resolved spec, results and status, and the status, step images and
+	// durations of their tasks.
+	DiffRuns(context.Context, *DiffRunsRequest) (*DiffRunsResponse, error)
+	// GetRunTimeline reconstructs the execution of a stored PipelineRun from
+	// its TaskRuns: their dependencies, waiting and running times, critical
+	// path and parallelism.
+	GetRunTimeline(context.Context, *GetRunTimelineRequest) (*RunTimeline, error)
+	// ListMigrations returns the progress of the migrations of Record data
+	// between versions of their type.
+	ListMigrations(context.Context, *ListMigrationsRequest) (*ListMigrationsResponse, error)
 	mustEmbedUnimplementedResultsServer()
 }
 
@@ -241,6 +366,27 @@ func (UnimplementedResultsServer) DeleteRecord(context.Context, *DeleteRecordReq
 func (UnimplementedResultsServer) GetRecordListSummary(context.Context, *RecordListSummaryRequest) (*RecordListSummary, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRecordListSummary not implemented")
 }
+func (UnimplementedResultsServer) ListFlakyTasks(context.Context, *ListFlakyTasksRequest) (*ListFlakyTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFlakyTasks not implemented")
+}
+func (UnimplementedResultsServer) GetDeliveryMetrics(context.Context, *DeliveryMetricsRequest) (*DeliveryMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryMetrics not implemented")
+}
+func (UnimplementedResultsServer) SearchRunsByDigest(context.Context, *SearchRunsByDigestRequest) (*SearchRunsByDigestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRunsByDigest not implemented")
+}
+func (UnimplementedResultsServer) SearchRunsByResult(context.Context, *SearchRunsByResultRequest) (*SearchRunsByResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRunsByResult not implemented")
+}
+func (UnimplementedResultsServer) DiffRuns(context.Context, *DiffRunsRequest) (*DiffRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRuns not implemented")
+}
+func (UnimplementedResultsServer) GetRunTimeline(context.Context, *GetRunTimelineRequest) (*RunTimeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunTimeline not implemented")
+}
+func (UnimplementedResultsServer) ListMigrations(context.Context, *ListMigrationsRequest) (*ListMigrationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMigrations not implemented")
+}
 func (UnimplementedResultsServer) mustEmbedUnimplementedResultsServer() {}
 func (UnimplementedResultsServer) testEmbeddedByValue()                 {}
 
@@ -460,6 +606,132 @@ func _Results_GetRecordListSummary_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Results_ListFlakyTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlakyTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).ListFlakyTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_ListFlakyTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).ListFlakyTasks(ctx, req.(*ListFlakyTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_GetDeliveryMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).GetDeliveryMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_GetDeliveryMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).GetDeliveryMetrics(ctx, req.(*DeliveryMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_SearchRunsByDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRunsByDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).SearchRunsByDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_SearchRunsByDigest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).SearchRunsByDigest(ctx, req.(*SearchRunsByDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_SearchRunsByResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRunsByResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).SearchRunsByResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_SearchRunsByResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).SearchRunsByResult(ctx, req.(*SearchRunsByResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_DiffRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).DiffRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_DiffRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).DiffRuns(ctx, req.(*DiffRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_GetRunTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).GetRunTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_GetRunTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).GetRunTimeline(ctx, req.(*GetRunTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Results_ListMigrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMigrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResultsServer).ListMigrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Results_ListMigrations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResultsServer).ListMigrations(ctx, req.(*ListMigrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Results_ServiceDesc is the grpc.ServiceDesc for Results service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -511,16 +783,45 @@ var Results_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRecordListSummary",
 			Handler:    _Results_GetRecordListSummary_Handler,
 		},
+		{
+			MethodName: "ListFlakyTasks",
+			Handler:    _Results_ListFlakyTasks_Handler,
+		},
+		{
+			MethodName: "GetDeliveryMetrics",
+			Handler:    _Results_GetDeliveryMetrics_Handler,
+		},
+		{
+			MethodName: "SearchRunsByDigest",
+			Handler:    _Results_SearchRunsByDigest_Handler,
+		},
+		{
+			MethodName: "SearchRunsByResult",
+			Handler:    _Results_SearchRunsByResult_Handler,
+		},
+		{
+			MethodName: "DiffRuns",
+			Handler:    _Results_DiffRuns_Handler,
+		},
+		{
+			MethodName: "GetRunTimeline",
+			Handler:    _Results_GetRunTimeline_Handler,
+		},
+		{
+			MethodName: "ListMigrations",
+			Handler:    _Results_ListMigrations_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
 
 const (
-	Logs_GetLog_FullMethodName    = "/tekton.results.v1alpha2.Logs/GetLog"
-	Logs_ListLogs_FullMethodName  = "/tekton.results.v1alpha2.Logs/ListLogs"
-	Logs_UpdateLog_FullMethodName = "/tekton.results.v1alpha2.Logs/UpdateLog"
-	Logs_DeleteLog_FullMethodName = "/tekton.results.v1alpha2.Logs/DeleteLog"
+	Logs_GetLog_FullMethodName       = "/tekton.results.v1alpha2.Logs/GetLog"
+	Logs_ListLogs_FullMethodName     = "/tekton.results.v1alpha2.Logs/ListLogs"
+	Logs_ListStepLogs_FullMethodName = "/tekton.results.v1alpha2.Logs/ListStepLogs"
+	Logs_UpdateLog_FullMethodName    = "/tekton.results.v1alpha2.Logs/UpdateLog"
+	Logs_DeleteLog_FullMethodName    = "/tekton.results.v1alpha2.Logs/DeleteLog"
 )
 
 // LogsClient is the client API for Logs service.
@@ -529,6 +830,7 @@ const (
 type LogsClient interface {
 	GetLog(ctx context.Context, in *GetLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
 	ListLogs(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	ListStepLogs(ctx context.Context, in *ListStepLogsRequest, opts ...grpc.CallOption) (*ListStepLogsResponse, error)
 	UpdateLog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Log, LogSummary], error)
 	DeleteLog(ctx context.Context, in *DeleteLogRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -570,6 +872,16 @@ func (c *logsClient) ListLogs(ctx context.Context, in *ListRecordsRequest, opts
 	return out, nil
 }
 
+func (c *logsClient) ListStepLogs(ctx context.Context, in *ListStepLogsRequest, opts ...grpc.CallOption) (*ListStepLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStepLogsResponse)
+	err := c.cc.Invoke(ctx, Logs_ListStepLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *logsClient) UpdateLog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Log, LogSummary], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &Logs_ServiceDesc.Streams[1], Logs_UpdateLog_FullMethodName, cOpts...)
@@ -599,6 +911,7 @@ func (c *logsClient) DeleteLog(ctx context.Context, in *DeleteLogRequest, opts .
 type LogsServer interface {
 	GetLog(*GetLogRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
 	ListLogs(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	ListStepLogs(context.Context, *ListStepLogsRequest) (*ListStepLogsResponse, error)
 	UpdateLog(grpc.ClientStreamingServer[Log, LogSummary]) error
 	DeleteLog(context.Context, *DeleteLogRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedLogsServer()
@@ -617,6 +930,9 @@ func (UnimplementedLogsServer) GetLog(*GetLogRequest, grpc.ServerStreamingServer
 func (UnimplementedLogsServer) ListLogs(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLogs not implemented")
 }
+func (UnimplementedLogsServer) ListStepLogs(context.Context, *ListStepLogsRequest) (*ListStepLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStepLogs not implemented")
+}
 func (UnimplementedLogsServer) UpdateLog(grpc.ClientStreamingServer[Log, LogSummary]) error {
 	return status.Errorf(codes.Unimplemented, "method UpdateLog not implemented")
 }
@@ -673,6 +989,24 @@ func _Logs_ListLogs_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Logs_ListStepLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStepLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServer).ListStepLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logs_ListStepLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServer).ListStepLogs(ctx, req.(*ListStepLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Logs_UpdateLog_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(LogsServer).UpdateLog(&grpc.GenericServerStream[Log, LogSummary]{ServerStream: stream})
 }
@@ -709,6 +1043,10 @@ var Logs_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListLogs",
 			Handler:    _Logs_ListLogs_Handler,
 		},
+		{
+			MethodName: "ListStepLogs",
+			Handler:    _Logs_ListStepLogs_Handler,
+		},
 		{
 			MethodName: "DeleteLog",
 			Handler:    _Logs_DeleteLog_Handler,
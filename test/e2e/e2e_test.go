@@ -906,6 +906,25 @@ func TestAuthorization(t *testing.T) {
 	})
 }
 
+func TestListMigrations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("admin", func(t *testing.T) {
+		gc, _ := resultsClient(t, allNamespacesAdminAccessTokenFile, nil)
+		if _, err := gc.ListMigrations(ctx, &resultsv1alpha2.ListMigrationsRequest{}); err != nil {
+			t.Fatalf("Error listing Migrations: %v", err)
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		gc, _ := resultsClient(t, allNamespacesReadAccessTokenFile, nil)
+		_, err := gc.ListMigrations(ctx, &resultsv1alpha2.ListMigrationsRequest{})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Expected %v listing Migrations, got: %v", codes.Unauthenticated, err)
+		}
+	})
+}
+
 func TestImpersonation(t *testing.T) {
 	ctx := context.Background()
 	p := "default"